@@ -33,6 +33,8 @@ var (
 	asteriscBin             = "./bin/asterisc"
 	asteriscServer          = "./bin/op-program"
 	asteriscPreState        = "./pre.json"
+	tracePlugin             = "./bin/trace-plugin"
+	tracePluginGameType     = "7"
 )
 
 func TestLogLevel(t *testing.T) {
@@ -183,6 +185,40 @@ func TestGameAllowlist(t *testing.T) {
 	})
 }
 
+func TestGameSources(t *testing.T) {
+	t.Run("DefaultsToEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.GameSources)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		addr := common.Address{0xcc}
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet,
+			"--game-source", "label=other;factory="+addr.Hex()+";rollup-rpc=http://rollup;l2-rpc=http://l2"))
+		require.Equal(t, []config.GameSource{{
+			Label:              "other",
+			GameFactoryAddress: addr,
+			RollupRpc:          "http://rollup",
+			L2Rpc:              "http://l2",
+		}}, cfg.GameSources)
+	})
+
+	t.Run("InvalidAddress", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid game source factory address", addRequiredArgs(config.TraceTypeAlphabet,
+			"--game-source", "factory=foo;rollup-rpc=http://rollup"))
+	})
+
+	t.Run("UnknownKey", func(t *testing.T) {
+		verifyArgsInvalid(t, "unknown key \"foo\"", addRequiredArgs(config.TraceTypeAlphabet,
+			"--game-source", "foo=bar"))
+	})
+
+	t.Run("MissingRollupRpc", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-source", "factory="+common.Address{0xcc}.Hex()))
+		require.ErrorIs(t, cfg.Check(), config.ErrMissingGameSourceRollupRpc)
+	})
+}
+
 func TestTxManagerFlagsSupported(t *testing.T) {
 	// Not a comprehensive list of flags, just enough to sanity check the txmgr.CLIFlags were defined
 	cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--"+txmgr.NumConfirmationsFlagName, "7"))
@@ -211,6 +247,30 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestJobTimeout(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.JobTimeout)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--job-timeout", "10m"))
+		require.Equal(t, 10*time.Minute, cfg.JobTimeout)
+	})
+}
+
+func TestDatadirQuota(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.DiskQuota)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--datadir-quota", "1048576"))
+		require.Equal(t, uint64(1048576), cfg.DiskQuota)
+	})
+}
+
 func TestMaxPendingTx(t *testing.T) {
 	t.Run("Valid", func(t *testing.T) {
 		expected := uint64(345)
@@ -251,6 +311,56 @@ func TestPollInterval(t *testing.T) {
 	})
 }
 
+func TestTracePluginRequiredArgs(t *testing.T) {
+	t.Run("TestTracePlugin", func(t *testing.T) {
+		t.Run("NotRequiredForAlphabetTrace", func(t *testing.T) {
+			configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, "--trace-plugin"))
+		})
+
+		t.Run("Required", func(t *testing.T) {
+			verifyArgsInvalid(t, "flag trace-plugin is required", addRequiredArgsExcept(config.TraceTypePlugin, "--trace-plugin"))
+		})
+
+		t.Run("Valid", func(t *testing.T) {
+			cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypePlugin, "--trace-plugin", "--trace-plugin=./plugin"))
+			require.Equal(t, "./plugin", cfg.TracePlugin)
+		})
+	})
+
+	t.Run("TestTracePluginGameType", func(t *testing.T) {
+		t.Run("Required", func(t *testing.T) {
+			verifyArgsInvalid(t, "flag trace-plugin-game-type is required", addRequiredArgsExcept(config.TraceTypePlugin, "--trace-plugin-game-type"))
+		})
+
+		t.Run("Invalid", func(t *testing.T) {
+			verifyArgsInvalid(t, "flag trace-plugin-game-type must be a valid game type", addRequiredArgsExcept(config.TraceTypePlugin, "--trace-plugin-game-type", "--trace-plugin-game-type=4294967296"))
+		})
+
+		t.Run("Valid", func(t *testing.T) {
+			cfg := configForArgs(t, addRequiredArgs(config.TraceTypePlugin))
+			require.Equal(t, uint32(7), cfg.TracePluginGameType)
+		})
+	})
+
+	t.Run("TestTracePluginTimeout", func(t *testing.T) {
+		t.Run("UsesDefault", func(t *testing.T) {
+			cfg := configForArgs(t, addRequiredArgs(config.TraceTypePlugin))
+			require.Equal(t, config.DefaultTracePluginTimeout, cfg.TracePluginTimeout)
+		})
+
+		t.Run("Valid", func(t *testing.T) {
+			cfg := configForArgs(t, addRequiredArgs(config.TraceTypePlugin, "--trace-plugin-timeout=5m"))
+			require.Equal(t, 5*time.Minute, cfg.TracePluginTimeout)
+		})
+	})
+
+	t.Run("TestL2EthRpc", func(t *testing.T) {
+		t.Run("Required", func(t *testing.T) {
+			verifyArgsInvalid(t, "flag l2-eth-rpc is required", addRequiredArgsExcept(config.TraceTypePlugin, "--l2-eth-rpc"))
+		})
+	})
+}
+
 func TestAsteriscRequiredArgs(t *testing.T) {
 	for _, traceType := range []config.TraceType{config.TraceTypeAsterisc} {
 		traceType := traceType
@@ -669,6 +779,18 @@ func TestGameWindow(t *testing.T) {
 	})
 }
 
+func TestShadowMode(t *testing.T) {
+	t.Run("DefaultsToFalse", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.ShadowMode)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--shadow-mode"))
+		require.True(t, cfg.ShadowMode)
+	})
+}
+
 func TestUnsafeAllowInvalidPrestate(t *testing.T) {
 	t.Run("DefaultsToFalse", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, "--unsafe-allow-invalid-prestate"))
@@ -781,6 +903,8 @@ func requiredArgs(traceType config.TraceType) map[string]string {
 		addRequiredCannonArgs(args)
 	case config.TraceTypeAsterisc:
 		addRequiredAsteriscArgs(args)
+	case config.TraceTypePlugin:
+		addRequiredTracePluginArgs(args)
 	case config.TraceTypeAlphabet:
 		addRequiredOutputArgs(args)
 	}
@@ -805,6 +929,13 @@ func addRequiredAsteriscArgs(args map[string]string) {
 	addRequiredOutputArgs(args)
 }
 
+func addRequiredTracePluginArgs(args map[string]string) {
+	args["--trace-plugin"] = tracePlugin
+	args["--trace-plugin-game-type"] = tracePluginGameType
+	args["--l2-eth-rpc"] = l2EthRpc
+	addRequiredOutputArgs(args)
+}
+
 func addRequiredOutputArgs(args map[string]string) {
 	args["--rollup-rpc"] = rollupRpc
 }
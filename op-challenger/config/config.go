@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -48,6 +49,12 @@ var (
 	ErrAsteriscNetworkAndRollupConfig     = errors.New("only specify one of network or rollup config path")
 	ErrAsteriscNetworkAndL2Genesis        = errors.New("only specify one of network or l2 genesis path")
 	ErrAsteriscNetworkUnknown             = errors.New("unknown asterisc network")
+
+	ErrMissingTracePlugin = errors.New("missing trace plugin")
+
+	ErrMissingGameSourceFactory   = errors.New("missing game source factory address")
+	ErrMissingGameSourceRollupRpc = errors.New("missing game source rollup rpc url")
+	ErrDuplicateGameSource        = errors.New("duplicate game source factory address")
 )
 
 type TraceType string
@@ -57,9 +64,10 @@ const (
 	TraceTypeCannon       TraceType = "cannon"
 	TraceTypeAsterisc     TraceType = "asterisc"
 	TraceTypePermissioned TraceType = "permissioned"
+	TraceTypePlugin       TraceType = "plugin"
 )
 
-var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeCannon, TraceTypePermissioned, TraceTypeAsterisc}
+var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeCannon, TraceTypePermissioned, TraceTypeAsterisc, TraceTypePlugin}
 
 func (t TraceType) String() string {
 	return string(t)
@@ -94,6 +102,7 @@ const (
 	DefaultCannonInfoFreq       = uint(10_000_000)
 	DefaultAsteriscSnapshotFreq = uint(1_000_000_000)
 	DefaultAsteriscInfoFreq     = uint(10_000_000)
+	DefaultTracePluginTimeout   = time.Hour
 	// DefaultGameWindow is the default maximum time duration in the past
 	// that the challenger will look for games to progress.
 	// The default value is 15 days, which is an 8 day resolution buffer
@@ -102,6 +111,15 @@ const (
 	DefaultMaxPendingTx = 10
 )
 
+// GameSource is an additional dispute game factory, usually for another chain, whose games are progressed alongside
+// the games of the primary factory. The trace type configuration is shared with the primary factory.
+type GameSource struct {
+	Label              string         // Identifies the source in logs and metrics
+	GameFactoryAddress common.Address // Address of the source's dispute game factory
+	RollupRpc          string         // Rollup RPC Url of the source's chain
+	L2Rpc              string         // L2 RPC Url of the source's chain (defaults to L2Rpc)
+}
+
 // Config is a well typed config that is parsed from the CLI params.
 // This also contains config options for auxiliary services.
 // It is used to initialize the challenger.
@@ -112,9 +130,12 @@ type Config struct {
 	GameAllowlist        []common.Address // Allowlist of fault game addresses
 	GameWindow           time.Duration    // Maximum time duration to look for games to progress
 	Datadir              string           // Data Directory
+	DiskQuota            uint64           // Maximum bytes of game data to keep in Datadir (0 == no limit)
 	MaxConcurrency       uint             // Maximum number of threads to use when progressing games
+	JobTimeout           time.Duration    // Maximum time a single progression of a game may run (0 == no limit)
 	PollInterval         time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
 	AllowInvalidPrestate bool             // Whether to allow responding to games where the prestate does not match
+	ShadowMode           bool             // Whether to log the transactions that would be sent instead of sending them
 
 	AdditionalBondClaimants []common.Address // List of addresses to claim bonds for in addition to the tx manager sender
 
@@ -126,6 +147,8 @@ type Config struct {
 
 	L2Rpc string // L2 RPC Url
 
+	GameSources []GameSource // Additional dispute game factories to progress games from
+
 	// Specific to the cannon trace provider
 	CannonBin                     string   // Path to the cannon executable to run when generating trace data
 	CannonServer                  string   // Path to the op-program executable that provides the pre-image oracle server
@@ -148,11 +171,17 @@ type Config struct {
 	AsteriscSnapshotFreq            uint // Frequency of snapshots to create when executing asterisc (in VM instructions)
 	AsteriscInfoFreq                uint // Frequency of asterisc progress log messages (in VM instructions)
 
+	// Specific to the plugin trace provider
+	TracePlugin         string        // Path to the trace plugin executable to run when generating trace data
+	TracePluginGameType uint32        // Game type of the games whose trace data is generated by the trace plugin
+	TracePluginTimeout  time.Duration // Maximum time a single call to the trace plugin may take (0 == no limit)
+
 	MaxPendingTx uint64 // Maximum number of pending transactions (0 == no limit)
 
 	TxMgrConfig   txmgr.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
+	RPC           oprpc.CLIConfig
 }
 
 func NewConfig(
@@ -176,6 +205,7 @@ func NewConfig(
 		TxMgrConfig:   txmgr.NewCLIConfig(l1EthRpc, txmgr.DefaultChallengerFlagValues),
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
+		RPC:           oprpc.DefaultCLIConfig(),
 
 		Datadir: datadir,
 
@@ -183,6 +213,7 @@ func NewConfig(
 		CannonInfoFreq:       DefaultCannonInfoFreq,
 		AsteriscSnapshotFreq: DefaultAsteriscSnapshotFreq,
 		AsteriscInfoFreq:     DefaultAsteriscInfoFreq,
+		TracePluginTimeout:   DefaultTracePluginTimeout,
 		GameWindow:           DefaultGameWindow,
 	}
 }
@@ -204,6 +235,9 @@ func (c Config) Check() error {
 	if c.GameFactoryAddress == (common.Address{}) {
 		return ErrMissingGameFactoryAddress
 	}
+	if err := c.checkGameSources(); err != nil {
+		return err
+	}
 	if len(c.TraceTypes) == 0 {
 		return ErrMissingTraceType
 	}
@@ -295,6 +329,14 @@ func (c Config) Check() error {
 			return ErrMissingAsteriscInfoFreq
 		}
 	}
+	if c.TraceTypeEnabled(TraceTypePlugin) {
+		if c.TracePlugin == "" {
+			return ErrMissingTracePlugin
+		}
+		if c.L2Rpc == "" {
+			return ErrMissingL2Rpc
+		}
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
@@ -304,5 +346,25 @@ func (c Config) Check() error {
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.RPC.Check(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c Config) checkGameSources() error {
+	factories := map[common.Address]bool{c.GameFactoryAddress: true}
+	for _, source := range c.GameSources {
+		if source.GameFactoryAddress == (common.Address{}) {
+			return ErrMissingGameSourceFactory
+		}
+		if factories[source.GameFactoryAddress] {
+			return fmt.Errorf("%w: %v", ErrDuplicateGameSource, source.GameFactoryAddress)
+		}
+		factories[source.GameFactoryAddress] = true
+		if source.RollupRpc == "" {
+			return fmt.Errorf("%w: %v", ErrMissingGameSourceRollupRpc, source.GameFactoryAddress)
+		}
+	}
 	return nil
 }
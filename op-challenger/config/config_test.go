@@ -30,6 +30,8 @@ var (
 	validAsteriscNetwork                   = "mainnet"
 	validAsteriscAbsolutPreState           = "pre.json"
 	validAsteriscAbsolutPreStateBaseURL, _ = url.Parse("http://localhost/bar/")
+
+	validTracePlugin = "./bin/trace-plugin"
 )
 
 var cannonTraceTypes = []TraceType{TraceTypeCannon, TraceTypePermissioned}
@@ -59,6 +61,10 @@ func validConfig(traceType TraceType) Config {
 	if traceType == TraceTypeAsterisc {
 		applyValidConfigForAsterisc(&cfg)
 	}
+	if traceType == TraceTypePlugin {
+		cfg.TracePlugin = validTracePlugin
+		cfg.L2Rpc = validL2Rpc
+	}
 	cfg.RollupRpc = validRollupRpc
 	return cfg
 }
@@ -100,6 +106,49 @@ func TestGameFactoryAddressRequired(t *testing.T) {
 	require.ErrorIs(t, config.Check(), ErrMissingGameFactoryAddress)
 }
 
+func TestGameSources(t *testing.T) {
+	source := GameSource{
+		Label:              "other",
+		GameFactoryAddress: common.Address{0xbb},
+		RollupRpc:          "http://localhost:9547",
+	}
+	t.Run("Valid", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon)
+		config.GameSources = []GameSource{source}
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("FactoryRequired", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon)
+		invalid := source
+		invalid.GameFactoryAddress = common.Address{}
+		config.GameSources = []GameSource{invalid}
+		require.ErrorIs(t, config.Check(), ErrMissingGameSourceFactory)
+	})
+
+	t.Run("RollupRpcRequired", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon)
+		invalid := source
+		invalid.RollupRpc = ""
+		config.GameSources = []GameSource{invalid}
+		require.ErrorIs(t, config.Check(), ErrMissingGameSourceRollupRpc)
+	})
+
+	t.Run("DuplicateSource", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon)
+		config.GameSources = []GameSource{source, source}
+		require.ErrorIs(t, config.Check(), ErrDuplicateGameSource)
+	})
+
+	t.Run("PrimaryFactory", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon)
+		invalid := source
+		invalid.GameFactoryAddress = config.GameFactoryAddress
+		config.GameSources = []GameSource{invalid}
+		require.ErrorIs(t, config.Check(), ErrDuplicateGameSource)
+	})
+}
+
 func TestSelectiveClaimResolutionNotRequired(t *testing.T) {
 	config := validConfig(TraceTypeCannon)
 	require.Equal(t, false, config.SelectiveClaimResolution)
@@ -324,6 +373,20 @@ func TestAsteriscRequiredArgs(t *testing.T) {
 	}
 }
 
+func TestTracePluginRequiredArgs(t *testing.T) {
+	t.Run("TestTracePluginRequired", func(t *testing.T) {
+		config := validConfig(TraceTypePlugin)
+		config.TracePlugin = ""
+		require.ErrorIs(t, config.Check(), ErrMissingTracePlugin)
+	})
+
+	t.Run("TestL2RpcRequired", func(t *testing.T) {
+		config := validConfig(TraceTypePlugin)
+		config.L2Rpc = ""
+		require.ErrorIs(t, config.Check(), ErrMissingL2Rpc)
+	})
+}
+
 func TestDatadirRequired(t *testing.T) {
 	config := validConfig(TraceTypeAlphabet)
 	config.Datadir = ""
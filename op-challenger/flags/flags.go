@@ -2,6 +2,7 @@ package flags
 
 import (
 	"fmt"
+	"math"
 	"net/url"
 	"runtime"
 	"slices"
@@ -18,6 +19,7 @@ import (
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -67,15 +69,27 @@ var (
 		EnvVars: prefixEnvVars("DATADIR"),
 	}
 	// Optional Flags
+	DiskQuotaFlag = &cli.Uint64Flag{
+		Name: "datadir-quota",
+		Usage: "Maximum bytes of game data to keep in the data directory. When exceeded, the data of the least " +
+			"recently progressed games is removed and regenerated when next needed. 0 for no limit.",
+		EnvVars: prefixEnvVars("DATADIR_QUOTA"),
+	}
 	MaxConcurrencyFlag = &cli.UintFlag{
 		Name:    "max-concurrency",
 		Usage:   "Maximum number of threads to use when progressing games",
 		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
 		Value:   uint(runtime.NumCPU()),
 	}
+	JobTimeoutFlag = &cli.DurationFlag{
+		Name: "job-timeout",
+		Usage: "Maximum time a single progression of a game may run before it is cancelled and the game retried, " +
+			"so a hung trace provider can't occupy a thread forever. 0 for no limit.",
+		EnvVars: prefixEnvVars("JOB_TIMEOUT"),
+	}
 	L2EthRpcFlag = &cli.StringFlag{
 		Name:    "l2-eth-rpc",
-		Usage:   "L2 Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)  (cannon/asterisc trace type only)",
+		Usage:   "L2 Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)  (cannon/asterisc/plugin trace type only)",
 		EnvVars: prefixEnvVars("L2_ETH_RPC"),
 	}
 	MaxPendingTransactionsFlag = &cli.Uint64Flag{
@@ -95,6 +109,13 @@ var (
 		Usage:   "List of addresses to claim bonds for, in addition to the configured transaction sender",
 		EnvVars: prefixEnvVars("ADDITIONAL_BOND_CLAIMANTS"),
 	}
+	GameSourceFlag = &cli.StringSliceFlag{
+		Name: "game-source",
+		Usage: "Additional dispute game factory to progress games from, usually for another chain. Specified as " +
+			"semicolon separated key=value pairs: factory=<address>;rollup-rpc=<url>[;l2-rpc=<url>][;label=<name>]. " +
+			"The L2 RPC defaults to the value of --l2-eth-rpc.",
+		EnvVars: prefixEnvVars("GAME_SOURCE"),
+	}
 	CannonNetworkFlag = &cli.StringFlag{
 		Name: "cannon-network",
 		Usage: fmt.Sprintf(
@@ -202,6 +223,24 @@ var (
 		EnvVars: prefixEnvVars("ASTERISC_INFO_FREQ"),
 		Value:   config.DefaultAsteriscInfoFreq,
 	}
+	TracePluginFlag = &cli.StringFlag{
+		Name: "trace-plugin",
+		Usage: "Path to a trace plugin executable to run when generating trace data. The plugin serves the trace " +
+			"JSON-RPC namespace over its stdin and stdout and is restarted if it exits or fails a health check " +
+			"(plugin trace type only)",
+		EnvVars: prefixEnvVars("TRACE_PLUGIN"),
+	}
+	TracePluginGameTypeFlag = &cli.UintFlag{
+		Name:    "trace-plugin-game-type",
+		Usage:   "Game type of the games to generate trace data for with the trace plugin (plugin trace type only)",
+		EnvVars: prefixEnvVars("TRACE_PLUGIN_GAME_TYPE"),
+	}
+	TracePluginTimeoutFlag = &cli.DurationFlag{
+		Name:    "trace-plugin-timeout",
+		Usage:   "Maximum time a single call to the trace plugin may take. 0 for no limit. (plugin trace type only)",
+		EnvVars: prefixEnvVars("TRACE_PLUGIN_TIMEOUT"),
+		Value:   config.DefaultTracePluginTimeout,
+	}
 	GameWindowFlag = &cli.DurationFlag{
 		Name: "game-window",
 		Usage: "The time window which the challenger will look for games to progress and claim bonds. " +
@@ -214,6 +253,12 @@ var (
 		Usage:   "Only resolve claims for the configured claimants",
 		EnvVars: prefixEnvVars("SELECTIVE_CLAIM_RESOLUTION"),
 	}
+	ShadowModeFlag = &cli.BoolFlag{
+		Name: "shadow-mode",
+		Usage: "Progress games and log the transactions that would be sent, with their estimated gas and bond, " +
+			"without sending them. Used to validate a configuration before running with real funds.",
+		EnvVars: prefixEnvVars("SHADOW_MODE"),
+	}
 	UnsafeAllowInvalidPrestate = &cli.BoolFlag{
 		Name:    "unsafe-allow-invalid-prestate",
 		Usage:   "Allow responding to games where the absolute prestate is configured incorrectly. THIS IS UNSAFE!",
@@ -234,11 +279,14 @@ var requiredFlags = []cli.Flag{
 // optionalFlags is a list of unchecked cli flags
 var optionalFlags = []cli.Flag{
 	TraceTypeFlag,
+	DiskQuotaFlag,
 	MaxConcurrencyFlag,
+	JobTimeoutFlag,
 	L2EthRpcFlag,
 	MaxPendingTransactionsFlag,
 	HTTPPollInterval,
 	AdditionalBondClaimants,
+	GameSourceFlag,
 	GameAllowlistFlag,
 	CannonNetworkFlag,
 	CannonRollupConfigFlag,
@@ -259,8 +307,12 @@ var optionalFlags = []cli.Flag{
 	AsteriscPreStatesURLFlag,
 	AsteriscSnapshotFreqFlag,
 	AsteriscInfoFreqFlag,
+	TracePluginFlag,
+	TracePluginGameTypeFlag,
+	TracePluginTimeoutFlag,
 	GameWindowFlag,
 	SelectiveClaimResolutionFlag,
+	ShadowModeFlag,
 	UnsafeAllowInvalidPrestate,
 }
 
@@ -269,6 +321,7 @@ func init() {
 	optionalFlags = append(optionalFlags, txmgr.CLIFlagsWithDefaults(EnvVarPrefix, txmgr.DefaultChallengerFlagValues)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, oprpc.CLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -330,6 +383,23 @@ func CheckAsteriscFlags(ctx *cli.Context) error {
 	return nil
 }
 
+func CheckTracePluginFlags(ctx *cli.Context) error {
+	if !ctx.IsSet(TracePluginFlag.Name) {
+		return fmt.Errorf("flag %s is required", TracePluginFlag.Name)
+	}
+	if !ctx.IsSet(TracePluginGameTypeFlag.Name) {
+		return fmt.Errorf("flag %s is required", TracePluginGameTypeFlag.Name)
+	}
+	if ctx.Uint(TracePluginGameTypeFlag.Name) > math.MaxUint32 {
+		return fmt.Errorf("flag %s must be a valid game type", TracePluginGameTypeFlag.Name)
+	}
+	// CannonL2Flag is checked because it is an alias with L2EthRpcFlag
+	if !ctx.IsSet(CannonL2Flag.Name) && !ctx.IsSet(L2EthRpcFlag.Name) {
+		return fmt.Errorf("flag %s is required", L2EthRpcFlag.Name)
+	}
+	return nil
+}
+
 func CheckRequired(ctx *cli.Context, traceTypes []config.TraceType) error {
 	for _, f := range requiredFlags {
 		if !ctx.IsSet(f.Names()[0]) {
@@ -346,6 +416,10 @@ func CheckRequired(ctx *cli.Context, traceTypes []config.TraceType) error {
 			if err := CheckAsteriscFlags(ctx); err != nil {
 				return err
 			}
+		case config.TraceTypePlugin:
+			if err := CheckTracePluginFlags(ctx); err != nil {
+				return err
+			}
 		case config.TraceTypeAlphabet:
 		default:
 			return fmt.Errorf("invalid trace type. must be one of %v", config.TraceTypes)
@@ -383,6 +457,37 @@ func getL2Rpc(ctx *cli.Context, logger log.Logger) (string, error) {
 	return l2Rpc, nil
 }
 
+func parseGameSources(ctx *cli.Context) ([]config.GameSource, error) {
+	var sources []config.GameSource
+	for _, value := range ctx.StringSlice(GameSourceFlag.Name) {
+		var source config.GameSource
+		for _, part := range strings.Split(value, ";") {
+			key, val, ok := strings.Cut(part, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid game source %q: expected key=value but got %q", value, part)
+			}
+			switch key {
+			case "factory":
+				addr, err := opservice.ParseAddress(val)
+				if err != nil {
+					return nil, fmt.Errorf("invalid game source factory address: %w", err)
+				}
+				source.GameFactoryAddress = addr
+			case "rollup-rpc":
+				source.RollupRpc = val
+			case "l2-rpc":
+				source.L2Rpc = val
+			case "label":
+				source.Label = val
+			default:
+				return nil, fmt.Errorf("invalid game source %q: unknown key %q", value, key)
+			}
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
 // NewConfigFromCLI parses the Config from the provided flags or environment variables.
 func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, error) {
 	traceTypes, err := parseTraceTypes(ctx)
@@ -445,6 +550,10 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 	if err != nil {
 		return nil, err
 	}
+	gameSources, err := parseGameSources(ctx)
+	if err != nil {
+		return nil, err
+	}
 	return &config.Config{
 		// Required Flags
 		L1EthRpc:                        ctx.String(L1EthRpcFlag.Name),
@@ -454,10 +563,12 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		GameAllowlist:                   allowedGames,
 		GameWindow:                      ctx.Duration(GameWindowFlag.Name),
 		MaxConcurrency:                  maxConcurrency,
+		JobTimeout:                      ctx.Duration(JobTimeoutFlag.Name),
 		L2Rpc:                           l2Rpc,
 		MaxPendingTx:                    ctx.Uint64(MaxPendingTransactionsFlag.Name),
 		PollInterval:                    ctx.Duration(HTTPPollInterval.Name),
 		AdditionalBondClaimants:         claimants,
+		GameSources:                     gameSources,
 		RollupRpc:                       ctx.String(RollupRpcFlag.Name),
 		CannonNetwork:                   ctx.String(CannonNetworkFlag.Name),
 		CannonRollupConfigPath:          ctx.String(CannonRollupConfigFlag.Name),
@@ -467,6 +578,7 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		CannonAbsolutePreState:          ctx.String(CannonPreStateFlag.Name),
 		CannonAbsolutePreStateBaseURL:   cannonPrestatesURL,
 		Datadir:                         ctx.String(DatadirFlag.Name),
+		DiskQuota:                       ctx.Uint64(DiskQuotaFlag.Name),
 		CannonSnapshotFreq:              ctx.Uint(CannonSnapshotFreqFlag.Name),
 		CannonInfoFreq:                  ctx.Uint(CannonInfoFreqFlag.Name),
 		AsteriscNetwork:                 ctx.String(AsteriscNetworkFlag.Name),
@@ -478,10 +590,15 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 		AsteriscAbsolutePreStateBaseURL: asteriscPreStatesURL,
 		AsteriscSnapshotFreq:            ctx.Uint(AsteriscSnapshotFreqFlag.Name),
 		AsteriscInfoFreq:                ctx.Uint(AsteriscInfoFreqFlag.Name),
+		TracePlugin:                     ctx.String(TracePluginFlag.Name),
+		TracePluginGameType:             uint32(ctx.Uint(TracePluginGameTypeFlag.Name)),
+		TracePluginTimeout:              ctx.Duration(TracePluginTimeoutFlag.Name),
 		TxMgrConfig:                     txMgrConfig,
 		MetricsConfig:                   metricsConfig,
 		PprofConfig:                     pprofConfig,
+		RPC:                             oprpc.ReadCLIConfig(ctx),
 		SelectiveClaimResolution:        ctx.Bool(SelectiveClaimResolutionFlag.Name),
 		AllowInvalidPrestate:            ctx.Bool(UnsafeAllowInvalidPrestate.Name),
+		ShadowMode:                      ctx.Bool(ShadowModeFlag.Name),
 	}, nil
 }
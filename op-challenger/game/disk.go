@@ -6,16 +6,33 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/shared"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 )
 
-const gameDirPrefix = "game-"
+const (
+	gameDirPrefix = "game-"
+	// quarantineDir is the directory within datadir that quarantined game directories are moved to.
+	quarantineDir = "quarantine"
+	// scratchDir is the directory within datadir that worker scratch directories are created in.
+	scratchDir = "scratch"
+	// sourceDirPrefix is the prefix of the directories within datadir holding the games of each additional game
+	// source, see scheduler.RegisterSource.
+	sourceDirPrefix = "source-"
+)
 
 // diskManager coordinates the storage of game data on disk.
 type diskManager struct {
 	datadir string
+	// traces is the cache of VM executions shared between games, whose entries are released along with the game
+	// directories. Nil for the games of additional sources, which don't use the cache.
+	traces *shared.TraceCache
 }
 
 func newDiskManager(dir string) *diskManager {
@@ -27,11 +44,65 @@ func (d *diskManager) DirForGame(addr common.Address) string {
 }
 
 func (d *diskManager) RemoveAllExcept(keep []common.Address) error {
-	entries, err := os.ReadDir(d.datadir)
+	dirs, err := d.gameDirs()
 	if err != nil {
-		return fmt.Errorf("failed to list directory: %w", err)
+		return err
 	}
 	var errs []error
+	for _, dir := range dirs {
+		if slices.Contains(keep, dir.addr) {
+			// Preserve data for games we should keep.
+			continue
+		}
+		errs = append(errs, os.RemoveAll(filepath.Join(d.datadir, dir.name)))
+	}
+	errs = append(errs, d.traces.ReleaseAllExcept(keep))
+	return errors.Join(errs...)
+}
+
+// ForSource returns a disk manager for the games of an additional game source, stored in their own directory
+// within datadir so they are never removed when cleaning up the games of another source.
+func (d *diskManager) ForSource(factory common.Address) scheduler.DiskManager {
+	return newDiskManager(d.sourceDir(factory))
+}
+
+func (d *diskManager) sourceDir(factory common.Address) string {
+	return filepath.Join(d.datadir, sourceDirPrefix+factory.Hex())
+}
+
+// DirForWorker returns the scratch directory for the worker, see scheduler.ScratchDir.
+func (d *diskManager) DirForWorker(workerID int) string {
+	return filepath.Join(d.datadir, scratchDir, "worker-"+strconv.Itoa(workerID))
+}
+
+// ExistingGames returns the games that have a directory in datadir.
+func (d *diskManager) ExistingGames() ([]common.Address, error) {
+	dirs, err := d.gameDirs()
+	if err != nil {
+		return nil, err
+	}
+	games := make([]common.Address, 0, len(dirs))
+	for _, dir := range dirs {
+		games = append(games, dir.addr)
+	}
+	return games, nil
+}
+
+type gameDir struct {
+	addr common.Address
+	name string
+}
+
+// gameDirs lists the game directories in datadir.
+func (d *diskManager) gameDirs() ([]gameDir, error) {
+	entries, err := os.ReadDir(d.datadir)
+	if errors.Is(err, os.ErrNotExist) {
+		// The directory of a game source isn't created until the first of its games is.
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list directory: %w", err)
+	}
+	var dirs []gameDir
 	for _, entry := range entries {
 		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), gameDirPrefix) {
 			// Skip files and directories that don't have the game directory prefix.
@@ -45,11 +116,21 @@ func (d *diskManager) RemoveAllExcept(keep []common.Address) error {
 			// Ignore directories with non-address names.
 			continue
 		}
-		if slices.Contains(keep, addr) {
-			// Preserve data for games we should keep.
-			continue
-		}
-		errs = append(errs, os.RemoveAll(filepath.Join(d.datadir, entry.Name())))
+		dirs = append(dirs, gameDir{addr: addr, name: entry.Name()})
 	}
-	return errors.Join(errs...)
+	return dirs, nil
+}
+
+// QuarantineGame moves the game's directory into the quarantine directory so it is no longer used or removed.
+// The directory name is suffixed with the current unix time so repeated quarantines don't collide.
+func (d *diskManager) QuarantineGame(addr common.Address) (string, error) {
+	dir := filepath.Join(d.datadir, quarantineDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create quarantine directory: %w", err)
+	}
+	dest := filepath.Join(dir, gameDirPrefix+addr.Hex()+"-"+strconv.FormatInt(time.Now().UnixNano(), 10))
+	if err := os.Rename(d.DirForGame(addr), dest); err != nil {
+		return "", fmt.Errorf("failed to quarantine game directory: %w", err)
+	}
+	return dest, nil
 }
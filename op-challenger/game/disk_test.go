@@ -4,7 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/shared"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -55,3 +57,222 @@ func TestDiskManager_RemoveAllExcept(t *testing.T) {
 	require.DirExists(t, unexpectedDir, "should not delete unexpected dir")
 	require.DirExists(t, invalidHexDir, "should not delete dir with invalid address")
 }
+
+func TestDiskManager_ExistingGames(t *testing.T) {
+	baseDir := t.TempDir()
+	disk := newDiskManager(baseDir)
+	game1 := common.Address{0x53}
+	game2 := common.Address{0xaa}
+	require.NoError(t, os.MkdirAll(disk.DirForGame(game1), 0777))
+	require.NoError(t, os.MkdirAll(disk.DirForGame(game2), 0777))
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "notagame"), 0777))
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, gameDirPrefix+"0xNOPE"), 0777))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, gameDirPrefix+common.Address{0xbb}.Hex()), []byte("test"), 0644))
+
+	games, err := disk.ExistingGames()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []common.Address{game1, game2}, games)
+}
+
+func TestDiskManager_QuarantineGame(t *testing.T) {
+	baseDir := t.TempDir()
+	disk := newDiskManager(baseDir)
+	game := common.Address{0x53}
+	dir := disk.DirForGame(game)
+	require.NoError(t, os.MkdirAll(dir, 0777))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "test.txt"), []byte("foo"), 0644))
+
+	dest, err := disk.QuarantineGame(game)
+	require.NoError(t, err)
+	require.NoDirExists(t, dir)
+	require.FileExists(t, filepath.Join(dest, "test.txt"))
+
+	// Quarantined directories are not treated as games or removed
+	games, err := disk.ExistingGames()
+	require.NoError(t, err)
+	require.Empty(t, games)
+	require.NoError(t, disk.RemoveAllExcept(nil))
+	require.FileExists(t, filepath.Join(dest, "test.txt"))
+}
+
+func TestDiskManager_DirForWorker(t *testing.T) {
+	baseDir := t.TempDir()
+	disk := newDiskManager(baseDir)
+	dir1 := disk.DirForWorker(1)
+	require.Equal(t, filepath.Join(baseDir, scratchDir, "worker-1"), dir1)
+	require.NotEqual(t, dir1, disk.DirForWorker(2))
+
+	// Scratch dirs aren't game dirs so aren't removed with unused game data
+	require.NoError(t, os.MkdirAll(dir1, 0777))
+	require.NoError(t, disk.RemoveAllExcept(nil))
+	require.DirExists(t, dir1)
+}
+
+func TestDiskManager_ForSource(t *testing.T) {
+	baseDir := t.TempDir()
+	game := common.Address{0x53}
+	factory := common.Address{0xfa}
+	disk := newDiskManager(baseDir)
+	source := disk.ForSource(factory)
+	require.Equal(t, filepath.Join(baseDir, sourceDirPrefix+factory.Hex(), gameDirPrefix+game.Hex()), source.DirForGame(game))
+	require.NoError(t, source.RemoveAllExcept(nil), "should ignore source directory that doesn't exist yet")
+
+	require.NoError(t, os.MkdirAll(disk.DirForGame(game), 0777))
+	require.NoError(t, os.MkdirAll(source.DirForGame(game), 0777))
+	require.NoError(t, disk.RemoveAllExcept(nil))
+	require.NoDirExists(t, disk.DirForGame(game))
+	require.DirExists(t, source.DirForGame(game), "should not delete games of other sources")
+	require.NoError(t, source.RemoveAllExcept(nil))
+	require.NoDirExists(t, source.DirForGame(game))
+}
+
+func TestQuotaDiskManager_EnforceQuota(t *testing.T) {
+	baseDir := t.TempDir()
+	oldest := common.Address{0xaa}
+	newest := common.Address{0xbb}
+	other := common.Address{0xcc}
+	disk := newGameDiskManager(baseDir, 150, nil).(*quotaDiskManager)
+	populate := func(addr common.Address, size int) {
+		dir := filepath.Join(disk.DirForGame(addr), "nested")
+		require.NoError(t, os.MkdirAll(dir, 0777))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "data"), make([]byte, size), 0644))
+	}
+	populate(oldest, 100)
+	populate(newest, 100)
+	populate(other, 50)
+
+	evicted, usage, err := disk.EnforceQuota([]common.Address{oldest, newest})
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{oldest}, evicted, "should only evict until within quota")
+	require.EqualValues(t, 150, usage)
+	require.NoDirExists(t, disk.DirForGame(oldest))
+	require.DirExists(t, disk.DirForGame(newest))
+
+	evicted, usage, err = disk.EnforceQuota([]common.Address{newest})
+	require.NoError(t, err)
+	require.Empty(t, evicted, "should not evict within quota")
+	require.EqualValues(t, 150, usage)
+
+	require.IsType(t, &diskManager{}, newGameDiskManager(baseDir, 0, nil), "should not enforce quota of 0")
+	source, ok := disk.ForSource(common.Address{0xfa}).(*quotaDiskManager)
+	require.True(t, ok, "should apply quota to game sources")
+	require.Same(t, disk.quota, source.quota, "should share quota with game sources")
+}
+
+func TestQuotaDiskManager_EnforceQuotaSharedWithSources(t *testing.T) {
+	baseDir := t.TempDir()
+	primaryGame := common.Address{0xaa}
+	sourceGame := common.Address{0xbb}
+	disk := newGameDiskManager(baseDir, 150, nil).(*quotaDiskManager)
+	source := disk.ForSource(common.Address{0xfa}).(*quotaDiskManager)
+	populate := func(disk *quotaDiskManager, addr common.Address, size int) {
+		require.NoError(t, os.MkdirAll(disk.DirForGame(addr), 0777))
+		require.NoError(t, os.WriteFile(filepath.Join(disk.DirForGame(addr), "data"), make([]byte, size), 0644))
+	}
+	populate(disk, primaryGame, 100)
+	populate(source, sourceGame, 100)
+
+	evicted, usage, err := disk.EnforceQuota(nil)
+	require.NoError(t, err)
+	require.Empty(t, evicted)
+	require.EqualValues(t, 200, usage, "should count the usage of every source")
+
+	evicted, usage, err = source.EnforceQuota([]common.Address{sourceGame})
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{sourceGame}, evicted, "should evict while the total exceeds the quota")
+	require.EqualValues(t, 100, usage)
+	require.NoDirExists(t, source.DirForGame(sourceGame))
+
+	evicted, usage, err = disk.EnforceQuota([]common.Address{primaryGame})
+	require.NoError(t, err)
+	require.Empty(t, evicted, "should not evict once the total is within the quota")
+	require.EqualValues(t, 100, usage)
+	require.DirExists(t, disk.DirForGame(primaryGame))
+}
+
+func TestQuotaDiskManager_EnforceQuotaMeasuresInBackground(t *testing.T) {
+	baseDir := t.TempDir()
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	disk := newGameDiskManager(baseDir, 150, nil).(*quotaDiskManager)
+	populate := func(addr common.Address, size int) {
+		require.NoError(t, os.MkdirAll(disk.DirForGame(addr), 0777))
+		require.NoError(t, os.WriteFile(filepath.Join(disk.DirForGame(addr), "data"), make([]byte, size), 0644))
+	}
+	populate(game1, 100)
+	evicted, usage, err := disk.EnforceQuota([]common.Address{game1})
+	require.NoError(t, err)
+	require.Empty(t, evicted)
+	require.EqualValues(t, 100, usage)
+
+	// New data isn't counted until measured again
+	populate(game2, 100)
+	evicted, usage, err = disk.EnforceQuota([]common.Address{game1, game2})
+	require.NoError(t, err)
+	require.Empty(t, evicted)
+	require.EqualValues(t, 100, usage)
+
+	disk.lock.Lock()
+	disk.refreshInterval = 0
+	disk.lock.Unlock()
+	require.Eventually(t, func() bool {
+		evicted, usage, err = disk.EnforceQuota([]common.Address{game1, game2})
+		require.NoError(t, err)
+		return len(evicted) > 0
+	}, 10*time.Second, time.Millisecond)
+	require.Equal(t, []common.Address{game1}, evicted)
+	require.EqualValues(t, 100, usage)
+	require.NoDirExists(t, disk.DirForGame(game1))
+
+	// Removed data no longer counts even before it is measured again
+	require.NoError(t, os.RemoveAll(disk.DirForGame(game2)))
+	_, usage, err = disk.EnforceQuota(nil)
+	require.NoError(t, err)
+	require.Zero(t, usage)
+}
+
+func TestQuotaDiskManager_EnforceQuotaCountsSharedTraces(t *testing.T) {
+	baseDir := t.TempDir()
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	game3 := common.Address{0xcc}
+	traces := shared.NewTraceCache(filepath.Join(baseDir, "shared-traces"))
+	disk := newGameDiskManager(baseDir, 150, traces).(*quotaDiskManager)
+	for _, game := range []common.Address{game1, game2, game3} {
+		require.NoError(t, os.MkdirAll(disk.DirForGame(game), 0777))
+		require.NoError(t, os.WriteFile(filepath.Join(disk.DirForGame(game), "data"), make([]byte, 10), 0644))
+	}
+	// An oversized entry shared by games 1 and 2 and a smaller one used by game 3
+	sharedDir, err := traces.Acquire(game1, common.Hash{0x01})
+	require.NoError(t, err)
+	_, err = traces.Acquire(game2, common.Hash{0x01})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(sharedDir, "proofs"), make([]byte, 200), 0644))
+	otherDir, err := traces.Acquire(game3, common.Hash{0x02})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(otherDir, "proofs"), make([]byte, 50), 0644))
+
+	evicted, usage, err := disk.EnforceQuota([]common.Address{game1, game2, game3})
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{game1, game2}, evicted, "should evict every game using the entry")
+	require.EqualValues(t, 60, usage)
+	require.NoDirExists(t, sharedDir, "should delete entry once all games using it are evicted")
+	require.DirExists(t, otherDir)
+	require.DirExists(t, disk.DirForGame(game3))
+}
+
+func TestDiskManager_RemoveAllExceptReleasesTraces(t *testing.T) {
+	baseDir := t.TempDir()
+	keep := common.Address{0x53}
+	delete := common.Address{0xaa}
+	traces := shared.NewTraceCache(filepath.Join(baseDir, "shared-traces"))
+	disk := newGameDiskManager(baseDir, 0, traces)
+	keptDir, err := traces.Acquire(keep, common.Hash{0x01})
+	require.NoError(t, err)
+	releasedDir, err := traces.Acquire(delete, common.Hash{0x02})
+	require.NoError(t, err)
+
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{keep}))
+	require.DirExists(t, keptDir, "should keep traces used by kept game")
+	require.NoDirExists(t, releasedDir, "should delete traces no longer used")
+}
@@ -0,0 +1,274 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/shared"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+)
+
+// diskUsageRefreshInterval is how often the sizes of the game directories are measured again. Measuring walks every
+// file of each game so is done in the background, with the quota enforced using the most recent sizes meanwhile.
+const diskUsageRefreshInterval = time.Minute
+
+// diskQuota is the quota shared by a quotaDiskManager and the disk managers of its additional game sources, so the
+// games of every source together are limited to the configured size.
+type diskQuota struct {
+	limit uint64
+
+	// lock serialises enforcing the quota so members don't evict concurrently based on the same usage.
+	lock    sync.Mutex
+	members []*quotaDiskManager
+}
+
+// quotaDiskManager is a diskManager that limits the total size of the game directories in datadir, along with those
+// of the other disk managers sharing its quota, see scheduler.QuotaDiskManager.
+type quotaDiskManager struct {
+	*diskManager
+	quota           *diskQuota
+	refreshInterval time.Duration
+
+	// lock guards the measured sizes, which are replaced by measurements made in the background.
+	lock sync.Mutex
+	// sizes is the most recently measured size of each game directory and entrySizes of each trace cache entry.
+	// Nil until first measured.
+	sizes      map[common.Address]uint64
+	entrySizes map[common.Hash]uint64
+	measuredAt time.Time
+	measuring  bool
+	// evictions is incremented each time data is evicted, so measurements started before are discarded.
+	evictions uint64
+}
+
+var _ scheduler.QuotaDiskManager = (*quotaDiskManager)(nil)
+
+// newGameDiskManager returns the disk manager for the games in dir, limited to quota bytes if quota is not 0.
+// Entries of the shared trace cache are released when no game being kept uses them and count towards the quota,
+// so are deleted once every game using them has been evicted. traces may be nil.
+func newGameDiskManager(dir string, quota uint64, traces *shared.TraceCache) scheduler.DiskManager {
+	disk := newDiskManager(dir)
+	disk.traces = traces
+	if quota == 0 {
+		return disk
+	}
+	return newQuotaDiskManager(disk, &diskQuota{limit: quota})
+}
+
+// newQuotaDiskManager creates a disk manager for the games in disk that counts towards quota.
+func newQuotaDiskManager(disk *diskManager, quota *diskQuota) *quotaDiskManager {
+	d := &quotaDiskManager{diskManager: disk, quota: quota, refreshInterval: diskUsageRefreshInterval}
+	quota.lock.Lock()
+	defer quota.lock.Unlock()
+	quota.members = append(quota.members, d)
+	return d
+}
+
+// ForSource returns a disk manager for the games of an additional game source, sharing the quota so the games of
+// every source count towards a single limit.
+func (d *quotaDiskManager) ForSource(factory common.Address) scheduler.DiskManager {
+	return newQuotaDiskManager(newDiskManager(d.sourceDir(factory)), d.quota)
+}
+
+// EnforceQuota evicts candidates using the most recently measured sizes of the game directories and trace cache
+// entries, measuring them first if they have never been measured. Data created since the last measurement isn't
+// counted until the sizes are measured again in the background, while data since removed no longer counts.
+// The usage of every disk manager sharing the quota is counted, so the candidates are evicted while the games of all
+// sources together exceed it. Each source evicts its own candidates when it is scheduled, so data is evicted from
+// every source until the total is back within the quota. Returns the total usage of all sources.
+func (d *quotaDiskManager) EnforceQuota(candidates []common.Address) ([]common.Address, uint64, error) {
+	d.quota.lock.Lock()
+	defer d.quota.lock.Unlock()
+	var others uint64
+	for _, member := range d.quota.members {
+		if member == d {
+			continue
+		}
+		usage, err := member.usage()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to measure usage of %v: %w", member.datadir, err)
+		}
+		others += usage
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	sizes, entries, entrySizes, usage, err := d.currentSizes()
+	if err != nil {
+		return nil, 0, err
+	}
+	var evicted []common.Address
+	var errs []error
+	for _, addr := range candidates {
+		if usage+others <= d.quota.limit {
+			break
+		}
+		size, hasDir := sizes[addr]
+		usesEntry := slices.ContainsFunc(entries, func(entry shared.Entry) bool { return slices.Contains(entry.Games, addr) })
+		if !hasDir && !usesEntry {
+			continue
+		}
+		if hasDir {
+			if err := os.RemoveAll(d.DirForGame(addr)); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			usage -= size
+			delete(d.sizes, addr)
+		}
+		evicted = append(evicted, addr)
+		// Delete the cache entries that were only used by evicted games.
+		for i := 0; i < len(entries); i++ {
+			entry := entries[i]
+			if !slices.Contains(entry.Games, addr) || !isSubset(entry.Games, evicted) {
+				continue
+			}
+			deleted, err := d.traces.Evict(entry.Key, entry.Games)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if deleted {
+				usage -= entrySizes[i]
+				delete(d.entrySizes, entry.Key)
+			}
+			entries = slices.Delete(entries, i, i+1)
+			entrySizes = slices.Delete(entrySizes, i, i+1)
+			i--
+		}
+	}
+	if len(evicted) > 0 {
+		d.evictions++
+	}
+	return evicted, usage + others, errors.Join(errs...)
+}
+
+// usage returns the total size of the game directories and trace cache entries using the most recent measurement.
+func (d *quotaDiskManager) usage() (uint64, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	_, _, _, usage, err := d.currentSizes()
+	return usage, err
+}
+
+// currentSizes returns the most recently measured size of each existing game directory and trace cache entry, and
+// their total, measuring them first if they have never been measured and starting a measurement in the background
+// if the last is out of date. The lock must be held.
+func (d *quotaDiskManager) currentSizes() (map[common.Address]uint64, []shared.Entry, []uint64, uint64, error) {
+	if d.sizes == nil {
+		sizes, entrySizes, err := d.measure()
+		if err != nil {
+			return nil, nil, nil, 0, err
+		}
+		d.sizes, d.entrySizes, d.measuredAt = sizes, entrySizes, time.Now()
+	} else if !d.measuring && time.Since(d.measuredAt) >= d.refreshInterval {
+		d.measuring = true
+		go d.refresh(d.evictions)
+	}
+	dirs, err := d.gameDirs()
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+	sizes := make(map[common.Address]uint64, len(dirs))
+	var usage uint64
+	for _, dir := range dirs {
+		sizes[dir.addr] = d.sizes[dir.addr]
+		usage += sizes[dir.addr]
+	}
+	entries, err := d.traces.Entries()
+	if err != nil {
+		return nil, nil, nil, 0, err
+	}
+	entrySizes := make([]uint64, len(entries))
+	for i, entry := range entries {
+		entrySizes[i] = d.entrySizes[entry.Key]
+		usage += entrySizes[i]
+	}
+	return sizes, entries, entrySizes, usage, nil
+}
+
+// refresh measures the sizes of the game directories and trace cache entries in the background, keeping the
+// measurement unless data was evicted after evictions while measuring.
+func (d *quotaDiskManager) refresh(evictions uint64) {
+	sizes, entrySizes, err := d.measure()
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.measuring = false
+	if err != nil || d.evictions != evictions {
+		// Measure again on the next call.
+		d.measuredAt = time.Time{}
+		return
+	}
+	d.sizes, d.entrySizes, d.measuredAt = sizes, entrySizes, time.Now()
+}
+
+// measure returns the size of each game directory and trace cache entry.
+func (d *quotaDiskManager) measure() (map[common.Address]uint64, map[common.Hash]uint64, error) {
+	dirs, err := d.gameDirs()
+	if err != nil {
+		return nil, nil, err
+	}
+	sizes := make(map[common.Address]uint64, len(dirs))
+	for _, dir := range dirs {
+		size, err := dirSize(filepath.Join(d.datadir, dir.name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to measure game directory %v: %w", dir.name, err)
+		}
+		sizes[dir.addr] = size
+	}
+	entries, err := d.traces.Entries()
+	if err != nil {
+		return nil, nil, err
+	}
+	entrySizes := make(map[common.Hash]uint64, len(entries))
+	for _, entry := range entries {
+		size, err := dirSize(entry.Dir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to measure trace cache entry %v: %w", entry.Key, err)
+		}
+		entrySizes[entry.Key] = size
+	}
+	return sizes, entrySizes, nil
+}
+
+// isSubset returns true if every address in a is also in b.
+func isSubset(a, b []common.Address) bool {
+	for _, addr := range a {
+		if !slices.Contains(b, addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// dirSize returns the total size in bytes of the regular files within dir.
+func dirSize(dir string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			// Files may be removed while the directory is being measured.
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/sender"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum/go-ethereum/common"
@@ -44,6 +45,50 @@ type Agent struct {
 	maxDepth         types.Depth
 	maxClockDuration time.Duration
 	log              log.Logger
+
+	// simulated holds the actions simulated in shadow mode. Simulated actions don't change the game so would
+	// otherwise be calculated and simulated again each time the game is progressed.
+	simulatedLock sync.Mutex
+	simulated     map[simulatedAction]bool
+
+	outcomeLock sync.Mutex
+	outcome     ActOutcome
+	// agreeWithRoot is whether the claimants agree with the root claim, or nil until it has been checked. The root
+	// claim never changes so it is only checked once.
+	agreeWithRoot *bool
+	// responded holds the contract index of the claims responded to by the most recent call to Act.
+	responded map[int]bool
+
+	// loaded holds the claims most recently loaded from the contract and the L1 time they were loaded at, see
+	// CaptureSnapshot.
+	loadedLock sync.Mutex
+	loaded     []types.Claim
+	loadedAt   time.Time
+}
+
+// ActOutcome describes the result of the most recent call to Act.
+type ActOutcome struct {
+	// Acted is true if a move, step or resolution was sent. Transactions that failed or were only simulated in
+	// shadow mode don't count.
+	Acted bool
+	// Deadline is when the chess clock of the earliest claim awaiting a response from the claimants expires, after
+	// which the claim can no longer be countered. Zero if no claims are awaiting a response.
+	Deadline time.Time
+	// ClaimsAtRisk is true if a claim made by one of the claimants has been countered by a claim that hasn't been
+	// responded to, so the claimant's bond is lost if the counter's clock expires.
+	ClaimsAtRisk bool
+	// StanceKnown is true once the agent has checked whether the claimants agree with the game's root claim, and
+	// AgreeWithRootClaim reports whether they do, so argue for the defender rather than the challenger.
+	StanceKnown        bool
+	AgreeWithRootClaim bool
+}
+
+// simulatedAction identifies an action simulated in shadow mode.
+type simulatedAction struct {
+	actionType types.ActionType
+	parentIdx  int
+	isAttack   bool
+	value      common.Hash
 }
 
 func NewAgent(
@@ -71,11 +116,16 @@ func NewAgent(
 		maxDepth:         maxDepth,
 		maxClockDuration: maxClockDuration,
 		log:              log,
+		simulated:        make(map[simulatedAction]bool),
 	}
 }
 
 // Act iterates the game & performs all of the next actions.
 func (a *Agent) Act(ctx context.Context) error {
+	a.outcomeLock.Lock()
+	a.outcome = ActOutcome{}
+	a.responded = make(map[int]bool)
+	a.outcomeLock.Unlock()
 	if a.tryResolve(ctx) {
 		return nil
 	}
@@ -88,11 +138,13 @@ func (a *Agent) Act(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("create game from contracts: %w", err)
 	}
+	a.recordStance(ctx, game)
 
 	actions, err := a.solver.CalculateNextActions(ctx, game)
 	if err != nil {
 		a.log.Error("Failed to calculate all required moves", "err", err)
 	}
+	actions = slices.DeleteFunc(actions, a.wasSimulated)
 
 	var wg sync.WaitGroup
 	wg.Add(len(actions))
@@ -100,9 +152,86 @@ func (a *Agent) Act(ctx context.Context) error {
 		go a.performAction(ctx, &wg, action)
 	}
 	wg.Wait()
+	a.recordClaimClocks(game)
 	return nil
 }
 
+// recordClaimClocks records the game's next deadline and whether the claimants have claims at risk from the claims
+// awaiting a response, which are those made by other claimants that haven't been countered or just responded to.
+func (a *Agent) recordClaimClocks(game types.Game) {
+	countered := make(map[int]bool)
+	for _, claim := range game.Claims() {
+		if !claim.IsRoot() {
+			countered[claim.ParentContractIndex] = true
+		}
+	}
+	now := a.l1Clock.Now()
+	a.outcomeLock.Lock()
+	defer a.outcomeLock.Unlock()
+	for _, claim := range game.Claims() {
+		if countered[claim.ContractIndex] || a.responded[claim.ContractIndex] || slices.Contains(a.claimants, claim.Claimant) {
+			continue
+		}
+		remaining := a.maxClockDuration - claim.ChessTime(now)
+		if remaining <= 0 {
+			// The claim can no longer be countered, only resolved.
+			continue
+		}
+		if deadline := now.Add(remaining); a.outcome.Deadline.IsZero() || deadline.Before(a.outcome.Deadline) {
+			a.outcome.Deadline = deadline
+		}
+		if claim.IsRoot() {
+			continue
+		}
+		if parent, err := game.GetParent(claim); err == nil && slices.Contains(a.claimants, parent.Claimant) {
+			a.outcome.ClaimsAtRisk = true
+		}
+	}
+}
+
+// recordStance checks whether the claimants agree with the game's root claim, unless already known.
+func (a *Agent) recordStance(ctx context.Context, game types.Game) {
+	a.outcomeLock.Lock()
+	known := a.agreeWithRoot != nil
+	a.outcomeLock.Unlock()
+	if known {
+		return
+	}
+	agree, err := a.solver.AgreeWithRootClaim(ctx, game)
+	if err != nil {
+		a.log.Warn("Failed to determine if root claim is correct", "err", err)
+		return
+	}
+	a.outcomeLock.Lock()
+	defer a.outcomeLock.Unlock()
+	a.agreeWithRoot = &agree
+}
+
+// LastOutcome returns the outcome of the most recent call to Act.
+func (a *Agent) LastOutcome() ActOutcome {
+	a.outcomeLock.Lock()
+	defer a.outcomeLock.Unlock()
+	outcome := a.outcome
+	if a.agreeWithRoot != nil {
+		outcome.StanceKnown = true
+		outcome.AgreeWithRootClaim = *a.agreeWithRoot
+	}
+	return outcome
+}
+
+func (a *Agent) recordActed() {
+	a.outcomeLock.Lock()
+	defer a.outcomeLock.Unlock()
+	a.outcome.Acted = true
+}
+
+func (a *Agent) recordResponse(parentIdx int) {
+	a.outcomeLock.Lock()
+	defer a.outcomeLock.Unlock()
+	a.outcome.Acted = true
+	a.responded[parentIdx] = true
+}
+
 func (a *Agent) performAction(ctx context.Context, wg *sync.WaitGroup, action types.Action) {
 	defer wg.Done()
 	actionLog := a.log.New("action", action.Type, "is_attack", action.IsAttack, "parent", action.ParentIdx)
@@ -130,8 +259,30 @@ func (a *Agent) performAction(ctx context.Context, wg *sync.WaitGroup, action ty
 	}
 	actionLog.Info("Performing action")
 	err := a.responder.PerformAction(ctx, action)
-	if err != nil {
+	if errors.Is(err, sender.ErrTxSimulated) {
+		a.simulatedLock.Lock()
+		a.simulated[toSimulatedAction(action)] = true
+		a.simulatedLock.Unlock()
+		actionLog.Info("Action simulated, not sent in shadow mode")
+	} else if err != nil {
 		actionLog.Error("Action failed", "err", err)
+	} else {
+		a.recordResponse(action.ParentIdx)
+	}
+}
+
+func (a *Agent) wasSimulated(action types.Action) bool {
+	a.simulatedLock.Lock()
+	defer a.simulatedLock.Unlock()
+	return a.simulated[toSimulatedAction(action)]
+}
+
+func toSimulatedAction(action types.Action) simulatedAction {
+	return simulatedAction{
+		actionType: action.Type,
+		parentIdx:  action.ParentIdx,
+		isAttack:   action.IsAttack,
+		value:      action.Value,
 	}
 }
 
@@ -147,8 +298,12 @@ func (a *Agent) tryResolve(ctx context.Context) bool {
 		return false
 	}
 	a.log.Info("Resolving game")
-	if err := a.responder.Resolve(); err != nil {
+	if err := a.responder.Resolve(); errors.Is(err, sender.ErrTxSimulated) {
+		a.log.Info("Game resolution simulated, not sent in shadow mode")
+	} else if err != nil {
 		a.log.Error("Failed to resolve the game", "err", err)
+	} else {
+		a.recordActed()
 	}
 	return true
 }
@@ -156,7 +311,7 @@ func (a *Agent) tryResolve(ctx context.Context) bool {
 var errNoResolvableClaims = errors.New("no resolvable claims")
 
 func (a *Agent) tryResolveClaims(ctx context.Context) error {
-	claims, err := a.loader.GetAllClaims(ctx, rpcblock.Latest)
+	claims, err := a.loadClaims(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to fetch claims: %w", err)
 	}
@@ -189,8 +344,14 @@ func (a *Agent) tryResolveClaims(ctx context.Context) error {
 	}
 	a.log.Info("Resolving claims", "numClaims", len(resolvableClaims))
 
-	if err := a.responder.ResolveClaims(resolvableClaims...); err != nil {
+	if err := a.responder.ResolveClaims(resolvableClaims...); errors.Is(err, sender.ErrTxSimulated) {
+		// The claims are still unresolved so would be found resolvable again and again.
+		a.log.Info("Claim resolution simulated, not sent in shadow mode", "numClaims", len(resolvableClaims))
+		return errNoResolvableClaims
+	} else if err != nil {
 		a.log.Error("Failed to resolve claims", "err", err)
+	} else {
+		a.recordActed()
 	}
 	return nil
 }
@@ -215,7 +376,7 @@ func (a *Agent) resolveClaims(ctx context.Context) error {
 
 // newGameFromContracts initializes a new game state from the state in the contract
 func (a *Agent) newGameFromContracts(ctx context.Context) (types.Game, error) {
-	claims, err := a.loader.GetAllClaims(ctx, rpcblock.Latest)
+	claims, err := a.loadClaims(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch claims: %w", err)
 	}
@@ -225,3 +386,16 @@ func (a *Agent) newGameFromContracts(ctx context.Context) (types.Game, error) {
 	game := types.NewGameState(claims, a.maxDepth)
 	return game, nil
 }
+
+// loadClaims loads the current claims from the contract and records them to be captured in snapshots.
+func (a *Agent) loadClaims(ctx context.Context) ([]types.Claim, error) {
+	claims, err := a.loader.GetAllClaims(ctx, rpcblock.Latest)
+	if err != nil {
+		return nil, err
+	}
+	a.loadedLock.Lock()
+	defer a.loadedLock.Unlock()
+	a.loaded = claims
+	a.loadedAt = a.l1Clock.Now()
+	return claims, nil
+}
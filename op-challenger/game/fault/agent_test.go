@@ -3,6 +3,7 @@ package fault
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"sync"
 	"testing"
@@ -21,6 +22,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/sender"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
@@ -166,6 +168,130 @@ func TestLoadClaimsWhenGameNotResolvable(t *testing.T) {
 	require.Zero(t, responder.resolveClaimCount, "should not send resolveClaim")
 }
 
+func TestActOutcomeReportsTransactionsSent(t *testing.T) {
+	depth := types.Depth(4)
+	tests := []struct {
+		name      string
+		resolve   bool
+		outputVal int64
+		simulated bool
+		expected  bool
+	}{
+		{name: "NoActionRequired", outputVal: 0, expected: false},
+		{name: "MoveSent", outputVal: 1, expected: true},
+		{name: "MoveSimulated", outputVal: 1, simulated: true, expected: false},
+		{name: "GameResolved", resolve: true, expected: true},
+		{name: "GameResolutionSimulated", resolve: true, simulated: true, expected: false},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			agent, claimLoader, responder := setupTestAgent(t)
+			if tc.resolve {
+				responder.callResolveStatus = gameTypes.GameStatusDefenderWon
+			} else {
+				responder.callResolveErr = errors.New("game is not resolvable")
+			}
+			responder.callResolveClaimErr = errors.New("claim is not resolvable")
+			responder.simulated = tc.simulated
+			claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(tc.outputVal), depth))
+			claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim()}
+
+			require.NoError(t, agent.Act(context.Background()))
+			require.Equal(t, tc.expected, agent.LastOutcome().Acted)
+		})
+	}
+}
+
+func TestActOutcomeReportsClaimClocks(t *testing.T) {
+	ours := common.Address{0xaa}
+	depth := types.Depth(4)
+	setup := func(t *testing.T) (*Agent, *stubResponder) {
+		agent, claimLoader, responder := setupTestAgent(t)
+		agent.claimants = []common.Address{ours}
+		responder.callResolveErr = errors.New("game is not resolvable")
+		responder.callResolveClaimErr = errors.New("claim is not resolvable")
+		claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+		now := agent.l1Clock.Now()
+		root := claimBuilder.CreateRootClaim(test.WithClaimant(ours))
+		root.Clock = types.Clock{Timestamp: now.Add(-time.Minute)}
+		counter := claimBuilder.AttackClaim(root, test.WithInvalidValue(true), test.WithClaimant(common.Address{0xbb}))
+		counter.ContractIndex = 1
+		counter.Clock = types.Clock{Duration: time.Minute, Timestamp: now}
+		claimLoader.claims = []types.Claim{root, counter}
+		return agent, responder
+	}
+
+	t.Run("Unanswered", func(t *testing.T) {
+		agent, responder := setup(t)
+		// The response is only simulated so the counter remains unanswered
+		responder.simulated = true
+		require.NoError(t, agent.Act(context.Background()))
+		outcome := agent.LastOutcome()
+		require.Equal(t, agent.l1Clock.Now().Add(agent.maxClockDuration-time.Minute), outcome.Deadline)
+		require.True(t, outcome.ClaimsAtRisk)
+	})
+
+	t.Run("Answered", func(t *testing.T) {
+		agent, responder := setup(t)
+		require.NoError(t, agent.Act(context.Background()))
+		require.Equal(t, 1, responder.performActionCount)
+		outcome := agent.LastOutcome()
+		require.True(t, outcome.Deadline.IsZero())
+		require.False(t, outcome.ClaimsAtRisk)
+	})
+}
+
+func TestActOutcomeReportsStance(t *testing.T) {
+	depth := types.Depth(4)
+	for _, agree := range []bool{true, false} {
+		agree := agree
+		t.Run(fmt.Sprintf("Agree-%v", agree), func(t *testing.T) {
+			agent, claimLoader, responder := setupTestAgent(t)
+			responder.callResolveErr = errors.New("game is not resolvable")
+			responder.callResolveClaimErr = errors.New("claim is not resolvable")
+			responder.simulated = true
+			claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+			claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim(test.WithInvalidValue(!agree))}
+			require.False(t, agent.LastOutcome().StanceKnown, "stance unknown before acting")
+
+			require.NoError(t, agent.Act(context.Background()))
+			outcome := agent.LastOutcome()
+			require.True(t, outcome.StanceKnown)
+			require.Equal(t, agree, outcome.AgreeWithRootClaim)
+		})
+	}
+}
+
+func TestShadowModeActionsSimulatedOnce(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.simulated = true
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(1), depth))
+	claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim()}
+
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 1, responder.performActionCount)
+
+	// The game is unchanged because the action was only simulated, so it shouldn't be simulated again
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 1, responder.performActionCount)
+}
+
+func TestShadowModeClaimResolutionSimulatedOnce(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.simulated = true
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim()}
+
+	// Would never return if the still unresolved claim was resolved again
+	require.NoError(t, agent.resolveClaims(context.Background()))
+	require.Equal(t, 1, responder.resolveClaimCount)
+}
+
 func setupTestAgent(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
 	logger := testlog.Logger(t, log.LevelInfo)
 	claimLoader := &stubClaimLoader{}
@@ -205,6 +331,17 @@ type stubResponder struct {
 	callResolveClaimCount int
 	callResolveClaimErr   error
 	resolveClaimCount     int
+
+	performActionCount int
+	// simulated makes every transaction sent report that it was simulated in shadow mode
+	simulated bool
+}
+
+func (s *stubResponder) sendErr() error {
+	if s.simulated {
+		return sender.ErrTxSimulated
+	}
+	return nil
 }
 
 func (s *stubResponder) CallResolve(_ context.Context) (gameTypes.GameStatus, error) {
@@ -218,7 +355,10 @@ func (s *stubResponder) Resolve() error {
 	s.l.Lock()
 	defer s.l.Unlock()
 	s.resolveCount++
-	return s.resolveErr
+	if s.resolveErr != nil {
+		return s.resolveErr
+	}
+	return s.sendErr()
 }
 
 func (s *stubResponder) CallResolveClaim(_ context.Context, _ uint64) error {
@@ -232,9 +372,12 @@ func (s *stubResponder) ResolveClaims(claims ...uint64) error {
 	s.l.Lock()
 	defer s.l.Unlock()
 	s.resolveClaimCount += len(claims)
-	return nil
+	return s.sendErr()
 }
 
 func (s *stubResponder) PerformAction(_ context.Context, _ types.Action) error {
-	return nil
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.performActionCount++
+	return s.sendErr()
 }
@@ -5,14 +5,23 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/sender"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// ErrClaimInProgress is returned by ClaimGame if the game's bonds are already being claimed by another call.
+var ErrClaimInProgress = errors.New("bonds already being claimed")
+
+// ErrClaimSimulated is returned by ClaimGame if credit claims were only simulated in shadow mode. The credit remains
+// outstanding but can't be claimed by retrying.
+var ErrClaimSimulated = errors.New("credit claim simulated in shadow mode")
+
 type TxSender interface {
 	SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error
 }
@@ -34,6 +43,10 @@ type Claimer struct {
 	contractCreator BondContractCreator
 	txSender        TxSender
 	claimants       []common.Address
+
+	// claiming is the set of games whose bonds are being claimed, so concurrent calls don't send duplicate claims.
+	lock     sync.Mutex
+	claiming map[common.Address]bool
 }
 
 var _ BondClaimer = (*Claimer)(nil)
@@ -45,52 +58,100 @@ func NewBondClaimer(l log.Logger, m BondClaimMetrics, contractCreator BondContra
 		contractCreator: contractCreator,
 		txSender:        txSender,
 		claimants:       claimants,
+		claiming:        make(map[common.Address]bool),
 	}
 }
 
 func (c *Claimer) ClaimBonds(ctx context.Context, games []types.GameMetadata) (err error) {
 	for _, game := range games {
-		for _, claimant := range c.claimants {
-			err = errors.Join(err, c.claimBond(ctx, game, claimant))
+		if _, claimErr := c.ClaimGame(ctx, game); !errors.Is(claimErr, ErrClaimInProgress) && !errors.Is(claimErr, ErrClaimSimulated) {
+			err = errors.Join(err, claimErr)
 		}
 	}
 	return err
 }
 
-func (c *Claimer) claimBond(ctx context.Context, game types.GameMetadata, addr common.Address) error {
+// ClaimGame claims the credit of each claimant from the game. Returns the credit that couldn't be claimed yet,
+// because the game is in progress, the credit is still locked or claiming it failed, so the claim should be retried.
+// Returns ErrClaimInProgress if the game's bonds are already being claimed, or ErrClaimSimulated if no claims failed
+// but some were only simulated in shadow mode.
+func (c *Claimer) ClaimGame(ctx context.Context, game types.GameMetadata) (*big.Int, error) {
+	if !c.beginClaim(game.Proxy) {
+		return nil, ErrClaimInProgress
+	}
+	defer c.endClaim(game.Proxy)
+	outstanding := new(big.Int)
+	var err error
+	simulated := false
+	for _, claimant := range c.claimants {
+		credit, claimErr := c.claimBond(ctx, game, claimant)
+		outstanding.Add(outstanding, credit)
+		if errors.Is(claimErr, ErrClaimSimulated) {
+			simulated = true
+			continue
+		}
+		err = errors.Join(err, claimErr)
+	}
+	if err == nil && simulated {
+		return outstanding, ErrClaimSimulated
+	}
+	return outstanding, err
+}
+
+func (c *Claimer) beginClaim(game common.Address) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.claiming[game] {
+		return false
+	}
+	c.claiming[game] = true
+	return true
+}
+
+func (c *Claimer) endClaim(game common.Address) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.claiming, game)
+}
+
+// claimBond claims the credit of addr from the game, returning the credit that remains to be claimed.
+func (c *Claimer) claimBond(ctx context.Context, game types.GameMetadata, addr common.Address) (*big.Int, error) {
 	c.logger.Debug("Attempting to claim bonds for", "game", game.Proxy, "addr", addr)
 
 	contract, err := c.contractCreator(game)
 	if err != nil {
-		return fmt.Errorf("failed to create bond contract: %w", err)
+		return common.Big0, fmt.Errorf("failed to create bond contract: %w", err)
 	}
 
 	credit, status, err := contract.GetCredit(ctx, addr)
 	if err != nil {
-		return fmt.Errorf("failed to get credit: %w", err)
+		return common.Big0, fmt.Errorf("failed to get credit: %w", err)
 	}
 
 	if status == types.GameStatusInProgress {
 		c.logger.Debug("Not claiming credit from in progress game", "game", game.Proxy, "addr", addr, "status", status)
-		return nil
+		return credit, nil
 	}
 	if credit.Cmp(big.NewInt(0)) == 0 {
 		c.logger.Debug("No credit to claim", "game", game.Proxy, "addr", addr)
-		return nil
+		return credit, nil
 	}
 
 	candidate, err := contract.ClaimCreditTx(ctx, addr)
 	if errors.Is(err, contracts.ErrSimulationFailed) {
 		c.logger.Debug("Credit still locked", "game", game.Proxy, "addr", addr)
-		return nil
+		return credit, nil
 	} else if err != nil {
-		return fmt.Errorf("failed to create credit claim tx: %w", err)
+		return credit, fmt.Errorf("failed to create credit claim tx: %w", err)
 	}
 
-	if err = c.txSender.SendAndWaitSimple("claim credit", candidate); err != nil {
-		return fmt.Errorf("failed to claim credit: %w", err)
+	if err = c.txSender.SendAndWaitSimple("claim credit", candidate); errors.Is(err, sender.ErrTxSimulated) {
+		c.logger.Info("Credit claim simulated, not sent in shadow mode", "game", game.Proxy, "addr", addr, "credit", credit)
+		return credit, ErrClaimSimulated
+	} else if err != nil {
+		return credit, fmt.Errorf("failed to claim credit: %w", err)
 	}
 
 	c.metrics.RecordBondClaimed(credit.Uint64())
-	return nil
+	return common.Big0, nil
 }
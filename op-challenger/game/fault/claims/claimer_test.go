@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/sender"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
@@ -99,6 +100,17 @@ func TestClaimer_ClaimBonds(t *testing.T) {
 		require.Equal(t, 0, m.RecordBondClaimedCalls)
 	})
 
+	t.Run("SimulatedClaimReturnsNil", func(t *testing.T) {
+		gameAddr := common.HexToAddress("0x1234")
+		c, m, contract, txSender := newTestClaimer(t)
+		contract.credit[txSender.From()] = 1
+		txSender.simulated = true
+		err := c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}})
+		require.NoError(t, err)
+		require.Equal(t, 1, txSender.sends)
+		require.Equal(t, 0, m.RecordBondClaimedCalls)
+	})
+
 	t.Run("MultipleBondClaimFails", func(t *testing.T) {
 		gameAddr := common.HexToAddress("0x1234")
 		c, m, contract, txSender := newTestClaimer(t)
@@ -111,6 +123,76 @@ func TestClaimer_ClaimBonds(t *testing.T) {
 	})
 }
 
+func TestClaimer_ClaimGame(t *testing.T) {
+	gameAddr := common.HexToAddress("0x1234")
+	claimant1 := common.Address{0xaa}
+	claimant2 := common.Address{0xbb}
+
+	t.Run("NoCreditOutstandingOnceClaimed", func(t *testing.T) {
+		c, _, contract, txSender := newTestClaimer(t, claimant1, claimant2)
+		contract.credit[claimant1] = 1
+		contract.credit[claimant2] = 2
+		outstanding, err := c.ClaimGame(context.Background(), types.GameMetadata{Proxy: gameAddr})
+		require.NoError(t, err)
+		require.Zero(t, outstanding.Sign())
+		require.Equal(t, 2, txSender.sends)
+	})
+
+	t.Run("CreditOutstandingForInProgressGame", func(t *testing.T) {
+		c, _, contract, _ := newTestClaimer(t, claimant1, claimant2)
+		contract.credit[claimant1] = 1
+		contract.credit[claimant2] = 2
+		contract.status = types.GameStatusInProgress
+		outstanding, err := c.ClaimGame(context.Background(), types.GameMetadata{Proxy: gameAddr})
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(3), outstanding)
+	})
+
+	t.Run("CreditOutstandingWhileLocked", func(t *testing.T) {
+		c, _, contract, _ := newTestClaimer(t, claimant1)
+		contract.credit[claimant1] = 5
+		contract.claimSimulationFails = true
+		outstanding, err := c.ClaimGame(context.Background(), types.GameMetadata{Proxy: gameAddr})
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(5), outstanding)
+	})
+
+	t.Run("CreditOutstandingWhenClaimFails", func(t *testing.T) {
+		c, _, contract, txSender := newTestClaimer(t, claimant1)
+		contract.credit[claimant1] = 5
+		txSender.sendFails = true
+		outstanding, err := c.ClaimGame(context.Background(), types.GameMetadata{Proxy: gameAddr})
+		require.ErrorIs(t, err, mockTxMgrSendError)
+		require.Equal(t, big.NewInt(5), outstanding)
+	})
+
+	t.Run("CreditOutstandingWhenSimulated", func(t *testing.T) {
+		c, m, contract, txSender := newTestClaimer(t, claimant1)
+		contract.credit[claimant1] = 5
+		txSender.simulated = true
+		outstanding, err := c.ClaimGame(context.Background(), types.GameMetadata{Proxy: gameAddr})
+		require.ErrorIs(t, err, ErrClaimSimulated)
+		require.Equal(t, big.NewInt(5), outstanding)
+		require.Equal(t, 1, txSender.sends)
+		require.Zero(t, m.RecordBondClaimedCalls, "should not record simulated claim as claimed")
+	})
+
+	t.Run("SkipGameAlreadyBeingClaimed", func(t *testing.T) {
+		c, m, contract, txSender := newTestClaimer(t, claimant1)
+		contract.credit[claimant1] = 1
+		require.True(t, c.beginClaim(gameAddr))
+		_, err := c.ClaimGame(context.Background(), types.GameMetadata{Proxy: gameAddr})
+		require.ErrorIs(t, err, ErrClaimInProgress)
+		require.NoError(t, c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+		require.Equal(t, 0, txSender.sends)
+		require.Equal(t, 0, m.RecordBondClaimedCalls)
+
+		c.endClaim(gameAddr)
+		require.NoError(t, c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}}))
+		require.Equal(t, 1, txSender.sends)
+	})
+}
+
 func newTestClaimer(t *testing.T, claimants ...common.Address) (*Claimer, *mockClaimMetrics, *stubBondContract, *mockTxSender) {
 	logger := testlog.Logger(t, log.LvlDebug)
 	m := &mockClaimMetrics{}
@@ -138,6 +220,7 @@ type mockTxSender struct {
 	sends      int
 	sendFails  bool
 	statusFail bool
+	simulated  bool
 }
 
 func (s *mockTxSender) From() common.Address {
@@ -152,6 +235,9 @@ func (s *mockTxSender) SendAndWaitSimple(_ string, _ ...txmgr.TxCandidate) error
 	if s.statusFail {
 		return errors.New("transaction reverted")
 	}
+	if s.simulated {
+		return fmt.Errorf("%w, purpose: claim credit", sender.ErrTxSimulated)
+	}
 	return nil
 }
 
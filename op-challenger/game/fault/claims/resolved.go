@@ -0,0 +1,218 @@
+package claims
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+const (
+	// resolvedClaimQueueSize is the number of claims that can be waiting for a worker before further claims are
+	// delayed until the next retry.
+	resolvedClaimQueueSize = 100
+	minClaimRetryDelay     = time.Minute
+	maxClaimRetryDelay     = time.Hour
+)
+
+type GameClaimer interface {
+	ClaimGame(ctx context.Context, game types.GameMetadata) (*big.Int, error)
+}
+
+type ResolvedClaimMetrics interface {
+	RecordBondClaimFailed()
+	RecordBondsOutstanding(games int, amount *big.Int)
+}
+
+// ResolvedGameClaimer claims the bonds of games as soon as they resolve in favour of the claimants, rather than
+// waiting for the next sweep of the BondClaimScheduler. Claims are made by a small pool of workers and retried with
+// exponential backoff, from one minute up to an hour, while credit remains locked or claiming fails, until no credit
+// remains to be claimed. It also implements BondClaimer so the sweep can use it to skip games it is already claiming.
+type ResolvedGameClaimer struct {
+	logger  log.Logger
+	metrics ResolvedClaimMetrics
+	clock   clock.Clock
+	claimer GameClaimer
+	workers int
+	queue   chan types.GameMetadata
+
+	// pending holds the games whose bonds are waiting to be claimed.
+	lock    sync.Mutex
+	pending map[common.Address]*pendingClaim
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+var _ BondClaimer = (*ResolvedGameClaimer)(nil)
+
+// pendingClaim is a game whose bonds are waiting to be claimed.
+type pendingClaim struct {
+	attempts    uint
+	outstanding *big.Int
+}
+
+func NewResolvedGameClaimer(logger log.Logger, metrics ResolvedClaimMetrics, cl clock.Clock, claimer GameClaimer, workers int) *ResolvedGameClaimer {
+	return &ResolvedGameClaimer{
+		logger:  logger,
+		metrics: metrics,
+		clock:   cl,
+		claimer: claimer,
+		workers: max(workers, 1),
+		queue:   make(chan types.GameMetadata, resolvedClaimQueueSize),
+		pending: make(map[common.Address]*pendingClaim),
+	}
+}
+
+func (c *ResolvedGameClaimer) Start(ctx context.Context) {
+	c.lock.Lock()
+	c.ctx, c.cancel = context.WithCancel(ctx)
+	c.lock.Unlock()
+	for i := 0; i < c.workers; i++ {
+		c.wg.Add(1)
+		go c.run()
+	}
+}
+
+func (c *ResolvedGameClaimer) Close() error {
+	if c.cancel == nil {
+		return nil
+	}
+	c.cancel()
+	c.wg.Wait()
+	return nil
+}
+
+// GameResolved queues the game's bonds to be claimed if it resolved with status, unless they are already waiting to
+// be claimed. Games still in progress are ignored and games resolved against the claimants are left to the
+// BondClaimScheduler's sweep by the scheduler, see scheduler.OutcomeReporter. It doesn't block so can be used as a
+// scheduler.ResolutionHandler.
+func (c *ResolvedGameClaimer) GameResolved(game types.GameMetadata, status types.GameStatus) {
+	if status != types.GameStatusChallengerWon && status != types.GameStatusDefenderWon {
+		c.logger.Debug("Not claiming bonds of unresolved game", "game", game.Proxy, "status", status)
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.pending[game.Proxy]; ok {
+		return
+	}
+	c.pending[game.Proxy] = &pendingClaim{outstanding: new(big.Int)}
+	c.recordOutstanding()
+	c.enqueue(game, 0)
+}
+
+// ClaimBonds claims the bonds of each game that isn't already waiting to be claimed after resolving, so that using
+// it as the BondClaimer of a BondClaimScheduler doesn't duplicate the claims of resolved games.
+func (c *ResolvedGameClaimer) ClaimBonds(ctx context.Context, games []types.GameMetadata) (err error) {
+	for _, game := range games {
+		c.lock.Lock()
+		_, pending := c.pending[game.Proxy]
+		c.lock.Unlock()
+		if pending {
+			continue
+		}
+		if _, claimErr := c.claimer.ClaimGame(ctx, game); !errors.Is(claimErr, ErrClaimInProgress) && !errors.Is(claimErr, ErrClaimSimulated) {
+			err = errors.Join(err, claimErr)
+		}
+	}
+	return err
+}
+
+// enqueue queues the game to be claimed by a worker, or retries it after the retry delay for its attempts if the
+// queue is full. The lock must be held.
+func (c *ResolvedGameClaimer) enqueue(game types.GameMetadata, attempts uint) {
+	select {
+	case c.queue <- game:
+	default:
+		c.logger.Warn("Bond claim queue full, delaying claim", "game", game.Proxy)
+		c.retryAfter(game, claimRetryDelay(attempts))
+	}
+}
+
+func (c *ResolvedGameClaimer) retryAfter(game types.GameMetadata, delay time.Duration) {
+	c.clock.AfterFunc(delay, func() {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		state, ok := c.pending[game.Proxy]
+		if !ok || (c.ctx != nil && c.ctx.Err() != nil) {
+			return
+		}
+		c.enqueue(game, state.attempts)
+	})
+}
+
+func (c *ResolvedGameClaimer) run() {
+	defer c.wg.Done()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case game := <-c.queue:
+			c.claim(game)
+		}
+	}
+}
+
+func (c *ResolvedGameClaimer) claim(game types.GameMetadata) {
+	outstanding, err := c.claimer.ClaimGame(c.ctx, game)
+	if c.ctx.Err() != nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	state := c.pending[game.Proxy]
+	state.attempts++
+	if errors.Is(err, ErrClaimSimulated) {
+		// Retrying won't claim the credit in shadow mode, but it remains outstanding.
+		state.outstanding = outstanding
+		c.recordOutstanding()
+		c.logger.Info("Not retrying bond claim simulated in shadow mode", "game", game.Proxy, "outstanding", outstanding)
+		return
+	}
+	if err != nil && !errors.Is(err, ErrClaimInProgress) {
+		c.metrics.RecordBondClaimFailed()
+		c.logger.Error("Failed to claim bonds of resolved game", "game", game.Proxy, "attempts", state.attempts, "err", err)
+	}
+	if err == nil && outstanding.Sign() == 0 {
+		delete(c.pending, game.Proxy)
+		c.recordOutstanding()
+		c.logger.Debug("No bonds remaining to claim from resolved game", "game", game.Proxy, "attempts", state.attempts)
+		return
+	}
+	if outstanding != nil {
+		state.outstanding = outstanding
+	}
+	c.recordOutstanding()
+	delay := claimRetryDelay(state.attempts)
+	c.logger.Debug("Retrying bond claim for resolved game", "game", game.Proxy, "outstanding", state.outstanding, "delay", delay)
+	c.retryAfter(game, delay)
+}
+
+// recordOutstanding reports the number of games with bonds waiting to be claimed and the total credit known to be
+// outstanding in them. The lock must be held.
+func (c *ResolvedGameClaimer) recordOutstanding() {
+	total := new(big.Int)
+	for _, state := range c.pending {
+		total.Add(total, state.outstanding)
+	}
+	c.metrics.RecordBondsOutstanding(len(c.pending), total)
+}
+
+// claimRetryDelay returns the delay before retrying a claim after the specified number of attempts, doubling from
+// minClaimRetryDelay up to maxClaimRetryDelay.
+func claimRetryDelay(attempts uint) time.Duration {
+	if attempts == 0 {
+		return minClaimRetryDelay
+	}
+	delay := minClaimRetryDelay << min(attempts-1, 10)
+	return min(delay, maxClaimRetryDelay)
+}
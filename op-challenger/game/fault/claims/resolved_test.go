@@ -0,0 +1,212 @@
+package claims
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvedGameClaimer_ClaimOnceResolved(t *testing.T) {
+	c, cl, claimer, m := setupTestResolvedGameClaimer(t)
+	game := types.GameMetadata{Proxy: common.Address{0xaa}}
+	c.GameResolved(game, types.GameStatusDefenderWon)
+	require.Equal(t, game, claimer.respond(t, common.Big0, nil))
+	m.requireOutstanding(t, 0, 0)
+	require.False(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Millisecond), "should not retry")
+}
+
+func TestResolvedGameClaimer_RetryWhileCreditOutstanding(t *testing.T) {
+	c, cl, claimer, m := setupTestResolvedGameClaimer(t)
+	game := types.GameMetadata{Proxy: common.Address{0xaa}}
+	c.GameResolved(game, types.GameStatusChallengerWon)
+	require.Equal(t, game, claimer.respond(t, big.NewInt(5), nil))
+	m.requireOutstanding(t, 1, 5)
+
+	require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second))
+	cl.AdvanceTime(minClaimRetryDelay)
+	require.Equal(t, game, claimer.respond(t, common.Big0, nil))
+	m.requireOutstanding(t, 0, 0)
+	require.Zero(t, m.failures())
+}
+
+func TestResolvedGameClaimer_BackoffAfterFailures(t *testing.T) {
+	c, cl, claimer, m := setupTestResolvedGameClaimer(t)
+	game := types.GameMetadata{Proxy: common.Address{0xaa}}
+	c.GameResolved(game, types.GameStatusDefenderWon)
+	require.Equal(t, game, claimer.respond(t, big.NewInt(3), mockClaimError))
+	m.requireOutstanding(t, 1, 3)
+	require.Equal(t, 1, m.failures())
+
+	require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second))
+	cl.AdvanceTime(minClaimRetryDelay)
+	require.Equal(t, game, claimer.respond(t, big.NewInt(3), mockClaimError))
+	require.Eventually(t, func() bool { return m.failures() == 2 }, 10*time.Second, time.Millisecond)
+
+	// The delay doubles after each attempt
+	require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second))
+	cl.AdvanceTime(minClaimRetryDelay)
+	claimer.requireNoClaim(t)
+	cl.AdvanceTime(minClaimRetryDelay)
+	require.Equal(t, game, claimer.respond(t, common.Big0, nil))
+	m.requireOutstanding(t, 0, 0)
+}
+
+func TestResolvedGameClaimer_NoRetryWhenSimulated(t *testing.T) {
+	c, cl, claimer, m := setupTestResolvedGameClaimer(t)
+	game := types.GameMetadata{Proxy: common.Address{0xaa}}
+	c.GameResolved(game, types.GameStatusDefenderWon)
+	require.Equal(t, game, claimer.respond(t, big.NewInt(5), ErrClaimSimulated))
+	m.requireOutstanding(t, 1, 5)
+	require.False(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Millisecond), "should not retry")
+	require.Zero(t, m.failures())
+}
+
+func TestResolvedGameClaimer_IgnoreGamesAlreadyPending(t *testing.T) {
+	c, cl, claimer, m := setupTestResolvedGameClaimer(t)
+	game := types.GameMetadata{Proxy: common.Address{0xaa}}
+	c.GameResolved(game, types.GameStatusDefenderWon)
+	c.GameResolved(game, types.GameStatusDefenderWon)
+	require.Equal(t, game, claimer.respond(t, big.NewInt(5), nil))
+	require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second))
+	c.GameResolved(game, types.GameStatusDefenderWon)
+	claimer.requireNoClaim(t)
+	m.requireOutstanding(t, 1, 5)
+}
+
+func TestResolvedGameClaimer_IgnoreGamesInProgress(t *testing.T) {
+	c, _, claimer, _ := setupTestResolvedGameClaimer(t)
+	c.GameResolved(types.GameMetadata{Proxy: common.Address{0xaa}}, types.GameStatusInProgress)
+	claimer.requireNoClaim(t)
+}
+
+func TestResolvedGameClaimer_ClaimBondsSkipsPendingGames(t *testing.T) {
+	c, cl, claimer, _ := setupTestResolvedGameClaimer(t)
+	resolved := types.GameMetadata{Proxy: common.Address{0xaa}}
+	other := types.GameMetadata{Proxy: common.Address{0xbb}}
+	c.GameResolved(resolved, types.GameStatusDefenderWon)
+	require.Equal(t, resolved, claimer.respond(t, big.NewInt(5), nil))
+	require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second))
+
+	// The resolved game is still waiting to be claimed so only the other game is claimed by the sweep
+	errs := make(chan error, 1)
+	go func() {
+		errs <- c.ClaimBonds(context.Background(), []types.GameMetadata{resolved, other})
+	}()
+	require.Equal(t, other, claimer.respond(t, common.Big0, nil))
+	require.NoError(t, <-errs)
+	claimer.requireNoClaim(t)
+}
+
+func TestClaimRetryDelay(t *testing.T) {
+	require.Equal(t, time.Minute, claimRetryDelay(0))
+	require.Equal(t, time.Minute, claimRetryDelay(1))
+	require.Equal(t, 2*time.Minute, claimRetryDelay(2))
+	require.Equal(t, 32*time.Minute, claimRetryDelay(6))
+	require.Equal(t, time.Hour, claimRetryDelay(7))
+	require.Equal(t, time.Hour, claimRetryDelay(100))
+}
+
+func setupTestResolvedGameClaimer(t *testing.T) (*ResolvedGameClaimer, *clock.DeterministicClock, *stubGameClaimer, *stubResolvedClaimMetrics) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	cl := clock.NewDeterministicClock(time.Unix(0, 0))
+	claimer := &stubGameClaimer{
+		games:   make(chan types.GameMetadata),
+		results: make(chan stubClaimResult),
+	}
+	m := &stubResolvedClaimMetrics{}
+	c := NewResolvedGameClaimer(logger, m, cl, claimer, 2)
+	c.Start(context.Background())
+	t.Cleanup(func() {
+		require.NoError(t, c.Close())
+	})
+	return c, cl, claimer, m
+}
+
+type stubClaimResult struct {
+	outstanding *big.Int
+	err         error
+}
+
+type stubGameClaimer struct {
+	games   chan types.GameMetadata
+	results chan stubClaimResult
+}
+
+func (s *stubGameClaimer) ClaimGame(ctx context.Context, game types.GameMetadata) (*big.Int, error) {
+	select {
+	case s.games <- game:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case result := <-s.results:
+		return result.outstanding, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// respond waits for the next claim and completes it with the specified result, returning the game claimed.
+func (s *stubGameClaimer) respond(t *testing.T, outstanding *big.Int, err error) types.GameMetadata {
+	var game types.GameMetadata
+	select {
+	case game = <-s.games:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Did not claim game")
+	}
+	s.results <- stubClaimResult{outstanding: outstanding, err: err}
+	return game
+}
+
+func (s *stubGameClaimer) requireNoClaim(t *testing.T) {
+	select {
+	case game := <-s.games:
+		t.Fatalf("Unexpected claim for game %v", game.Proxy)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+type stubResolvedClaimMetrics struct {
+	lock              sync.Mutex
+	failed            int
+	outstandingGames  int
+	outstandingAmount *big.Int
+}
+
+func (m *stubResolvedClaimMetrics) RecordBondClaimFailed() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.failed++
+}
+
+func (m *stubResolvedClaimMetrics) RecordBondsOutstanding(games int, amount *big.Int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.outstandingGames = games
+	m.outstandingAmount = amount
+}
+
+func (m *stubResolvedClaimMetrics) failures() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.failed
+}
+
+// requireOutstanding waits for the outstanding bonds to be reported as expected, since they are recorded after
+// the claim returns.
+func (m *stubResolvedClaimMetrics) requireOutstanding(t *testing.T, games int, amount int64) {
+	require.Eventually(t, func() bool {
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		return m.outstandingGames == games && m.outstandingAmount != nil && m.outstandingAmount.Int64() == amount
+	}, 10*time.Second, time.Millisecond)
+}
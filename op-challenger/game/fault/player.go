@@ -23,6 +23,15 @@ import (
 
 type actor func(ctx context.Context) error
 
+// outcomeReporter returns the outcome of the most recent call to the actor.
+type outcomeReporter func() ActOutcome
+
+// stateSnapshotter captures and restores the on-chain state the actor acts on.
+type stateSnapshotter interface {
+	CaptureSnapshot() ([]byte, error)
+	RestoreSnapshot(data []byte) error
+}
+
 type GameInfo interface {
 	GetStatus(context.Context) (gameTypes.GameStatus, error)
 	GetClaimCount(context.Context) (uint64, error)
@@ -49,6 +58,21 @@ type GamePlayer struct {
 	prestateValidators []Validator
 	status             gameTypes.GameStatus
 	gameL1Head         eth.BlockID
+
+	// txSender sends the player's transactions and is nil if the game was already complete.
+	txSender *shadowableTxSender
+	// outcome reports the outcome of each call to act and is nil if the game was already complete.
+	outcome outcomeReporter
+	// snapshots captures the state act used and is nil if the game was already complete.
+	snapshots stateSnapshotter
+	// acted is true if the most recent call to ProgressGame sent a transaction.
+	acted bool
+	// deadline and claimsAtRisk are reported by the most recent call to act, see ActOutcome.
+	deadline     time.Time
+	claimsAtRisk bool
+	// stanceKnown and agreeWithRootClaim are the claimants' stance on the root claim reported by act.
+	stanceKnown        bool
+	agreeWithRootClaim bool
 }
 
 type GameContract interface {
@@ -75,6 +99,7 @@ func NewGamePlayer(
 	dir string,
 	addr common.Address,
 	txSender TxSender,
+	shadowTxSender TxSender,
 	loader GameContract,
 	syncValidator SyncValidator,
 	validators []Validator,
@@ -138,10 +163,11 @@ func NewGamePlayer(
 	if err != nil {
 		return nil, fmt.Errorf("failed to load min large preimage size: %w", err)
 	}
-	direct := preimages.NewDirectPreimageUploader(logger, txSender, loader)
-	large := preimages.NewLargePreimageUploader(logger, l1Clock, txSender, oracle)
+	sender := &shadowableTxSender{live: txSender, shadow: shadowTxSender}
+	direct := preimages.NewDirectPreimageUploader(logger, sender, loader)
+	large := preimages.NewLargePreimageUploader(logger, l1Clock, sender, oracle)
 	uploader := preimages.NewSplitPreimageUploader(direct, large, minLargePreimageSize)
-	responder, err := responder.NewFaultResponder(logger, txSender, loader, uploader, oracle)
+	responder, err := responder.NewFaultResponder(logger, sender, loader, uploader, oracle)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the responder: %w", err)
 	}
@@ -149,6 +175,9 @@ func NewGamePlayer(
 	agent := NewAgent(m, systemClock, l1Clock, loader, gameDepth, maxClockDuration, accessor, responder, logger, selective, claimants)
 	return &GamePlayer{
 		act:                agent.Act,
+		txSender:           sender,
+		outcome:            agent.LastOutcome,
+		snapshots:          agent,
 		loader:             loader,
 		logger:             logger,
 		status:             status,
@@ -171,7 +200,70 @@ func (g *GamePlayer) Status() gameTypes.GameStatus {
 	return g.status
 }
 
+// EnableShadowMode switches the player to simulating the transactions it would send, logging them with their
+// estimated gas instead of sending them.
+func (g *GamePlayer) EnableShadowMode() error {
+	if g.txSender == nil {
+		// The game is already complete so no transactions will be sent.
+		return nil
+	}
+	if err := g.txSender.enable(); err != nil {
+		return err
+	}
+	g.logger.Info("Shadow mode enabled, transactions will be logged but not sent")
+	return nil
+}
+
+// ActionTaken returns true if the most recent call to ProgressGame sent a move, step or resolution, so passes that
+// found nothing to do aren't treated as actions by the scheduler.
+func (g *GamePlayer) ActionTaken() bool {
+	return g.acted
+}
+
+// NextDeadline returns when the chess clock of the earliest claim awaiting a response expires, so games nearest to
+// losing a claim by default are progressed first.
+func (g *GamePlayer) NextDeadline() (time.Time, bool) {
+	return g.deadline, !g.deadline.IsZero()
+}
+
+// ClaimsAtRisk returns true if any claims of the claimants have been countered without a response.
+func (g *GamePlayer) ClaimsAtRisk() bool {
+	return g.claimsAtRisk
+}
+
+// ResolvedInFavour returns true if the game resolved with status in favour of the claimants, being won by the
+// defender if they agree with the root claim or by the challenger if they don't. Returns false as the second value
+// if their stance isn't known, for example because the game had already resolved when the player was created.
+func (g *GamePlayer) ResolvedInFavour(status gameTypes.GameStatus) (bool, bool) {
+	if !g.stanceKnown {
+		return false, false
+	}
+	if g.agreeWithRootClaim {
+		return status == gameTypes.GameStatusDefenderWon, true
+	}
+	return status == gameTypes.GameStatusChallengerWon, true
+}
+
+// CaptureSnapshot captures the claims and L1 time the most recent progression acted on, so that replaying the
+// snapshot reproduces its decisions regardless of how the game has changed since.
+func (g *GamePlayer) CaptureSnapshot() ([]byte, error) {
+	if g.snapshots == nil {
+		return nil, nil
+	}
+	return g.snapshots.CaptureSnapshot()
+}
+
+// RestoreSnapshot makes the player act on the claims and L1 time from a snapshot instead of the current game state.
+func (g *GamePlayer) RestoreSnapshot(data []byte) error {
+	if g.snapshots == nil {
+		// The game is already complete so won't act.
+		return nil
+	}
+	return g.snapshots.RestoreSnapshot(data)
+}
+
 func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
+	g.acted = false
 	if g.status != gameTypes.GameStatusInProgress {
 		// Game is already complete so don't try to perform further actions.
 		g.logger.Trace("Skipping completed game")
@@ -184,9 +276,23 @@ func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 		g.logger.Error("Could not check local node was in sync", "err", err)
 		return g.status
 	}
-	g.logger.Trace("Checking if actions are required")
-	if err := g.act(ctx); err != nil {
-		g.logger.Error("Error when acting on game", "err", err)
+	if gameTypes.ActionsSuppressed(ctx) {
+		g.logger.Info("Actions paused, only updating game status")
+	} else {
+		g.logger.Trace("Checking if actions are required")
+		if err := g.act(ctx); err != nil {
+			g.logger.Error("Error when acting on game", "err", err)
+		}
+		if g.outcome != nil {
+			outcome := g.outcome()
+			g.acted = outcome.Acted
+			g.deadline = outcome.Deadline
+			g.claimsAtRisk = outcome.ClaimsAtRisk
+			if outcome.StanceKnown {
+				g.stanceKnown = true
+				g.agreeWithRootClaim = outcome.AgreeWithRootClaim
+			}
+		}
 	}
 	status, err := g.loader.GetStatus(ctx)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -105,6 +106,82 @@ func TestValidateLocalNodeSync(t *testing.T) {
 	require.Equal(t, 1, gameState.callCount, "does not act when not in sync")
 }
 
+func TestDoNotActWhenActionsSuppressed(t *testing.T) {
+	_, game, gameState, _ := setupProgressGameTest(t)
+	gameState.status = types.GameStatusDefenderWon
+
+	status := game.ProgressGame(types.WithActionsSuppressed(context.Background()))
+	require.Equal(t, 0, gameState.callCount, "does not act when actions are suppressed")
+	require.Equal(t, types.GameStatusDefenderWon, status, "still updates status")
+}
+
+func TestReportActionTaken(t *testing.T) {
+	_, game, gameState, _ := setupProgressGameTest(t)
+	gameState.acted = true
+	game.ProgressGame(context.Background())
+	require.True(t, game.ActionTaken(), "reports transaction sent")
+
+	gameState.acted = false
+	game.ProgressGame(context.Background())
+	require.False(t, game.ActionTaken(), "reports no-op pass")
+
+	gameState.acted = true
+	game.ProgressGame(types.WithActionsSuppressed(context.Background()))
+	require.False(t, game.ActionTaken(), "does not act when actions are suppressed")
+}
+
+func TestReportClaimClocks(t *testing.T) {
+	_, game, gameState, _ := setupProgressGameTest(t)
+	_, ok := game.NextDeadline()
+	require.False(t, ok, "no deadline before acting")
+
+	deadline := time.Unix(1000, 0)
+	gameState.deadline = deadline
+	gameState.claimsAtRisk = true
+	game.ProgressGame(context.Background())
+	actual, ok := game.NextDeadline()
+	require.True(t, ok)
+	require.Equal(t, deadline, actual)
+	require.True(t, game.ClaimsAtRisk())
+
+	gameState.deadline = time.Time{}
+	gameState.claimsAtRisk = false
+	game.ProgressGame(context.Background())
+	_, ok = game.NextDeadline()
+	require.False(t, ok, "no claims awaiting a response")
+	require.False(t, game.ClaimsAtRisk())
+}
+
+func TestResolvedInFavour(t *testing.T) {
+	_, game, gameState, _ := setupProgressGameTest(t)
+	_, known := game.ResolvedInFavour(types.GameStatusDefenderWon)
+	require.False(t, known, "stance unknown before acting")
+
+	agree := true
+	gameState.stance = &agree
+	game.ProgressGame(context.Background())
+	favour, known := game.ResolvedInFavour(types.GameStatusDefenderWon)
+	require.True(t, known)
+	require.True(t, favour)
+	favour, _ = game.ResolvedInFavour(types.GameStatusChallengerWon)
+	require.False(t, favour)
+
+	disagree := false
+	gameState.stance = &disagree
+	game.ProgressGame(context.Background())
+	favour, _ = game.ResolvedInFavour(types.GameStatusChallengerWon)
+	require.True(t, favour)
+	favour, _ = game.ResolvedInFavour(types.GameStatusDefenderWon)
+	require.False(t, favour)
+
+	// The stance is kept when the outcome doesn't report it
+	gameState.stance = nil
+	game.ProgressGame(context.Background())
+	favour, known = game.ResolvedInFavour(types.GameStatusChallengerWon)
+	require.True(t, known)
+	require.True(t, favour)
+}
+
 func TestValidatePrestate(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -167,6 +244,7 @@ func setupProgressGameTest(t *testing.T) (*testlog.CapturingHandler, *GamePlayer
 	syncValidator := &stubSyncValidator{}
 	game := &GamePlayer{
 		act:           gameState.Act,
+		outcome:       gameState.Outcome,
 		loader:        gameState,
 		logger:        logger,
 		syncValidator: syncValidator,
@@ -187,11 +265,15 @@ func (s *stubSyncValidator) ValidateNodeSynced(_ context.Context, _ eth.BlockID)
 }
 
 type stubGameState struct {
-	status     types.GameStatus
-	claimCount uint64
-	callCount  int
-	actErr     error
-	Err        error
+	status       types.GameStatus
+	claimCount   uint64
+	callCount    int
+	actErr       error
+	acted        bool
+	deadline     time.Time
+	claimsAtRisk bool
+	stance       *bool
+	Err          error
 }
 
 func (s *stubGameState) Act(ctx context.Context) error {
@@ -199,6 +281,15 @@ func (s *stubGameState) Act(ctx context.Context) error {
 	return s.actErr
 }
 
+func (s *stubGameState) Outcome() ActOutcome {
+	outcome := ActOutcome{Acted: s.acted, Deadline: s.deadline, ClaimsAtRisk: s.claimsAtRisk}
+	if s.stance != nil {
+		outcome.StanceKnown = true
+		outcome.AgreeWithRootClaim = *s.stance
+	}
+	return outcome
+}
+
 func (s *stubGameState) GetStatus(ctx context.Context) (types.GameStatus, error) {
 	return s.status, nil
 }
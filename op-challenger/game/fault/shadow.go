@@ -0,0 +1,38 @@
+package fault
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errShadowSenderUnavailable = errors.New("no tx sender available to simulate transactions")
+
+// shadowableTxSender sends transactions with the live sender until shadow mode is enabled, after which they are
+// only simulated by the shadow sender.
+type shadowableTxSender struct {
+	live    TxSender
+	shadow  TxSender
+	enabled atomic.Bool
+}
+
+func (s *shadowableTxSender) From() common.Address {
+	return s.live.From()
+}
+
+func (s *shadowableTxSender) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error {
+	if s.enabled.Load() {
+		return s.shadow.SendAndWaitSimple(txPurpose, txs...)
+	}
+	return s.live.SendAndWaitSimple(txPurpose, txs...)
+}
+
+func (s *shadowableTxSender) enable() error {
+	if s.shadow == nil {
+		return errShadowSenderUnavailable
+	}
+	s.enabled.Store(true)
+	return nil
+}
@@ -0,0 +1,52 @@
+package fault
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnableShadowMode(t *testing.T) {
+	t.Run("SwitchesToShadowSender", func(t *testing.T) {
+		_, game, _, _ := setupProgressGameTest(t)
+		live := &stubTxSender{}
+		shadow := &stubTxSender{}
+		game.txSender = &shadowableTxSender{live: live, shadow: shadow}
+
+		require.NoError(t, game.txSender.SendAndWaitSimple("move", txmgr.TxCandidate{}))
+		require.Equal(t, 1, live.sends)
+
+		require.NoError(t, game.EnableShadowMode())
+		require.NoError(t, game.txSender.SendAndWaitSimple("move", txmgr.TxCandidate{}))
+		require.Equal(t, 1, live.sends, "should not send with live sender")
+		require.Equal(t, 1, shadow.sends)
+	})
+
+	t.Run("FailsWithoutShadowSender", func(t *testing.T) {
+		_, game, _, _ := setupProgressGameTest(t)
+		game.txSender = &shadowableTxSender{live: &stubTxSender{}}
+		require.ErrorIs(t, game.EnableShadowMode(), errShadowSenderUnavailable)
+	})
+
+	t.Run("CompletedGame", func(t *testing.T) {
+		_, game, _, _ := setupProgressGameTest(t)
+		game.status = types.GameStatusDefenderWon
+		require.NoError(t, game.EnableShadowMode(), "completed games send no transactions")
+	})
+}
+
+type stubTxSender struct {
+	sends int
+}
+
+func (s *stubTxSender) From() common.Address {
+	return common.Address{0xaa}
+}
+
+func (s *stubTxSender) SendAndWaitSimple(_ string, _ ...txmgr.TxCandidate) error {
+	s.sends++
+	return nil
+}
@@ -0,0 +1,113 @@
+package fault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// agentSnapshot is the on-chain state the agent most recently acted on.
+type agentSnapshot struct {
+	L1Time time.Time       `json:"l1Time"`
+	Claims []claimSnapshot `json:"claims"`
+}
+
+// claimSnapshot is the encoding of a types.Claim, which can't be encoded directly since its position is unexported.
+type claimSnapshot struct {
+	Value               common.Hash    `json:"value"`
+	Bond                *hexutil.Big   `json:"bond"`
+	GIndex              *hexutil.Big   `json:"gindex"`
+	CounteredBy         common.Address `json:"counteredBy"`
+	Claimant            common.Address `json:"claimant"`
+	ClockDuration       time.Duration  `json:"clockDuration"`
+	ClockTimestamp      time.Time      `json:"clockTimestamp"`
+	ContractIndex       int            `json:"contractIndex"`
+	ParentContractIndex int            `json:"parentContractIndex"`
+}
+
+func newClaimSnapshot(claim types.Claim) claimSnapshot {
+	return claimSnapshot{
+		Value:               claim.Value,
+		Bond:                (*hexutil.Big)(claim.Bond),
+		GIndex:              (*hexutil.Big)(claim.Position.ToGIndex()),
+		CounteredBy:         claim.CounteredBy,
+		Claimant:            claim.Claimant,
+		ClockDuration:       claim.Clock.Duration,
+		ClockTimestamp:      claim.Clock.Timestamp,
+		ContractIndex:       claim.ContractIndex,
+		ParentContractIndex: claim.ParentContractIndex,
+	}
+}
+
+func (c claimSnapshot) claim() (types.Claim, error) {
+	if c.GIndex == nil {
+		return types.Claim{}, fmt.Errorf("claim %v has no position", c.ContractIndex)
+	}
+	return types.Claim{
+		ClaimData: types.ClaimData{
+			Value:    c.Value,
+			Bond:     c.Bond.ToInt(),
+			Position: types.NewPositionFromGIndex(c.GIndex.ToInt()),
+		},
+		CounteredBy:         c.CounteredBy,
+		Claimant:            c.Claimant,
+		Clock:               types.NewClock(c.ClockDuration, c.ClockTimestamp),
+		ContractIndex:       c.ContractIndex,
+		ParentContractIndex: c.ParentContractIndex,
+	}, nil
+}
+
+// staticClaimLoader returns the claims restored from a snapshot instead of loading them from the contract.
+type staticClaimLoader []types.Claim
+
+func (l staticClaimLoader) GetAllClaims(_ context.Context, _ rpcblock.Block) ([]types.Claim, error) {
+	return l, nil
+}
+
+// fixedClock reports the L1 time restored from a snapshot.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// CaptureSnapshot encodes the claims and L1 time the most recent call to Act used, or nil if Act hasn't loaded any
+// claims yet.
+func (a *Agent) CaptureSnapshot() ([]byte, error) {
+	a.loadedLock.Lock()
+	defer a.loadedLock.Unlock()
+	if a.loaded == nil {
+		return nil, nil
+	}
+	snapshot := agentSnapshot{L1Time: a.loadedAt}
+	for _, claim := range a.loaded {
+		snapshot.Claims = append(snapshot.Claims, newClaimSnapshot(claim))
+	}
+	return json.Marshal(snapshot)
+}
+
+// RestoreSnapshot makes the agent act on the claims and L1 time from a snapshot captured by CaptureSnapshot rather
+// than the current state of the game, so that the captured decisions are reproduced.
+func (a *Agent) RestoreSnapshot(data []byte) error {
+	var snapshot agentSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return fmt.Errorf("failed to decode agent snapshot: %w", err)
+	}
+	claims := make(staticClaimLoader, 0, len(snapshot.Claims))
+	for _, c := range snapshot.Claims {
+		claim, err := c.claim()
+		if err != nil {
+			return err
+		}
+		claims = append(claims, claim)
+	}
+	a.loader = claims
+	a.l1Clock = fixedClock(snapshot.L1Time)
+	return nil
+}
@@ -0,0 +1,83 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureSnapshotBeforeAct(t *testing.T) {
+	agent, _, _ := setupTestAgent(t)
+	state, err := agent.CaptureSnapshot()
+	require.NoError(t, err)
+	require.Nil(t, state)
+}
+
+func TestReplayCapturedClaimsAndClock(t *testing.T) {
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	setup := func(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
+		agent, claimLoader, responder := setupTestAgent(t)
+		responder.callResolveErr = errors.New("game is not resolvable")
+		responder.callResolveClaimErr = errors.New("claim is not resolvable")
+		return agent, claimLoader, responder
+	}
+
+	live, claimLoader, _ := setup(t)
+	now := live.l1Clock.Now()
+	root := claimBuilder.CreateRootClaim(test.WithClaimant(common.Address{0xaa}))
+	root.Bond = big.NewInt(1000)
+	root.Clock = types.Clock{Timestamp: now.Add(-time.Minute)}
+	counter := claimBuilder.AttackClaim(root, test.WithInvalidValue(true), test.WithClaimant(common.Address{0xbb}))
+	counter.ContractIndex = 1
+	counter.Clock = types.Clock{Duration: time.Minute, Timestamp: now}
+	claimLoader.claims = []types.Claim{root, counter}
+	require.NoError(t, live.Act(context.Background()))
+
+	state, err := live.CaptureSnapshot()
+	require.NoError(t, err)
+
+	replay, replayLoader, replayResponder := setup(t)
+	// The game has since moved on, but the replay should act on the captured state
+	replay.l1Clock = clock.NewDeterministicClock(now.Add(time.Hour))
+	replayLoader.claims = []types.Claim{root}
+	require.NoError(t, replay.RestoreSnapshot(state))
+
+	claims, err := replay.loader.GetAllClaims(context.Background(), rpcblock.Latest)
+	require.NoError(t, err)
+	require.Len(t, claims, 2)
+	for i, claim := range []types.Claim{root, counter} {
+		require.Equal(t, claim.ID(), claims[i].ID())
+		require.Equal(t, claim.Bond, claims[i].Bond)
+		require.Equal(t, claim.Claimant, claims[i].Claimant)
+		require.Equal(t, claim.Clock.Duration, claims[i].Clock.Duration)
+		require.True(t, claim.Clock.Timestamp.Equal(claims[i].Clock.Timestamp))
+	}
+
+	replayResponder.simulated = true
+	require.NoError(t, replay.Act(context.Background()))
+	require.Equal(t, 1, replayResponder.performActionCount, "should respond to the captured counter")
+	require.Zero(t, replayLoader.callCount, "should not load claims from the contract")
+	outcome := replay.LastOutcome()
+	require.Equal(t, now.Add(replay.maxClockDuration-time.Minute).UnixMilli(), outcome.Deadline.UnixMilli(),
+		"should use the captured L1 time")
+}
+
+func TestRestoreSnapshotOnCompletedGame(t *testing.T) {
+	_, game, _, _ := setupProgressGameTest(t)
+	game.snapshots = nil
+	state, err := game.CaptureSnapshot()
+	require.NoError(t, err)
+	require.Nil(t, state)
+	require.NoError(t, game.RestoreSnapshot([]byte("{}")))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/asterisc"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/shared"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/split"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
@@ -26,6 +27,7 @@ func NewOutputAsteriscTraceAccessor(
 	prestateProvider types.PrestateProvider,
 	rollupClient OutputRollupClient,
 	dir string,
+	traces *shared.GameTraces,
 	l1Head eth.BlockID,
 	splitDepth types.Depth,
 	prestateBlock uint64,
@@ -39,6 +41,11 @@ func NewOutputAsteriscTraceAccessor(
 		if err != nil {
 			return nil, fmt.Errorf("failed to fetch asterisc local inputs: %w", err)
 		}
+		if traces != nil {
+			return traces.Provider(depth, localInputs, func(dir string) types.TraceProvider {
+				return asterisc.NewTraceProvider(logger, m, cfg, prestateProvider, localInputs, dir, depth)
+			})
+		}
 		provider := asterisc.NewTraceProvider(logger, m, cfg, prestateProvider, localInputs, subdir, depth)
 		return provider, nil
 	}
@@ -0,0 +1,248 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var ErrUnavailable = errors.New("trace plugin unavailable")
+
+const (
+	defaultHealthCheckInterval = 30 * time.Second
+	healthCheckTimeout         = 10 * time.Second
+	defaultRestartDelay        = 5 * time.Second
+)
+
+// Plugin runs an executable providing the execution trace of an alternative fault proof VM as a child process,
+// serving the trace namespace over its stdin and stdout, see Serve. The plugin is health checked periodically and
+// restarted if it exits or fails a health check. Calls made while it is restarting fail with ErrUnavailable.
+type Plugin struct {
+	logger  log.Logger
+	path    string
+	timeout time.Duration
+
+	healthCheckInterval time.Duration
+	restartDelay        time.Duration
+
+	lock sync.RWMutex
+	// client is the client of the running plugin process, or nil if it isn't running.
+	client *rpc.Client
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewPlugin creates a Plugin running the executable at path. Each call to the plugin is limited to timeout, unless
+// timeout is 0.
+func NewPlugin(logger log.Logger, path string, timeout time.Duration) *Plugin {
+	return &Plugin{
+		logger:              logger.New("plugin", path),
+		path:                path,
+		timeout:             timeout,
+		healthCheckInterval: defaultHealthCheckInterval,
+		restartDelay:        defaultRestartDelay,
+	}
+}
+
+// Start launches the plugin, returning an error if it can't be started or fails its first health check, then keeps
+// it running until Close is called.
+func (p *Plugin) Start(ctx context.Context) error {
+	proc, err := p.launch()
+	if err != nil {
+		return err
+	}
+	if err := checkHealth(ctx, proc.client); err != nil {
+		proc.stop()
+		return fmt.Errorf("trace plugin failed health check: %w", err)
+	}
+	p.setClient(proc.client)
+	runCtx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+	go p.supervise(runCtx, proc)
+	p.logger.Info("Started trace plugin")
+	return nil
+}
+
+// Close stops the plugin. Calls made after Close fail with ErrUnavailable.
+func (p *Plugin) Close() {
+	if p.cancel == nil {
+		return
+	}
+	p.cancel()
+	<-p.done
+}
+
+// AbsolutePreStateCommitment returns the commitment to the plugin VM's absolute prestate.
+func (p *Plugin) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	var commitment common.Hash
+	if err := p.call(ctx, &commitment, "absolutePreStateCommitment"); err != nil {
+		return common.Hash{}, err
+	}
+	return commitment, nil
+}
+
+func (p *Plugin) call(ctx context.Context, result any, method string, args ...any) error {
+	p.lock.RLock()
+	client := p.client
+	p.lock.RUnlock()
+	if client == nil {
+		return ErrUnavailable
+	}
+	if p.timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	if err := client.CallContext(ctx, result, Namespace+"_"+method, args...); err != nil {
+		return fmt.Errorf("trace plugin call %v failed: %w", method, err)
+	}
+	return nil
+}
+
+func (p *Plugin) setClient(client *rpc.Client) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.client = client
+}
+
+// supervise restarts the plugin whenever the running process exits or fails a health check, until ctx is done.
+func (p *Plugin) supervise(ctx context.Context, proc *process) {
+	defer close(p.done)
+	for {
+		p.monitor(ctx, proc)
+		p.setClient(nil)
+		proc.stop()
+		proc = p.restart(ctx)
+		if proc == nil {
+			return
+		}
+		p.setClient(proc.client)
+	}
+}
+
+// monitor returns once the process exits, fails a health check or ctx is done.
+func (p *Plugin) monitor(ctx context.Context, proc *process) {
+	ticker := time.NewTicker(p.healthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-proc.exited:
+			p.logger.Error("Trace plugin exited", "err", proc.err)
+			return
+		case <-ticker.C:
+			if err := checkHealth(ctx, proc.client); err != nil {
+				if ctx.Err() == nil {
+					p.logger.Error("Trace plugin failed health check", "err", err)
+				}
+				return
+			}
+		}
+	}
+}
+
+// restart launches the plugin again after the restart delay, retrying until it succeeds or ctx is done, in which
+// case it returns nil.
+func (p *Plugin) restart(ctx context.Context) *process {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(p.restartDelay):
+		}
+		proc, err := p.launch()
+		if err != nil {
+			p.logger.Error("Failed to restart trace plugin", "err", err)
+			continue
+		}
+		p.logger.Warn("Restarted trace plugin")
+		return proc
+	}
+}
+
+func checkHealth(ctx context.Context, client *rpc.Client) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+	return client.CallContext(ctx, nil, Namespace+"_health")
+}
+
+// process is a running plugin process.
+type process struct {
+	cmd    *exec.Cmd
+	client *rpc.Client
+	// in and out are the challenger's ends of the process's stdin and stdout.
+	in  *os.File
+	out *os.File
+
+	// exited is closed once the process exits, after which err is the error it exited with.
+	exited chan struct{}
+	err    error
+}
+
+func (p *Plugin) launch() (*process, error) {
+	// The pipes are created explicitly rather than with exec.Cmd.StdinPipe and StdoutPipe so the client can keep
+	// reading from them while the process is waited for.
+	stdin, in, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace plugin stdin: %w", err)
+	}
+	out, stdout, err := os.Pipe()
+	if err != nil {
+		_ = stdin.Close()
+		_ = in.Close()
+		return nil, fmt.Errorf("failed to create trace plugin stdout: %w", err)
+	}
+	stderr := oplog.NewWriter(p.logger, log.LevelInfo)
+	cmd := exec.Command(p.path)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	err = cmd.Start()
+	// The process holds its own copies of its ends of the pipes.
+	_ = stdin.Close()
+	_ = stdout.Close()
+	if err != nil {
+		_ = in.Close()
+		_ = out.Close()
+		_ = stderr.Close()
+		return nil, fmt.Errorf("failed to start trace plugin: %w", err)
+	}
+	client, err := rpc.DialIO(context.Background(), out, in)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		_ = in.Close()
+		_ = out.Close()
+		_ = stderr.Close()
+		return nil, fmt.Errorf("failed to connect to trace plugin: %w", err)
+	}
+	proc := &process{cmd: cmd, client: client, in: in, out: out, exited: make(chan struct{})}
+	go func() {
+		proc.err = cmd.Wait()
+		_ = stderr.Close()
+		close(proc.exited)
+	}()
+	return proc, nil
+}
+
+// stop kills the process if it is still running and releases its resources.
+func (proc *process) stop() {
+	_ = proc.cmd.Process.Kill()
+	<-proc.exited
+	proc.client.Close()
+	_ = proc.in.Close()
+	_ = proc.out.Close()
+}
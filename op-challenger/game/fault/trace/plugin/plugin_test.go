@@ -0,0 +1,156 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// testPluginEnv is set when the test binary is run as a trace plugin by the tests.
+const testPluginEnv = "OP_CHALLENGER_TEST_TRACE_PLUGIN"
+
+var (
+	testPrestate = common.Hash{0xaa}
+	// crashIndex is the trace index at which the test plugin exits.
+	crashIndex = big.NewInt(999)
+	// hangIndex is the trace index at which the test plugin never responds.
+	hangIndex = big.NewInt(1000)
+)
+
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(testPluginEnv); mode != "" {
+		if err := Serve(&testBackend{healthy: mode != "unhealthy"}, os.Stdin, os.Stdout); err != nil {
+			os.Exit(2)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestTraceProvider(t *testing.T) {
+	plugin := startTestPlugin(t, "healthy", 0)
+	inputs := utils.LocalGameInputs{L1Head: common.Hash{0x01}, L2Claim: common.Hash{0x02}, L2BlockNumber: big.NewInt(3)}
+	provider := NewTraceProvider(plugin, inputs, "/data/game", 4)
+	ctx := context.Background()
+
+	prestate, err := provider.AbsolutePreStateCommitment(ctx)
+	require.NoError(t, err)
+	require.Equal(t, testPrestate, prestate)
+
+	pos := types.NewPosition(4, big.NewInt(5))
+	claim, err := provider.Get(ctx, pos)
+	require.NoError(t, err)
+	require.Equal(t, testClaim(TraceRequest{Dir: "/data/game", LocalInputs: newLocalInputs(inputs), Depth: 4, TraceIndex: (*hexutil.Big)(big.NewInt(5))}), claim)
+
+	preState, proof, oracle, err := provider.GetStepData(ctx, pos)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x05}, preState)
+	require.Equal(t, []byte("/data/game"), proof)
+	require.Equal(t, types.NewPreimageOracleData([]byte{0x01, 0x02}, []byte{0x03}, 4), oracle)
+}
+
+func TestCallTimeout(t *testing.T) {
+	plugin := startTestPlugin(t, "healthy", 100*time.Millisecond)
+	provider := NewTraceProvider(plugin, utils.LocalGameInputs{}, t.TempDir(), 4)
+	_, err := provider.Get(context.Background(), types.NewPosition(4, hangIndex))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The plugin continues serving other calls
+	_, err = provider.Get(context.Background(), types.NewPosition(4, big.NewInt(1)))
+	require.NoError(t, err)
+}
+
+func TestRestartAfterExit(t *testing.T) {
+	plugin := startTestPlugin(t, "healthy", 0)
+	provider := NewTraceProvider(plugin, utils.LocalGameInputs{}, t.TempDir(), 4)
+	_, err := provider.Get(context.Background(), types.NewPosition(4, crashIndex))
+	require.Error(t, err)
+
+	require.Eventually(t, func() bool {
+		_, err := provider.Get(context.Background(), types.NewPosition(4, big.NewInt(1)))
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond, "should restart plugin after it exits")
+}
+
+func TestStartFailsWhenUnhealthy(t *testing.T) {
+	t.Setenv(testPluginEnv, "unhealthy")
+	plugin := NewPlugin(testlog.Logger(t, log.LevelInfo), os.Args[0], 0)
+	require.ErrorContains(t, plugin.Start(context.Background()), "failed health check")
+	_, err := plugin.AbsolutePreStateCommitment(context.Background())
+	require.ErrorIs(t, err, ErrUnavailable)
+}
+
+func TestStartFailsWhenPluginMissing(t *testing.T) {
+	plugin := NewPlugin(testlog.Logger(t, log.LevelInfo), filepath.Join(t.TempDir(), "missing"), 0)
+	require.ErrorContains(t, plugin.Start(context.Background()), "failed to start trace plugin")
+}
+
+func TestUnavailableAfterClose(t *testing.T) {
+	plugin := startTestPlugin(t, "healthy", 0)
+	plugin.Close()
+	_, err := plugin.AbsolutePreStateCommitment(context.Background())
+	require.ErrorIs(t, err, ErrUnavailable)
+}
+
+func startTestPlugin(t *testing.T, mode string, timeout time.Duration) *Plugin {
+	t.Setenv(testPluginEnv, mode)
+	plugin := NewPlugin(testlog.Logger(t, log.LevelInfo), os.Args[0], timeout)
+	plugin.healthCheckInterval = 10 * time.Millisecond
+	plugin.restartDelay = 10 * time.Millisecond
+	require.NoError(t, plugin.Start(context.Background()))
+	t.Cleanup(plugin.Close)
+	return plugin
+}
+
+func testClaim(req TraceRequest) common.Hash {
+	return common.BigToHash(new(big.Int).Add(req.LocalInputs.L2BlockNumber.ToInt(), req.TraceIndex.ToInt()))
+}
+
+type testBackend struct {
+	healthy bool
+}
+
+func (b *testBackend) Health(_ context.Context) error {
+	if !b.healthy {
+		return errors.New("unhealthy")
+	}
+	return nil
+}
+
+func (b *testBackend) AbsolutePreStateCommitment(_ context.Context) (common.Hash, error) {
+	return testPrestate, nil
+}
+
+func (b *testBackend) Get(ctx context.Context, req TraceRequest) (common.Hash, error) {
+	if req.TraceIndex.ToInt().Cmp(crashIndex) == 0 {
+		os.Exit(1)
+	}
+	if req.TraceIndex.ToInt().Cmp(hangIndex) == 0 {
+		<-ctx.Done()
+		return common.Hash{}, ctx.Err()
+	}
+	if req.LocalInputs.L2BlockNumber == nil {
+		req.LocalInputs.L2BlockNumber = (*hexutil.Big)(big.NewInt(0))
+	}
+	return testClaim(req), nil
+}
+
+func (b *testBackend) GetStepData(_ context.Context, req TraceRequest) (*StepData, error) {
+	return &StepData{
+		PreState:  req.TraceIndex.ToInt().Bytes(),
+		ProofData: []byte(req.Dir),
+		Oracle:    &OracleData{Key: []byte{0x01, 0x02}, Data: []byte{0x03}, Offset: 4},
+	}, nil
+}
@@ -0,0 +1,147 @@
+package plugin
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+// Namespace is the JSON-RPC namespace of the methods served by trace plugins.
+const Namespace = "trace"
+
+// Backend is implemented by trace plugins to provide the execution trace of an alternative fault proof VM, see
+// Serve. Methods may be called concurrently, including Health while trace data is being generated.
+type Backend interface {
+	// Health returns an error if the plugin is unable to generate trace data.
+	Health(ctx context.Context) error
+	// AbsolutePreStateCommitment returns the commitment to the VM's absolute prestate, which must match the absolute
+	// prestate of the games the plugin is used for.
+	AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error)
+	// Get returns the claim value at the requested trace index.
+	Get(ctx context.Context, req TraceRequest) (common.Hash, error)
+	// GetStepData returns the data required to execute the step at the requested trace index.
+	GetStepData(ctx context.Context, req TraceRequest) (*StepData, error)
+}
+
+// LocalInputs are the local inputs of the program run by the VM, see utils.LocalGameInputs.
+type LocalInputs struct {
+	L1Head        common.Hash  `json:"l1Head"`
+	L2Head        common.Hash  `json:"l2Head"`
+	L2OutputRoot  common.Hash  `json:"l2OutputRoot"`
+	L2Claim       common.Hash  `json:"l2Claim"`
+	L2BlockNumber *hexutil.Big `json:"l2BlockNumber"`
+}
+
+func newLocalInputs(inputs utils.LocalGameInputs) LocalInputs {
+	return LocalInputs{
+		L1Head:        inputs.L1Head,
+		L2Head:        inputs.L2Head,
+		L2OutputRoot:  inputs.L2OutputRoot,
+		L2Claim:       inputs.L2Claim,
+		L2BlockNumber: (*hexutil.Big)(inputs.L2BlockNumber),
+	}
+}
+
+// TraceRequest identifies a position in the trace of a VM execution.
+type TraceRequest struct {
+	// Dir is the directory the plugin may store the data of the execution in, which is removed with the game.
+	Dir         string       `json:"dir"`
+	LocalInputs LocalInputs  `json:"localInputs"`
+	Depth       uint64       `json:"depth"`
+	TraceIndex  *hexutil.Big `json:"traceIndex"`
+}
+
+// StepData is the data required to execute a step of the VM, see types.TraceProvider.GetStepData.
+type StepData struct {
+	PreState  hexutil.Bytes `json:"preState"`
+	ProofData hexutil.Bytes `json:"proofData"`
+	// Oracle is the preimage that must be loaded into the oracle before the step is executed, if any.
+	Oracle *OracleData `json:"oracle,omitempty"`
+}
+
+// OracleData is a preimage to load into the preimage oracle, see types.PreimageOracleData. The blob fields are only
+// set for blob preimages.
+type OracleData struct {
+	Key            hexutil.Bytes  `json:"key"`
+	Data           hexutil.Bytes  `json:"data"`
+	Offset         hexutil.Uint64 `json:"offset"`
+	BlobFieldIndex hexutil.Uint64 `json:"blobFieldIndex,omitempty"`
+	BlobCommitment hexutil.Bytes  `json:"blobCommitment,omitempty"`
+	BlobProof      hexutil.Bytes  `json:"blobProof,omitempty"`
+}
+
+func (d *OracleData) toPreimageOracleData() *types.PreimageOracleData {
+	if d == nil {
+		return nil
+	}
+	if len(d.BlobCommitment) > 0 {
+		return types.NewPreimageOracleBlobData(d.Key, d.Data, uint32(d.Offset), uint64(d.BlobFieldIndex), d.BlobCommitment, d.BlobProof)
+	}
+	return types.NewPreimageOracleData(d.Key, d.Data, uint32(d.Offset))
+}
+
+// Serve serves the backend to the challenger over in and out, typically the plugin process's stdin and stdout,
+// until in is closed. Plugins must not write anything else to out.
+func Serve(backend Backend, in io.Reader, out io.Writer) error {
+	server := rpc.NewServer()
+	if err := server.RegisterName(Namespace, &api{backend: backend}); err != nil {
+		return err
+	}
+	defer server.Stop()
+	codec := rpc.NewCodec(&stdioConn{in: in, out: out})
+	server.ServeCodec(codec, 0)
+	return nil
+}
+
+// api exposes a Backend as the JSON-RPC methods of the trace namespace.
+type api struct {
+	backend Backend
+}
+
+func (a *api) Health(ctx context.Context) error {
+	return a.backend.Health(ctx)
+}
+
+func (a *api) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	return a.backend.AbsolutePreStateCommitment(ctx)
+}
+
+func (a *api) Get(ctx context.Context, req TraceRequest) (common.Hash, error) {
+	return a.backend.Get(ctx, req)
+}
+
+func (a *api) GetStepData(ctx context.Context, req TraceRequest) (*StepData, error) {
+	return a.backend.GetStepData(ctx, req)
+}
+
+// stdioConn is an rpc.Conn over a reader and writer, such as those of a process.
+type stdioConn struct {
+	in  io.Reader
+	out io.Writer
+}
+
+func (c *stdioConn) Read(b []byte) (int, error) {
+	return c.in.Read(b)
+}
+
+func (c *stdioConn) Write(b []byte) (int, error) {
+	return c.out.Write(b)
+}
+
+func (c *stdioConn) Close() error {
+	if closer, ok := c.in.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (c *stdioConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
@@ -0,0 +1,67 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+var ErrMissingStepData = errors.New("trace plugin returned no step data")
+
+var _ types.TraceProvider = (*TraceProvider)(nil)
+
+// TraceProvider is a [types.TraceProvider] for a VM execution whose trace is generated by a trace plugin.
+type TraceProvider struct {
+	plugin *Plugin
+	dir    string
+	inputs LocalInputs
+	depth  types.Depth
+}
+
+// NewTraceProvider returns a [TraceProvider] for the execution with the local inputs, whose data the plugin may
+// store in dir.
+func NewTraceProvider(plugin *Plugin, localInputs utils.LocalGameInputs, dir string, depth types.Depth) *TraceProvider {
+	return &TraceProvider{
+		plugin: plugin,
+		dir:    dir,
+		inputs: newLocalInputs(localInputs),
+		depth:  depth,
+	}
+}
+
+func (p *TraceProvider) Get(ctx context.Context, pos types.Position) (common.Hash, error) {
+	var claim common.Hash
+	if err := p.plugin.call(ctx, &claim, "get", p.request(pos)); err != nil {
+		return common.Hash{}, err
+	}
+	return claim, nil
+}
+
+func (p *TraceProvider) GetStepData(ctx context.Context, pos types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	var data *StepData
+	if err := p.plugin.call(ctx, &data, "getStepData", p.request(pos)); err != nil {
+		return nil, nil, nil, err
+	}
+	if data == nil {
+		return nil, nil, nil, ErrMissingStepData
+	}
+	return data.PreState, data.ProofData, data.Oracle.toPreimageOracleData(), nil
+}
+
+func (p *TraceProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	return p.plugin.AbsolutePreStateCommitment(ctx)
+}
+
+func (p *TraceProvider) request(pos types.Position) TraceRequest {
+	return TraceRequest{
+		Dir:         p.dir,
+		LocalInputs: p.inputs,
+		Depth:       uint64(p.depth),
+		TraceIndex:  (*hexutil.Big)(pos.TraceIndex(p.depth)),
+	}
+}
@@ -0,0 +1,307 @@
+package shared
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+// refsDir is the directory within each cache entry holding an empty file named after each game using the entry.
+const refsDir = "refs"
+
+// TraceCache stores the data generated when executing a VM, such as proofs and snapshots, in directories shared by
+// every game that requires the same execution, so games disputing the same output root don't each regenerate it.
+// The games using each entry are recorded on disk so entries survive restarts and are only deleted once no game
+// being tracked still uses them, see ReleaseAllExcept. Safe for concurrent use.
+type TraceCache struct {
+	dir string
+
+	// lock guards entryLocks and the references of every entry, so an entry can't be deleted between checking it
+	// is unused and removing its directory while a game starts using it.
+	lock sync.Mutex
+	// entryLocks serialises the use of each entry so only one game at a time executes the VM in its directory.
+	// Each is a channel with a buffer of one that is held while it contains a value. Locks are removed with their
+	// entry.
+	entryLocks map[common.Hash]chan struct{}
+}
+
+func NewTraceCache(dir string) *TraceCache {
+	return &TraceCache{
+		dir:        dir,
+		entryLocks: make(map[common.Hash]chan struct{}),
+	}
+}
+
+// ExecutionKey identifies a VM execution by everything that determines its trace: the VM, its absolute prestate,
+// the depth of the trace and the local inputs of the execution.
+func ExecutionKey(vm string, prestate common.Hash, depth types.Depth, inputs utils.LocalGameInputs) common.Hash {
+	data := make([]byte, 0, len(vm)+32*6+8)
+	data = append(data, vm...)
+	data = append(data, prestate.Bytes()...)
+	data = binary.BigEndian.AppendUint64(data, uint64(depth))
+	data = append(data, inputs.L1Head.Bytes()...)
+	data = append(data, inputs.L2Head.Bytes()...)
+	data = append(data, inputs.L2OutputRoot.Bytes()...)
+	data = append(data, inputs.L2Claim.Bytes()...)
+	if inputs.L2BlockNumber != nil {
+		data = append(data, common.BigToHash(inputs.L2BlockNumber).Bytes()...)
+	}
+	return crypto.Keccak256Hash(data)
+}
+
+// Acquire records that the game uses the entry and returns the entry's directory.
+func (c *TraceCache) Acquire(game common.Address, key common.Hash) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	dir := c.entryDir(key)
+	if err := os.MkdirAll(filepath.Join(dir, refsDir), 0755); err != nil {
+		return "", fmt.Errorf("failed to create trace cache entry %v: %w", key, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, refsDir, game.Hex()), nil, 0644); err != nil {
+		return "", fmt.Errorf("failed to record game %v using trace cache entry %v: %w", game, key, err)
+	}
+	return dir, nil
+}
+
+// ReleaseAllExcept removes the references to entries from games not in keep, then deletes the entries that are no
+// longer used by any game. It is called alongside scheduler.DiskManager.RemoveAllExcept with the same games.
+// A nil TraceCache does nothing.
+func (c *TraceCache) ReleaseAllExcept(keep []common.Address) error {
+	if c == nil {
+		return nil
+	}
+	keys, err := c.keys()
+	if err != nil {
+		return err
+	}
+	release := func(game common.Address) bool { return !slices.Contains(keep, game) }
+	var errs []error
+	for _, key := range keys {
+		if _, err := c.release(key, release); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Entry is an entry of the cache and the games using it.
+type Entry struct {
+	Key   common.Hash
+	Dir   string
+	Games []common.Address
+}
+
+// Entries lists the entries of the cache, so the space they use can be counted towards a disk quota.
+// A nil TraceCache has no entries.
+func (c *TraceCache) Entries() ([]Entry, error) {
+	if c == nil {
+		return nil, nil
+	}
+	keys, err := c.keys()
+	if err != nil {
+		return nil, err
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entries := make([]Entry, 0, len(keys))
+	for _, key := range keys {
+		refs, err := c.refs(key)
+		if err != nil {
+			return nil, err
+		}
+		entry := Entry{Key: key, Dir: c.entryDir(key)}
+		for _, ref := range refs {
+			if common.IsHexAddress(ref) {
+				entry.Games = append(entry.Games, common.HexToAddress(ref))
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Evict removes the references to the entry from the games and deletes the entry if no other game uses it.
+// Returns true if the entry was deleted. Entries that are being used to generate trace data are not deleted.
+func (c *TraceCache) Evict(key common.Hash, games []common.Address) (bool, error) {
+	return c.release(key, func(game common.Address) bool { return slices.Contains(games, game) })
+}
+
+// keys lists the keys of the entries in the cache.
+func (c *TraceCache) keys() ([]common.Hash, error) {
+	entries, err := os.ReadDir(c.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list trace cache entries: %w", err)
+	}
+	var keys []common.Hash
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		key := common.HexToHash(entry.Name())
+		if key.Hex() != entry.Name() {
+			// Not a cache entry.
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// refs lists the names of the references to the entry. The lock must be held.
+func (c *TraceCache) refs(key common.Hash) ([]string, error) {
+	refs, err := os.ReadDir(filepath.Join(c.entryDir(key), refsDir))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list games using trace cache entry %v: %w", key, err)
+	}
+	names := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		names = append(names, ref.Name())
+	}
+	return names, nil
+}
+
+// release removes the references to the entry from the games for which release returns true and deletes the
+// entry if it is no longer used. Returns true if the entry was deleted.
+func (c *TraceCache) release(key common.Hash, release func(game common.Address) bool) (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	refs, err := c.refs(key)
+	if err != nil {
+		return false, err
+	}
+	used := false
+	for _, ref := range refs {
+		if !common.IsHexAddress(ref) || !release(common.HexToAddress(ref)) {
+			used = true
+			continue
+		}
+		if err := os.Remove(filepath.Join(c.entryDir(key), refsDir, ref)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return false, fmt.Errorf("failed to release trace cache entry %v: %w", key, err)
+		}
+	}
+	if used {
+		return false, nil
+	}
+	lock, ok := c.entryLocks[key]
+	if ok {
+		select {
+		case lock <- struct{}{}:
+			defer func() { <-lock }()
+		default:
+			// The entry is still being used, e.g. by a game that has just been dropped, so is deleted next time.
+			return false, nil
+		}
+	}
+	if err := os.RemoveAll(c.entryDir(key)); err != nil {
+		return false, err
+	}
+	delete(c.entryLocks, key)
+	return true, nil
+}
+
+func (c *TraceCache) entryDir(key common.Hash) string {
+	return filepath.Join(c.dir, key.Hex())
+}
+
+func (c *TraceCache) entryLock(key common.Hash) chan struct{} {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	lock, ok := c.entryLocks[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		c.entryLocks[key] = lock
+	}
+	return lock
+}
+
+// lockEntry waits for the entry's lock, returning the function to release it, or an error if ctx is done first.
+func (c *TraceCache) lockEntry(ctx context.Context, key common.Hash) (func(), error) {
+	for {
+		lock := c.entryLock(key)
+		select {
+		case lock <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		c.lock.Lock()
+		current := c.entryLocks[key] == lock
+		c.lock.Unlock()
+		if current {
+			return func() { <-lock }, nil
+		}
+		// The entry was deleted while waiting so its lock no longer serialises the use of its directory.
+		<-lock
+	}
+}
+
+// ForGame returns the view of the cache used by a game whose VM executions start from the prestate.
+// A nil TraceCache returns nil, in which case games store executions in their own directory.
+func (c *TraceCache) ForGame(vm string, game common.Address, prestate common.Hash) *GameTraces {
+	if c == nil {
+		return nil
+	}
+	return &GameTraces{cache: c, vm: vm, game: game, prestate: prestate}
+}
+
+// GameTraces creates the trace providers of a game using the directories of a TraceCache.
+type GameTraces struct {
+	cache    *TraceCache
+	vm       string
+	game     common.Address
+	prestate common.Hash
+}
+
+// Provider returns a trace provider for the execution with the specified inputs, created by create with the
+// directory of the execution's cache entry. Calls to the provider are serialised with those of any other game's
+// provider for the same execution.
+func (g *GameTraces) Provider(depth types.Depth, inputs utils.LocalGameInputs, create func(dir string) types.TraceProvider) (types.TraceProvider, error) {
+	key := ExecutionKey(g.vm, g.prestate, depth, inputs)
+	dir, err := g.cache.Acquire(g.game, key)
+	if err != nil {
+		return nil, err
+	}
+	return &lockedProvider{TraceProvider: create(dir), cache: g.cache, key: key}, nil
+}
+
+// lockedProvider is a trace provider that holds its cache entry's lock while generating trace data.
+type lockedProvider struct {
+	types.TraceProvider
+	cache *TraceCache
+	key   common.Hash
+}
+
+// acquire waits for the entry's lock, returning the function to release it, or an error if ctx is done first.
+func (p *lockedProvider) acquire(ctx context.Context) (func(), error) {
+	return p.cache.lockEntry(ctx, p.key)
+}
+
+func (p *lockedProvider) Get(ctx context.Context, i types.Position) (common.Hash, error) {
+	release, err := p.acquire(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer release()
+	return p.TraceProvider.Get(ctx, i)
+}
+
+func (p *lockedProvider) GetStepData(ctx context.Context, i types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	release, err := p.acquire(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	defer release()
+	return p.TraceProvider.GetStepData(ctx, i)
+}
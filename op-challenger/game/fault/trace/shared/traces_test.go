@@ -0,0 +1,213 @@
+package shared
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionKey(t *testing.T) {
+	inputs := utils.LocalGameInputs{
+		L1Head:        common.Hash{0x01},
+		L2Head:        common.Hash{0x02},
+		L2OutputRoot:  common.Hash{0x03},
+		L2Claim:       common.Hash{0x04},
+		L2BlockNumber: big.NewInt(5),
+	}
+	key := ExecutionKey("cannon", common.Hash{0xaa}, 30, inputs)
+	require.Equal(t, key, ExecutionKey("cannon", common.Hash{0xaa}, 30, inputs))
+
+	require.NotEqual(t, key, ExecutionKey("asterisc", common.Hash{0xaa}, 30, inputs))
+	require.NotEqual(t, key, ExecutionKey("cannon", common.Hash{0xbb}, 30, inputs))
+	require.NotEqual(t, key, ExecutionKey("cannon", common.Hash{0xaa}, 31, inputs))
+	for _, modify := range []func(*utils.LocalGameInputs){
+		func(i *utils.LocalGameInputs) { i.L1Head = common.Hash{0xff} },
+		func(i *utils.LocalGameInputs) { i.L2Head = common.Hash{0xff} },
+		func(i *utils.LocalGameInputs) { i.L2OutputRoot = common.Hash{0xff} },
+		func(i *utils.LocalGameInputs) { i.L2Claim = common.Hash{0xff} },
+		func(i *utils.LocalGameInputs) { i.L2BlockNumber = big.NewInt(6) },
+	} {
+		modified := inputs
+		modify(&modified)
+		require.NotEqual(t, key, ExecutionKey("cannon", common.Hash{0xaa}, 30, modified))
+	}
+}
+
+func TestReleaseAllExcept(t *testing.T) {
+	cache := NewTraceCache(filepath.Join(t.TempDir(), "traces"))
+	require.NoError(t, cache.ReleaseAllExcept(nil), "should not fail before any entries are created")
+
+	game1 := common.Address{0x01}
+	game2 := common.Address{0x02}
+	shared := common.Hash{0xaa}
+	other := common.Hash{0xbb}
+	sharedDir, err := cache.Acquire(game1, shared)
+	require.NoError(t, err)
+	dir, err := cache.Acquire(game2, shared)
+	require.NoError(t, err)
+	require.Equal(t, sharedDir, dir, "should use the same directory for the same execution")
+	otherDir, err := cache.Acquire(game2, other)
+	require.NoError(t, err)
+	require.NotEqual(t, sharedDir, otherDir)
+	sharedFile := filepath.Join(sharedDir, "proofs.json")
+	require.NoError(t, os.WriteFile(sharedFile, []byte("proofs"), 0644))
+
+	// Entries are kept while any game still uses them
+	require.NoError(t, cache.ReleaseAllExcept([]common.Address{game1}))
+	require.FileExists(t, sharedFile)
+	require.NoDirExists(t, otherDir, "should delete entry no longer used by any game")
+
+	require.NoError(t, cache.ReleaseAllExcept(nil))
+	require.NoDirExists(t, sharedDir)
+}
+
+func TestReleaseAllExceptSkipsEntriesInUse(t *testing.T) {
+	cache := NewTraceCache(t.TempDir())
+	game := common.Address{0x01}
+	provider, err := cache.ForGame("cannon", game, common.Hash{0xaa}).Provider(30, utils.LocalGameInputs{}, func(dir string) types.TraceProvider {
+		return &stubTraceProvider{}
+	})
+	require.NoError(t, err)
+	locked := provider.(*lockedProvider)
+	release, err := locked.acquire(context.Background())
+	require.NoError(t, err)
+
+	key := ExecutionKey("cannon", common.Hash{0xaa}, 30, utils.LocalGameInputs{})
+	require.NoError(t, cache.ReleaseAllExcept(nil))
+	require.DirExists(t, cache.entryDir(key), "should not delete entry while it is in use")
+
+	release()
+	require.NoError(t, cache.ReleaseAllExcept(nil))
+	require.NoDirExists(t, cache.entryDir(key))
+}
+
+func TestReleaseAllExceptKeepsEntryAcquiredConcurrently(t *testing.T) {
+	cache := NewTraceCache(t.TempDir())
+	game := common.Address{0x01}
+	for i := 0; i < 100; i++ {
+		key := common.Hash{byte(i)}
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, cache.ReleaseAllExcept([]common.Address{game}))
+		}()
+		dir, err := cache.Acquire(game, key)
+		require.NoError(t, err)
+		wg.Wait()
+		require.FileExists(t, filepath.Join(dir, refsDir, game.Hex()), "should not delete entry acquired by a game being kept")
+	}
+}
+
+func TestReleaseAllExceptRemovesEntryLocks(t *testing.T) {
+	cache := NewTraceCache(t.TempDir())
+	provider, err := cache.ForGame("cannon", common.Address{0x01}, common.Hash{0xaa}).Provider(30, utils.LocalGameInputs{}, func(dir string) types.TraceProvider {
+		return &stubTraceProvider{}
+	})
+	require.NoError(t, err)
+	_, err = provider.Get(context.Background(), types.NewPositionFromGIndex(big.NewInt(1)))
+	require.NoError(t, err)
+	require.Len(t, cache.entryLocks, 1)
+
+	require.NoError(t, cache.ReleaseAllExcept(nil))
+	require.Empty(t, cache.entryLocks, "should remove lock of deleted entry")
+
+	// A provider created before the entry was deleted uses the same lock as those created after
+	release, err := provider.(*lockedProvider).acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = cache.lockEntry(ctx, ExecutionKey("cannon", common.Hash{0xaa}, 30, utils.LocalGameInputs{}))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestEvict(t *testing.T) {
+	cache := NewTraceCache(t.TempDir())
+	entries, err := cache.Entries()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	game1 := common.Address{0x01}
+	game2 := common.Address{0x02}
+	key := common.Hash{0xaa}
+	dir, err := cache.Acquire(game1, key)
+	require.NoError(t, err)
+	_, err = cache.Acquire(game2, key)
+	require.NoError(t, err)
+	entries, err = cache.Entries()
+	require.NoError(t, err)
+	require.Equal(t, []Entry{{Key: key, Dir: dir, Games: []common.Address{game1, game2}}}, entries)
+
+	deleted, err := cache.Evict(key, []common.Address{game1})
+	require.NoError(t, err)
+	require.False(t, deleted, "should not delete entry still used by another game")
+	require.DirExists(t, dir)
+
+	deleted, err = cache.Evict(key, []common.Address{game2})
+	require.NoError(t, err)
+	require.True(t, deleted)
+	require.NoDirExists(t, dir)
+}
+
+func TestProviderSerialisesExecutions(t *testing.T) {
+	cache := NewTraceCache(t.TempDir())
+	stub := &stubTraceProvider{hash: common.Hash{0xcc}}
+	create := func(dir string) types.TraceProvider {
+		return stub
+	}
+	provider1, err := cache.ForGame("cannon", common.Address{0x01}, common.Hash{0xaa}).Provider(30, utils.LocalGameInputs{}, create)
+	require.NoError(t, err)
+	provider2, err := cache.ForGame("cannon", common.Address{0x02}, common.Hash{0xaa}).Provider(30, utils.LocalGameInputs{}, create)
+	require.NoError(t, err)
+
+	hash, err := provider2.Get(context.Background(), types.NewPositionFromGIndex(big.NewInt(1)))
+	require.NoError(t, err)
+	require.Equal(t, stub.hash, hash)
+
+	// While one game executes the VM, other games using the same entry wait for it
+	release, err := provider1.(*lockedProvider).acquire(context.Background())
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = provider2.Get(ctx, types.NewPositionFromGIndex(big.NewInt(1)))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	_, _, _, err = provider2.GetStepData(ctx, types.NewPositionFromGIndex(big.NewInt(1)))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	release()
+	hash, err = provider2.Get(context.Background(), types.NewPositionFromGIndex(big.NewInt(1)))
+	require.NoError(t, err)
+	require.Equal(t, stub.hash, hash)
+}
+
+func TestNilTraceCache(t *testing.T) {
+	var cache *TraceCache
+	require.Nil(t, cache.ForGame("cannon", common.Address{0x01}, common.Hash{0xaa}))
+	require.NoError(t, cache.ReleaseAllExcept(nil))
+	entries, err := cache.Entries()
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+type stubTraceProvider struct {
+	types.TraceProvider
+	hash common.Hash
+}
+
+func (s *stubTraceProvider) Get(_ context.Context, _ types.Position) (common.Hash, error) {
+	return s.hash, nil
+}
+
+func (s *stubTraceProvider) GetStepData(_ context.Context, _ types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	return nil, nil, nil, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
 	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/sender"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -80,7 +81,10 @@ func (c *PreimageChallenger) Challenge(ctx context.Context, blockHash common.Has
 	c.log.Debug("Created preimage challenge transactions", "count", len(txs))
 	if len(txs) > 0 {
 		err := c.sender.SendAndWaitSimple("challenge preimages", txs...)
-		if err != nil {
+		if errors.Is(err, sender.ErrTxSimulated) {
+			c.log.Info("Preimage challenges simulated, not sent in shadow mode", "count", len(txs))
+			return nil
+		} else if err != nil {
 			c.metrics.RecordPreimageChallengeFailed()
 			return fmt.Errorf("failed to send challenge txs: %w", err)
 		}
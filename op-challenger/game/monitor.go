@@ -2,12 +2,10 @@ package game
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"sync"
 	"time"
 
-	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -125,9 +123,7 @@ func (m *gameMonitor) progressGames(ctx context.Context, blockHash common.Hash,
 	if err := m.claimer.Schedule(blockNumber, gamesToPlay); err != nil {
 		return fmt.Errorf("failed to schedule bond claims: %w", err)
 	}
-	if err := m.scheduler.Schedule(gamesToPlay, blockNumber); errors.Is(err, scheduler.ErrBusy) {
-		m.logger.Info("Scheduler still busy with previous update")
-	} else if err != nil {
+	if err := m.scheduler.Schedule(gamesToPlay, blockNumber); err != nil {
 		return fmt.Errorf("failed to schedule games: %w", err)
 	}
 	return nil
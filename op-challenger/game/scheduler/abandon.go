@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"bytes"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type AbandonMetricer interface {
+	RecordGameAbandoned(reason string)
+}
+
+// AbandonReasonRetryAge is the reason recorded for games abandoned because they kept failing for longer than
+// the limit set by WithMaxRetryAge.
+const AbandonReasonRetryAge = "retry age exceeded"
+
+// AbandonedGame describes a game the scheduler has stopped progressing, see AbandonedGames.
+type AbandonedGame struct {
+	Game common.Address
+	// Factory is the factory of the source the game is from, see RegisterSource, or zero for the primary factory.
+	Factory common.Address
+	Reason  string
+	// FirstFailure is the time of the first failure in the run of failures that led to the game being abandoned.
+	FirstFailure time.Time
+	// Time is when the game was abandoned.
+	Time time.Time
+}
+
+// recordFailure records a failure to create a job for or progress the game, abandoning it if it has been failing
+// for longer than the limit set by WithMaxRetryAge. The lock must be held.
+func (c *coordinator) recordFailure(addr common.Address, state *gameState) {
+	now := c.cfg.clock.Now()
+	if state.firstFailure.IsZero() {
+		state.firstFailure = now
+		return
+	}
+	maxRetryAge := c.gameTypeSettings(state.gameType).MaxRetryAge
+	if maxRetryAge <= 0 || now.Sub(state.firstFailure) <= maxRetryAge {
+		return
+	}
+	if _, ok := c.abandoned[addr]; ok {
+		return
+	}
+	c.abandoned[addr] = AbandonedGame{Game: addr, Factory: c.factory, Reason: AbandonReasonRetryAge, FirstFailure: state.firstFailure, Time: now}
+	c.m.RecordGameAbandoned(AbandonReasonRetryAge)
+	c.events.Emit(addr, EventAbandoned, c.cycle, state.correlationID)
+	c.logger.Error("Abandoning game", "game", addr, "reason", AbandonReasonRetryAge, "firstFailure", state.firstFailure)
+	c.tracer.Log(addr, "Abandoned game", "reason", AbandonReasonRetryAge)
+}
+
+// pruneAbandoned forgets abandoned games whose state has been removed because they stopped being scheduled.
+// The lock must be held.
+func (c *coordinator) pruneAbandoned() {
+	for addr := range c.abandoned {
+		if _, ok := c.states[addr]; !ok {
+			delete(c.abandoned, addr)
+		}
+	}
+}
+
+// AbandonedGames returns the games of the primary factory and every registered source that are no longer being
+// progressed because they were abandoned, ordered by address. A game is forgotten, and progressed again if it is
+// later scheduled, once it stops being scheduled.
+func (s *Scheduler) AbandonedGames() []AbandonedGame {
+	var games []AbandonedGame
+	for _, c := range s.coordinators() {
+		c.lock.Lock()
+		for _, game := range c.abandoned {
+			games = append(games, game)
+		}
+		c.lock.Unlock()
+	}
+	slices.SortFunc(games, func(a, b AbandonedGame) int {
+		if diff := bytes.Compare(a.Game[:], b.Game[:]); diff != 0 {
+			return diff
+		}
+		return compareAddresses(a.Factory, b.Factory)
+	})
+	return games
+}
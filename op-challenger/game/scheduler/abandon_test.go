@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbandonGameAtMaxRetryAge(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	c.cfg.maxRetryAge = 5 * time.Minute
+	m := c.m.(*stubSchedulerMetrics)
+	failing := common.Address{0xaa}
+	healthy := common.Address{0xbb}
+	ctx := context.Background()
+	start := cl.Now()
+
+	require.NoError(t, c.schedule(ctx, asGames(failing, healthy), 0))
+	games.created[failing].ProgressErr = errors.New("boom")
+	block := uint64(0)
+	progress := func() {
+		for len(workQueue) > 0 {
+			require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+		}
+		cl.AdvanceTime(time.Minute)
+		block++
+		require.NoError(t, c.schedule(ctx, asGames(failing, healthy), block))
+	}
+	// Failures up to the limit are retried
+	for i := 0; i < 6; i++ {
+		progress()
+		require.Len(t, workQueue, 2, "should retry failing game")
+		require.Empty(t, m.abandoned)
+	}
+
+	// Abandoned once the time since the first failure exceeds the limit
+	progress()
+	require.Len(t, workQueue, 1, "should not schedule abandoned game")
+	require.Equal(t, healthy, (<-workQueue).addr)
+	require.Equal(t, map[string]int{AbandonReasonRetryAge: 1}, m.abandoned)
+	abandoned, ok := c.abandoned[failing]
+	require.True(t, ok)
+	require.Equal(t, AbandonReasonRetryAge, abandoned.Reason)
+	require.Equal(t, start, abandoned.FirstFailure)
+	require.Equal(t, start.Add(6*time.Minute), abandoned.Time)
+	require.Equal(t, 7, games.created[failing].ProgressCount)
+
+	// Forgotten once it stops being scheduled
+	require.NoError(t, c.schedule(ctx, asGames(healthy), block+1))
+	require.Empty(t, c.abandoned)
+}
+
+func TestMaxRetryAgeResetOnSuccess(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	c.cfg.maxRetryAge = 5 * time.Minute
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+	progressErr := errors.New("boom")
+
+	for i := 0; i < 20; i++ {
+		require.NoError(t, c.schedule(ctx, asGames(gameAddr), uint64(i)))
+		require.Len(t, workQueue, 1, "should not abandon game that intermittently succeeds")
+		if i%4 == 3 {
+			games.created[gameAddr].ProgressErr = nil
+		} else {
+			games.created[gameAddr].ProgressErr = progressErr
+		}
+		require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+		cl.AdvanceTime(time.Minute)
+	}
+	require.Empty(t, c.abandoned)
+}
+
+func TestAbandonGameFailingToCreateJobs(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	c.cfg.maxRetryAge = time.Minute
+	m := c.m.(*stubSchedulerMetrics)
+	gameAddr := common.Address{0xaa}
+	games.creationFails = gameAddr
+	ctx := context.Background()
+
+	require.Error(t, c.schedule(ctx, asGames(gameAddr), 0))
+	cl.AdvanceTime(time.Minute)
+	require.Error(t, c.schedule(ctx, asGames(gameAddr), 1))
+	require.Empty(t, m.abandoned)
+	cl.AdvanceTime(time.Second)
+	require.Error(t, c.schedule(ctx, asGames(gameAddr), 2))
+	require.Equal(t, 1, m.abandoned[AbandonReasonRetryAge])
+
+	// No further attempts are made to create a player
+	games.creationFails = common.Address{}
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 3))
+	require.Empty(t, workQueue)
+	require.Empty(t, games.created)
+}
+
+func TestAbandonedGames(t *testing.T) {
+	s := NewScheduler(testlog.Logger(t, log.LevelInfo), metrics.NoopMetrics, &trackingDiskManager{}, 1, nil, false)
+	require.Empty(t, s.AbandonedGames())
+	game1 := AbandonedGame{Game: common.Address{0xaa}, Reason: AbandonReasonRetryAge}
+	game2 := AbandonedGame{Game: common.Address{0xbb}, Reason: AbandonReasonRetryAge}
+	s.coordinator.abandoned[game2.Game] = game2
+	s.coordinator.abandoned[game1.Game] = game1
+	require.Equal(t, []AbandonedGame{game1, game2}, s.AbandonedGames())
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AckSink is notified once for each game passed to Schedule when it reaches a terminal state in the pipeline, see
+// WithAckSink. The cycle identifies the scheduling cycle the batch was scheduled in.
+type AckSink func(addr common.Address, cycle uint64)
+
+// ackBatch acknowledges each game of the batch scheduled in cycle. Games that had a job created for them are
+// acknowledged once that job reaches a terminal state, see ackJob. All others, including games listed more than once,
+// are acknowledged immediately. The lock must be held.
+func (c *coordinator) ackBatch(games []types.GameMetadata, jobs []job, cycle uint64) {
+	if c.cfg.ackSink == nil {
+		return
+	}
+	unclaimed := make(map[common.Address]int, len(jobs))
+	for i, j := range jobs {
+		unclaimed[j.addr] = i
+	}
+	for _, game := range games {
+		if i, ok := unclaimed[game.Proxy]; ok {
+			jobs[i].ackCycle = cycle
+			delete(unclaimed, game.Proxy)
+			continue
+		}
+		c.cfg.ackSink(game.Proxy, cycle)
+	}
+}
+
+// ackSkippedBatch immediately acknowledges each game of a batch that was rejected before its cycle started.
+func (c *coordinator) ackSkippedBatch(games []types.GameMetadata, cycle uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.ackBatch(games, nil, cycle)
+}
+
+// ackJob acknowledges the game the job was created for if the job is responsible for acknowledging a scheduled game.
+// Called once the job reaches a terminal state: its result is processed or it is released without being
+// progressed. The lock must be held.
+func (c *coordinator) ackJob(j job) {
+	if j.ackCycle == 0 || c.cfg.ackSink == nil {
+		return
+	}
+	c.cfg.ackSink(j.addr, j.ackCycle)
+}
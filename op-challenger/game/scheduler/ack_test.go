@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+type ack struct {
+	game  common.Address
+	cycle uint64
+}
+
+func TestAckEachScheduledGameOnce(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	var acks []ack
+	c.cfg.ackSink = func(addr common.Address, cycle uint64) {
+		acks = append(acks, ack{addr, cycle})
+	}
+	normal := common.Address{0xaa}
+	filtered := common.Address{0xbb}
+	inflight := common.Address{0xcc}
+	WithGameFilter(func(game types.GameMetadata) bool {
+		return game.Proxy != filtered
+	})(&c.cfg)
+	c.filter = c.cfg.gameFilter
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(inflight), 0))
+	require.Empty(t, acks, "should not ack until the job completes")
+	inflightJob := <-workQueue
+
+	// The in-flight game and the duplicate entry are deduped to existing jobs
+	require.NoError(t, c.schedule(ctx, asGames(normal, filtered, inflight, normal), 1))
+	require.ElementsMatch(t, []ack{{filtered, 2}, {inflight, 2}, {normal, 2}}, acks)
+
+	require.NoError(t, c.processResult(runJob(ctx, inflightJob)))
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.Empty(t, workQueue)
+	require.ElementsMatch(t, []ack{{filtered, 2}, {inflight, 2}, {normal, 2}, {inflight, 1}, {normal, 2}}, acks)
+}
+
+func TestAckReleasedJobs(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 1)
+	c.cfg.queueFullStrategy = QueueFullDrop
+	var acks []ack
+	c.cfg.ackSink = func(addr common.Address, cycle uint64) {
+		acks = append(acks, ack{addr, cycle})
+	}
+	queued := common.Address{0xaa}
+	dropped := common.Address{0xbb}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(queued, dropped), 0))
+	require.Equal(t, []ack{{dropped, 1}}, acks, "should ack dropped job")
+
+	j := <-workQueue
+	j.player = nil
+	require.ErrorIs(t, c.processResult(j), errInvalidResult)
+	require.Equal(t, []ack{{dropped, 1}, {queued, 1}}, acks, "should ack discarded result")
+
+	// Batches rejected before their cycle starts are acked immediately
+	c.jobLimitReached.Store(true)
+	require.NoError(t, c.schedule(ctx, asGames(queued), 1))
+	require.Equal(t, []ack{{dropped, 1}, {queued, 1}, {queued, 2}}, acks)
+}
+
+func TestAckRetriedInvalidResultOnce(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.invalidResultPolicy = InvalidResultRetryOnce
+	var acks []ack
+	c.cfg.ackSink = func(addr common.Address, cycle uint64) {
+		acks = append(acks, ack{addr, cycle})
+	}
+	game := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(game), 0))
+	j := <-workQueue
+	player := j.player
+	j.player = nil
+	require.ErrorIs(t, c.processResult(j), errInvalidResult)
+	require.Empty(t, acks, "should ack when the retry completes")
+
+	retry := <-workQueue
+	retry.player = player
+	require.NoError(t, c.processResult(runJob(ctx, retry)))
+	require.Equal(t, []ack{{game, 1}}, acks)
+}
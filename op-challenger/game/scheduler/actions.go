@@ -0,0 +1,26 @@
+package scheduler
+
+type ActionsPausedMetricer interface {
+	RecordActionSuppressed()
+}
+
+// PauseActions stops players taking actions while still progressing games so their status continues to be tracked.
+// Jobs already being progressed are unaffected. Unlike stopping the scheduler, games continue to be scheduled as
+// normal. Use ResumeActions to allow actions again.
+func (s *Scheduler) PauseActions() {
+	if !s.actionsPaused.Swap(true) {
+		s.logger.Warn("Pausing game actions, games will only be observed")
+	}
+}
+
+// ResumeActions allows players to take actions again after PauseActions.
+func (s *Scheduler) ResumeActions() {
+	if s.actionsPaused.Swap(false) {
+		s.logger.Info("Resuming game actions")
+	}
+}
+
+// ActionsPaused returns true if actions are currently paused by PauseActions.
+func (s *Scheduler) ActionsPaused() bool {
+	return s.actionsPaused.Load()
+}
@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseActions(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	player := &observingPlayer{StubGamePlayer: test.StubGamePlayer{StatusValue: types.GameStatusInProgress}}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return player, nil
+	}
+	m := &suppressedActionMetrics{}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, m, disk, 1, createPlayer, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+	gameAddr := common.Address{0xaa}
+	status := func() types.GameStatus {
+		s.coordinator.lock.Lock()
+		defer s.coordinator.lock.Unlock()
+		return s.coordinator.states[gameAddr].status
+	}
+
+	require.NoError(t, s.Schedule(asGames(gameAddr), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Equal(t, 1, player.acted)
+
+	s.PauseActions()
+	require.True(t, s.ActionsPaused())
+	require.NoError(t, s.Schedule(asGames(gameAddr), 1))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Equal(t, 1, player.acted, "should not act while actions are paused")
+	require.Equal(t, 1, player.observed)
+	require.Equal(t, types.GameStatusInProgress, status())
+	require.EqualValues(t, 1, m.suppressed.Load())
+
+	// Status updates are still tracked while actions are paused
+	player.StatusValue = types.GameStatusDefenderWon
+	require.NoError(t, s.Schedule(asGames(gameAddr), 2))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Equal(t, 1, player.acted, "should not act while actions are paused")
+	require.Equal(t, 2, player.observed)
+	require.Equal(t, types.GameStatusDefenderWon, status())
+	require.EqualValues(t, 1, m.suppressed.Load(), "should not record suppressed actions for resolved games")
+
+	s.ResumeActions()
+	require.False(t, s.ActionsPaused())
+}
+
+// observingPlayer only acts when actions aren't suppressed, counting how many times it observed or acted on the game.
+type observingPlayer struct {
+	test.StubGamePlayer
+	acted    int
+	observed int
+}
+
+func (p *observingPlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	p.ActionTakenValue = !types.ActionsSuppressed(ctx)
+	if p.ActionTakenValue {
+		p.acted++
+	} else {
+		p.observed++
+	}
+	return p.StubGamePlayer.ProgressGame(ctx)
+}
+
+type suppressedActionMetrics struct {
+	metrics.NoopMetricsImpl
+	suppressed atomic.Int32
+}
+
+func (m *suppressedActionMetrics) RecordActionSuppressed() {
+	m.suppressed.Add(1)
+}
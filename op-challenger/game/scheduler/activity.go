@@ -0,0 +1,33 @@
+package scheduler
+
+import "math"
+
+// activityDecay reduces how often chronically idle games are scheduled.
+// Each game has an activity score in (0, 1]. Results where the player took no action multiply the
+// score by factor, while any action immediately restores it to 1. A game is only scheduled once at
+// least 1/score cycles have passed since it was last scheduled, up to a maximum of maxInterval cycles.
+type activityDecay struct {
+	factor      float64
+	maxInterval uint64
+}
+
+func (a activityDecay) enabled() bool {
+	return a.factor > 0 && a.factor < 1 && a.maxInterval > 1
+}
+
+// update returns the new activity score after a result.
+func (a activityDecay) update(score float64, acted bool) float64 {
+	if acted || !a.enabled() {
+		return 1
+	}
+	// No point decaying further than the point where the max interval applies.
+	return math.Max(score*a.factor, 1/float64(a.maxInterval))
+}
+
+// interval returns the number of cycles between schedules for a game with the specified activity score.
+func (a activityDecay) interval(score float64) uint64 {
+	if !a.enabled() || score <= 0 {
+		return 1
+	}
+	return max(1, min(a.maxInterval, uint64(math.Floor(1/score))))
+}
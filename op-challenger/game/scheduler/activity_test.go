@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActivityDecay(t *testing.T) {
+	decay := activityDecay{factor: 0.5, maxInterval: 4}
+	score := 1.0
+	require.EqualValues(t, 1, decay.interval(score))
+
+	score = decay.update(score, false)
+	require.Equal(t, 0.5, score)
+	require.EqualValues(t, 2, decay.interval(score))
+
+	score = decay.update(score, false)
+	require.EqualValues(t, 4, decay.interval(score))
+
+	// Score stops decaying once the max interval is reached
+	score = decay.update(score, false)
+	score = decay.update(score, false)
+	require.Equal(t, 0.25, score)
+	require.EqualValues(t, 4, decay.interval(score))
+
+	// Recovers immediately on action
+	score = decay.update(score, true)
+	require.Equal(t, 1.0, score)
+	require.EqualValues(t, 1, decay.interval(score))
+}
+
+func TestActivityDecayDisabled(t *testing.T) {
+	for _, decay := range []activityDecay{{}, {factor: 1, maxInterval: 4}, {factor: 0.5, maxInterval: 1}} {
+		require.Equal(t, 1.0, decay.update(1, false))
+		require.EqualValues(t, 1, decay.interval(0.1))
+	}
+}
+
+func TestScheduleIdleGamesLessFrequently(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.activityDecay = activityDecay{factor: 0.5, maxInterval: 4}
+	activeGame := common.Address{0xaa}
+	idleGame := common.Address{0xbb}
+	ctx := context.Background()
+
+	// Processes all results and returns the set of games that were scheduled
+	processResults := func() []common.Address {
+		var scheduled []common.Address
+		for len(workQueue) > 0 {
+			j := <-workQueue
+			scheduled = append(scheduled, j.addr)
+			require.NoError(t, c.processResult(runJob(ctx, j)))
+		}
+		return scheduled
+	}
+	runCycle := func() []common.Address {
+		require.NoError(t, c.schedule(ctx, asGames(activeGame, idleGame), 0))
+		return processResults()
+	}
+	require.NoError(t, c.schedule(ctx, asGames(activeGame, idleGame), 0))
+	games.created[activeGame].ActionTakenValue = true
+	require.ElementsMatch(t, []common.Address{activeGame, idleGame}, processResults())
+
+	// Idle game now has an interval of 2
+	require.ElementsMatch(t, []common.Address{activeGame}, runCycle())
+	require.ElementsMatch(t, []common.Address{activeGame, idleGame}, runCycle())
+
+	// Idle game now has an interval of 4
+	require.ElementsMatch(t, []common.Address{activeGame}, runCycle())
+	require.ElementsMatch(t, []common.Address{activeGame}, runCycle())
+	require.ElementsMatch(t, []common.Address{activeGame}, runCycle())
+
+	// Idle game takes action when next scheduled so should be scheduled every cycle again
+	games.created[idleGame].ActionTakenValue = true
+	require.ElementsMatch(t, []common.Address{activeGame, idleGame}, runCycle())
+	require.ElementsMatch(t, []common.Address{activeGame, idleGame}, runCycle())
+}
+
+func TestSchedulePinnedIdleGamesEveryCycle(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.activityDecay = activityDecay{factor: 0.5, maxInterval: 4}
+	s := &Scheduler{coordinator: c}
+	pinnedGame := common.Address{0xaa}
+	idleGame := common.Address{0xbb}
+	ctx := context.Background()
+	s.PinGame(pinnedGame)
+	require.Equal(t, []common.Address{pinnedGame}, s.PinnedGames())
+
+	runCycle := func() []common.Address {
+		require.NoError(t, c.schedule(ctx, asGames(pinnedGame, idleGame), 0))
+		var scheduled []common.Address
+		for len(workQueue) > 0 {
+			j := <-workQueue
+			scheduled = append(scheduled, j.addr)
+			require.NoError(t, c.processResult(runJob(ctx, j)))
+		}
+		return scheduled
+	}
+	require.ElementsMatch(t, []common.Address{pinnedGame, idleGame}, runCycle())
+	// Neither game takes action but only the unpinned game is decayed
+	require.ElementsMatch(t, []common.Address{pinnedGame}, runCycle())
+	require.ElementsMatch(t, []common.Address{pinnedGame, idleGame}, runCycle())
+	require.ElementsMatch(t, []common.Address{pinnedGame}, runCycle())
+	require.ElementsMatch(t, []common.Address{pinnedGame}, runCycle())
+	require.ElementsMatch(t, []common.Address{pinnedGame}, runCycle())
+	require.ElementsMatch(t, []common.Address{pinnedGame, idleGame}, runCycle())
+	require.Less(t, c.states[pinnedGame].activity, 1.0, "should still track activity of pinned games")
+
+	// Once unpinned the game is decayed according to its activity
+	s.UnpinGame(pinnedGame)
+	require.Empty(t, s.PinnedGames())
+	require.Empty(t, runCycle())
+}
+
+func TestPinnedGamesStillCoolDown(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.activityDecay = activityDecay{factor: 0.5, maxInterval: 4}
+	WithActionCooldown(time.Minute)(&c.cfg)
+	s := &Scheduler{coordinator: c}
+	game := common.Address{0xaa}
+	ctx := context.Background()
+	s.PinGame(game)
+
+	require.NoError(t, c.schedule(ctx, asGames(game), 0))
+	games.created[game].ActionTakenValue = true
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.NoError(t, c.schedule(ctx, asGames(game), 1))
+	require.Empty(t, workQueue, "should not bypass cooldown")
+	decision, reason, _ := s.LastDecision(game)
+	require.Equal(t, DecisionSkipped, decision)
+	require.Equal(t, SkipReasonCoolingDown, reason)
+}
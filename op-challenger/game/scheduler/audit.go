@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ActionCategoryReporter is an optional interface a GamePlayer can implement to describe the kind of action taken
+// by its most recent ProgressGame call, e.g. "move" or "resolve", for the audit log set by WithAuditSink.
+type ActionCategoryReporter interface {
+	ActionCategory() string
+}
+
+type AuditMetricer interface {
+	RecordAuditWriteFailure()
+}
+
+// AuditRecord is written to the audit sink as a single line of JSON for each progression that took action.
+type AuditRecord struct {
+	Game          common.Address `json:"game"`
+	Category      string         `json:"category,omitempty"`
+	Time          time.Time      `json:"time"`
+	Gas           uint64         `json:"gas"`
+	Cycle         uint64         `json:"cycle"`
+	Block         uint64         `json:"block"`
+	CorrelationID string         `json:"correlationId,omitempty"`
+}
+
+// auditLog writes an AuditRecord for each action-taking result to the sink set by WithAuditSink.
+// Records are written synchronously, in the order results are processed, and synced if the sink supports it
+// so they are durable before processing continues.
+type auditLog struct {
+	m      AuditMetricer
+	errLog *errorThrottle
+	sink   io.Writer
+}
+
+func newAuditLog(m AuditMetricer, errLog *errorThrottle, sink io.Writer) *auditLog {
+	return &auditLog{m: m, errLog: errLog, sink: sink}
+}
+
+// observe writes the audit record for the job if the player took action.
+func (a *auditLog) observe(j job, now time.Time) {
+	if !j.acted {
+		return
+	}
+	record := AuditRecord{
+		Game:          j.addr,
+		Category:      j.actionCategory,
+		Time:          now,
+		Gas:           j.gas,
+		Cycle:         j.cycle,
+		Block:         j.block,
+		CorrelationID: j.correlationID,
+	}
+	if err := a.write(record); err != nil {
+		// The action has already been taken so all that can be done is to report the missing record.
+		a.m.RecordAuditWriteFailure()
+		a.errLog.Log(log.LevelError, "audit", "Failed to write audit record", err, "game", j.addr, "cycle", j.cycle, "block", j.block)
+	}
+}
+
+func (a *auditLog) write(record AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+	if _, err := a.sink.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	if syncer, ok := a.sink.(interface{ Sync() error }); ok {
+		if err := syncer.Sync(); err != nil {
+			return fmt.Errorf("failed to sync audit record: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package scheduler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditActionTakingResults(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	var sink bytes.Buffer
+	m := &auditMetrics{}
+	c.audit = newAuditLog(m, c.errLog, &sink)
+	players := make(map[common.Address]*auditPlayer)
+	c.createPlayer = func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		player := &auditPlayer{StubGamePlayer: &test.StubGamePlayer{Addr: game.Proxy}, category: "move", gas: uint64(game.Proxy[0])}
+		players[game.Proxy] = player
+		return player, nil
+	}
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	game3 := common.Address{0xcc}
+	ctx := context.Background()
+	progress := func(block uint64, acted ...common.Address) {
+		require.NoError(t, c.schedule(ctx, asGames(game1, game2, game3), block))
+		for addr, player := range players {
+			player.ActionTakenValue = slices.Contains(acted, addr)
+		}
+		for len(workQueue) > 0 {
+			require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+			cl.AdvanceTime(time.Second)
+		}
+	}
+	start := cl.Now()
+	progress(10, game1, game3)
+	progress(11, game2)
+	progress(12)
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(&sink)
+	for scanner.Scan() {
+		var record AuditRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, records, 3)
+	expected := []AuditRecord{
+		{Game: game1, Category: "move", Time: start, Gas: 0xaa, Cycle: 1, Block: 10},
+		{Game: game3, Category: "move", Time: start.Add(2 * time.Second), Gas: 0xcc, Cycle: 1, Block: 10},
+		{Game: game2, Category: "move", Time: start.Add(4 * time.Second), Gas: 0xbb, Cycle: 2, Block: 11},
+	}
+	for i, record := range records {
+		require.Equal(t, expected[i].Game, record.Game)
+		require.Equal(t, expected[i].Category, record.Category)
+		require.True(t, expected[i].Time.Equal(record.Time), "record %v time", i)
+		require.Equal(t, expected[i].Gas, record.Gas)
+		require.Equal(t, expected[i].Cycle, record.Cycle)
+		require.Equal(t, expected[i].Block, record.Block)
+	}
+	require.Zero(t, m.failures)
+}
+
+func TestAuditSyncsSink(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	sink := &syncingWriter{}
+	c.audit = newAuditLog(&auditMetrics{}, c.errLog, sink)
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	games.created[gameAddr].ActionTakenValue = true
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.Equal(t, 1, sink.syncs)
+	require.Equal(t, 1, bytes.Count(sink.Bytes(), []byte("\n")))
+}
+
+func TestAuditWriteFailure(t *testing.T) {
+	c, workQueue, _, games, _, logs := setupCoordinatorTest(t, 10)
+	m := &auditMetrics{}
+	c.audit = newAuditLog(m, c.errLog, &failingWriter{err: errors.New("disk full")})
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	games.created[gameAddr].ActionTakenValue = true
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)), "result should still be processed")
+	require.Equal(t, 1, m.failures)
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Failed to write audit record"), testlog.NewAttributesFilter("game", gameAddr.String())))
+	require.True(t, c.states[gameAddr].lastActed)
+}
+
+type auditPlayer struct {
+	*test.StubGamePlayer
+	category string
+	gas      uint64
+}
+
+func (p *auditPlayer) ActionCategory() string {
+	return p.category
+}
+
+func (p *auditPlayer) GasSpent() uint64 {
+	return p.gas
+}
+
+type auditMetrics struct {
+	metrics.NoopMetricsImpl
+	failures int
+}
+
+func (m *auditMetrics) RecordAuditWriteFailure() {
+	m.failures++
+}
+
+type syncingWriter struct {
+	bytes.Buffer
+	syncs int
+}
+
+func (w *syncingWriter) Sync() error {
+	w.syncs++
+	return nil
+}
+
+type failingWriter struct {
+	err error
+}
+
+func (w *failingWriter) Write(_ []byte) (int, error) {
+	return 0, w.err
+}
@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type GlobalBackoffMetricer interface {
+	RecordGlobalBackoff(d time.Duration)
+}
+
+// globalBackoff slows down scheduling of all games when a high proportion of progressions are failing, for example
+// because a backend is degraded. The delay before each cycle's jobs are dispatched doubles, from initial up to max,
+// for each cycle in which the failure rate of the results processed since the previous cycle reaches the threshold.
+// It resets to zero after a cycle with a lower failure rate. A cycle with no results leaves the delay unchanged.
+type globalBackoff struct {
+	threshold float64
+	initial   time.Duration
+	max       time.Duration
+
+	delay    time.Duration
+	results  int
+	failures int
+}
+
+func (b *globalBackoff) enabled() bool {
+	return b.initial > 0
+}
+
+// record counts the outcome of a progression towards the failure rate for the current cycle.
+func (b *globalBackoff) record(failed bool) {
+	b.results++
+	if failed {
+		b.failures++
+	}
+}
+
+// startCycle updates the delay based on the failure rate since the previous cycle and returns the new delay.
+func (b *globalBackoff) startCycle() time.Duration {
+	if b.results == 0 {
+		return b.delay
+	}
+	if float64(b.failures)/float64(b.results) >= b.threshold {
+		b.delay = min(max(b.delay*2, b.initial), b.max)
+	} else {
+		b.delay = 0
+	}
+	b.results = 0
+	b.failures = 0
+	return b.delay
+}
+
+// awaitBackoff waits for the delay before dispatching jobs, processing results while it waits.
+// Returns early if ctx is done.
+func (c *coordinator) awaitBackoff(ctx context.Context, delay time.Duration) {
+	if delay == 0 {
+		return
+	}
+	c.logger.Warn("High failure rate, delaying progression of all games", "delay", delay)
+	timer := c.cfg.clock.NewTimer(delay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.Ch():
+			return
+		case result := <-c.resultQueue:
+			if err := c.processResult(result); err != nil {
+				c.errLog.Log(log.LevelError, "result", "Failed to process result", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
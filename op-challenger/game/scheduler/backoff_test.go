@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalBackoff(t *testing.T) {
+	b := globalBackoff{threshold: 0.5, initial: time.Second, max: 3 * time.Second}
+	require.Zero(t, b.startCycle(), "no results")
+
+	recordResults := func(failures, successes int) {
+		for i := 0; i < failures; i++ {
+			b.record(true)
+		}
+		for i := 0; i < successes; i++ {
+			b.record(false)
+		}
+	}
+	recordResults(1, 1)
+	require.Equal(t, time.Second, b.startCycle())
+	recordResults(3, 1)
+	require.Equal(t, 2*time.Second, b.startCycle())
+	require.Equal(t, 2*time.Second, b.startCycle(), "delay unchanged without results")
+	recordResults(1, 0)
+	require.Equal(t, 3*time.Second, b.startCycle(), "capped at max")
+	recordResults(1, 0)
+	require.Equal(t, 3*time.Second, b.startCycle(), "capped at max")
+	recordResults(1, 2)
+	require.Zero(t, b.startCycle(), "reset on recovery")
+}
+
+func TestDelayDispatchWhileFailureRateHigh(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	c.backoff = globalBackoff{threshold: 0.5, initial: time.Second, max: time.Minute}
+	m := c.m.(*stubSchedulerMetrics)
+	games := asGames(common.Address{0xaa}, common.Address{0xbb})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// schedule runs a cycle, advancing the clock by the expected backoff delay, and returns the results.
+	runCycle := func(block uint64, delay time.Duration, progressErr error) {
+		done := make(chan error, 1)
+		go func() {
+			done <- c.schedule(ctx, games, block)
+		}()
+		if delay > 0 {
+			require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second), "should wait for backoff")
+			require.Empty(t, workQueue, "should not dispatch jobs before backoff completes")
+			cl.AdvanceTime(delay - time.Millisecond)
+			require.Empty(t, workQueue, "should not dispatch jobs before backoff completes")
+			cl.AdvanceTime(time.Millisecond)
+		}
+		require.NoError(t, readWithTimeout(t, done))
+		require.Len(t, workQueue, len(games))
+		for len(workQueue) > 0 {
+			j := <-workQueue
+			j.status = types.GameStatusInProgress
+			j.err = progressErr
+			require.NoError(t, c.processResult(j))
+		}
+	}
+
+	failure := errors.New("backend unavailable")
+	runCycle(0, 0, failure)
+	runCycle(1, time.Second, failure)
+	runCycle(2, 2*time.Second, failure)
+	runCycle(3, 4*time.Second, nil)
+	runCycle(4, 0, nil)
+	require.Equal(t, []time.Duration{0, time.Second, 2 * time.Second, 4 * time.Second, 0}, m.backoff)
+}
@@ -0,0 +1,34 @@
+package scheduler
+
+type ResultBackpressureMetricer interface {
+	RecordPendingResults(n int)
+	RecordResultBackpressure(paused bool)
+}
+
+// resultBackpressure decides when to pause dispatching jobs because too many results are waiting to be processed.
+// Dispatch is paused once the number of pending results reaches the high water mark and resumes once it drops
+// below the low water mark. A high water mark of 0 disables backpressure.
+type resultBackpressure struct {
+	high int
+	low  int
+
+	paused bool
+}
+
+func newResultBackpressure(high, low uint) resultBackpressure {
+	return resultBackpressure{high: int(high), low: int(min(low, high))}
+}
+
+// update records the number of pending results and returns true if the paused state changed.
+func (b *resultBackpressure) update(pending int) bool {
+	if b.high == 0 {
+		return false
+	}
+	prev := b.paused
+	if b.paused {
+		b.paused = pending >= b.low
+	} else {
+		b.paused = pending >= b.high
+	}
+	return b.paused != prev
+}
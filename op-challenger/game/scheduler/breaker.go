@@ -0,0 +1,160 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrGameNotQuarantined is returned by ReleaseQuarantine when the game isn't quarantined.
+var ErrGameNotQuarantined = errors.New("game not quarantined")
+
+type FailureBreakerMetricer interface {
+	RecordGameQuarantined()
+	RecordQuarantinedGames(n int)
+}
+
+// QuarantinedGame describes a game that isn't being progressed because it failed too many times in a row, see
+// WithCircuitBreaker.
+type QuarantinedGame struct {
+	Game common.Address
+	// Failures is the number of consecutive failures to create a job for or progress the game.
+	Failures uint
+	// Until is when the game is next progressed, unless released sooner by ReleaseQuarantine.
+	Until time.Time
+}
+
+// failureState is a game's run of consecutive failures to create a job for or progress it, see failurePolicy.
+type failureState struct {
+	// streak is the number of consecutive failures.
+	streak uint
+	// retryAt is the time until which the game isn't progressed again, see WithFailureBackoff.
+	retryAt time.Time
+	// quarantinedUntil is the time until which the game is quarantined, see WithCircuitBreaker.
+	quarantinedUntil time.Time
+}
+
+func (s *failureState) quarantined(now time.Time) bool {
+	return now.Before(s.quarantinedUntil)
+}
+
+// failurePolicy backs off games after each consecutive failure, see WithFailureBackoff, and quarantines them once
+// the run of failures reaches the threshold set by WithCircuitBreaker.
+type failurePolicy struct {
+	cfg    *config
+	m      FailureBreakerMetricer
+	logger log.Logger
+	trace  traceFunc
+	// games holds the run of failures of each game that failed since it last succeeded.
+	games map[common.Address]*failureState
+}
+
+func newFailurePolicy(cfg *config, m FailureBreakerMetricer, logger log.Logger, trace traceFunc) *failurePolicy {
+	return &failurePolicy{cfg: cfg, m: m, logger: logger, trace: trace, games: make(map[common.Address]*failureState)}
+}
+
+func (p *failurePolicy) recordResult(j job, outcome Outcome, _ uint32, now time.Time) {
+	switch outcome {
+	case OutcomeSuccess:
+		// Ends the run of failures, lifting any backoff or quarantine.
+		p.forget(j.addr)
+	case OutcomeTransientFailure, OutcomePermanentFailure:
+		p.recordFailure(j.addr, now)
+	}
+}
+
+// recordFailure extends the game's run of consecutive failures, backing off before it's progressed again and
+// quarantining it once the run reaches the threshold. A game that fails its first progression after leaving
+// quarantine is quarantined again immediately.
+func (p *failurePolicy) recordFailure(addr common.Address, now time.Time) {
+	failures, ok := p.games[addr]
+	if !ok {
+		failures = &failureState{}
+		p.games[addr] = failures
+	}
+	failures.streak++
+	if delay := p.cfg.failureBackoff.delay(failures.streak); delay > 0 {
+		failures.retryAt = now.Add(delay)
+		p.trace(addr, "Backing off failing game", "failures", failures.streak, "delay", delay)
+	}
+	threshold := p.cfg.breakerThreshold
+	if threshold == 0 || failures.streak < threshold {
+		return
+	}
+	failures.quarantinedUntil = now.Add(p.cfg.breakerCooldown)
+	p.m.RecordGameQuarantined()
+	p.logger.Warn("Quarantining failing game", "game", addr, "failures", failures.streak, "until", failures.quarantinedUntil)
+	p.trace(addr, "Quarantined game", "failures", failures.streak, "cooldown", p.cfg.breakerCooldown)
+}
+
+func (p *failurePolicy) skipReason(addr common.Address, now time.Time) (string, time.Time) {
+	failures := p.state(addr)
+	if failures.quarantined(now) {
+		return SkipReasonQuarantined, failures.quarantinedUntil
+	}
+	if now.Before(failures.retryAt) {
+		return SkipReasonFailureBackoff, failures.retryAt
+	}
+	return "", time.Time{}
+}
+
+func (p *failurePolicy) forget(addr common.Address) {
+	delete(p.games, addr)
+}
+
+// state returns the game's run of failures, which is empty if it succeeded or hasn't failed.
+func (p *failurePolicy) state(addr common.Address) failureState {
+	if failures, ok := p.games[addr]; ok {
+		return *failures
+	}
+	return failureState{}
+}
+
+// quarantinedGames returns the games quarantined at now, ordered by address.
+func (p *failurePolicy) quarantinedGames(now time.Time) []QuarantinedGame {
+	var games []QuarantinedGame
+	for addr, failures := range p.games {
+		if failures.quarantined(now) {
+			games = append(games, QuarantinedGame{Game: addr, Failures: failures.streak, Until: failures.quarantinedUntil})
+		}
+	}
+	slices.SortFunc(games, func(a, b QuarantinedGame) int {
+		return bytes.Compare(a.Game[:], b.Game[:])
+	})
+	return games
+}
+
+// quarantinedGames returns the games currently quarantined, ordered by address. The lock must be held.
+func (c *coordinator) quarantinedGames() []QuarantinedGame {
+	return c.failures.quarantinedGames(c.cfg.clock.Now())
+}
+
+// QuarantinedGames returns the games that aren't being progressed because they failed too many times in a row,
+// ordered by address, see WithCircuitBreaker.
+func (s *Scheduler) QuarantinedGames() []QuarantinedGame {
+	c := s.coordinator
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.quarantinedGames()
+}
+
+// ReleaseQuarantine re-enables a quarantined game so it's progressed again from the next cycle, resetting its run of
+// failures and any backoff. Returns ErrGameNotQuarantined if the game isn't quarantined.
+func (s *Scheduler) ReleaseQuarantine(addr common.Address) error {
+	c := s.coordinator
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	failures := c.failures.state(addr)
+	if !failures.quarantined(c.cfg.clock.Now()) {
+		return ErrGameNotQuarantined
+	}
+	c.failures.forget(addr)
+	c.m.RecordQuarantinedGames(len(c.quarantinedGames()))
+	c.logger.Info("Released game from quarantine", "game", addr, "failures", failures.streak)
+	c.tracer.Log(addr, "Released game from quarantine")
+	return nil
+}
@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailureBackoffAndCircuitBreaker(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	c.cfg.decisionTTL = time.Hour
+	WithFailureBackoff(time.Minute, 4*time.Minute)(&c.cfg)
+	WithCircuitBreaker(3, 10*time.Minute)(&c.cfg)
+	s := &Scheduler{coordinator: c}
+	m := c.m.(*stubSchedulerMetrics)
+	failing := common.Address{0xaa}
+	ctx := context.Background()
+	block := uint64(0)
+	schedule := func() {
+		block++
+		require.NoError(t, c.schedule(ctx, asGames(failing), block))
+	}
+	progress := func() {
+		require.Len(t, workQueue, 1, "should progress game")
+		require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	}
+
+	schedule()
+	games.created[failing].ProgressErr = errors.New("boom")
+	progress()
+
+	// Backs off after each failure, doubling the delay
+	schedule()
+	require.Empty(t, workQueue, "should back off after failure")
+	require.Equal(t, SkipReasonFailureBackoff, c.decisions[failing].reason)
+	cl.AdvanceTime(time.Minute)
+	schedule()
+	progress()
+	cl.AdvanceTime(time.Minute)
+	schedule()
+	require.Empty(t, workQueue, "should double backoff")
+	cl.AdvanceTime(time.Minute)
+	schedule()
+	require.Zero(t, m.gamesQuarantined)
+	progress()
+
+	// Quarantined once the threshold is reached
+	require.Equal(t, 1, m.gamesQuarantined)
+	until := cl.Now().Add(10 * time.Minute)
+	require.Equal(t, []QuarantinedGame{{Game: failing, Failures: 3, Until: until}}, s.QuarantinedGames())
+	cl.AdvanceTime(9 * time.Minute)
+	schedule()
+	require.Empty(t, workQueue, "should not progress quarantined game")
+	require.Equal(t, SkipReasonQuarantined, c.decisions[failing].reason)
+	require.Equal(t, 1, m.quarantinedGames)
+
+	// Quarantined again immediately if the first progression after the cooldown fails
+	cl.AdvanceTime(time.Minute)
+	schedule()
+	progress()
+	require.Equal(t, 2, m.gamesQuarantined)
+	require.Len(t, s.QuarantinedGames(), 1)
+
+	// Released manually
+	require.NoError(t, s.ReleaseQuarantine(failing))
+	require.Empty(t, s.QuarantinedGames())
+	require.Equal(t, 0, m.quarantinedGames)
+	require.ErrorIs(t, s.ReleaseQuarantine(failing), ErrGameNotQuarantined)
+	games.created[failing].ProgressErr = nil
+	schedule()
+	progress()
+	require.Zero(t, c.failures.state(failing).streak)
+	require.ErrorIs(t, s.ReleaseQuarantine(common.Address{0xbb}), ErrGameNotQuarantined)
+}
+
+func TestCircuitBreakerResetOnSuccess(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	WithCircuitBreaker(2, time.Hour)(&c.cfg)
+	m := c.m.(*stubSchedulerMetrics)
+	game := common.Address{0xaa}
+	ctx := context.Background()
+	progressErr := errors.New("boom")
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, c.schedule(ctx, asGames(game), uint64(i)))
+		require.Len(t, workQueue, 1, "should not quarantine game that intermittently succeeds")
+		if i%2 == 1 {
+			games.created[game].ProgressErr = nil
+		} else {
+			games.created[game].ProgressErr = progressErr
+		}
+		require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	}
+	require.Zero(t, m.gamesQuarantined)
+}
@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// restoreCheckpoint replaces the state of the scheduler with the checkpoint written by a previous run, if any, see
+// WithCheckpoint. A checkpoint that can't be loaded or decoded is logged and ignored so it never prevents the
+// scheduler starting.
+func (s *Scheduler) restoreCheckpoint(ctx context.Context) {
+	if !s.cfg.checkpoint {
+		return
+	}
+	data, err := s.cfg.stateStore.Load(ctx, SchedulerStateGame, StateKeyCheckpoint)
+	if errors.Is(err, ErrStateNotFound) {
+		s.logger.Info("No scheduler checkpoint to restore", "path", s.cfg.checkpointPath)
+		return
+	} else if err != nil {
+		s.logger.Error("Failed to load scheduler checkpoint, starting without it", "path", s.cfg.checkpointPath, "err", err)
+		return
+	}
+	if err := s.importFullState(data); err != nil {
+		s.logger.Error("Failed to restore scheduler checkpoint, starting without it", "path", s.cfg.checkpointPath, "err", err)
+		return
+	}
+	s.logger.Info("Restored scheduler checkpoint", "path", s.cfg.checkpointPath)
+}
+
+// checkpoint writes a checkpoint each time ticker fires, if not nil, and a final checkpoint once ctx is done.
+func (s *Scheduler) checkpoint(ctx context.Context, ticker clock.Ticker) {
+	defer s.wg.Done()
+	var tick <-chan time.Time
+	if ticker != nil {
+		defer ticker.Stop()
+		tick = ticker.Ch()
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			// The final checkpoint is written after ctx is cancelled so must not be cancelled with it.
+			if err := s.writeCheckpoint(context.WithoutCancel(ctx)); err != nil {
+				s.logger.Error("Failed to write final scheduler checkpoint", "path", s.cfg.checkpointPath, "err", err)
+			}
+			return
+		case <-tick:
+			if err := s.writeCheckpoint(ctx); err != nil {
+				s.coordinator.errLog.Log(log.LevelError, "checkpoint", "Failed to write scheduler checkpoint", err, "path", s.cfg.checkpointPath)
+			}
+		}
+	}
+}
+
+// writeCheckpoint replaces the checkpoint in the state store with the current state of the scheduler.
+func (s *Scheduler) writeCheckpoint(ctx context.Context) error {
+	data, err := s.ExportFullState()
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err := s.cfg.stateStore.Save(ctx, SchedulerStateGame, StateKeyCheckpoint, data); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// resumePending enqueues jobs for the imported games that had a job queued or in flight when their state was
+// exported, so they are progressed without waiting for the next batch. Called by the loop when it starts.
+func (c *coordinator) resumePending(ctx context.Context) {
+	var jobs []job
+	c.lock.Lock()
+	pending := make([]types.GameMetadata, 0, len(c.pendingResume))
+	for addr := range c.pendingResume {
+		if state, ok := c.states[addr]; ok {
+			pending = append(pending, state.metadata(addr))
+		}
+	}
+	c.lock.Unlock()
+	slices.SortFunc(pending, func(a, b types.GameMetadata) int {
+		return compareAddresses(a.Proxy, b.Proxy)
+	})
+	inits := c.initPlayers(ctx, pending)
+	c.lock.Lock()
+	for _, game := range pending {
+		addr := game.Proxy
+		if _, ok := c.states[addr]; !ok {
+			continue
+		}
+		j, err := c.createJob(game, c.lastScheduledBlockNum, inits)
+		if err != nil {
+			c.errLog.Log(log.LevelWarn, "resume", "Failed to create job for pending game", err, "game", addr)
+			c.recordDecision(addr, DecisionFailed, err.Error())
+			continue
+		}
+		if j != nil {
+			jobs = append(jobs, *j)
+			c.idle.Add(1)
+			c.m.RecordGameUpdateScheduled()
+			c.events.Emit(j.addr, EventScheduled, j.cycle, j.correlationID)
+			c.recordDecision(j.addr, DecisionScheduled, "")
+		}
+	}
+	c.pendingResume = nil
+	c.lock.Unlock()
+	c.writeSnapshots(ctx)
+	if len(jobs) == 0 {
+		return
+	}
+
+	for i, j := range jobs {
+		if err := c.enqueueJob(ctx, j); err != nil {
+			c.abandonJobs(jobs[i:])
+			c.logger.Warn("Failed to enqueue jobs for pending games", "remaining", len(jobs)-i, "err", err)
+			return
+		}
+		c.tracer.Log(j.addr, "Enqueued job for pending game", "block", j.block)
+	}
+	c.logger.Info("Resumed pending games", "count", len(jobs))
+}
@@ -0,0 +1,146 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointResumesPendingGames(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "checkpoint.json")
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	idle := common.Address{0xaa}
+	inflight := common.Address{0xbb}
+	release := make(chan struct{})
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		if g.Proxy == inflight {
+			return &blockingPlayer{release: release}, nil
+		}
+		return &test.StubGamePlayer{Addr: g.Proxy, StatusValue: types.GameStatusInProgress}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false, WithClock(cl), WithCheckpoint(path, time.Minute))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("No scheduler checkpoint to restore")))
+	require.NoError(t, s.Schedule(asGames(idle, inflight), 0))
+	require.Eventually(t, func() bool {
+		s.coordinator.lock.Lock()
+		defer s.coordinator.lock.Unlock()
+		state, ok := s.coordinator.states[idle]
+		return ok && !state.inflight
+	}, 10*time.Second, 10*time.Millisecond)
+
+	cl.AdvanceTime(time.Minute)
+	var checkpoint []byte
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return false
+		}
+		var state fullState
+		require.NoError(t, json.Unmarshal(data, &state))
+		checkpoint = data
+		return len(state.Games) == 2 && state.Games[1].Pending && !state.Games[0].Pending
+	}, 10*time.Second, 10*time.Millisecond, "should checkpoint every interval")
+	close(release)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.NoError(t, s.Close())
+
+	// Restart from the checkpoint written while the game was in flight
+	restored := filepath.Join(dir, "restored.json")
+	require.NoError(t, os.WriteFile(restored, checkpoint, 0644))
+	progressed := make(chan common.Address, 10)
+	createPlayer = func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &riskPlayer{StubGamePlayer: &test.StubGamePlayer{Addr: g.Proxy, StatusValue: types.GameStatusInProgress}, progressed: progressed}, nil
+	}
+	s = NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false, WithClock(cl), WithCheckpoint(restored, 0))
+	s.Start(ctx)
+	require.Equal(t, inflight, readWithTimeout(t, progressed), "should progress pending game without a new batch")
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Empty(t, progressed)
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Restored scheduler checkpoint")))
+	require.NoError(t, s.Close())
+
+	// A final checkpoint is written when the scheduler stops
+	data, err := os.ReadFile(restored)
+	require.NoError(t, err)
+	var state fullState
+	require.NoError(t, json.Unmarshal(data, &state))
+	require.Len(t, state.Games, 2)
+	require.False(t, state.Games[1].Pending)
+	require.Equal(t, time.Duration(0), s.EffectiveConfig().CheckpointInterval)
+	require.Equal(t, restored, s.EffectiveConfig().CheckpointPath)
+}
+
+func TestCheckpointSavedToStateStore(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	store := newMemStateStore()
+	failing := common.Address{0xaa}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{Addr: g.Proxy, StatusValue: types.GameStatusInProgress, ProgressErr: errors.New("boom")}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	opts := []SchedulerOption{WithStateStore(store), WithCheckpoint("", 0), WithFailureBackoff(time.Hour, time.Hour)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, opts...)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	require.NoError(t, s.Schedule(asGames(failing), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.NoError(t, s.Close())
+	_, err := store.Load(ctx, SchedulerStateGame, StateKeyCheckpoint)
+	require.NoError(t, err, "should save checkpoint to the state store")
+
+	s = NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, opts...)
+	s.Start(ctx)
+	defer s.Close()
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Restored scheduler checkpoint")))
+	data, err := s.ExportFullState()
+	require.NoError(t, err)
+	var state fullState
+	require.NoError(t, json.Unmarshal(data, &state))
+	require.Len(t, state.Games, 1)
+	require.Equal(t, failing, state.Games[0].Game)
+	require.EqualValues(t, 1, state.Games[0].FailureStreak, "should restore failure history from the state store")
+}
+
+func TestCheckpointIgnoredIfInvalid(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0644))
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{Addr: g.Proxy, StatusValue: types.GameStatusInProgress}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, WithCheckpoint(path, 0))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Failed to restore scheduler checkpoint, starting without it")))
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.NoError(t, s.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var state fullState
+	require.NoError(t, json.Unmarshal(data, &state), "should replace invalid checkpoint")
+	require.Len(t, state.Games, 1)
+}
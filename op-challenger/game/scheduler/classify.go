@@ -0,0 +1,105 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// errClassifiedFailure is logged for results classified as failures that didn't report an error.
+var errClassifiedFailure = errors.New("result classified as failure")
+
+// Outcome classifies the result of progressing a game, deciding how the scheduler reacts to it.
+type Outcome int
+
+const (
+	// OutcomeSuccess indicates the game was progressed successfully. The game's failure count is reset and the
+	// result counts as a success for WithGlobalBackoff.
+	OutcomeSuccess Outcome = iota
+	// OutcomeTransientFailure indicates progressing the game failed but may succeed if retried, e.g. because of an
+	// RPC error. The result counts as a failure of the game, see WithFailureDemotion and WithDependencies, and as
+	// a failure for WithGlobalBackoff.
+	OutcomeTransientFailure
+	// OutcomePermanentFailure indicates progressing the game failed and retrying isn't expected to help.
+	// The result counts as a failure of the game but is ignored by WithGlobalBackoff as backing off won't help.
+	OutcomePermanentFailure
+	// OutcomeNoOp indicates the game was neither progressed nor failed, e.g. because there was nothing to do.
+	// The game's failure count and WithGlobalBackoff are unaffected.
+	OutcomeNoOp
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeSuccess:
+		return "success"
+	case OutcomeTransientFailure:
+		return "transient-failure"
+	case OutcomePermanentFailure:
+		return "permanent-failure"
+	case OutcomeNoOp:
+		return "no-op"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(o))
+	}
+}
+
+// ResultClassifier classifies a completed job, see WithResultClassifier. player is the game's player, so that a
+// classifier can inspect any more detailed result it provides, and err is the error it reported, if any.
+type ResultClassifier func(player GamePlayer, result ResultSummary, err error) Outcome
+
+// defaultResultClassifier treats results as transient failures if the player reported an error (see ErrorReporter)
+// and successes otherwise.
+func defaultResultClassifier(_ GamePlayer, _ ResultSummary, err error) Outcome {
+	if err != nil {
+		return OutcomeTransientFailure
+	}
+	return OutcomeSuccess
+}
+
+// classify returns the outcome of the job using the classifier set by WithResultClassifier, or the default
+// classification if none is set.
+func (c *coordinator) classify(j job) Outcome {
+	if j.cancelled {
+		// Any failure was caused by cancelling the progression so isn't held against the game.
+		return OutcomeNoOp
+	}
+	classifier := c.cfg.resultClassifier
+	if classifier == nil {
+		classifier = defaultResultClassifier
+	}
+	return classifier(j.player, j.summary(), j.err)
+}
+
+// recordOutcome updates the game's state and the global backoff according to the outcome of its job, returning the
+// outcome so it can also be recorded by the scheduling policies. The lock must be held.
+func (c *coordinator) recordOutcome(j job, state *gameState) Outcome {
+	outcome := c.classify(j)
+	c.tracer.Log(j.addr, "Classified result", "outcome", outcome)
+	switch outcome {
+	case OutcomeSuccess:
+		c.backoff.record(false)
+		state.progressFailures = 0
+		state.succeeded = true
+		state.firstFailure = time.Time{}
+		c.events.Emit(j.addr, EventCompleted, j.cycle, j.correlationID)
+	case OutcomeTransientFailure, OutcomePermanentFailure:
+		if outcome == OutcomeTransientFailure {
+			c.backoff.record(true)
+		}
+		state.progressFailures++
+		err := j.err
+		if err == nil {
+			err = errClassifiedFailure
+		}
+		c.errLog.Log(log.LevelWarn, "progress", "Failed to progress game", err, "game", j.addr, "failures", state.progressFailures, "outcome", outcome, "correlation", j.correlationID)
+		c.events.EmitFailed(j.addr, err, j.cycle, j.correlationID)
+		c.recordFailure(j.addr, state)
+	case OutcomeNoOp:
+		c.events.Emit(j.addr, EventCompleted, j.cycle, j.correlationID)
+	default:
+		c.logger.Error("Ignoring unknown result outcome", "game", j.addr, "outcome", outcome)
+	}
+	return outcome
+}
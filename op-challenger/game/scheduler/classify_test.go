@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultClassification(t *testing.T) {
+	progressErr := errors.New("boom")
+	tests := []struct {
+		name         string
+		classifier   ResultClassifier
+		err          error
+		failures     uint
+		succeeded    bool
+		backoffFails int
+		backoffTotal int
+	}{
+		{name: "DefaultSuccess", succeeded: true, backoffTotal: 1},
+		{name: "DefaultFailure", err: progressErr, failures: 1, backoffFails: 1, backoffTotal: 1},
+		{name: "Success", classifier: classifyAs(OutcomeSuccess), err: progressErr, succeeded: true, backoffTotal: 1},
+		{name: "TransientFailure", classifier: classifyAs(OutcomeTransientFailure), failures: 1, backoffFails: 1, backoffTotal: 1},
+		{name: "PermanentFailure", classifier: classifyAs(OutcomePermanentFailure), failures: 1},
+		{name: "NoOp", classifier: classifyAs(OutcomeNoOp), err: progressErr},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+			c.cfg.resultClassifier = test.classifier
+			gameAddr := common.Address{0xaa}
+
+			require.NoError(t, c.schedule(context.Background(), asGames(gameAddr), 0))
+			j := <-workQueue
+			j.err = test.err
+			require.NoError(t, c.processResult(j))
+
+			state := c.states[gameAddr]
+			require.Equal(t, test.failures, state.progressFailures)
+			require.Equal(t, test.succeeded, state.succeeded)
+			require.Equal(t, test.backoffFails, c.backoff.failures)
+			require.Equal(t, test.backoffTotal, c.backoff.results)
+		})
+	}
+}
+
+func TestResultClassifierReceivesResult(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	progressErr := errors.New("boom")
+	var gotPlayer GamePlayer
+	var gotResult ResultSummary
+	var gotErr error
+	c.cfg.resultClassifier = func(player GamePlayer, result ResultSummary, err error) Outcome {
+		gotPlayer, gotResult, gotErr = player, result, err
+		return OutcomeNoOp
+	}
+	gameAddr := common.Address{0xaa}
+
+	require.NoError(t, c.schedule(context.Background(), asGames(gameAddr), 5))
+	j := <-workQueue
+	j.err = progressErr
+	j.acted = true
+	require.NoError(t, c.processResult(j))
+
+	require.Same(t, games.created[gameAddr], gotPlayer)
+	require.Equal(t, j.summary(), gotResult)
+	require.ErrorIs(t, gotErr, progressErr)
+}
+
+func TestResultClassifierAffectsDependencies(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	prereq := common.Address{0xaa}
+	dependent := common.Address{0xbb}
+	c.cfg.dependencies = map[common.Address][]common.Address{dependent: {prereq}}
+	c.cfg.resultClassifier = classifyAs(OutcomePermanentFailure)
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(prereq, dependent), 0))
+	require.Len(t, workQueue, 1)
+	require.NoError(t, c.processResult(<-workQueue))
+	require.NoError(t, c.schedule(ctx, asGames(prereq, dependent), 1))
+	require.Equal(t, fmt.Sprintf("prerequisite %v failed", prereq), c.states[dependent].skipReason)
+}
+
+func classifyAs(outcome Outcome) ResultClassifier {
+	return func(_ GamePlayer, _ ResultSummary, _ error) Outcome {
+		return outcome
+	}
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+)
+
+// coalesceBatches merges next into the pending batch it arrived behind, so the games of both are scheduled in a single
+// cycle. Games are deduplicated by address, keeping the order they were first listed in and the metadata and timeout
+// from next. The merged batch takes its block number and, if set, correlation id from next. Games only listed by
+// pending keep the correlation id they were scheduled with if it differs from the merged batch's.
+func coalesceBatches(pending blockGames, next blockGames) blockGames {
+	games := make([]types.GameMetadata, 0, len(pending.games)+len(next.games))
+	index := make(map[common.Address]int, len(pending.games)+len(next.games))
+	for _, batch := range []blockGames{pending, next} {
+		for _, game := range batch.games {
+			if i, ok := index[game.Proxy]; ok {
+				games[i] = game
+				continue
+			}
+			index[game.Proxy] = len(games)
+			games = append(games, game)
+		}
+	}
+	merged := blockGames{
+		blockNumber:   max(pending.blockNumber, next.blockNumber),
+		games:         games,
+		correlationID: pending.correlationID,
+		factory:       next.factory,
+	}
+	if next.correlationID != "" {
+		merged.correlationID = next.correlationID
+	}
+	merged.gameCorrelationIDs = mergeCorrelationIDs(pending, next, merged.correlationID)
+	if len(pending.timeouts) == 0 && len(next.timeouts) == 0 {
+		return merged
+	}
+	merged.timeouts = make(map[common.Address]time.Duration, len(pending.timeouts)+len(next.timeouts))
+	for addr, timeout := range pending.timeouts {
+		if !slices.ContainsFunc(next.games, func(game types.GameMetadata) bool { return game.Proxy == addr }) {
+			merged.timeouts[addr] = timeout
+		}
+	}
+	maps.Copy(merged.timeouts, next.timeouts)
+	return merged
+}
+
+// mergeCorrelationIDs returns the correlation ids of the games in the merged batch that differ from its correlation
+// id, or nil if all games use the batch's correlation id.
+func mergeCorrelationIDs(pending blockGames, next blockGames, correlationID string) map[common.Address]string {
+	var ids map[common.Address]string
+	set := func(addr common.Address, id string) {
+		if id == correlationID {
+			delete(ids, addr)
+			return
+		}
+		if ids == nil {
+			ids = make(map[common.Address]string)
+		}
+		ids[addr] = id
+	}
+	for _, batch := range []blockGames{pending, next} {
+		for _, game := range batch.games {
+			if id, ok := batch.gameCorrelationIDs[game.Proxy]; ok {
+				set(game.Proxy, id)
+			} else if batch.correlationID != "" {
+				set(game.Proxy, batch.correlationID)
+			} else {
+				// Games scheduled without a correlation id are tagged with the merged batch's.
+				delete(ids, game.Proxy)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoalesceBatches(t *testing.T) {
+	gameA := types.GameMetadata{Proxy: common.Address{0xaa}, GameType: 1}
+	gameB := types.GameMetadata{Proxy: common.Address{0xbb}}
+	gameC := types.GameMetadata{Proxy: common.Address{0xcc}}
+	updatedA := types.GameMetadata{Proxy: gameA.Proxy, GameType: 2}
+	pending := blockGames{
+		blockNumber:   5,
+		games:         []types.GameMetadata{gameA, gameB},
+		timeouts:      map[common.Address]time.Duration{gameA.Proxy: time.Second, gameB.Proxy: time.Minute},
+		correlationID: "first",
+	}
+	next := blockGames{
+		blockNumber: 6,
+		games:       []types.GameMetadata{gameC, updatedA},
+		timeouts:    map[common.Address]time.Duration{gameC.Proxy: time.Hour},
+	}
+
+	merged := coalesceBatches(pending, next)
+	require.EqualValues(t, 6, merged.blockNumber)
+	require.Equal(t, []types.GameMetadata{updatedA, gameB, gameC}, merged.games)
+	require.Equal(t, map[common.Address]time.Duration{gameB.Proxy: time.Minute, gameC.Proxy: time.Hour}, merged.timeouts,
+		"should use timeouts from the latest batch listing each game")
+	require.Equal(t, "first", merged.correlationID, "should keep correlation id if next doesn't set one")
+	require.Nil(t, merged.gameCorrelationIDs)
+
+	next.correlationID = "second"
+	next.blockNumber = 4
+	merged = coalesceBatches(pending, next)
+	require.Equal(t, "second", merged.correlationID)
+	require.Equal(t, map[common.Address]string{gameB.Proxy: "first"}, merged.gameCorrelationIDs,
+		"should keep correlation id of games only listed by pending")
+	require.EqualValues(t, 5, merged.blockNumber, "should not go back to an earlier block")
+
+	third := blockGames{games: []types.GameMetadata{gameC}, correlationID: "third"}
+	merged = coalesceBatches(merged, third)
+	require.Equal(t, "third", merged.correlationID)
+	require.Equal(t, map[common.Address]string{gameA.Proxy: "second", gameB.Proxy: "first"}, merged.gameCorrelationIDs)
+
+	merged = coalesceBatches(blockGames{games: []types.GameMetadata{gameA}}, blockGames{games: []types.GameMetadata{gameB}})
+	require.Nil(t, merged.timeouts)
+}
+
+func TestMergeBatchWhenScheduleQueueFull(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{StatusValue: types.GameStatusInProgress}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	m := &coalesceMetrics{}
+	s := NewScheduler(logger, m, disk, 2, createPlayer, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Loop not running - first call fills the queue and the rest are merged into it
+	s.started.Store(true)
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 0))
+	require.NoError(t, s.Schedule(asGames(common.Address{0xbb}, common.Address{0xaa}), 1))
+	require.NoError(t, s.Schedule(asGames(common.Address{0xcc}), 2))
+	require.Equal(t, 2, m.Coalesced())
+	require.Len(t, s.scheduleQueue, 1)
+
+	s.Start(ctx)
+	defer s.Close()
+	require.NoError(t, s.WaitIdle(ctx))
+	require.ElementsMatch(t, []common.Address{{0xaa}, {0xbb}, {0xcc}}, <-disk.removeExceptCalls, "should schedule merged games in one cycle")
+}
+
+func TestMergeConcurrentBatches(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{StatusValue: types.GameStatusInProgress}, nil
+	}
+	const count = 20
+	// Files are cleaned up after the cycle and each result
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, count+1)}
+	m := &coalesceMetrics{}
+	s := NewScheduler(logger, m, disk, 2, createPlayer, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Loop not running - however the calls interleave, every batch ends up merged into the single queued batch
+	s.started.Store(true)
+	var wg sync.WaitGroup
+	expected := make([]common.Address, count)
+	for i := 0; i < count; i++ {
+		expected[i] = common.Address{byte(i + 1)}
+		wg.Add(1)
+		go func(addr common.Address, block uint64) {
+			defer wg.Done()
+			require.NoError(t, s.Schedule(asGames(addr), block))
+		}(expected[i], uint64(i))
+	}
+	wg.Wait()
+	require.Equal(t, count-1, m.Coalesced())
+	require.Len(t, s.scheduleQueue, 1)
+
+	s.Start(ctx)
+	defer s.Close()
+	require.NoError(t, s.WaitIdle(ctx), "should count merged batches as a single piece of work")
+	require.ElementsMatch(t, expected, <-disk.removeExceptCalls, "should schedule merged games in one cycle")
+}
+
+type coalesceMetrics struct {
+	metrics.NoopMetricsImpl
+	lock      sync.Mutex
+	coalesced int
+}
+
+func (m *coalesceMetrics) RecordBatchCoalesced() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.coalesced++
+}
+
+func (m *coalesceMetrics) Coalesced() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.coalesced
+}
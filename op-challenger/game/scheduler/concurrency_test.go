@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// concurrencyTracker records the most calls in progress at once. Calls are held until released so tests control
+// how long they overlap.
+type concurrencyTracker struct {
+	active    atomic.Int32
+	maxActive atomic.Int32
+
+	lock sync.Mutex
+	gate chan struct{}
+}
+
+func newConcurrencyTracker() *concurrencyTracker {
+	return &concurrencyTracker{gate: make(chan struct{})}
+}
+
+// call records a call in progress until it is released or ctx is done.
+func (c *concurrencyTracker) call(ctx context.Context) {
+	recordMax(&c.maxActive, c.active.Add(1))
+	defer c.active.Add(-1)
+	c.lock.Lock()
+	gate := c.gate
+	c.lock.Unlock()
+	select {
+	case <-gate:
+	case <-ctx.Done():
+	}
+}
+
+// pass records a call that isn't held.
+func (c *concurrencyTracker) pass() {
+	recordMax(&c.maxActive, c.active.Add(1))
+	c.active.Add(-1)
+}
+
+// waitActive waits until n calls are in progress.
+func (c *concurrencyTracker) waitActive(t *testing.T, n int32) {
+	require.Eventually(t, func() bool {
+		return c.active.Load() == n
+	}, 10*time.Second, time.Millisecond, "calls should be in progress")
+}
+
+// releaseAt waits until n calls are in progress then releases them and all later calls.
+func (c *concurrencyTracker) releaseAt(t *testing.T, n int32) {
+	c.waitActive(t, n)
+	c.release()
+}
+
+// release releases the calls in progress and all later calls.
+func (c *concurrencyTracker) release() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	close(c.gate)
+}
+
+// hold resets the maximum and holds later calls until released again.
+func (c *concurrencyTracker) hold() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.gate = make(chan struct{})
+	c.maxActive.Store(0)
+}
+
+// recordMax raises max to n if it is lower.
+func recordMax(max *atomic.Int32, n int32) {
+	for curr := max.Load(); n > curr && !max.CompareAndSwap(curr, n); curr = max.Load() {
+	}
+}
@@ -0,0 +1,49 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type ActionCooldownMetricer interface {
+	RecordGameCoolingDown()
+}
+
+// actionCooldown holds back games for a while after their player took action, giving any sent transactions time to
+// be mined, see WithActionCooldown.
+type actionCooldown struct {
+	cfg *config
+	m   ActionCooldownMetricer
+	// until holds the time until which each game isn't scheduled after its player took action.
+	until map[common.Address]time.Time
+}
+
+func newActionCooldown(cfg *config, m ActionCooldownMetricer) *actionCooldown {
+	return &actionCooldown{cfg: cfg, m: m, until: make(map[common.Address]time.Time)}
+}
+
+func (p *actionCooldown) recordResult(j job, _ Outcome, gameType uint32, now time.Time) {
+	if cooldown := p.cfg.gameTypeSettings(gameType).ActionCooldown; j.acted && cooldown > 0 {
+		p.until[j.addr] = now.Add(cooldown)
+	}
+}
+
+func (p *actionCooldown) skipReason(addr common.Address, now time.Time) (string, time.Time) {
+	until := p.until[addr]
+	if !now.Before(until) {
+		return "", time.Time{}
+	}
+	p.m.RecordGameCoolingDown()
+	return SkipReasonCoolingDown, until
+}
+
+func (p *actionCooldown) forget(addr common.Address) {
+	delete(p.until, addr)
+}
+
+// coolingDownUntil returns the time until which the game isn't scheduled, which may have passed, or zero if its
+// player hasn't taken action.
+func (p *actionCooldown) coolingDownUntil(addr common.Address) time.Time {
+	return p.until[addr]
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 
@@ -12,15 +16,37 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
-var errUnknownGame = errors.New("unknown game")
+var (
+	errUnknownGame     = errors.New("unknown game")
+	errDuplicateResult = errors.New("duplicate result")
+	errInvalidGame     = errors.New("invalid game")
+)
 
 type PlayerCreator func(game types.GameMetadata, dir string) (GamePlayer, error)
 
+// CoordinatorMetricer records the metrics of tracking and progressing games. The metrics of each optional feature
+// are grouped into the feature's own interface.
 type CoordinatorMetricer interface {
 	RecordActedL1Block(n uint64)
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
+	RecordGameStatusRegression(from, to types.GameStatus)
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
+	RecordDuplicateResult()
+
+	AbandonMetricer
+	ActionsPausedMetricer
+	DiskQuotaMetricer
+	DropMetricer
+	InvalidGameMetricer
+	JobTimeoutMetricer
+	PlayerInitMetricer
+	PolicyMetricer
+	ReconcileMetricer
+	RecoveryMetricer
+	ResultBackpressureMetricer
+	RetentionMetricer
+	TrackedGamesMetricer
 }
 
 type gameState struct {
@@ -28,12 +54,80 @@ type gameState struct {
 	inflight              bool
 	lastProcessedBlockNum uint64
 	status                types.GameStatus
+
+	// pendingJobID is the id of the job whose result is awaited, or 0 if there is none.
+	pendingJobID uint64
+
+	// followUps is the number of consecutive follow up passes that have been enqueued for the game.
+	followUps uint
+
+	// activity is the game's activity score used to reduce scheduling of idle games.
+	activity float64
+	// lastScheduledCycle is the cycle in which a job was last created for the game.
+	lastScheduledCycle uint64
+	// lastSeenCycle is the cycle in which the game was last included in a scheduled batch.
+	lastSeenCycle uint64
+
+	// lastErr is the most recent error creating a job for the game and lastErrTime when it occurred.
+	lastErr     error
+	lastErrTime time.Time
+	// retries is the number of consecutive cycles in which creating a job for the game failed.
+	retries uint
+	// firstFailure is the time of the first of the consecutive failures to create a job for or progress the
+	// game, or zero if the most recent progression succeeded.
+	firstFailure time.Time
+
+	// scratchpad is the game's in-memory scratchpad, see Scratchpad.
+	scratchpad Scratchpad
+
+	// progressFailures is the number of consecutive progressions of the game that reported an error.
+	progressFailures uint
+
+	// lastActed is true if the player took action in the game's most recent progression.
+	lastActed bool
+	// succeeded is true once the game has been progressed without error.
+	succeeded bool
+	// skipReason describes why the game was most recently not scheduled because of its dependencies.
+	skipReason string
+	// resolvedAt is the time the game was first seen to be resolved, or zero if it is in progress.
+	resolvedAt time.Time
+	// forced is set when ForceSchedule was called while a job was in flight, to progress the game again once
+	// the job completes.
+	forced bool
+	// gameType and timestamp are from the game's metadata, used to apply the settings set by WithGameTypeConfig
+	// and the filter set by SetGameFilter.
+	gameType  uint32
+	timestamp uint64
+	// urgent is set when ScheduleUrgent was called while a job was in flight, to progress the game again using
+	// the urgent workers once the job completes.
+	urgent bool
+	// initFailures is the number of consecutive attempts in which the game's player failed to initialize, and
+	// initRetryAt the time until which it isn't created again, see WithPlayerInitPolicy.
+	initFailures uint
+	initRetryAt  time.Time
+	// filtered is set while the game is excluded by the filter set by WithGameFilter or SetGameFilter.
+	filtered bool
+	// correlationID is the correlation id of the batch that most recently created a job for the game, carried
+	// by its subsequent jobs, see ScheduleCorrelated.
+	correlationID string
+	// lastUpdated is when the result of the game's most recent progression was processed, see ListGames.
+	lastUpdated time.Time
+}
+
+// metadata returns the metadata of the game, as most recently scheduled.
+func (s *gameState) metadata(addr common.Address) types.GameMetadata {
+	return types.GameMetadata{GameType: s.gameType, Timestamp: s.timestamp, Proxy: addr}
 }
 
 // coordinator manages the set of current games, queues games to be played (on separate worker threads) and
 // cleans up data files once a game is resolved.
-// All function calls must be made on the same thread.
+// All calls to schedule and processResult must be made on the same thread. exportState may be called concurrently.
 type coordinator struct {
+	// lock guards the game states so they can be inspected from other goroutines.
+	// It is not held while blocked enqueuing jobs or creating players so that inspection isn't delayed by a full job
+	// queue or the upstream node.
+	lock sync.Mutex
+
 	// jobQueue is the outgoing queue for jobs being sent to workers for progression
 	jobQueue chan<- job
 
@@ -45,27 +139,196 @@ type coordinator struct {
 	createPlayer PlayerCreator
 	states       map[common.Address]*gameState
 	disk         DiskManager
+	store        StateStore
+	tracer       *gameTracer
+	errLog       *errorThrottle
+
+	// results forwards each result to the sink set by WithResultSink, or is nil if no sink is set.
+	results *resultPublisher
+	// onResolved is the handler set by WithResolutionHandler, or nil if none is set or the coordinator tracks the
+	// games of a source registered with RegisterSource.
+	onResolved ResolutionHandler
+	// resolved holds the games whose resolution hasn't been passed to onResolved yet, see notifyResolved.
+	resolved []resolvedGame
+	// audit writes a record of each action-taking result to the sink set by WithAuditSink, or is nil if no sink
+	// is set.
+	audit resultObserver
+	// cooldown holds back games after their player took action, see WithActionCooldown.
+	cooldown *actionCooldown
+	// failures backs off and quarantines games that keep failing, see WithFailureBackoff and WithCircuitBreaker.
+	failures *failurePolicy
+	// priorities holds the dispatch priority of each game, see DeadlineReporter and ClaimRiskReporter.
+	priorities gamePriorities
+	// events forwards lifecycle events to the publisher set by WithEventPublisher, or is nil if no publisher is set.
+	events *eventQueue
+	// urgentQueue sends jobs to the workers set by WithUrgentWorkers, or is nil if there are none.
+	urgentQueue chan<- job
+	// recoverable quarantines the directories of games whose player failed to initialize with
+	// PlayerInitQuarantine, or is nil if the DiskManager doesn't implement RecoverableDiskManager.
+	recoverable RecoverableDiskManager
+	// upstream limits concurrent interactions with the upstream node, see WithUpstreamConcurrency. Nil if unlimited.
+	upstream *upstreamLimiter
+	// filter excludes games from being progressed, see SetGameFilter. Nil if all games are included.
+	filter GameFilter
+	// canceller cancels the jobs of games excluded by a new filter.
+	canceller *jobCanceller
 
 	allowInvalidPrestate bool
+	cfg                  config
+
+	// idle tracks jobs that have been scheduled but not yet had their result processed.
+	idle *idleTracker
 
 	// lastScheduledBlockNum is the highest block number that the coordinator has seen and scheduled jobs.
 	lastScheduledBlockNum uint64
+
+	// cycle is incremented each time a new batch of games is scheduled.
+	cycle uint64
+	// firstSeen records the cycle in which each game was first scheduled. Retained after the game state is
+	// removed so that it remains available for a while after the game resolves.
+	firstSeen map[common.Address]*firstSeen
+	// history holds the most recent results of each game, see WithRecentResults.
+	history resultHistory
+	// latencies holds the latencies of the most recent jobs, see LatencyPercentiles.
+	latencies latencyWindow
+
+	// resultLag tracks whether result processing is keeping up with workers, see reportResultLag.
+	resultLag resultLag
+	// prewarmed holds games whose directories were created by Scheduler.Prewarm and that haven't been scheduled yet.
+	prewarmed map[common.Address]bool
+	// pinned holds the games pinned by PinGame, which are scheduled every cycle regardless of their activity.
+	pinned map[common.Address]bool
+	// ignored holds the games that aren't scheduled because of IgnoreGame.
+	ignored map[common.Address]bool
+	// pendingResume holds the imported games that had a job queued or in flight when their state was exported, to
+	// be progressed once the scheduler starts regardless of their activity, see resumePending.
+	pendingResume map[common.Address]bool
+
+	// notReady holds the games in the batch being scheduled that failed the check set by WithReadinessCheck.
+	notReady map[common.Address]bool
+
+	// timeouts holds the timeout for each game in the batch most recently scheduled, see ScheduleGames.
+	timeouts map[common.Address]time.Duration
+	// correlationID is the correlation id of the batch most recently scheduled, see ScheduleCorrelated.
+	correlationID string
+	// gameCorrelationIDs holds the correlation ids of games in the batch most recently scheduled that differ from
+	// correlationID because they were merged from another batch.
+	gameCorrelationIDs map[common.Address]string
+
+	// decisions holds the most recent scheduling decision for each game, see LastDecision.
+	decisions map[common.Address]decisionRecord
+
+	// abandoned holds the games that are no longer progressed, see WithMaxRetryAge.
+	abandoned map[common.Address]AbandonedGame
+
+	// waiters holds the channels to send the result of each game's current job to, see ScheduleGroupAndWait.
+	waiters map[common.Address][]chan GameResult
+
+	// snapshots holds the jobs created whose snapshot hasn't been captured yet, see writeSnapshots.
+	snapshots []pendingSnapshot
+
+	// deferred holds jobs that couldn't be enqueued because the job queue was full, see QueueFullDefer.
+	deferred []job
+
+	// backpressure pauses dispatching jobs while too many results are pending, see WithResultBackpressure.
+	// Only accessed from the thread calling schedule and processResult.
+	backpressure resultBackpressure
+	// memory pauses dispatching jobs while process memory use is too high, see WithMemoryPressure.
+	// Nil if not enabled.
+	memory *memoryGuard
+
+	// backoff delays dispatching jobs while many progressions are failing, see WithGlobalBackoff.
+	backoff globalBackoff
+
+	// dispatchRand shuffles the jobs in each batch, see WithRandomizedDispatch. Nil if disabled.
+	dispatchRand *rand.Rand
+	// dropRand chooses the jobs to drop with DropRandom. Nil for other policies.
+	dropRand *rand.Rand
+	// sequencer holds back results to process them in dispatch order, see WithStableOrder. Nil if disabled.
+	sequencer *resultSequencer
+
+	// gas tracks the estimated gas spent on actions against the budget set by WithGasBudget.
+	gas gasBudget
+
+	// lastJobID is the id assigned to the most recently created job. It is shared with the coordinators of any
+	// sources registered with RegisterSource so job ids are unique across the scheduler.
+	lastJobID *atomic.Uint64
+	// quota limits the space used by game data, or is nil if the disk manager has no quota, see QuotaDiskManager.
+	quota QuotaDiskManager
+	// factory is the factory of the source whose games are tracked, see RegisterSource, or zero for the primary
+	// factory.
+	factory common.Address
+
+	// processedJobs is the total number of job results processed.
+	processedJobs uint64
+	// jobLimitReached is set once processedJobs reaches the limit set by WithMaxTotalJobs.
+	// After that no further jobs are scheduled.
+	jobLimitReached atomic.Bool
 }
 
 // schedule takes the current list of games to attempt to progress, filters out games that have previous
 // progressions already in-flight and schedules jobs to progress on the outbound jobQueue.
 // To avoid deadlock, it may process results from the inbound resultQueue while adding jobs to the outbound jobQueue.
 // Returns an error if a game couldn't be scheduled because of an error. It will continue attempting to progress
-// all games even if an error occurs with one game.
+// all games even if an error occurs with one game, unless ctx is done in which case no further jobs are enqueued
+// and the returned error includes ctx.Err().
 func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata, blockNumber uint64) error {
+	// The games as supplied, each of which is acknowledged by the sink set by WithAckSink.
+	scheduled := games
+	ackCycle := c.cycle + 1
+	if c.jobLimitReached.Load() {
+		c.logger.Debug("Job limit reached, not scheduling games", "count", len(games))
+		c.skipBatch(games, SkipReasonJobLimit)
+		c.ackSkippedBatch(scheduled, ackCycle)
+		return nil
+	}
+	if c.cfg.scheduleTransform != nil {
+		games = c.cfg.scheduleTransform(games)
+	}
+	games = c.filterInvalidGames(games)
+	if !c.checkBatchGate(ctx, games) {
+		c.skipBatch(games, SkipReasonGated)
+		c.ackSkippedBatch(scheduled, ackCycle)
+		return nil
+	}
+	notReady := c.checkReadiness(ctx, games)
+	c.lock.Lock()
+	c.notReady = notReady
+	c.cycle++
+	c.gas.startCycle(c.cfg.clock.Now())
+	var backoffDelay time.Duration
+	if c.backoff.enabled() {
+		backoffDelay = c.backoff.startCycle()
+		c.m.RecordGlobalBackoff(backoffDelay)
+	}
 	// First remove any game states we no longer require
+	now := c.cfg.clock.Now()
 	for addr, state := range c.states {
-		if !state.inflight && !slices.ContainsFunc(games, func(candidate types.GameMetadata) bool {
+		if !c.retained(state, now) && (!state.inflight || c.retentionExpired(state, now)) && !slices.ContainsFunc(games, func(candidate types.GameMetadata) bool {
 			return candidate.Proxy == addr
 		}) {
-			delete(c.states, addr)
+			c.forgetGame(addr)
+		}
+	}
+	// Remove the data of games that are no longer required once per cycle. Games in the batch that aren't tracked
+	// yet are kept, as they may have data from a previous run.
+	keepGames := c.gamesToKeep()
+	for _, game := range games {
+		if _, ok := c.states[game.Proxy]; !ok {
+			keepGames = append(keepGames, game.Proxy)
 		}
 	}
+	candidates := c.quotaCandidates()
+	inShard := slices.DeleteFunc(slices.Clone(games), func(game types.GameMetadata) bool {
+		return !c.inCurrentShard(game.Proxy)
+	})
+	// Game data is removed, measured and evicted, and players created, without the lock so inspecting the game
+	// states isn't delayed by disk I/O or the upstream node.
+	c.lock.Unlock()
+	c.removeGameFiles(keepGames)
+	c.enforceDiskQuota(candidates)
+	inits := c.initPlayers(ctx, inShard)
+	c.lock.Lock()
 
 	var gamesInProgress int
 	var gamesChallengerWon int
@@ -76,14 +339,43 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 	// Otherwise, results may start being processed before all games are recorded, resulting in existing
 	// data directories potentially being deleted for games that are required.
 	for _, game := range games {
-		if j, err := c.createJob(ctx, game, blockNumber); err != nil {
-			errs = append(errs, fmt.Errorf("failed to create job for game %v: %w", game.Proxy, err))
-		} else if j != nil {
-			jobs = append(jobs, *j)
-			c.m.RecordGameUpdateScheduled()
+		delete(c.prewarmed, game.Proxy)
+		var j *job
+		var err error
+		outOfShard := !c.inCurrentShard(game.Proxy)
+		if outOfShard {
+			c.tracer.Log(game.Proxy, "Not in current schedule shard", "cycle", c.cycle)
+			c.skip(game.Proxy, SkipReasonOutOfShard)
+		} else {
+			j, err = c.createJob(game, blockNumber, inits)
 		}
 		state, ok := c.states[game.Proxy]
+		if err != nil {
+			c.tracer.Log(game.Proxy, "Failed to create job", "err", err)
+			c.recordDecision(game.Proxy, DecisionFailed, err.Error())
+			errs = append(errs, fmt.Errorf("failed to create job for game %v: %w", game.Proxy, err))
+			if ok {
+				state.lastErr = err
+				state.lastErrTime = c.cfg.clock.Now()
+				state.retries++
+				c.failures.recordFailure(game.Proxy, c.cfg.clock.Now())
+				c.recordFailure(game.Proxy, state)
+			}
+		} else {
+			// Waiting for the player initialization backoff to expire doesn't end the run of failures.
+			if ok && !outOfShard && state.initFailures == 0 {
+				state.retries = 0
+			}
+			if j != nil {
+				jobs = append(jobs, *j)
+				c.idle.Add(1)
+				c.m.RecordGameUpdateScheduled()
+				c.events.Emit(j.addr, EventScheduled, j.cycle, j.correlationID)
+				c.recordDecision(j.addr, DecisionScheduled, "")
+			}
+		}
 		if ok {
+			state.lastSeenCycle = c.cycle
 			switch state.status {
 			case types.GameStatusInProgress:
 				gamesInProgress++
@@ -92,11 +384,20 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 			case types.GameStatusChallengerWon:
 				gamesChallengerWon++
 			}
-		} else {
+		} else if !outOfShard {
 			c.logger.Warn("Game not found in states map", "game", game.Proxy)
 		}
 	}
+	c.ackBatch(scheduled, jobs, c.cycle)
+	c.recordFirstSeen(games)
+	c.pruneRecentResults()
+	c.pruneDecisions()
+	c.limitTrackedGames()
+	c.pruneAbandoned()
 	c.m.RecordGamesStatus(gamesInProgress, gamesDefenderWon, gamesChallengerWon)
+	if c.cfg.breakerThreshold > 0 {
+		c.m.RecordQuarantinedGames(len(c.quarantinedGames()))
+	}
 
 	lowestProcessedBlockNum := blockNumber
 	for _, state := range c.states {
@@ -104,61 +405,428 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 	}
 	c.lastScheduledBlockNum = blockNumber
 	c.m.RecordActedL1Block(lowestProcessedBlockNum)
+	// Enqueue jobs for games with claims at risk, then those nearest their deadline, then higher priority jobs
+	// first, otherwise preserving the order of the games unless randomised.
+	c.shuffleJobs(jobs)
+	slices.SortStableFunc(jobs, compareJobPriority)
+	if c.cfg.queueFullStrategy == QueueFullDrop {
+		// Choose which jobs to drop up front rather than dropping those that happen to be last in the batch.
+		jobs = c.shedJobs(jobs, cap(c.jobQueue)-len(c.jobQueue)-len(c.deferred))
+	}
+	if c.sequencer != nil {
+		c.sequenceJobs(jobs)
+	}
+	// Jobs deferred from previous cycles are enqueued first.
+	jobs = append(c.deferred, jobs...)
+	c.deferred = nil
+	c.lock.Unlock()
+	c.writeSnapshots(ctx)
+
+	c.awaitBackoff(ctx, backoffDelay)
 
 	// Finally, enqueue the jobs
-	for _, j := range jobs {
+	var unqueued []job
+	for i, j := range jobs {
+		c.awaitPendingResults(ctx)
+		c.awaitMemory(ctx)
+		// Abort the fan-out promptly if the scheduler is shutting down rather than racing to fill a queue
+		// that will be abandoned. Jobs that were already enqueued are handled when the workers drain.
+		if ctx.Err() != nil {
+			c.logger.Debug("Context done, aborting job fan-out", "remaining", len(jobs)-i)
+			c.abandonJobs(jobs[i:])
+			return errors.Join(append(errs, ctx.Err())...)
+		}
+		if c.jobLimitReached.Load() {
+			c.logger.Info("Job limit reached, aborting job fan-out", "remaining", len(jobs)-i)
+			c.abandonJobs(jobs[i:])
+			return errors.Join(errs...)
+		}
+		if c.cfg.queueFullStrategy != QueueFullBlock {
+			j.enqueuedAt = c.cfg.clock.Now()
+			select {
+			case c.jobQueue <- j:
+				c.tracer.Log(j.addr, "Enqueued job", "block", j.block)
+			default:
+				c.tracer.Log(j.addr, "Job queue full", "strategy", c.cfg.queueFullStrategy)
+				unqueued = append(unqueued, j)
+			}
+			continue
+		}
 		if err := c.enqueueJob(ctx, j); err != nil {
+			c.abandonJobs([]job{j})
+			c.tracer.Log(j.addr, "Failed to enqueue job", "err", err)
 			errs = append(errs, fmt.Errorf("failed to enqueue job for game %v: %w", j.addr, err))
+		} else {
+			c.tracer.Log(j.addr, "Enqueued job", "block", j.block)
+		}
+	}
+	if len(unqueued) > 0 {
+		if c.cfg.queueFullStrategy == QueueFullDrop {
+			c.logger.Warn("Job queue full, dropping jobs", "count", len(unqueued))
+			c.m.RecordJobsDropped(len(unqueued))
+			c.lock.Lock()
+			for _, j := range unqueued {
+				c.skip(j.addr, SkipReasonDropped)
+			}
+			c.releaseJobs(unqueued)
+			c.lock.Unlock()
+		} else {
+			c.logger.Debug("Job queue full, deferring jobs", "count", len(unqueued))
+			c.lock.Lock()
+			for _, j := range unqueued {
+				c.skip(j.addr, SkipReasonDeferred)
+			}
+			c.deferred = append(c.deferred, unqueued...)
+			c.lock.Unlock()
 		}
 	}
 	return errors.Join(errs...)
 }
 
+// unmetDependency returns a description of the first prerequisite of the game that has not yet been
+// handled successfully, or an empty string if all prerequisites have been.
+func (c *coordinator) unmetDependency(game common.Address) string {
+	for _, prereq := range c.cfg.dependencies[game] {
+		state, ok := c.states[prereq]
+		switch {
+		case !ok:
+			return fmt.Sprintf("awaiting prerequisite %v", prereq)
+		case state.status != types.GameStatusInProgress:
+			continue
+		case state.progressFailures > 0:
+			return fmt.Sprintf("prerequisite %v failed", prereq)
+		case !state.succeeded:
+			return fmt.Sprintf("awaiting prerequisite %v", prereq)
+		}
+	}
+	return ""
+}
+
+// abandonJobs releases jobs that were created but will not be enqueued.
+func (c *coordinator) abandonJobs(jobs []job) {
+	// Releasing jobs may process held results, see WithStableOrder.
+	defer c.notifyResolved()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.releaseJobs(jobs)
+}
+
+// releaseJobs releases jobs that were created but will not be enqueued. The lock must be held.
+func (c *coordinator) releaseJobs(jobs []job) {
+	for _, j := range jobs {
+		if state, ok := c.states[j.addr]; ok {
+			state.inflight = false
+			state.pendingJobID = 0
+		}
+		c.notifyWaiters(GameResult{Game: j.addr, Err: fmt.Errorf("%w: %v", ErrJobAbandoned, j.addr)})
+		c.ackJob(j)
+		c.m.RecordGameUpdateCompleted()
+		c.idle.Done()
+		if c.sequencer != nil {
+			c.releaseSequenced(j.id)
+		}
+	}
+}
+
 // createJob updates the state for the specified game and returns the job to enqueue for it, if any
-// Returns (nil, nil) when there is no error and no job to enqueue
-func (c *coordinator) createJob(ctx context.Context, game types.GameMetadata, blockNumber uint64) (*job, error) {
+// Returns (nil, nil) when there is no error and no job to enqueue.
+// The game's player, if it doesn't have one yet, is taken from inits, see initPlayers. The lock must be held.
+func (c *coordinator) createJob(game types.GameMetadata, blockNumber uint64, inits map[common.Address]playerInit) (*job, error) {
+	correlationID := c.correlationIDFor(game.Proxy)
+	c.tracer.Log(game.Proxy, "Creating job", "block", blockNumber, "correlation", correlationID)
 	state, ok := c.states[game.Proxy]
 	if !ok {
 		// This is the first time we're seeing this game, so its last processed block
 		// is the last block the coordinator processed (it didn't exist yet).
-		state = &gameState{lastProcessedBlockNum: c.lastScheduledBlockNum}
+		state = &gameState{lastProcessedBlockNum: c.lastScheduledBlockNum, activity: 1}
 		c.states[game.Proxy] = state
 	}
+	state.gameType = game.GameType
+	state.timestamp = game.Timestamp
 	if state.inflight {
 		c.logger.Debug("Not rescheduling already in-flight game", "game", game.Proxy)
+		c.tracer.Log(game.Proxy, "Not rescheduling already in-flight game")
+		c.skip(game.Proxy, SkipReasonInFlight)
+		return nil, nil
+	}
+	if abandoned, ok := c.abandoned[game.Proxy]; ok {
+		c.logger.Debug("Not scheduling abandoned game", "game", game.Proxy, "reason", abandoned.Reason)
+		c.tracer.Log(game.Proxy, "Not scheduling abandoned game", "reason", abandoned.Reason)
+		c.skip(game.Proxy, SkipReasonAbandoned)
+		return nil, nil
+	}
+	if c.ignored[game.Proxy] {
+		c.logger.Debug("Not scheduling ignored game", "game", game.Proxy)
+		c.tracer.Log(game.Proxy, "Not scheduling ignored game")
+		c.skip(game.Proxy, SkipReasonIgnored)
+		return nil, nil
+	}
+	if !c.included(game) {
+		state.filtered = true
+		c.logger.Debug("Not scheduling game excluded by filter", "game", game.Proxy)
+		c.tracer.Log(game.Proxy, "Not scheduling game excluded by filter")
+		c.skip(game.Proxy, SkipReasonFiltered)
+		return nil, nil
+	}
+	state.filtered = false
+	if c.skipForPolicy(game.Proxy, c.failures) {
 		return nil, nil
 	}
 	// Create the player separately to the state so we retry creating it if it fails on the first attempt.
 	if state.player == nil {
-		player, err := c.createPlayer(game, c.disk.DirForGame(game.Proxy))
-		if err != nil {
-			return nil, fmt.Errorf("failed to create game player: %w", err)
+		if now := c.cfg.clock.Now(); now.Before(state.initRetryAt) {
+			c.logger.Debug("Not retrying player initialization until backoff expires", "game", game.Proxy, "until", state.initRetryAt)
+			c.tracer.Log(game.Proxy, "Not retrying player initialization until backoff expires", "remaining", state.initRetryAt.Sub(now))
+			c.skip(game.Proxy, SkipReasonInitBackoff)
+			return nil, nil
+		}
+		init, ok := inits[game.Proxy]
+		if !ok {
+			// The game became eligible after the players were created, so its player is created next cycle.
+			c.logger.Debug("Not scheduling game until its player is created", "game", game.Proxy)
+			c.tracer.Log(game.Proxy, "Not scheduling game until its player is created")
+			c.skip(game.Proxy, SkipReasonAwaitingPlayer)
+			return nil, nil
 		}
-		if err := player.ValidatePrestate(ctx); err != nil {
-			if !c.allowInvalidPrestate || !errors.Is(err, types.ErrInvalidPrestate) {
+		if init.err != nil {
+			return nil, init.err
+		}
+		player, dir := init.player, init.dir
+		if err := init.prestateErr; err != nil {
+			if !errors.Is(err, types.ErrInvalidPrestate) {
+				// The prestate couldn't be loaded, rather than being loaded and found to be invalid.
+				return nil, c.playerInitFailed(game.Proxy, state, fmt.Errorf("failed to validate prestate: %w", err))
+			}
+			if !c.allowInvalidPrestate {
 				return nil, fmt.Errorf("failed to validate prestate: %w", err)
 			}
 			c.logger.Error("Invalid prestate", "game", game.Proxy, "err", err)
 		}
+		state.initFailures = 0
+		state.initRetryAt = time.Time{}
 		state.player = player
 		state.status = player.Status()
+		c.recordResolution(state)
+		c.tracer.Log(game.Proxy, "Created game player", "dir", dir, "status", state.status)
+	}
+	if state.status == types.GameStatusInProgress {
+		if reason := c.unmetDependency(game.Proxy); reason != "" {
+			state.skipReason = reason
+			c.logger.Debug("Not scheduling game with unmet dependency", "game", game.Proxy, "reason", reason)
+			c.tracer.Log(game.Proxy, "Not scheduling game with unmet dependency", "reason", reason)
+			c.skip(game.Proxy, reason)
+			return nil, nil
+		}
+		state.skipReason = ""
+		if c.notReady[game.Proxy] {
+			c.logger.Debug("Not scheduling game that isn't ready", "game", game.Proxy)
+			c.tracer.Log(game.Proxy, "Not scheduling game that isn't ready")
+			c.m.RecordGameNotReady()
+			c.skip(game.Proxy, SkipReasonNotReady)
+			return nil, nil
+		}
+		if c.skipForPolicy(game.Proxy, c.cooldown) {
+			return nil, nil
+		}
+		if state.lastActed && c.gas.exhausted() {
+			c.logger.Debug("Not rescheduling game until gas budget is available", "game", game.Proxy, "spent", c.gas.spent)
+			c.tracer.Log(game.Proxy, "Not rescheduling game until gas budget is available", "spent", c.gas.spent)
+			c.m.RecordGasBudgetDeferred()
+			c.skip(game.Proxy, SkipReasonGasBudget)
+			return nil, nil
+		}
+		if interval := c.cfg.activityDecay.interval(state.activity); c.cycle-state.lastScheduledCycle < interval && !c.pinned[game.Proxy] && !c.pendingResume[game.Proxy] {
+			c.logger.Debug("Not rescheduling idle game", "game", game.Proxy, "activity", state.activity, "interval", interval)
+			c.tracer.Log(game.Proxy, "Not rescheduling idle game", "activity", state.activity, "interval", interval)
+			c.skip(game.Proxy, SkipReasonIdle)
+			return nil, nil
+		}
 	}
 	state.inflight = true
 	if state.status != types.GameStatusInProgress {
 		c.logger.Debug("Not rescheduling resolved game", "game", game.Proxy, "status", state.status)
+		c.tracer.Log(game.Proxy, "Not rescheduling resolved game", "status", state.status)
+		c.skip(game.Proxy, SkipReasonResolved)
 		return nil, nil
 	}
-	return newJob(blockNumber, game.Proxy, state.player, state.status), nil
+	state.lastScheduledCycle = c.cycle
+	state.correlationID = correlationID
+	j := c.newJob(blockNumber, game.Proxy, state)
+	if c.cfg.captureSnapshots {
+		c.snapshots = append(c.snapshots, pendingSnapshot{game: game, job: *j})
+	}
+	return j, nil
+}
+
+// validatePrestate validates the player's prestate, which may load it from the upstream node, within the limit set
+// by WithUpstreamConcurrency.
+func (c *coordinator) validatePrestate(ctx context.Context, player GamePlayer) error {
+	release, err := c.upstream.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return player.ValidatePrestate(ctx)
+}
+
+// playerInit is a player created for a game by initPlayers, to be installed by createJob.
+type playerInit struct {
+	player GamePlayer
+	dir    string
+	// err is the error creating the player or enabling its shadow mode, in which case player is nil.
+	err error
+	// prestateErr is the error validating the player's prestate.
+	prestateErr error
+}
+
+// initPlayers creates the players of the games that createJob would create a player for and validates their
+// prestates. The lock must not be held: it is only taken to choose the games, so that inspecting the game states
+// isn't delayed while waiting for and calling the upstream node.
+func (c *coordinator) initPlayers(ctx context.Context, games []types.GameMetadata) map[common.Address]playerInit {
+	c.lock.Lock()
+	var pending []types.GameMetadata
+	for _, game := range games {
+		if c.needsPlayer(game) {
+			pending = append(pending, game)
+		}
+	}
+	c.lock.Unlock()
+	inits := make(map[common.Address]playerInit, len(pending))
+	for _, game := range pending {
+		if _, ok := inits[game.Proxy]; ok {
+			// The game is duplicated in the batch.
+			continue
+		}
+		inits[game.Proxy] = c.initPlayer(ctx, game, c.disk.DirForGame(game.Proxy))
+	}
+	return inits
+}
+
+func (c *coordinator) initPlayer(ctx context.Context, game types.GameMetadata, dir string) playerInit {
+	player, err := c.createPlayer(game, dir)
+	if err != nil {
+		return playerInit{err: fmt.Errorf("failed to create game player: %w", err)}
+	}
+	if c.cfg.shadowMode {
+		if err := enableShadowMode(player); err != nil {
+			return playerInit{err: fmt.Errorf("failed to enable shadow mode: %w", err)}
+		}
+	}
+	return playerInit{player: player, dir: dir, prestateErr: c.validatePrestate(ctx, player)}
+}
+
+// needsPlayer returns true if the game has no player and isn't held back from being scheduled before its player
+// would be created, mirroring the checks made by createJob. The lock must be held.
+func (c *coordinator) needsPlayer(game types.GameMetadata) bool {
+	if _, abandoned := c.abandoned[game.Proxy]; abandoned || c.ignored[game.Proxy] || !c.included(game) {
+		return false
+	}
+	state, ok := c.states[game.Proxy]
+	if !ok {
+		return true
+	}
+	now := c.cfg.clock.Now()
+	if state.player != nil || state.inflight || now.Before(state.initRetryAt) {
+		return false
+	}
+	reason, _ := c.failures.skipReason(game.Proxy, now)
+	return reason == ""
+}
+
+// newJob creates a job with a unique id to progress the game and records it as the game's pending job.
+func (c *coordinator) newJob(blockNumber uint64, addr common.Address, state *gameState) *job {
+	j := newJob(blockNumber, addr, state.player, state.status)
+	j.id = c.lastJobID.Add(1)
+	j.factory = c.factory
+	j.scratchpad = state.scratchpad.clone()
+	j.priority = c.cfg.failureDemotion.priority(state.progressFailures)
+	j.timeout = c.timeouts[addr]
+	if j.timeout == 0 {
+		j.timeout = c.gameTypeSettings(state.gameType).Timeout
+	}
+	if j.timeout == 0 {
+		j.timeout = c.cfg.jobTimeout
+	}
+	c.priorities.apply(j)
+	j.cycle = c.cycle
+	j.createdAt = c.cfg.clock.Now()
+	j.correlationID = state.correlationID
+	state.pendingJobID = j.id
+	return j
+}
+
+// filterInvalidGames removes games that can't possibly be valid, such as the zero address, so that a cycle
+// isn't wasted failing to create jobs for them. The supplied slice is not modified.
+func (c *coordinator) filterInvalidGames(games []types.GameMetadata) []types.GameMetadata {
+	if !slices.ContainsFunc(games, isInvalidGame) {
+		return games
+	}
+	valid := make([]types.GameMetadata, 0, len(games))
+	for _, game := range games {
+		if isInvalidGame(game) {
+			c.m.RecordInvalidGameFiltered()
+			c.lock.Lock()
+			c.recordDecisionInCycle(game.Proxy, DecisionSkipped, SkipReasonInvalid, c.cycle+1)
+			c.lock.Unlock()
+			c.errLog.Log(log.LevelWarn, "invalid", "Ignoring invalid game", fmt.Errorf("%w: %v", errInvalidGame, game.Proxy))
+			continue
+		}
+		valid = append(valid, game)
+	}
+	return valid
+}
+
+func isInvalidGame(game types.GameMetadata) bool {
+	return game.Proxy == (common.Address{})
+}
+
+// awaitPendingResults processes results, without dispatching any further jobs, while result backpressure is
+// applied. Returns immediately if ctx is done.
+func (c *coordinator) awaitPendingResults(ctx context.Context) {
+	for c.updateBackpressure() {
+		select {
+		case result := <-c.resultQueue:
+			if err := c.processResult(result); err != nil {
+				c.errLog.Log(log.LevelError, "result", "Failed to process result", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// updateBackpressure records the number of pending results and returns true if dispatch is paused.
+func (c *coordinator) updateBackpressure() bool {
+	if c.backpressure.high == 0 {
+		return false
+	}
+	pending := len(c.resultQueue)
+	c.m.RecordPendingResults(pending)
+	if c.backpressure.update(pending) {
+		c.m.RecordResultBackpressure(c.backpressure.paused)
+		if c.backpressure.paused {
+			c.logger.Info("Too many pending results, pausing job dispatch", "pending", pending)
+		} else {
+			c.logger.Info("Pending results drained, resuming job dispatch", "pending", pending)
+		}
+	}
+	return c.backpressure.paused
 }
 
 func (c *coordinator) enqueueJob(ctx context.Context, j job) error {
+	return c.enqueueJobOn(ctx, c.jobQueue, j)
+}
+
+// enqueueJobOn sends the job to queue, processing results while waiting for space.
+func (c *coordinator) enqueueJobOn(ctx context.Context, queue chan<- job, j job) error {
 	for {
+		j.enqueuedAt = c.cfg.clock.Now()
 		select {
-		case c.jobQueue <- j:
+		case queue <- j:
 			return nil
 		case result := <-c.resultQueue:
 			if err := c.processResult(result); err != nil {
-				c.logger.Error("Failed to process result", "err", err)
+				c.errLog.Log(log.LevelError, "result", "Failed to process result", err)
 			}
 		case <-ctx.Done():
 			return ctx.Err()
@@ -167,39 +835,280 @@ func (c *coordinator) enqueueJob(ctx context.Context, j job) error {
 }
 
 func (c *coordinator) processResult(j job) error {
+	// Deferred first so the resolution handler is called once the lock is released.
+	defer c.notifyResolved()
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	defer c.resultLag.processed.Add(1)
+	c.canceller.forget(j.id)
+	if c.sequencer != nil {
+		return c.processSequenced(j)
+	}
+	return c.applyResult(j)
+}
+
+// applyResult updates the game state with the result of the job. The lock must be held.
+func (c *coordinator) applyResult(j job) error {
 	state, ok := c.states[j.addr]
 	if !ok {
+		c.ackJob(j)
 		return fmt.Errorf("game %v received unexpected result: %w", j.addr, errUnknownGame)
 	}
+	if j.id == 0 || j.id != state.pendingJobID {
+		// Drop the result rather than recording status or acting on it twice.
+		c.m.RecordDuplicateResult()
+		c.tracer.Log(j.addr, "Dropping duplicate result", "job", j.id, "pendingJob", state.pendingJobID)
+		return fmt.Errorf("game %v received result for job %v while awaiting %v: %w", j.addr, j.id, state.pendingJobID, errDuplicateResult)
+	}
+	if err := validateResult(j); err != nil {
+		return c.handleInvalidResult(j, state, err)
+	}
+	defer c.ackJob(j)
+	c.tracer.Log(j.addr, "Processing result", "block", j.block, "prevStatus", state.status, "status", j.status, "followUp", j.followUp)
+	if c.results != nil {
+		c.results.Publish(j.summary())
+	}
+	c.history.record(j.summary(), c.cfg.clock.Now())
+	if !j.createdAt.IsZero() {
+		c.latencies.record(c.cfg.clock.Since(j.createdAt))
+	}
 	state.inflight = false
+	state.pendingJobID = 0
+	if state.status != types.GameStatusInProgress && j.status != state.status {
+		// Resolved games can't change status so this indicates a reorg or a bug reading the game.
+		c.logger.Warn("Game status regressed", "game", j.addr, "from", state.status, "to", j.status)
+		c.m.RecordGameStatusRegression(state.status, j.status)
+		state.resolvedAt = time.Time{}
+	}
+	resolved := state.status == types.GameStatusInProgress && j.status != types.GameStatusInProgress
+	statusChanged := state.status != j.status
 	state.status = j.status
+	c.recordResolution(state)
 	state.lastProcessedBlockNum = j.block
-	c.deleteResolvedGameFiles()
+	state.lastUpdated = c.cfg.clock.Now()
+	state.activity = c.cfg.activityDecay.update(state.activity, j.acted)
+	c.priorities.record(j)
+	state.lastActed = j.acted
+	if j.actionsSuppressed && j.status == types.GameStatusInProgress {
+		c.m.RecordActionSuppressed()
+		c.logger.Debug("Progressed game with actions suppressed", "game", j.addr, "acted", j.acted)
+	}
+	c.gas.record(j.gas)
+	c.recordDuration(j)
+	outcome := c.recordOutcome(j, state)
+	for _, policy := range []resultPolicy{c.failures, c.cooldown} {
+		policy.recordResult(j, outcome, state.gameType, c.cfg.clock.Now())
+	}
+	if statusChanged {
+		c.events.EmitStatus(j.addr, EventStatusChanged, j.status, j.cycle, j.correlationID)
+	}
+	if resolved {
+		c.events.Emit(j.addr, EventResolved, j.cycle, j.correlationID)
+		switch j.status {
+		case types.GameStatusDefenderWon:
+			c.events.Emit(j.addr, EventDefenderWon, j.cycle, j.correlationID)
+		case types.GameStatusChallengerWon:
+			c.events.Emit(j.addr, EventChallengerWon, j.cycle, j.correlationID)
+		}
+		if c.onResolved != nil && !resolvedAgainst(j) {
+			c.resolved = append(c.resolved, resolvedGame{game: state.metadata(j.addr), status: j.status})
+		}
+	}
+	c.notifyWaiters(GameResult{Game: j.addr, Result: j.summary(), Err: j.err})
+	if c.audit != nil {
+		c.audit.observe(j, c.cfg.clock.Now())
+	}
+	if state.status != types.GameStatusInProgress {
+		state.scratchpad = nil
+	} else if !j.scratchpad.withinLimits() {
+		c.logger.Warn("Discarding scratchpad update exceeding size limits", "game", j.addr, "entries", len(j.scratchpad))
+	} else {
+		state.scratchpad = j.scratchpad
+	}
 	c.m.RecordGameUpdateCompleted()
+	c.processedJobs++
+	if c.cfg.maxTotalJobs > 0 && c.processedJobs >= c.cfg.maxTotalJobs && !c.jobLimitReached.Load() {
+		c.logger.Info("Job limit reached, no further jobs will be scheduled", "limit", c.cfg.maxTotalJobs)
+		c.jobLimitReached.Store(true)
+	}
+	c.enqueueDeferred()
+	c.enqueueFollowUp(j, state)
+	if state.urgent {
+		c.enqueueUrgent(j.addr, state)
+	}
+	if state.forced {
+		c.enqueueForced(j.addr, state)
+	}
+	c.retryTimedOut(j, state)
+	c.idle.Done()
 	return nil
 }
 
-func (c *coordinator) deleteResolvedGameFiles() {
+// OutcomeReporter is an optional interface a GamePlayer can implement to report whether its game resolved in favour
+// of the challenger. Games reported to have resolved against the challenger aren't passed to the handler set by
+// WithResolutionHandler.
+type OutcomeReporter interface {
+	// ResolvedInFavour returns true if the game resolving with status is in favour of the challenger, or false as
+	// the second value if the player doesn't know.
+	ResolvedInFavour(status types.GameStatus) (bool, bool)
+}
+
+// resolvedAgainst returns true if the job's player reports its game resolved against the challenger.
+func resolvedAgainst(j job) bool {
+	reporter, ok := j.player.(OutcomeReporter)
+	if !ok {
+		return false
+	}
+	favour, known := reporter.ResolvedInFavour(j.status)
+	return known && !favour
+}
+
+// resolvedGame is a game that resolved with status, to be passed to the handler set by WithResolutionHandler.
+type resolvedGame struct {
+	game   types.GameMetadata
+	status types.GameStatus
+}
+
+// notifyResolved calls the handler set by WithResolutionHandler with the games that resolved since it was last
+// called. The lock must not be held, so the handler may inspect the scheduler.
+func (c *coordinator) notifyResolved() {
+	c.lock.Lock()
+	resolved := c.resolved
+	c.resolved = nil
+	c.lock.Unlock()
+	for _, r := range resolved {
+		c.onResolved(r.game, r.status)
+	}
+}
+
+// enqueueDeferred enqueues as many jobs deferred because the job queue was full as there is now space for.
+// The lock must be held.
+func (c *coordinator) enqueueDeferred() {
+	if c.jobLimitReached.Load() {
+		c.releaseJobs(c.deferred)
+		c.deferred = nil
+		return
+	}
+	if c.backpressure.paused || c.memory.Paused() {
+		return
+	}
+	for len(c.deferred) > 0 {
+		c.deferred[0].enqueuedAt = c.cfg.clock.Now()
+		select {
+		case c.jobQueue <- c.deferred[0]:
+			c.tracer.Log(c.deferred[0].addr, "Enqueued deferred job", "block", c.deferred[0].block)
+			c.deferred = c.deferred[1:]
+		default:
+			return
+		}
+	}
+}
+
+// enqueueFollowUp immediately re-enqueues the game if its player requested another pass and the
+// limit on consecutive follow up passes hasn't been reached.
+// The follow up is only enqueued if there is space in the job queue. Otherwise, to avoid blocking
+// result processing, the game will be progressed the next time it is scheduled.
+func (c *coordinator) enqueueFollowUp(j job, state *gameState) {
+	if _, abandoned := c.abandoned[j.addr]; abandoned || state.filtered || !j.followUp || state.status != types.GameStatusInProgress || state.followUps >= c.cfg.maxFollowUps || c.jobLimitReached.Load() || c.backpressure.paused || c.memory.Paused() {
+		state.followUps = 0
+		return
+	}
+	followUp := c.newJob(j.block, j.addr, state)
+	followUp.enqueuedAt = c.cfg.clock.Now()
+	select {
+	case c.jobQueue <- *followUp:
+		state.followUps++
+		state.inflight = true
+		c.idle.Add(1)
+		c.m.RecordGameUpdateScheduled()
+		c.events.Emit(j.addr, EventScheduled, followUp.cycle, followUp.correlationID)
+		c.logger.Debug("Enqueued follow up pass", "game", j.addr, "followUps", state.followUps)
+	default:
+		state.followUps = 0
+		c.logger.Debug("Job queue full, deferring follow up pass to next schedule", "game", j.addr)
+	}
+}
+
+// gamesToKeep returns the games whose data must not be removed, which are those in progress, in flight, retained
+// after resolving or prewarmed. The lock must be held.
+func (c *coordinator) gamesToKeep() []common.Address {
 	var keepGames []common.Address
+	var retained int
+	now := c.cfg.clock.Now()
 	for addr, state := range c.states {
-		if state.status == types.GameStatusInProgress || state.inflight {
+		if c.retained(state, now) {
+			retained++
+			keepGames = append(keepGames, addr)
+		} else if state.status == types.GameStatusInProgress || state.inflight {
 			keepGames = append(keepGames, addr)
 		}
 	}
+	c.m.RecordResolvedGamesRetained(retained)
+	for addr := range c.prewarmed {
+		keepGames = append(keepGames, addr)
+	}
+	return keepGames
+}
+
+// removeGameFiles removes the data of all games other than keepGames. The lock must not be held.
+func (c *coordinator) removeGameFiles(keepGames []common.Address) {
 	if err := c.disk.RemoveAllExcept(keepGames); err != nil {
-		c.logger.Error("Unable to cleanup game data", "err", err)
+		c.errLog.Log(log.LevelError, "cleanup", "Unable to cleanup game data", err)
 	}
 }
 
-func newCoordinator(logger log.Logger, m CoordinatorMetricer, jobQueue chan<- job, resultQueue <-chan job, createPlayer PlayerCreator, disk DiskManager, allowInvalidPrestate bool) *coordinator {
-	return &coordinator{
+func newCoordinator(logger log.Logger, m CoordinatorMetricer, jobQueue chan<- job, resultQueue <-chan job, createPlayer PlayerCreator, disk DiskManager, allowInvalidPrestate bool, cfg config) *coordinator {
+	c := &coordinator{
 		logger:               logger,
 		m:                    m,
 		jobQueue:             jobQueue,
 		resultQueue:          resultQueue,
 		createPlayer:         createPlayer,
 		disk:                 disk,
+		store:                cfg.stateStore,
 		states:               make(map[common.Address]*gameState),
+		priorities:           make(gamePriorities),
+		firstSeen:            make(map[common.Address]*firstSeen),
+		prewarmed:            make(map[common.Address]bool),
+		pinned:               make(map[common.Address]bool),
+		ignored:              make(map[common.Address]bool),
+		waiters:              make(map[common.Address][]chan GameResult),
+		abandoned:            make(map[common.Address]AbandonedGame),
+		decisions:            make(map[common.Address]decisionRecord),
+		lastJobID:            new(atomic.Uint64),
+		filter:               cfg.gameFilter,
+		canceller:            newJobCanceller(),
+		history:              newResultHistory(cfg.recentResults),
+		latencies:            newLatencyWindow(cfg.latencyWindow),
+		dispatchRand:         newDispatchRand(cfg),
+		dropRand:             newDropRand(cfg),
+		sequencer:            newResultSequencerFor(cfg),
+		tracer:               newGameTracer(logger, cfg.maxTracedGames),
+		errLog:               newErrorThrottle(logger, cfg.clock, cfg.errorLogWindow),
 		allowInvalidPrestate: allowInvalidPrestate,
+		cfg:                  cfg,
+		idle:                 newIdleTracker(logger),
+		gas:                  gasBudget{budget: cfg.gasBudget, window: cfg.gasBudgetWindow},
+		backpressure:         newResultBackpressure(cfg.resultsHighWater, cfg.resultsLowWater),
+		memory:               newMemoryGuard(cfg.memoryHighWater, cfg.memoryLowWater, cfg.memoryForceGC),
+		backoff:              globalBackoff{threshold: cfg.backoffThreshold, initial: cfg.backoffInitial, max: cfg.backoffMax},
 	}
+	// The policies read the coordinator's config and tracer so they see any later changes to them.
+	c.cooldown = newActionCooldown(&c.cfg, m)
+	c.failures = newFailurePolicy(&c.cfg, m, logger, c.traceGame)
+	return c
+}
+
+// forgetGame stops tracking the game, removing its state and the state kept for it by the policies. The lock must
+// be held.
+func (c *coordinator) forgetGame(addr common.Address) {
+	delete(c.states, addr)
+	delete(c.priorities, addr)
+	c.cooldown.forget(addr)
+	c.failures.forget(addr)
+}
+
+// traceGame logs a scheduling step of a game traced with TraceGame.
+func (c *coordinator) traceGame(addr common.Address, msg string, ctx ...any) {
+	c.tracer.Log(addr, msg, ctx...)
 }
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -127,6 +129,7 @@ func TestResultForUnknownGame(t *testing.T) {
 
 func TestProcessResultsWhileJobQueueFull(t *testing.T) {
 	c, workQueue, resultQueue, games, disk, _ := setupCoordinatorTest(t, 0)
+	c.cfg.queueFullStrategy = QueueFullBlock
 	gameAddr1 := common.Address{0xaa}
 	gameAddr2 := common.Address{0xbb}
 	gameAddr3 := common.Address{0xcc}
@@ -167,6 +170,70 @@ loop:
 	require.Empty(t, disk.deletedDirs, "should not have deleted any directories")
 }
 
+func TestDeferJobsWhenJobQueueFull(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 1)
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	gameAddr3 := common.Address{0xcc}
+	ctx := context.Background()
+
+	// Returns without blocking even though nothing is reading from the queue
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2, gameAddr3), 0))
+	require.Len(t, workQueue, 1)
+	require.Len(t, c.deferred, 2)
+
+	// Deferred jobs are enqueued as results free up space
+	var progressed []common.Address
+	for i := 0; i < 3; i++ {
+		j := <-workQueue
+		progressed = append(progressed, j.addr)
+		require.NoError(t, c.processResult(runJob(ctx, j)))
+	}
+	require.Equal(t, []common.Address{gameAddr1, gameAddr2, gameAddr3}, progressed)
+	require.Empty(t, c.deferred)
+	require.True(t, c.idle.IsIdle())
+}
+
+func TestDeferredJobsEnqueuedBeforeNewBatch(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 1)
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	gameAddr3 := common.Address{0xcc}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2), 0))
+	j := <-workQueue
+	require.Equal(t, gameAddr1, j.addr)
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2, gameAddr3), 1))
+	require.Equal(t, gameAddr2, (<-workQueue).addr, "should enqueue deferred job first")
+	require.Len(t, c.deferred, 1)
+	require.Equal(t, gameAddr3, c.deferred[0].addr)
+}
+
+func TestDropJobsWhenJobQueueFull(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 1)
+	c.cfg.queueFullStrategy = QueueFullDrop
+	m := c.m.(*stubSchedulerMetrics)
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	gameAddr3 := common.Address{0xcc}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2, gameAddr3), 0))
+	require.Len(t, workQueue, 1)
+	require.Empty(t, c.deferred)
+	require.Equal(t, 2, m.droppedJobs)
+	require.False(t, c.states[gameAddr2].inflight)
+	require.False(t, c.states[gameAddr3].inflight)
+
+	// Dropped games are scheduled again with the next batch
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.True(t, c.idle.IsIdle())
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr2), 1))
+	require.Equal(t, gameAddr2, (<-workQueue).addr)
+}
+
 func TestDeleteDataForResolvedGames(t *testing.T) {
 	c, workQueue, _, _, disk, _ := setupCoordinatorTest(t, 10)
 	gameAddr1 := common.Address{0xaa}
@@ -180,11 +247,11 @@ func TestDeleteDataForResolvedGames(t *testing.T) {
 	j := <-workQueue
 	j.status = types.GameStatusDefenderWon
 	require.NoError(t, c.processResult(j))
-	// But ensure its data directory is marked as existing
-	disk.DirForGame(gameAddr3)
 
 	games := asGames(gameAddr1, gameAddr2, gameAddr3)
 	require.NoError(t, c.schedule(ctx, games, 0))
+	// But ensure its data directory is marked as existing
+	disk.DirForGame(gameAddr3)
 
 	// The work queue should only contain jobs for games 1 and 2
 	// A resolved game should not be scheduled for an update.
@@ -201,7 +268,9 @@ func TestDeleteDataForResolvedGames(t *testing.T) {
 		}
 		require.NoError(t, c.processResult(j))
 	}
+	require.True(t, disk.gameDirExists[gameAddr2], "game 2 data should not be deleted until the next cycle")
 
+	require.NoError(t, c.schedule(ctx, games, 1))
 	require.True(t, disk.gameDirExists[gameAddr1], "game 1 data should be preserved (not resolved)")
 	require.False(t, disk.gameDirExists[gameAddr2], "game 2 data should be deleted")
 	require.True(t, disk.gameDirExists[gameAddr3], "game 3 data should be preserved (inflight)")
@@ -357,6 +426,259 @@ func TestDropOldGameStates(t *testing.T) {
 	require.Contains(t, c.states, gameAddr4, "should create state for game 4")
 }
 
+func TestEnqueueFollowUpPasses(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.maxFollowUps = 3
+	gameAddr1 := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1), 0))
+	player := games.created[gameAddr1]
+	player.FollowUps = 10
+
+	// Initial pass plus the maximum number of follow ups
+	for i := 0; i < 4; i++ {
+		require.Lenf(t, workQueue, 1, "should have enqueued pass %v", i)
+		require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	}
+	require.Empty(t, workQueue, "should stop enqueuing follow ups at the limit")
+	require.Equal(t, 4, player.ProgressCount)
+
+	// The next schedule starts a new chain of follow ups
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1), 1))
+	require.Len(t, workQueue, 1, "should schedule game again")
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.Len(t, workQueue, 1, "should enqueue follow up after new schedule")
+}
+
+func TestFollowUpPassesDisabledByDefault(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	gameAddr1 := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1), 0))
+	games.created[gameAddr1].FollowUps = 10
+
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.Empty(t, workQueue, "should not enqueue follow up")
+}
+
+func TestNoFollowUpForResolvedGame(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.maxFollowUps = 3
+	gameAddr1 := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1), 0))
+	player := games.created[gameAddr1]
+	player.FollowUps = 10
+	player.StatusValue = types.GameStatusDefenderWon
+
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.Empty(t, workQueue, "should not enqueue follow up for resolved game")
+}
+
+func TestAbortScheduleWhenContextDone(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 100)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var addrs []common.Address
+	for i := 0; i < 100; i++ {
+		addrs = append(addrs, common.Address{byte(i)})
+	}
+	// Cancel the context partway through the batch, after all jobs have been created but before any are enqueued.
+	c.createPlayer = func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		if game.Proxy == addrs[len(addrs)-1] {
+			cancel()
+		}
+		return games.CreateGame(game, dir)
+	}
+
+	err := c.schedule(ctx, asGames(addrs...), 0)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Empty(t, workQueue, "should not enqueue jobs after context is done")
+	waitCtx, waitCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer waitCancel()
+	require.NoError(t, c.idle.Wait(waitCtx), "should not leave unenqueued jobs pending")
+}
+
+func TestSkipGamesCoolingDownAfterAction(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	c.cfg.actionCooldown = 30 * time.Second
+	m := c.m.(*stubSchedulerMetrics)
+	actingGame := common.Address{0xaa}
+	idleGame := common.Address{0xbb}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(actingGame, idleGame), 0))
+	games.created[actingGame].ActionTakenValue = true
+	for len(workQueue) > 0 {
+		require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	}
+
+	// Acting game is cooling down so only the idle game is scheduled
+	cl.AdvanceTime(29 * time.Second)
+	require.NoError(t, c.schedule(ctx, asGames(actingGame, idleGame), 1))
+	require.Len(t, workQueue, 1)
+	j := <-workQueue
+	require.Equal(t, idleGame, j.addr)
+	require.NoError(t, c.processResult(runJob(ctx, j)))
+	require.Equal(t, 1, m.coolingDown)
+
+	// Cooldown expires
+	cl.AdvanceTime(time.Second)
+	require.NoError(t, c.schedule(ctx, asGames(actingGame, idleGame), 2))
+	require.Len(t, workQueue, 2)
+	require.Equal(t, 1, m.coolingDown)
+}
+
+func TestStopSchedulingWhenJobLimitReached(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.maxTotalJobs = 2
+	c.cfg.maxFollowUps = 5
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2), 0))
+	games.created[gameAddr1].FollowUps = 10
+	games.created[gameAddr2].FollowUps = 10
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.False(t, c.jobLimitReached.Load())
+	require.Len(t, workQueue, 2, "should enqueue follow up before limit is reached")
+
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.True(t, c.jobLimitReached.Load())
+	require.Len(t, workQueue, 1, "should not enqueue follow up after limit is reached")
+
+	// Outstanding jobs are still processed but no new jobs are scheduled
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2), 1))
+	require.Empty(t, workQueue)
+	require.True(t, c.idle.IsIdle())
+}
+
+func TestDropDuplicateResults(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	m := c.m.(*stubSchedulerMetrics)
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	j := runJob(ctx, <-workQueue)
+	require.NoError(t, c.processResult(j))
+	require.ErrorIs(t, c.processResult(j), errDuplicateResult)
+	require.Equal(t, 1, m.duplicates)
+	require.EqualValues(t, 1, c.processedJobs, "should not count duplicate result")
+	require.True(t, c.idle.IsIdle())
+
+	// A stale result arriving while a newer job for the game is pending is also dropped
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 1))
+	games.created[gameAddr].StatusValue = types.GameStatusDefenderWon
+	require.ErrorIs(t, c.processResult(j), errDuplicateResult)
+	require.Equal(t, 2, m.duplicates)
+	require.Equal(t, types.GameStatusInProgress, c.states[gameAddr].status)
+	require.True(t, c.states[gameAddr].inflight)
+
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.Equal(t, types.GameStatusDefenderWon, c.states[gameAddr].status)
+	require.EqualValues(t, 2, c.processedJobs)
+}
+
+func TestScheduleTransform(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	canaryGame := common.Address{0xca}
+	deniedGame := common.Address{0xde}
+	gameAddr := common.Address{0xaa}
+	c.cfg.scheduleTransform = func(games []types.GameMetadata) []types.GameMetadata {
+		var result []types.GameMetadata
+		for _, game := range games {
+			if game.Proxy != deniedGame {
+				result = append(result, game)
+			}
+		}
+		return append(result, types.GameMetadata{Proxy: canaryGame})
+	}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(deniedGame, gameAddr), 0))
+	require.Len(t, workQueue, 2)
+	require.Equal(t, gameAddr, (<-workQueue).addr)
+	require.Equal(t, canaryGame, (<-workQueue).addr)
+	require.NotContains(t, c.states, deniedGame)
+
+	// Transformed games that are in flight are still skipped
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 1))
+	require.Empty(t, workQueue)
+}
+
+func TestSkipZeroAddressGame(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	batch := asGames(gameAddr1, common.Address{}, gameAddr2)
+
+	require.NoError(t, c.schedule(context.Background(), batch, 0))
+	require.Len(t, workQueue, 2)
+	require.Equal(t, gameAddr1, (<-workQueue).addr)
+	require.Equal(t, gameAddr2, (<-workQueue).addr)
+	require.NotContains(t, c.states, common.Address{})
+	require.NotContains(t, games.created, common.Address{})
+	require.Equal(t, 1, c.m.(*stubSchedulerMetrics).invalidGames)
+	// The caller's batch is left unchanged
+	require.Equal(t, asGames(gameAddr1, common.Address{}, gameAddr2), batch)
+}
+
+func TestPauseDispatchWhileResultsPending(t *testing.T) {
+	c, workQueue, resultQueue, _, _, _ := setupCoordinatorTest(t, 10)
+	c.backpressure = newResultBackpressure(3, 1)
+	m := c.m.(*stubSchedulerMetrics)
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	gameAddr3 := common.Address{0xcc}
+	gameAddr4 := common.Address{0xdd}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2, gameAddr3), 0))
+	require.Len(t, workQueue, 3)
+	require.Empty(t, m.backpressure, "should not pause while results are below the high water mark")
+
+	// Results are slow to be processed and reach the high water mark
+	for i := 0; i < 3; i++ {
+		resultQueue <- <-workQueue
+	}
+
+	// Dispatch of the new game waits until all pending results have been processed
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2, gameAddr3, gameAddr4), 1))
+	require.Equal(t, []bool{true, false}, m.backpressure)
+	require.Empty(t, resultQueue)
+	require.Len(t, workQueue, 1)
+	require.Equal(t, gameAddr4, (<-workQueue).addr)
+	for _, addr := range []common.Address{gameAddr1, gameAddr2, gameAddr3} {
+		require.False(t, c.states[addr].inflight, "result should have been processed")
+	}
+}
+
+func TestResumeDispatchBelowLowWaterMark(t *testing.T) {
+	c, workQueue, resultQueue, _, _, _ := setupCoordinatorTest(t, 10)
+	c.backpressure = newResultBackpressure(3, 2)
+	m := c.m.(*stubSchedulerMetrics)
+	games := asGames(common.Address{0xaa}, common.Address{0xbb}, common.Address{0xcc})
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, games, 0))
+	for i := 0; i < 3; i++ {
+		resultQueue <- <-workQueue
+	}
+	require.NoError(t, c.schedule(ctx, append(games, types.GameMetadata{Proxy: common.Address{0xdd}}), 1))
+	require.Equal(t, []bool{true, false}, m.backpressure)
+	// Dispatch resumes as soon as pending results drop below the low water mark
+	require.Len(t, resultQueue, 1)
+	require.Len(t, workQueue, 1)
+}
+
 func setupCoordinatorTest(t *testing.T, bufferSize int) (*coordinator, <-chan job, chan job, *createdGames, *stubDiskManager, *testlog.CapturingHandler) {
 	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
 	workQueue := make(chan job, bufferSize)
@@ -366,7 +688,7 @@ func setupCoordinatorTest(t *testing.T, bufferSize int) (*coordinator, <-chan jo
 		created: make(map[common.Address]*test.StubGamePlayer),
 	}
 	disk := &stubDiskManager{gameDirExists: make(map[common.Address]bool)}
-	c := newCoordinator(logger, &stubSchedulerMetrics{}, workQueue, resultQueue, games.CreateGame, disk, false)
+	c := newCoordinator(logger, &stubSchedulerMetrics{}, workQueue, resultQueue, games.CreateGame, disk, false, defaultConfig())
 	return c, workQueue, resultQueue, games, disk, logs
 }
 
@@ -403,7 +725,32 @@ func (c *createdGames) CreateGame(fdg types.GameMetadata, dir string) (GamePlaye
 }
 
 type stubSchedulerMetrics struct {
-	actedL1Blocks uint64
+	actedL1Blocks    uint64
+	coolingDown      int
+	duplicates       int
+	droppedJobs      int
+	gasDeferred      int
+	notReady         int
+	regressions      []statusChange
+	invalidGames     int
+	backpressure     []bool
+	backoff          []time.Duration
+	retained         int
+	suppressed       int
+	tracked          int
+	invalid          int
+	orphanedDirs     int
+	missingDirs      int
+	abandoned        map[string]int
+	gatedCycles      int
+	initFailures     int
+	quarantined      int
+	gamesQuarantined int
+	quarantinedGames int
+	diskUsage        uint64
+	diskEvictions    int
+	timedOut         int
+	jobDurations     []time.Duration
 }
 
 func (s *stubSchedulerMetrics) RecordActedL1Block(n uint64) {
@@ -414,6 +761,112 @@ func (s *stubSchedulerMetrics) RecordGamesStatus(_, _, _ int) {}
 func (s *stubSchedulerMetrics) RecordGameUpdateScheduled()    {}
 func (s *stubSchedulerMetrics) RecordGameUpdateCompleted()    {}
 
+func (s *stubSchedulerMetrics) RecordGameCoolingDown() {
+	s.coolingDown++
+}
+
+func (s *stubSchedulerMetrics) RecordDuplicateResult() {
+	s.duplicates++
+}
+
+func (s *stubSchedulerMetrics) RecordJobsDropped(n int) {
+	s.droppedJobs += n
+}
+
+type statusChange struct {
+	from, to types.GameStatus
+}
+
+func (s *stubSchedulerMetrics) RecordGameStatusRegression(from, to types.GameStatus) {
+	s.regressions = append(s.regressions, statusChange{from: from, to: to})
+}
+
+func (s *stubSchedulerMetrics) RecordGameNotReady() {
+	s.notReady++
+}
+
+func (s *stubSchedulerMetrics) RecordGasBudgetDeferred() {
+	s.gasDeferred++
+}
+
+func (s *stubSchedulerMetrics) RecordInvalidGameFiltered() {
+	s.invalidGames++
+}
+
+func (s *stubSchedulerMetrics) RecordPendingResults(_ int) {}
+
+func (s *stubSchedulerMetrics) RecordActionSuppressed() {
+	s.suppressed++
+}
+
+func (s *stubSchedulerMetrics) RecordResolvedGamesRetained(n int) {
+	s.retained = n
+}
+
+func (s *stubSchedulerMetrics) RecordCycleGated() {
+	s.gatedCycles++
+}
+
+func (s *stubSchedulerMetrics) RecordPlayerInitFailure() {
+	s.initFailures++
+}
+
+func (s *stubSchedulerMetrics) RecordGameDirQuarantined() {
+	s.quarantined++
+}
+
+func (s *stubSchedulerMetrics) RecordDiskUsage(bytes uint64) {
+	s.diskUsage = bytes
+}
+
+func (s *stubSchedulerMetrics) RecordDiskEvictions(n int) {
+	s.diskEvictions += n
+}
+
+func (s *stubSchedulerMetrics) RecordGameQuarantined() {
+	s.gamesQuarantined++
+}
+
+func (s *stubSchedulerMetrics) RecordQuarantinedGames(n int) {
+	s.quarantinedGames = n
+}
+
+func (s *stubSchedulerMetrics) RecordGameUpdateTimedOut() {
+	s.timedOut++
+}
+
+func (s *stubSchedulerMetrics) RecordJobDuration(d time.Duration) {
+	s.jobDurations = append(s.jobDurations, d)
+}
+
+func (s *stubSchedulerMetrics) RecordGameAbandoned(reason string) {
+	if s.abandoned == nil {
+		s.abandoned = make(map[string]int)
+	}
+	s.abandoned[reason]++
+}
+
+func (s *stubSchedulerMetrics) RecordDiskInconsistencies(orphaned, missing int) {
+	s.orphanedDirs = orphaned
+	s.missingDirs = missing
+}
+
+func (s *stubSchedulerMetrics) RecordInvalidResult() {
+	s.invalid++
+}
+
+func (s *stubSchedulerMetrics) RecordTrackedGames(n int) {
+	s.tracked = n
+}
+
+func (s *stubSchedulerMetrics) RecordGlobalBackoff(d time.Duration) {
+	s.backoff = append(s.backoff, d)
+}
+
+func (s *stubSchedulerMetrics) RecordResultBackpressure(paused bool) {
+	s.backpressure = append(s.backpressure, paused)
+}
+
 type stubDiskManager struct {
 	gameDirExists map[common.Address]bool
 	deletedDirs   []common.Address
@@ -444,3 +897,104 @@ func asGames(addrs ...common.Address) []types.GameMetadata {
 	}
 	return games
 }
+
+func TestDetectGameStatusRegression(t *testing.T) {
+	c, workQueue, _, _, _, logs := setupCoordinatorTest(t, 10)
+	m := c.m.(*stubSchedulerMetrics)
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	// Normal forward transitions aren't flagged
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	require.NoError(t, c.processResult(<-workQueue))
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 1))
+	j := <-workQueue
+	j.status = types.GameStatusChallengerWon
+	require.NoError(t, c.processResult(j))
+	require.Empty(t, m.regressions)
+
+	// Inject a result reporting the resolved game as in progress
+	j.id = c.lastJobID.Load() + 1
+	c.states[gameAddr].pendingJobID = j.id
+	c.idle.Add(1)
+	j.status = types.GameStatusInProgress
+	require.NoError(t, c.processResult(j))
+	require.Equal(t, []statusChange{{from: types.GameStatusChallengerWon, to: types.GameStatusInProgress}}, m.regressions)
+	require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter("Game status regressed")))
+	require.Equal(t, types.GameStatusInProgress, c.states[gameAddr].status)
+	require.True(t, c.states[gameAddr].resolvedAt.IsZero(), "should no longer be considered resolved")
+}
+
+func TestNotifyResolutionHandlerOnceResolved(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	var resolved []types.GameMetadata
+	var statuses []types.GameStatus
+	var tracked []TrackedGame
+	c.onResolved = func(game types.GameMetadata, status types.GameStatus) {
+		resolved = append(resolved, game)
+		statuses = append(statuses, status)
+		// The handler may inspect the scheduler
+		tracked = c.trackedGames(nil)
+	}
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	require.NoError(t, c.processResult(<-workQueue))
+	require.Empty(t, resolved, "should not notify while game is in progress")
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 1))
+	j := <-workQueue
+	j.status = types.GameStatusChallengerWon
+	require.NoError(t, c.processResult(j))
+	require.Equal(t, asGames(gameAddr), resolved)
+	require.Equal(t, []types.GameStatus{types.GameStatusChallengerWon}, statuses)
+	require.Len(t, tracked, 1)
+	require.Equal(t, types.GameStatusChallengerWon, tracked[0].Status)
+
+	// Resolved games aren't progressed again so are only reported once
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 2))
+	require.Empty(t, workQueue)
+	require.Len(t, resolved, 1)
+}
+
+func TestSkipResolutionHandlerForGamesResolvedAgainstChallenger(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	var resolved []common.Address
+	c.onResolved = func(game types.GameMetadata, status types.GameStatus) {
+		resolved = append(resolved, game.Proxy)
+	}
+	won := common.Address{0xaa}
+	lost := common.Address{0xbb}
+	unknown := common.Address{0xcc}
+	stances := map[common.Address]*outcomePlayer{
+		won:     {favoured: types.GameStatusChallengerWon, known: true},
+		lost:    {favoured: types.GameStatusDefenderWon, known: true},
+		unknown: {},
+	}
+	c.createPlayer = func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		player := stances[game.Proxy]
+		player.StubGamePlayer = &test.StubGamePlayer{Addr: game.Proxy, StatusValue: types.GameStatusInProgress}
+		return player, nil
+	}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(won, lost, unknown), 0))
+	for len(workQueue) > 0 {
+		j := <-workQueue
+		j.status = types.GameStatusChallengerWon
+		require.NoError(t, c.processResult(j))
+	}
+	require.ElementsMatch(t, []common.Address{won, unknown}, resolved)
+}
+
+// outcomePlayer reports the game resolved in favour of the challenger if it resolved with the favoured status.
+type outcomePlayer struct {
+	*test.StubGamePlayer
+	favoured types.GameStatus
+	known    bool
+}
+
+func (p *outcomePlayer) ResolvedInFavour(status types.GameStatus) (bool, bool) {
+	return status == p.favoured, p.known
+}
@@ -0,0 +1,41 @@
+package scheduler
+
+import (
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+// ScheduleCorrelated schedules a batch of games like Schedule, tagging the jobs created for them with the
+// correlation id so the logs, events, result summaries and audit records for those games can be tied back to the
+// decision that scheduled them in another system. If correlationID is empty, one is generated for the batch.
+func (s *Scheduler) ScheduleCorrelated(games []types.GameMetadata, blockNumber uint64, correlationID string) error {
+	return s.enqueueBatch(blockGames{blockNumber: blockNumber, games: games, correlationID: correlationID})
+}
+
+// newCorrelationID generates a correlation id for a batch scheduled without one.
+func newCorrelationID() string {
+	return uuid.NewString()
+}
+
+// setCorrelationID sets the correlation id applied to games scheduled by the batch about to be scheduled,
+// generating one if it is empty, and the correlation ids of games merged into the batch from batches with a
+// different correlation id. Returns the correlation id used for the batch.
+func (c *coordinator) setCorrelationID(correlationID string, games map[common.Address]string) string {
+	if correlationID == "" {
+		correlationID = newCorrelationID()
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.correlationID = correlationID
+	c.gameCorrelationIDs = games
+	return correlationID
+}
+
+// correlationIDFor returns the correlation id to tag the game's job with. The lock must be held.
+func (c *coordinator) correlationIDFor(addr common.Address) string {
+	if correlationID, ok := c.gameCorrelationIDs[addr]; ok {
+		return correlationID
+	}
+	return c.correlationID
+}
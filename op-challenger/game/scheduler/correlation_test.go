@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleCorrelated(t *testing.T) {
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+
+	t.Run("ProvidedId", func(t *testing.T) {
+		s, results, publisher, audit := newCorrelationTestScheduler(t)
+		require.NoError(t, s.ScheduleCorrelated(asGames(game1, game2), 1, "monitor-42"))
+		require.NoError(t, s.WaitIdle(context.Background()))
+
+		for _, result := range results.next(t, 2) {
+			require.Equal(t, "monitor-42", result.CorrelationID, "result for game %v", result.Game)
+		}
+		events := publisher.next(t, 6)
+		for _, event := range events {
+			require.Equal(t, "monitor-42", event.CorrelationID, "%v event for game %v", event.Type, event.Game)
+		}
+		records := audit.records(t)
+		require.Len(t, records, 2)
+		for _, record := range records {
+			require.Equal(t, "monitor-42", record.CorrelationID, "audit record for game %v", record.Game)
+		}
+	})
+
+	t.Run("GeneratedPerBatch", func(t *testing.T) {
+		s, results, publisher, _ := newCorrelationTestScheduler(t)
+		progress := func(block uint64) string {
+			require.NoError(t, s.Schedule(asGames(game1, game2), block))
+			require.NoError(t, s.WaitIdle(context.Background()))
+			batch := results.next(t, 2)
+			require.NotEmpty(t, batch[0].CorrelationID)
+			require.Equal(t, batch[0].CorrelationID, batch[1].CorrelationID, "should share the batch correlation id")
+			for _, event := range publisher.next(t, 6) {
+				require.Equal(t, batch[0].CorrelationID, event.CorrelationID, "%v event for game %v", event.Type, event.Game)
+			}
+			return batch[0].CorrelationID
+		}
+		require.NotEqual(t, progress(1), progress(2), "should generate a new correlation id for each batch")
+	})
+}
+
+func TestCorrelationIDOfMergedGames(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	c.setCorrelationID("second", map[common.Address]string{game2: "first"})
+	require.NoError(t, c.schedule(context.Background(), asGames(game1, game2), 1))
+	require.Len(t, workQueue, 2)
+	ids := make(map[common.Address]string)
+	for i := 0; i < 2; i++ {
+		j := <-workQueue
+		ids[j.addr] = j.correlationID
+	}
+	require.Equal(t, map[common.Address]string{game1: "second", game2: "first"}, ids,
+		"should keep the correlation id of games merged from another batch")
+}
+
+func newCorrelationTestScheduler(t *testing.T) (*Scheduler, *correlationResults, *correlationPublisher, *correlationAudit) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{Addr: g.Proxy, StatusValue: types.GameStatusInProgress, ActionTakenValue: true}, nil
+	}
+	results := &correlationResults{results: make(chan ResultSummary, 10)}
+	publisher := &correlationPublisher{events: make(chan Event, 100)}
+	audit := &correlationAudit{}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false,
+		WithResultSink(results.record), WithEventPublisher(publisher), WithAuditSink(audit))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	s.Start(ctx)
+	t.Cleanup(func() {
+		require.NoError(t, s.Close())
+		cancel()
+	})
+	return s, results, publisher, audit
+}
+
+type correlationResults struct {
+	results chan ResultSummary
+}
+
+func (r *correlationResults) record(_ context.Context, result ResultSummary) error {
+	r.results <- result
+	return nil
+}
+
+func (r *correlationResults) next(t *testing.T, n int) []ResultSummary {
+	var results []ResultSummary
+	for i := 0; i < n; i++ {
+		results = append(results, readWithTimeout(t, r.results))
+	}
+	return results
+}
+
+type correlationPublisher struct {
+	events chan Event
+}
+
+func (p *correlationPublisher) Publish(event Event) {
+	p.events <- event
+}
+
+func (p *correlationPublisher) next(t *testing.T, n int) []Event {
+	var events []Event
+	for i := 0; i < n; i++ {
+		events = append(events, readWithTimeout(t, p.events))
+	}
+	return events
+}
+
+type correlationAudit struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (a *correlationAudit) Write(p []byte) (int, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.buf.Write(p)
+}
+
+func (a *correlationAudit) records(t *testing.T) []AuditRecord {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	var records []AuditRecord
+	scanner := bufio.NewScanner(bytes.NewReader(a.buf.Bytes()))
+	for scanner.Scan() {
+		var record AuditRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	return records
+}
@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// minDeadlineTimeout is the shortest time a progression is given when its timeout is bounded by the game's
+// deadline, so a game close to its deadline still has time to respond rather than timing out immediately.
+const minDeadlineTimeout = 30 * time.Second
+
+// DeadlineReporter is an optional interface a GamePlayer can implement to report the game's next on-chain
+// deadline, such as the expiry of the chess clock of the side due to move, after each ProgressGame call.
+// Games nearest their deadline are dispatched first and each progression's timeout is bounded by the time
+// remaining until the deadline when it is dispatched.
+type DeadlineReporter interface {
+	// NextDeadline returns the game's next deadline, or false if it has none.
+	NextDeadline() (time.Time, bool)
+}
+
+// ClaimRiskReporter is an optional interface a GamePlayer can implement to report, after each ProgressGame call,
+// whether any of the challenger's claims in the game are at risk of being countered or timing out. Games with
+// claims at risk are dispatched before all others.
+type ClaimRiskReporter interface {
+	// ClaimsAtRisk returns true if the challenger has claims at risk in the game.
+	ClaimsAtRisk() bool
+}
+
+// DeadlineResolver looks up the next on-chain deadline of a game whose player doesn't implement DeadlineReporter,
+// see WithDeadlineResolver. Returns the zero time if the game has no deadline.
+type DeadlineResolver func(ctx context.Context, game common.Address) (time.Time, error)
+
+// gamePriority is a game's dispatch priority as reported by its most recent progression, see DeadlineReporter and
+// ClaimRiskReporter.
+type gamePriority struct {
+	// deadline is the game's next on-chain deadline, or zero if unknown.
+	deadline time.Time
+	// claimsAtRisk is set if the challenger has claims at risk in the game.
+	claimsAtRisk bool
+}
+
+// gamePriorities holds the dispatch priority of each game as reported by its most recent progression.
+type gamePriorities map[common.Address]gamePriority
+
+// record updates the game's priority from the result of a progression.
+func (p gamePriorities) record(j job) {
+	priority := gamePriority{deadline: j.deadline, claimsAtRisk: j.claimsAtRisk}
+	if priority == (gamePriority{}) {
+		delete(p, j.addr)
+		return
+	}
+	p[j.addr] = priority
+}
+
+// apply sets the priority of the game's next job. Its timeout is bounded by the deadline when it is dispatched.
+func (p gamePriorities) apply(j *job) {
+	priority := p[j.addr]
+	j.deadline = priority.deadline
+	j.claimsAtRisk = priority.claimsAtRisk
+}
+
+// compareJobPriority orders jobs for dispatch. Jobs for games where the challenger has claims at risk come first,
+// see ClaimRiskReporter. Then jobs for games with a known deadline, nearest deadline first so games whose clock has
+// already expired are progressed before all others, followed by the remaining jobs in priority order, see
+// WithFailureDemotion.
+func compareJobPriority(a, b job) int {
+	if a.claimsAtRisk != b.claimsAtRisk {
+		if a.claimsAtRisk {
+			return -1
+		}
+		return 1
+	}
+	if a.deadline.IsZero() != b.deadline.IsZero() {
+		if a.deadline.IsZero() {
+			return 1
+		}
+		return -1
+	}
+	if c := a.deadline.Compare(b.deadline); c != 0 {
+		return c
+	}
+	return b.priority - a.priority
+}
+
+// deadlineTimeout returns the timeout to use for a job dispatched at now, bounding timeout by the time remaining
+// until the game's deadline but never below minDeadlineTimeout, or timeout if shorter. The timeout is unchanged if
+// the game has no deadline or it has already passed.
+func deadlineTimeout(timeout time.Duration, deadline time.Time, now time.Time) time.Duration {
+	if deadline.IsZero() {
+		return timeout
+	}
+	remaining := deadline.Sub(now)
+	if remaining <= 0 {
+		return timeout
+	}
+	remaining = max(remaining, minDeadlineTimeout)
+	if timeout > 0 && timeout <= remaining {
+		return timeout
+	}
+	return remaining
+}
+
+// resolveDeadline returns the next deadline of the job's game using the resolver set by WithDeadlineResolver if
+// its player doesn't report one itself. Returns the zero time if the deadline is unknown.
+func (w *worker) resolveDeadline(ctx context.Context, j job) time.Time {
+	if _, ok := j.player.(DeadlineReporter); ok || w.deadlines == nil {
+		return j.deadline
+	}
+	deadline, err := w.deadlines(ctx, j.addr)
+	if err != nil {
+		w.logger.Warn("Failed to resolve game deadline", "game", j.addr, "err", err)
+		return time.Time{}
+	}
+	return deadline
+}
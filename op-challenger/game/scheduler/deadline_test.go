@@ -0,0 +1,171 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadlineTimeout(t *testing.T) {
+	now := time.Unix(1000, 0)
+	require.Equal(t, time.Minute, deadlineTimeout(time.Minute, time.Time{}, now), "no deadline")
+	require.Equal(t, 45*time.Second, deadlineTimeout(time.Minute, now.Add(45*time.Second), now), "bounded by deadline")
+	require.Equal(t, time.Minute, deadlineTimeout(time.Minute, now.Add(time.Hour), now), "deadline after timeout")
+	require.Equal(t, 45*time.Second, deadlineTimeout(0, now.Add(45*time.Second), now), "no timeout")
+	require.Equal(t, minDeadlineTimeout, deadlineTimeout(time.Minute, now.Add(time.Second), now), "bounded by minimum")
+	require.Equal(t, minDeadlineTimeout, deadlineTimeout(0, now.Add(time.Second), now), "minimum without timeout")
+	require.Equal(t, 5*time.Second, deadlineTimeout(5*time.Second, now.Add(time.Second), now), "timeout below minimum")
+	require.Equal(t, time.Minute, deadlineTimeout(time.Minute, now.Add(-time.Second), now), "expired deadline")
+	require.Zero(t, deadlineTimeout(0, now, now), "expired deadline without timeout")
+}
+
+func TestCompareJobPriority(t *testing.T) {
+	now := time.Unix(1000, 0)
+	jobs := []job{
+		{addr: common.Address{0x01}, priority: 1},
+		{addr: common.Address{0x02}, priority: -1, deadline: now.Add(time.Hour)},
+		{addr: common.Address{0x03}, priority: 2},
+		{addr: common.Address{0x04}, deadline: now.Add(time.Minute)},
+		{addr: common.Address{0x05}, priority: -5, deadline: now.Add(-time.Minute)},
+		{addr: common.Address{0x06}, priority: -2, claimsAtRisk: true},
+		{addr: common.Address{0x07}, deadline: now.Add(time.Hour), claimsAtRisk: true},
+	}
+	slices.SortStableFunc(jobs, compareJobPriority)
+	var order []common.Address
+	for _, j := range jobs {
+		order = append(order, j.addr)
+	}
+	require.Equal(t, []common.Address{{0x07}, {0x06}, {0x05}, {0x04}, {0x02}, {0x03}, {0x01}}, order)
+}
+
+func TestScheduleNearestDeadlineFirst(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	far := common.Address{0x11}
+	none := common.Address{0x22}
+	near := common.Address{0x33}
+	expired := common.Address{0x44}
+	deadlines := map[common.Address]time.Time{
+		far:     cl.Now().Add(time.Hour),
+		near:    cl.Now().Add(30 * time.Second),
+		expired: cl.Now().Add(-time.Minute),
+	}
+	c.createPlayer = func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &clockPlayer{
+			StubGamePlayer: &test.StubGamePlayer{Addr: game.Proxy, StatusValue: types.GameStatusInProgress},
+			deadline:       deadlines[game.Proxy],
+		}, nil
+	}
+	timeout := 2 * time.Minute
+	c.setTimeouts(map[common.Address]time.Duration{far: timeout, none: timeout, near: timeout, expired: timeout})
+	ctx := context.Background()
+	games := asGames(far, none, near, expired)
+
+	dispatch := func(block uint64) []common.Address {
+		require.NoError(t, c.schedule(ctx, games, block))
+		var order []common.Address
+		for len(workQueue) > 0 {
+			j := <-workQueue
+			order = append(order, j.addr)
+			require.Equal(t, timeout, j.timeout, "timeout should not be bounded until dispatched")
+			require.NoError(t, c.processResult(runJob(ctx, j)))
+		}
+		return order
+	}
+
+	// Deadlines aren't known until the games have been progressed
+	order := dispatch(1)
+	require.Equal(t, []common.Address{far, none, near, expired}, order)
+
+	order = dispatch(2)
+	require.Equal(t, []common.Address{expired, near, far, none}, order)
+
+	// Clearing the reported deadline returns the game to the normal order
+	c.states[expired].player.(*clockPlayer).deadline = time.Time{}
+	dispatch(3)
+	order = dispatch(4)
+	require.Equal(t, []common.Address{near, far, none, expired}, order)
+}
+
+func TestWorkerBoundsTimeoutByDeadlineWhenDispatched(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	in := make(chan job, 1)
+	out := make(chan job, 1)
+	w := newTestWorker(t, in, out, &metricSink{})
+	w.clock = cl
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go w.progressGames(ctx, &wg)
+
+	dispatch := func(deadline time.Time) time.Duration {
+		in <- job{
+			player:   &test.StubGamePlayer{StatusValue: types.GameStatusInProgress},
+			timeout:  2 * time.Minute,
+			deadline: deadline,
+		}
+		return readWithTimeout(t, out).timeout
+	}
+
+	// The job was created 90s before its deadline but waited in the queue for 30s
+	deadline := cl.Now().Add(90 * time.Second)
+	cl.AdvanceTime(30 * time.Second)
+	require.Equal(t, time.Minute, dispatch(deadline))
+
+	// Games close to their deadline are still given time to respond
+	require.Equal(t, minDeadlineTimeout, dispatch(cl.Now().Add(time.Second)))
+
+	cancel()
+	wg.Wait()
+}
+
+func TestResolveDeadline(t *testing.T) {
+	deadline := time.Unix(5000, 0)
+	game := common.Address{0xaa}
+	resolved := make(map[common.Address]int)
+	var resolveErr error
+	w := &worker{
+		logger: testlog.Logger(t, log.LevelInfo),
+		deadlines: func(ctx context.Context, addr common.Address) (time.Time, error) {
+			resolved[addr]++
+			return deadline, resolveErr
+		},
+	}
+	ctx := context.Background()
+
+	require.Equal(t, deadline, w.resolveDeadline(ctx, job{addr: game, player: &test.StubGamePlayer{}}))
+	require.Equal(t, 1, resolved[game])
+
+	resolveErr = errors.New("boom")
+	require.Zero(t, w.resolveDeadline(ctx, job{addr: game, player: &test.StubGamePlayer{}}))
+
+	// Players reporting their own deadline aren't resolved, even if they have no deadline
+	require.Zero(t, w.resolveDeadline(ctx, job{addr: game, player: &clockPlayer{StubGamePlayer: &test.StubGamePlayer{}}}))
+	require.Equal(t, 2, resolved[game])
+
+	w.deadlines = nil
+	require.Zero(t, w.resolveDeadline(ctx, job{addr: game, player: &test.StubGamePlayer{}}))
+}
+
+// clockPlayer reports a chess clock deadline for its game.
+type clockPlayer struct {
+	*test.StubGamePlayer
+	deadline time.Time
+}
+
+func (p *clockPlayer) NextDeadline() (time.Time, bool) {
+	return p.deadline, !p.deadline.IsZero()
+}
@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// defaultDecisionTTL is how long the most recent scheduling decision for a game is kept by default.
+const defaultDecisionTTL = time.Hour
+
+// Decision is the outcome of considering a game for progression in a scheduling cycle, see Scheduler.LastDecision.
+type Decision string
+
+const (
+	// DecisionScheduled means a job to progress the game was created and enqueued.
+	DecisionScheduled Decision = "scheduled"
+	// DecisionSkipped means the game was deliberately not progressed, for the reason given.
+	DecisionSkipped Decision = "skipped"
+	// DecisionFailed means a job couldn't be created for the game because of the error given as the reason.
+	DecisionFailed Decision = "failed"
+)
+
+// Reasons recorded for skipped games. Games skipped because of an unmet dependency, see WithDependencies, record
+// a description of the dependency instead.
+const (
+	SkipReasonInvalid        = "invalid game"
+	SkipReasonGated          = "batch gate closed"
+	SkipReasonJobLimit       = "job limit reached"
+	SkipReasonOutOfShard     = "not in current schedule shard"
+	SkipReasonInFlight       = "already in flight"
+	SkipReasonAbandoned      = "abandoned"
+	SkipReasonFiltered       = "excluded by game filter"
+	SkipReasonIgnored        = "ignored by operator"
+	SkipReasonInitBackoff    = "player initialization backing off"
+	SkipReasonAwaitingPlayer = "awaiting player creation"
+	SkipReasonQuarantined    = "quarantined after repeated failures"
+	SkipReasonFailureBackoff = "backing off after failure"
+	SkipReasonNotReady       = "not ready"
+	SkipReasonCoolingDown    = "cooling down after action"
+	SkipReasonGasBudget      = "gas budget exhausted"
+	SkipReasonIdle           = "idle"
+	SkipReasonResolved       = "resolved"
+	SkipReasonDropped        = "dropped because job queue full"
+	SkipReasonDeferred       = "deferred because job queue full"
+)
+
+// decisionRecord is the most recent scheduling decision made for a game.
+type decisionRecord struct {
+	decision Decision
+	reason   string
+	cycle    uint64
+	time     time.Time
+}
+
+// recordDecision records the decision made for the game in the current cycle, replacing any earlier decision.
+// Does nothing if decisions aren't being kept, see WithDecisionTTL. The lock must be held.
+func (c *coordinator) recordDecision(addr common.Address, decision Decision, reason string) {
+	c.recordDecisionInCycle(addr, decision, reason, c.cycle)
+}
+
+func (c *coordinator) recordDecisionInCycle(addr common.Address, decision Decision, reason string, cycle uint64) {
+	if c.cfg.decisionTTL <= 0 {
+		return
+	}
+	c.decisions[addr] = decisionRecord{decision: decision, reason: reason, cycle: cycle, time: c.cfg.clock.Now()}
+}
+
+// skip records that the game was skipped for the reason given. The lock must be held.
+func (c *coordinator) skip(addr common.Address, reason string) {
+	c.recordDecision(addr, DecisionSkipped, reason)
+}
+
+// skipBatch records that all the games in a batch were skipped before the cycle started.
+func (c *coordinator) skipBatch(games []types.GameMetadata, reason string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, game := range games {
+		c.recordDecisionInCycle(game.Proxy, DecisionSkipped, reason, c.cycle+1)
+	}
+}
+
+// pruneDecisions removes decisions recorded more than the TTL set by WithDecisionTTL ago. The lock must be held.
+func (c *coordinator) pruneDecisions() {
+	now := c.cfg.clock.Now()
+	for addr, record := range c.decisions {
+		if now.Sub(record.time) > c.cfg.decisionTTL {
+			delete(c.decisions, addr)
+		}
+	}
+}
+
+// LastDecision returns the most recent decision made when considering the game for progression, the reason for
+// it and the scheduling cycle in which it was made, to explain why a game was or wasn't progressed.
+// Decisions made before a cycle starts, for invalid games or batches rejected by the gate set by WithBatchGate,
+// report the number of the cycle that was about to start. The reason is empty for DecisionScheduled.
+// Returns an empty Decision if no decision has been recorded for the game within the TTL set by WithDecisionTTL.
+func (s *Scheduler) LastDecision(addr common.Address) (Decision, string, uint64) {
+	c := s.coordinator
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	record, ok := c.decisions[addr]
+	if !ok {
+		return "", "", 0
+	}
+	return record.decision, record.reason, record.cycle
+}
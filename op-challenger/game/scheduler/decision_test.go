@@ -0,0 +1,139 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecisionReasons(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	ctx := context.Background()
+	scheduled := common.Address{0xaa}
+	notReady := common.Address{0xbb}
+	resolved := common.Address{0xcc}
+	failing := common.Address{0xdd}
+	games.createCompleted = resolved
+	games.creationFails = failing
+	c.cfg.readinessCheck = func(ctx context.Context, addr common.Address) (bool, error) {
+		return addr != notReady, nil
+	}
+
+	require.Error(t, c.schedule(ctx, asGames(scheduled, notReady, resolved, failing, common.Address{}), 0))
+	requireDecision(t, c, scheduled, DecisionScheduled, "", 1)
+	requireDecision(t, c, notReady, DecisionSkipped, SkipReasonNotReady, 1)
+	requireDecision(t, c, resolved, DecisionSkipped, SkipReasonResolved, 1)
+	requireDecision(t, c, common.Address{}, DecisionSkipped, SkipReasonInvalid, 1)
+	record := c.decisions[failing]
+	require.Equal(t, DecisionFailed, record.decision)
+	require.Contains(t, record.reason, "failed to create game player")
+
+	// Still in flight in the next cycle
+	require.NoError(t, c.schedule(ctx, asGames(scheduled), 1))
+	requireDecision(t, c, scheduled, DecisionSkipped, SkipReasonInFlight, 2)
+
+	// Cooling down once the result is processed
+	c.cfg.actionCooldown = time.Hour
+	games.created[scheduled].ActionTakenValue = true
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.NoError(t, c.schedule(ctx, asGames(scheduled), 2))
+	requireDecision(t, c, scheduled, DecisionSkipped, SkipReasonCoolingDown, 3)
+
+	// Gated batches are recorded against the cycle that would have started
+	c.cfg.batchGate = func(ctx context.Context, games []common.Address) (bool, error) {
+		return false, nil
+	}
+	require.NoError(t, c.schedule(ctx, asGames(scheduled), 3))
+	requireDecision(t, c, scheduled, DecisionSkipped, SkipReasonGated, 4)
+}
+
+func TestDecisionDroppedAndDependency(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 1)
+	c.cfg.queueFullStrategy = QueueFullDrop
+	ctx := context.Background()
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	dependent := common.Address{0xcc}
+	WithDependencies(map[common.Address][]common.Address{dependent: {game1}})(&c.cfg)
+
+	require.NoError(t, c.schedule(ctx, asGames(game1, game2, dependent), 0))
+	require.Len(t, workQueue, 1)
+	requireDecision(t, c, game1, DecisionScheduled, "", 1)
+	requireDecision(t, c, game2, DecisionSkipped, SkipReasonDropped, 1)
+	record := c.decisions[dependent]
+	require.Equal(t, DecisionSkipped, record.decision)
+	require.Contains(t, record.reason, game1.Hex())
+}
+
+func TestDecisionTTL(t *testing.T) {
+	c, _, _, _, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	c.cfg.decisionTTL = time.Minute
+	ctx := context.Background()
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+
+	require.NoError(t, c.schedule(ctx, asGames(game1), 0))
+	require.Contains(t, c.decisions, game1)
+
+	cl.AdvanceTime(time.Minute)
+	require.NoError(t, c.schedule(ctx, asGames(game2), 1))
+	require.Contains(t, c.decisions, game1, "should keep decisions until the TTL has passed")
+
+	cl.AdvanceTime(time.Second)
+	require.NoError(t, c.schedule(ctx, asGames(game2), 2))
+	require.NotContains(t, c.decisions, game1)
+	require.Contains(t, c.decisions, game2)
+}
+
+func TestDecisionsDisabled(t *testing.T) {
+	c, _, _, _, _, _ := setupCoordinatorTest(t, 10)
+	WithDecisionTTL(0)(&c.cfg)
+	require.NoError(t, c.schedule(context.Background(), asGames(common.Address{0xaa}), 0))
+	require.Empty(t, c.decisions)
+}
+
+func TestLastDecision(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{StatusValue: types.GameStatusInProgress}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+	game := common.Address{0xaa}
+
+	decision, reason, cycle := s.LastDecision(game)
+	require.Empty(t, decision)
+	require.Empty(t, reason)
+	require.Zero(t, cycle)
+
+	require.NoError(t, s.Schedule(asGames(game), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	decision, reason, cycle = s.LastDecision(game)
+	require.Equal(t, DecisionScheduled, decision)
+	require.Empty(t, reason)
+	require.Equal(t, uint64(1), cycle)
+}
+
+func requireDecision(t *testing.T, c *coordinator, addr common.Address, decision Decision, reason string, cycle uint64) {
+	t.Helper()
+	record, ok := c.decisions[addr]
+	require.True(t, ok, "no decision recorded for %v", addr)
+	require.Equal(t, decision, record.decision, "decision for %v", addr)
+	require.Equal(t, reason, record.reason, "reason for %v", addr)
+	require.Equal(t, cycle, record.cycle, "cycle for %v", addr)
+}
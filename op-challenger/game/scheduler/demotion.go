@@ -0,0 +1,21 @@
+package scheduler
+
+// failureDemotion lowers the scheduling priority of games that repeatedly fail to progress.
+// Once a game has failed threshold consecutive times, its priority is lowered by amount for each
+// consecutive failure from then on.
+type failureDemotion struct {
+	threshold uint
+	amount    uint
+}
+
+func (f failureDemotion) enabled() bool {
+	return f.threshold > 0 && f.amount > 0
+}
+
+// priority returns the scheduling priority of a game with the specified number of consecutive failures.
+func (f failureDemotion) priority(failures uint) int {
+	if !f.enabled() || failures < f.threshold {
+		return 0
+	}
+	return -int((failures - f.threshold + 1) * f.amount)
+}
@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailureDemotionPriority(t *testing.T) {
+	demotion := failureDemotion{threshold: 3, amount: 2}
+	require.Equal(t, 0, demotion.priority(0))
+	require.Equal(t, 0, demotion.priority(2))
+	require.Equal(t, -2, demotion.priority(3))
+	require.Equal(t, -4, demotion.priority(4))
+
+	require.Equal(t, 0, failureDemotion{threshold: 0, amount: 2}.priority(10))
+	require.Equal(t, 0, failureDemotion{threshold: 3, amount: 0}.priority(10))
+}
+
+func TestDemoteRepeatedlyFailingGames(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.failureDemotion = failureDemotion{threshold: 2, amount: 1}
+	failingGame := common.Address{0xaa}
+	healthyGame1 := common.Address{0xbb}
+	healthyGame2 := common.Address{0xcc}
+	ctx := context.Background()
+
+	runCycle := func(block uint64) []common.Address {
+		require.NoError(t, c.schedule(ctx, asGames(failingGame, healthyGame1, healthyGame2), block))
+		var order []common.Address
+		for len(workQueue) > 0 {
+			j := <-workQueue
+			order = append(order, j.addr)
+			require.NoError(t, c.processResult(runJob(ctx, j)))
+		}
+		return order
+	}
+
+	require.Equal(t, []common.Address{failingGame, healthyGame1, healthyGame2}, runCycle(0))
+	games.created[failingGame].ProgressErr = errors.New("rpc timeout")
+	require.Equal(t, []common.Address{failingGame, healthyGame1, healthyGame2}, runCycle(1))
+	require.Equal(t, []common.Address{failingGame, healthyGame1, healthyGame2}, runCycle(2))
+
+	// Failed twice so is now demoted behind the healthy games but still progressed
+	require.Equal(t, []common.Address{healthyGame1, healthyGame2, failingGame}, runCycle(3))
+	require.Equal(t, []common.Address{healthyGame1, healthyGame2, failingGame}, runCycle(4))
+
+	// Priority is restored once the game succeeds
+	games.created[failingGame].ProgressErr = nil
+	require.Equal(t, []common.Address{healthyGame1, healthyGame2, failingGame}, runCycle(5))
+	require.Equal(t, []common.Address{failingGame, healthyGame1, healthyGame2}, runCycle(6))
+}
+
+func TestDemotedGamesDoNotStarveOthersWhenQueueFull(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 1)
+	c.cfg.failureDemotion = failureDemotion{threshold: 1, amount: 1}
+	failingGame := common.Address{0xaa}
+	healthyGame := common.Address{0xbb}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(failingGame), 0))
+	games.created[failingGame].ProgressErr = errors.New("rpc timeout")
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+
+	// Only one slot in the queue and it goes to the healthy game
+	require.NoError(t, c.schedule(ctx, asGames(failingGame, healthyGame), 1))
+	require.Equal(t, healthyGame, (<-workQueue).addr)
+}
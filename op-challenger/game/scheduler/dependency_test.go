@@ -0,0 +1,76 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleDependentAfterPrerequisiteSucceeds(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	gameA := common.Address{0xaa}
+	gameB := common.Address{0xbb}
+	c.cfg.dependencies = map[common.Address][]common.Address{gameB: {gameA}}
+	ctx := context.Background()
+
+	// B is only scheduled once A has been successfully progressed
+	require.Equal(t, []common.Address{gameA}, runDependencyCycle(t, c, workQueue, 0, gameA, gameB))
+	require.Equal(t, []common.Address{gameA, gameB}, runDependencyCycle(t, c, workQueue, 1, gameA, gameB))
+	require.Empty(t, c.states[gameB].skipReason)
+
+	// B waits for a prerequisite that isn't known
+	c.cfg.dependencies[gameB] = []common.Address{{0xcc}}
+	require.NoError(t, c.schedule(ctx, asGames(gameB), 2))
+	require.Empty(t, workQueue)
+	require.Contains(t, c.states[gameB].skipReason, "awaiting prerequisite")
+}
+
+func TestSkipDependentWhenPrerequisiteFails(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	gameA := common.Address{0xaa}
+	gameB := common.Address{0xbb}
+	c.cfg.dependencies = map[common.Address][]common.Address{gameB: {gameA}}
+
+	require.Equal(t, []common.Address{gameA}, runDependencyCycle(t, c, workQueue, 0, gameA, gameB))
+	games.created[gameA].ProgressErr = errors.New("boom")
+	require.Equal(t, []common.Address{gameA, gameB}, runDependencyCycle(t, c, workQueue, 1, gameA, gameB))
+
+	// A failed so B is skipped with a reason
+	require.Equal(t, []common.Address{gameA}, runDependencyCycle(t, c, workQueue, 2, gameA, gameB))
+	require.Contains(t, c.states[gameB].skipReason, "failed")
+	exported := c.exportState(newInFlightTracker(c.cfg.clock, 1))
+	require.Equal(t, c.states[gameB].skipReason, exported.Games[1].SkipReason)
+
+	// Once A recovers, B is scheduled again
+	games.created[gameA].ProgressErr = nil
+	require.Equal(t, []common.Address{gameA}, runDependencyCycle(t, c, workQueue, 3, gameA, gameB))
+	require.Equal(t, []common.Address{gameA, gameB}, runDependencyCycle(t, c, workQueue, 4, gameA, gameB))
+}
+
+func TestResolvedPrerequisiteSatisfiesDependency(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	gameA := common.Address{0xaa}
+	gameB := common.Address{0xbb}
+	games.createCompleted = gameA
+	c.cfg.dependencies = map[common.Address][]common.Address{gameB: {gameA}}
+
+	require.Equal(t, []common.Address{gameB}, runDependencyCycle(t, c, workQueue, 0, gameA, gameB))
+	require.Equal(t, types.GameStatusDefenderWon, c.states[gameA].status)
+}
+
+// runDependencyCycle schedules the games, processes all results and returns the games that were progressed.
+func runDependencyCycle(t *testing.T, c *coordinator, workQueue <-chan job, block uint64, games ...common.Address) []common.Address {
+	ctx := context.Background()
+	require.NoError(t, c.schedule(ctx, asGames(games...), block))
+	var progressed []common.Address
+	for len(workQueue) > 0 {
+		j := <-workQueue
+		progressed = append(progressed, j.addr)
+		require.NoError(t, c.processResult(runJob(ctx, j)))
+	}
+	return progressed
+}
@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Names of the disk operations reported via DiskMetricer.
+const (
+	DiskOpDirForGame = "dir"
+	DiskOpRemove     = "remove"
+	DiskOpWrite      = "write"
+	DiskOpRead       = "read"
+	DiskOpDelete     = "delete"
+)
+
+type DiskMetricer interface {
+	RecordDiskOp(op string, d time.Duration)
+}
+
+// instrumentedDisk is a DiskManager decorator that records the duration of each operation.
+type instrumentedDisk struct {
+	disk  DiskManager
+	m     DiskMetricer
+	clock clock.Clock
+}
+
+var _ DiskManager = (*instrumentedDisk)(nil)
+
+func newInstrumentedDisk(disk DiskManager, m DiskMetricer, cl clock.Clock) *instrumentedDisk {
+	return &instrumentedDisk{disk: disk, m: m, clock: cl}
+}
+
+func (d *instrumentedDisk) DirForGame(addr common.Address) string {
+	defer d.record(DiskOpDirForGame, d.clock.Now())
+	return d.disk.DirForGame(addr)
+}
+
+func (d *instrumentedDisk) RemoveAllExcept(addrs []common.Address) error {
+	defer d.record(DiskOpRemove, d.clock.Now())
+	return d.disk.RemoveAllExcept(addrs)
+}
+
+func (d *instrumentedDisk) record(op string, start time.Time) {
+	d.m.RecordDiskOp(op, d.clock.Since(start))
+}
+
+// instrumentedStateStore is a StateStore decorator that records the duration of each operation.
+type instrumentedStateStore struct {
+	store StateStore
+	m     DiskMetricer
+	clock clock.Clock
+}
+
+var _ FlushableStateStore = (*instrumentedStateStore)(nil)
+
+func newInstrumentedStateStore(store StateStore, m DiskMetricer, cl clock.Clock) *instrumentedStateStore {
+	return &instrumentedStateStore{store: store, m: m, clock: cl}
+}
+
+func (s *instrumentedStateStore) Save(ctx context.Context, game common.Address, key string, data []byte) error {
+	defer s.record(DiskOpWrite, s.clock.Now())
+	return s.store.Save(ctx, game, key, data)
+}
+
+func (s *instrumentedStateStore) Load(ctx context.Context, game common.Address, key string) ([]byte, error) {
+	defer s.record(DiskOpRead, s.clock.Now())
+	return s.store.Load(ctx, game, key)
+}
+
+func (s *instrumentedStateStore) Delete(ctx context.Context, game common.Address, key string) error {
+	defer s.record(DiskOpDelete, s.clock.Now())
+	return s.store.Delete(ctx, game, key)
+}
+
+// Flush persists the buffered writes of the underlying store if it implements FlushableStateStore.
+func (s *instrumentedStateStore) Flush(ctx context.Context) error {
+	if store, ok := s.store.(FlushableStateStore); ok {
+		return store.Flush(ctx)
+	}
+	return nil
+}
+
+func (s *instrumentedStateStore) record(op string, start time.Time) {
+	s.m.RecordDiskOp(op, s.clock.Since(start))
+}
@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstrumentedDisk(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	m := &diskOpMetrics{}
+	disk := newInstrumentedDisk(&slowDiskManager{clock: cl, delay: 2 * time.Second}, m, cl)
+
+	require.Equal(t, "/games/"+common.Address{0xaa}.Hex(), disk.DirForGame(common.Address{0xaa}))
+	require.NoError(t, disk.RemoveAllExcept(nil))
+	require.Equal(t, []recordedDiskOp{
+		{op: DiskOpDirForGame, d: 2 * time.Second},
+		{op: DiskOpRemove, d: 2 * time.Second},
+	}, m.recorded())
+}
+
+func TestInstrumentedStateStore(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	m := &diskOpMetrics{}
+	store := newInstrumentedStateStore(NewDiskStateStore(&tempDirDiskManager{dir: t.TempDir()}, ""), m, cl)
+	ctx := context.Background()
+	game := common.Address{0xaa}
+
+	require.NoError(t, store.Save(ctx, game, "key", []byte("data")))
+	_, err := store.Load(ctx, game, "key")
+	require.NoError(t, err)
+	require.NoError(t, store.Delete(ctx, game, "key"))
+	_, err = store.Load(ctx, game, "key")
+	require.ErrorIs(t, err, ErrStateNotFound)
+	require.Equal(t, []string{DiskOpWrite, DiskOpRead, DiskOpDelete, DiskOpRead}, m.ops())
+}
+
+func TestSchedulerRecordsDiskOps(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	m := &diskOpMetrics{}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	games := &createdGames{t: t, created: make(map[common.Address]*test.StubGamePlayer)}
+	s := NewScheduler(logger, m, disk, 1, games.CreateGame, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	ops := m.ops()
+	require.Contains(t, ops, DiskOpDirForGame)
+	require.Contains(t, ops, DiskOpRemove)
+}
+
+type slowDiskManager struct {
+	clock *clock.DeterministicClock
+	delay time.Duration
+}
+
+func (s *slowDiskManager) DirForGame(addr common.Address) string {
+	s.clock.AdvanceTime(s.delay)
+	return "/games/" + addr.Hex()
+}
+
+func (s *slowDiskManager) RemoveAllExcept(_ []common.Address) error {
+	s.clock.AdvanceTime(s.delay)
+	return nil
+}
+
+type recordedDiskOp struct {
+	op string
+	d  time.Duration
+}
+
+type diskOpMetrics struct {
+	metrics.NoopMetricsImpl
+	lock sync.Mutex
+	log  []recordedDiskOp
+}
+
+func (m *diskOpMetrics) RecordDiskOp(op string, d time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.log = append(m.log, recordedDiskOp{op: op, d: d})
+}
+
+func (m *diskOpMetrics) recorded() []recordedDiskOp {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return slices.Clone(m.log)
+}
+
+func (m *diskOpMetrics) ops() []string {
+	var ops []string
+	for _, r := range m.recorded() {
+		ops = append(ops, r.op)
+	}
+	return ops
+}
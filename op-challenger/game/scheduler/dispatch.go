@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"sync/atomic"
+)
+
+// jobDispatcher takes jobs from the job queue as soon as they are enqueued and hands the most urgent waiting job to
+// the next free worker, so urgent games aren't held up behind a backlog of jobs enqueued before them, see
+// WithPriorityDispatch. Jobs are ordered by compareJobPriority, with jobs of equal priority dispatched in the order
+// they were enqueued.
+type jobDispatcher struct {
+	in <-chan job
+	// out is unbuffered so a job is only chosen once a worker is ready to progress it.
+	out chan job
+	// limit is the maximum number of jobs held waiting for a worker. Once reached, further jobs wait in the job
+	// queue so backpressure still applies.
+	limit int
+	// held is the number of jobs currently waiting for a worker.
+	held atomic.Int64
+}
+
+func newJobDispatcher(in <-chan job, limit int) *jobDispatcher {
+	return &jobDispatcher{in: in, out: make(chan job), limit: max(limit, 1)}
+}
+
+// run dispatches jobs until ctx is done. Jobs still waiting when it returns are abandoned along with those left in
+// the job queue.
+func (d *jobDispatcher) run(ctx context.Context) {
+	var waiting jobHeap
+	for {
+		var in <-chan job
+		if waiting.Len() < d.limit {
+			in = d.in
+		}
+		var out chan<- job
+		var next job
+		if waiting.Len() > 0 {
+			out = d.out
+			next = waiting.entries[0].job
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case j := <-in:
+			waiting.seq++
+			heap.Push(&waiting, jobHeapEntry{job: j, seq: waiting.seq})
+		case out <- next:
+			heap.Pop(&waiting)
+		}
+		d.held.Store(int64(waiting.Len()))
+	}
+}
+
+// Len returns the number of jobs waiting for a worker. A nil jobDispatcher holds no jobs.
+func (d *jobDispatcher) Len() int {
+	if d == nil {
+		return 0
+	}
+	return int(d.held.Load())
+}
+
+// Cap returns the maximum number of jobs held waiting for a worker. A nil jobDispatcher holds no jobs.
+func (d *jobDispatcher) Cap() int {
+	if d == nil {
+		return 0
+	}
+	return d.limit
+}
+
+type jobHeapEntry struct {
+	job job
+	// seq is the order the job was received in, used to break ties between jobs of equal priority.
+	seq uint64
+}
+
+// jobHeap is a min-heap of jobs ordered by compareJobPriority, for use with container/heap.
+type jobHeap struct {
+	entries []jobHeapEntry
+	seq     uint64
+}
+
+func (h *jobHeap) Len() int {
+	return len(h.entries)
+}
+
+func (h *jobHeap) Less(i, j int) bool {
+	if c := compareJobPriority(h.entries[i].job, h.entries[j].job); c != 0 {
+		return c < 0
+	}
+	return h.entries[i].seq < h.entries[j].seq
+}
+
+func (h *jobHeap) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+}
+
+func (h *jobHeap) Push(x any) {
+	h.entries = append(h.entries, x.(jobHeapEntry))
+}
+
+func (h *jobHeap) Pop() any {
+	last := h.entries[len(h.entries)-1]
+	h.entries[len(h.entries)-1] = jobHeapEntry{}
+	h.entries = h.entries[:len(h.entries)-1]
+	return last
+}
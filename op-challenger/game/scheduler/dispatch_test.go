@@ -0,0 +1,172 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobDispatcherOrdersWaitingJobs(t *testing.T) {
+	in := make(chan job, 10)
+	d := newJobDispatcher(in, 3)
+	idle := job{addr: common.Address{0x01}}
+	deadline := job{addr: common.Address{0x02}, deadline: time.Unix(1000, 0)}
+	atRisk := job{addr: common.Address{0x03}, claimsAtRisk: true}
+	late := job{addr: common.Address{0x04}, claimsAtRisk: true}
+	in <- idle
+	in <- deadline
+	in <- atRisk
+	in <- late
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.run(ctx)
+
+	require.Eventually(t, func() bool {
+		return d.Len() == 3
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Len(t, in, 1, "should leave jobs in the queue once the limit is reached")
+	require.Equal(t, 3, d.Cap())
+
+	order := []common.Address{readWithTimeout(t, d.out).addr}
+	// The last job isn't considered until there is space for it, then jumps ahead of the remaining jobs
+	require.Eventually(t, func() bool {
+		return d.Len() == 3
+	}, 10*time.Second, 10*time.Millisecond)
+	for i := 0; i < 3; i++ {
+		order = append(order, readWithTimeout(t, d.out).addr)
+	}
+	require.Equal(t, []common.Address{atRisk.addr, late.addr, deadline.addr, idle.addr}, order)
+	require.Eventually(t, func() bool {
+		return d.Len() == 0
+	}, 10*time.Second, 10*time.Millisecond)
+
+	var disabled *jobDispatcher
+	require.Zero(t, disabled.Len())
+	require.Zero(t, disabled.Cap())
+}
+
+func TestJobDispatcherPreservesOrderOfEqualPriority(t *testing.T) {
+	in := make(chan job, 10)
+	d := newJobDispatcher(in, 10)
+	for i := 0; i < 5; i++ {
+		in <- job{addr: common.Address{byte(i)}}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.Eventually(t, func() bool {
+		return len(in) == 5
+	}, 10*time.Second, 10*time.Millisecond)
+	go d.run(ctx)
+	require.Eventually(t, func() bool {
+		return d.Len() == 5
+	}, 10*time.Second, 10*time.Millisecond)
+	for i := 0; i < 5; i++ {
+		require.Equal(t, common.Address{byte(i)}, readWithTimeout(t, d.out).addr)
+	}
+}
+
+func TestClaimsAtRiskCarriedToNextJob(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	quiet := common.Address{0xaa}
+	risky := common.Address{0xbb}
+	c.createPlayer = func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &riskPlayer{
+			StubGamePlayer: &test.StubGamePlayer{Addr: game.Proxy, StatusValue: types.GameStatusInProgress},
+			atRisk:         game.Proxy == risky,
+		}, nil
+	}
+	ctx := context.Background()
+
+	// Risk isn't known until the games have been progressed
+	require.NoError(t, c.schedule(ctx, asGames(quiet, risky), 0))
+	for _, addr := range []common.Address{quiet, risky} {
+		j := <-workQueue
+		require.Equal(t, addr, j.addr)
+		require.False(t, j.claimsAtRisk)
+		require.NoError(t, c.processResult(runJob(ctx, j)))
+	}
+
+	require.NoError(t, c.schedule(ctx, asGames(quiet, risky), 1))
+	j := <-workQueue
+	require.Equal(t, risky, j.addr, "should dispatch game with claims at risk first")
+	require.True(t, j.claimsAtRisk)
+	require.Equal(t, quiet, (<-workQueue).addr)
+}
+
+func TestPriorityDispatch(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	release := make(chan struct{}, 1)
+	progressed := make(chan common.Address, 10)
+	blocker := common.Address{0x01}
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		var p GamePlayer = &riskPlayer{
+			StubGamePlayer: &test.StubGamePlayer{Addr: game.Proxy, StatusValue: types.GameStatusInProgress},
+			atRisk:         game.Proxy == common.Address{0xcc},
+			progressed:     progressed,
+		}
+		if game.Proxy == blocker {
+			p = &blockingPlayer{release: release}
+		}
+		return p, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, WithPriorityDispatch())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	// Learn which games have claims at risk
+	release <- struct{}{}
+	games := []common.Address{{0xaa}, {0xcc}}
+	require.NoError(t, s.Schedule(asGames(append([]common.Address{blocker}, games...)...), 0))
+	for range games {
+		readWithTimeout(t, progressed)
+	}
+	require.NoError(t, s.WaitIdle(ctx))
+
+	// Occupy the only worker while the games are enqueued one at a time behind it
+	require.NoError(t, s.ForceSchedule(ctx, blocker))
+	require.Eventually(t, func() bool {
+		return s.activeWorkers.Load() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	for i, game := range games {
+		require.NoError(t, s.ForceSchedule(ctx, game))
+		require.Eventually(t, func() bool {
+			return s.dispatcher.Len() == i+1
+		}, 10*time.Second, 10*time.Millisecond)
+	}
+	require.Greater(t, s.Pressure(), 0.0)
+	release <- struct{}{}
+
+	require.Equal(t, common.Address{0xcc}, readWithTimeout(t, progressed), "should progress game with claims at risk first")
+	require.Equal(t, common.Address{0xaa}, readWithTimeout(t, progressed))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.True(t, s.EffectiveConfig().PriorityDispatch)
+}
+
+type riskPlayer struct {
+	*test.StubGamePlayer
+	atRisk     bool
+	progressed chan common.Address
+}
+
+func (p *riskPlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	status := p.StubGamePlayer.ProgressGame(ctx)
+	if p.progressed != nil {
+		p.progressed <- p.Addr
+	}
+	return status
+}
+
+func (p *riskPlayer) ClaimsAtRisk() bool {
+	return p.atRisk
+}
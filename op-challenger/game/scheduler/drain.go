@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidDrainTarget = errors.New("invalid drain target")
+	ErrInvalidConcurrency = errors.New("invalid concurrency")
+)
+
+type WorkerPoolMetricer interface {
+	RecordWorkerPoolSize(n uint)
+}
+
+// DrainTo gracefully reduces the number of workers to target, for example to shed load during a rolling deploy
+// while still progressing urgent games. Surplus workers are retired once they finish their current job and the
+// remaining workers continue to serve as normal. Any ramp up in progress stops at target workers.
+// Returns once all surplus workers have stopped accepting jobs, or ctx.Err() if ctx is done first in which case
+// some surplus workers may still be running. Returns ErrInvalidDrainTarget if target is zero or more than the
+// current number of workers.
+func (s *Scheduler) DrainTo(ctx context.Context, target uint) error {
+	s.workersLock.Lock()
+	live := uint(s.liveWorkers.Load())
+	if target == 0 || target > live {
+		s.workersLock.Unlock()
+		return fmt.Errorf("%w: %v with %v workers", ErrInvalidDrainTarget, target, live)
+	}
+	s.drainedTo = target
+	surplus := live - target
+	s.workersLock.Unlock()
+	s.m.RecordWorkerPoolSize(target)
+
+	s.logger.Info("Draining workers", "from", live, "to", target)
+	for i := uint(0); i < surplus; i++ {
+		select {
+		case s.retire <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// canStartWorker returns true unless the number of workers currently running has reached the number configured,
+// as reduced by DrainTo or changed by SetConcurrency. The workersLock must be held.
+func (s *Scheduler) canStartWorker() bool {
+	live := uint(s.liveWorkers.Load())
+	return live < s.maxConcurrency && (s.drainedTo == 0 || live < s.drainedTo)
+}
+
+// Concurrency returns the configured number of workers, which is reduced by DrainTo, and the number of workers
+// currently running. While ramping up, or shortly after DrainTo or Close, fewer or more workers may be alive
+// than configured.
+func (s *Scheduler) Concurrency() (configured uint, alive uint) {
+	s.workersLock.Lock()
+	configured = s.maxConcurrency
+	if s.drainedTo != 0 {
+		configured = s.drainedTo
+	}
+	s.workersLock.Unlock()
+	return configured, uint(s.liveWorkers.Load())
+}
+
+// concurrencyRequest asks the scheduler loop to resize the worker pool, see SetConcurrency.
+type concurrencyRequest struct {
+	target uint
+	// surplus receives the number of workers to retire to reach the target.
+	surplus chan uint
+}
+
+// SetConcurrency changes the number of workers to target while the scheduler is running, for example to react to
+// a spike in the number of games without restarting. Workers are started immediately when growing the pool. When
+// shrinking, surplus workers are retired once they finish their current job as for DrainTo. Any ramp up in
+// progress stops at target workers. Unlike DrainTo, target may be higher than the number of workers the scheduler
+// was created with, replacing it as the configured concurrency. The job and result queues keep the size they were
+// created with. Returns once any surplus workers have stopped accepting jobs, or ctx.Err() if ctx is done first in
+// which case some surplus workers may still be running. Returns ErrInvalidConcurrency if target is zero.
+func (s *Scheduler) SetConcurrency(ctx context.Context, target uint) error {
+	if !s.started.Load() {
+		return ErrNotStarted
+	}
+	if target == 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidConcurrency, target)
+	}
+	req := concurrencyRequest{target: target, surplus: make(chan uint, 1)}
+	select {
+	case s.concurrencyRequests <- req:
+	case <-s.stopped:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	var surplus uint
+	select {
+	case surplus = <-req.surplus:
+	case <-s.stopped:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	for i := uint(0); i < surplus; i++ {
+		select {
+		case s.retire <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// handleConcurrency starts the workers needed to reach the requested concurrency, which must be done from the loop
+// so they are stopped along with it, and replies with the number of surplus workers for the caller to retire.
+func (s *Scheduler) handleConcurrency(ctx context.Context, req concurrencyRequest) {
+	s.workersLock.Lock()
+	live := uint(s.liveWorkers.Load())
+	from := s.maxConcurrency
+	if s.drainedTo != 0 {
+		from = s.drainedTo
+	}
+	s.maxConcurrency = req.target
+	s.drainedTo = 0
+	for ; live < req.target; live++ {
+		s.startWorker(ctx)
+	}
+	s.workersLock.Unlock()
+	s.m.RecordWorkerPoolSize(req.target)
+	s.logger.Info("Changing worker concurrency", "from", from, "to", req.target)
+	req.surplus <- live - req.target
+}
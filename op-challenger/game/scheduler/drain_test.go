@@ -0,0 +1,239 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDrainTo(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	calls := newConcurrencyTracker()
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &concurrencyPlayer{calls: calls}, nil
+	}
+	m := &executorMetrics{}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 100)}
+	s := NewScheduler(logger, m, disk, 8, createPlayer, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	var games []common.Address
+	for i := 0; i < 8; i++ {
+		games = append(games, common.Address{byte(i + 1)})
+	}
+	require.NoError(t, s.Schedule(asGames(games...), 0))
+	require.Eventually(t, func() bool {
+		return m.active.Load() == 8
+	}, 10*time.Second, 10*time.Millisecond, "all workers should be busy")
+
+	// Surplus workers are retired after their current job completes
+	drained := make(chan error, 1)
+	go func() {
+		drained <- s.DrainTo(ctx, 2)
+	}()
+	require.Never(t, func() bool {
+		return len(drained) > 0
+	}, 100*time.Millisecond, 10*time.Millisecond, "should wait for busy workers")
+	calls.release()
+	require.NoError(t, <-drained)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Eventually(t, func() bool {
+		return s.liveWorkers.Load() == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	require.EqualValues(t, 2, m.idle.Load())
+	require.Zero(t, m.active.Load())
+	require.Equal(t, uint(2), s.EffectiveConfig().DrainedTo)
+
+	// Work continues at the reduced level
+	calls.hold()
+	require.NoError(t, s.Schedule(asGames(games...), 1))
+	calls.releaseAt(t, 2)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.EqualValues(t, 2, calls.maxActive.Load())
+	require.EqualValues(t, 2, m.idle.Load())
+	require.Zero(t, m.active.Load())
+
+	require.ErrorIs(t, s.DrainTo(ctx, 3), ErrInvalidDrainTarget, "should not increase concurrency")
+	require.ErrorIs(t, s.DrainTo(ctx, 0), ErrInvalidDrainTarget, "should not drain to zero")
+}
+
+func TestDrainToStopsRampUp(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &blockingPlayer{}, nil
+	}
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	m := &executorMetrics{}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address)}
+	s := NewScheduler(logger, m, disk, 4, createPlayer, false, WithClock(cl), WithRampUp(3*time.Second))
+	s.Start(context.Background())
+	defer s.Close()
+
+	require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second), "should start ramp up ticker")
+	cl.AdvanceTime(time.Second)
+	require.Eventually(t, func() bool {
+		return m.idle.Load() == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, s.DrainTo(context.Background(), 2))
+
+	cl.AdvanceTime(2 * time.Second)
+	require.Never(t, func() bool {
+		return m.idle.Load() > 2
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+// concurrencyPlayer records the maximum number of players progressing games at once.
+type concurrencyPlayer struct {
+	blockingPlayer
+	calls *concurrencyTracker
+}
+
+func (p *concurrencyPlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	p.calls.call(ctx)
+	return types.GameStatusInProgress
+}
+
+func TestConcurrency(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	release := make(chan struct{})
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &blockingPlayer{release: release}, nil
+	}
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 100)}
+	s := NewScheduler(logger, &executorMetrics{}, disk, 4, createPlayer, false, WithClock(cl), WithRampUp(3*time.Second))
+	configured, alive := s.Concurrency()
+	require.Equal(t, uint(4), configured)
+	require.Zero(t, alive, "no workers before start")
+
+	s.Start(context.Background())
+	configured, alive = s.Concurrency()
+	require.Equal(t, uint(4), configured)
+	require.Equal(t, uint(1), alive, "should start with one worker when ramping up")
+
+	// Ramp up starts the remaining workers
+	require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second), "should start ramp up ticker")
+	for i := uint(2); i <= 4; i++ {
+		cl.AdvanceTime(time.Second)
+		requireConcurrency(t, s, 4, i)
+	}
+
+	// Resizing retires surplus workers
+	require.NoError(t, s.DrainTo(context.Background(), 2))
+	requireConcurrency(t, s, 2, 2)
+
+	require.NoError(t, s.Close())
+	requireConcurrency(t, s, 2, 0)
+}
+
+func requireConcurrency(t *testing.T, s *Scheduler, configured uint, alive uint) {
+	require.Eventually(t, func() bool {
+		c, a := s.Concurrency()
+		return c == configured && a == alive
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestSetConcurrency(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	calls := newConcurrencyTracker()
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &concurrencyPlayer{calls: calls}, nil
+	}
+	m := &poolSizeMetrics{}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 100)}
+	s := NewScheduler(logger, m, disk, 2, createPlayer, false)
+	require.ErrorIs(t, s.SetConcurrency(context.Background(), 4), ErrNotStarted)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+	require.EqualValues(t, 2, m.poolSize.Load())
+
+	var games []common.Address
+	for i := 0; i < 4; i++ {
+		games = append(games, common.Address{byte(i + 1)})
+	}
+	require.NoError(t, s.Schedule(asGames(games...), 0))
+	require.Eventually(t, func() bool {
+		return m.active.Load() == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// New workers pick up queued jobs straight away
+	require.NoError(t, s.SetConcurrency(ctx, 4))
+	require.Eventually(t, func() bool {
+		return m.active.Load() == 4
+	}, 10*time.Second, 10*time.Millisecond)
+	require.EqualValues(t, 4, m.poolSize.Load())
+	configured, alive := s.Concurrency()
+	require.Equal(t, uint(4), configured)
+	require.Equal(t, uint(4), alive)
+	require.Equal(t, uint(4), s.EffectiveConfig().MaxConcurrency)
+
+	// Surplus workers are retired after their current job completes
+	shrunk := make(chan error, 1)
+	go func() {
+		shrunk <- s.SetConcurrency(ctx, 1)
+	}()
+	require.Never(t, func() bool {
+		return len(shrunk) > 0
+	}, 100*time.Millisecond, 10*time.Millisecond, "should wait for busy workers")
+	calls.release()
+	require.NoError(t, <-shrunk)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Eventually(t, func() bool {
+		return s.liveWorkers.Load() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	require.EqualValues(t, 1, m.idle.Load())
+	require.EqualValues(t, 1, m.poolSize.Load())
+
+	// Work continues at the new level
+	calls.hold()
+	require.NoError(t, s.Schedule(asGames(games...), 1))
+	calls.releaseAt(t, 1)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.EqualValues(t, 1, calls.maxActive.Load())
+
+	require.ErrorIs(t, s.SetConcurrency(ctx, 0), ErrInvalidConcurrency)
+}
+
+func TestSetConcurrencyStopsRampUp(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &blockingPlayer{}, nil
+	}
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	m := &executorMetrics{}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address)}
+	s := NewScheduler(logger, m, disk, 4, createPlayer, false, WithClock(cl), WithRampUp(3*time.Second))
+	s.Start(context.Background())
+	defer s.Close()
+
+	require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second), "should start ramp up ticker")
+	require.NoError(t, s.SetConcurrency(context.Background(), 2))
+	require.EqualValues(t, 2, m.idle.Load())
+
+	cl.AdvanceTime(3 * time.Second)
+	require.Never(t, func() bool {
+		return m.idle.Load() > 2
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+type poolSizeMetrics struct {
+	executorMetrics
+	poolSize atomic.Uint32
+}
+
+func (m *poolSizeMetrics) RecordWorkerPoolSize(n uint) {
+	m.poolSize.Store(uint32(n))
+}
@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type DropMetricer interface {
+	RecordJobsDropped(n int)
+}
+
+// DropPolicy determines which jobs are dropped when a batch doesn't fit in the job queue with QueueFullDrop.
+type DropPolicy int
+
+const (
+	// DropLowestPriority drops the jobs with the lowest priority (see WithFailureDemotion) first, then those latest
+	// in the batch. This is the default.
+	DropLowestPriority DropPolicy = iota
+	// DropOldestSeen drops the jobs for the games that were first scheduled longest ago first.
+	DropOldestSeen
+	// DropLeastActive drops the jobs for the games with the lowest activity score (see WithActivityDecay) first.
+	DropLeastActive
+	// DropRandom drops randomly chosen jobs.
+	DropRandom
+)
+
+func (p DropPolicy) String() string {
+	switch p {
+	case DropLowestPriority:
+		return "lowest-priority"
+	case DropOldestSeen:
+		return "oldest-seen"
+	case DropLeastActive:
+		return "least-active"
+	case DropRandom:
+		return "random"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(p))
+	}
+}
+
+// shedJobs drops jobs chosen by the policy set by WithDropPolicy so that no more than capacity remain, returning
+// the remaining jobs in their original order. The lock must be held.
+func (c *coordinator) shedJobs(jobs []job, capacity int) []job {
+	excess := len(jobs) - max(capacity, 0)
+	if excess <= 0 {
+		return jobs
+	}
+	order := make([]int, len(jobs))
+	for i := range order {
+		order[i] = i
+	}
+	if c.cfg.dropPolicy == DropRandom {
+		c.dropRand.Shuffle(len(order), func(i, j int) {
+			order[i], order[j] = order[j], order[i]
+		})
+	} else {
+		slices.SortStableFunc(order, func(a, b int) int {
+			if r := c.compareDropOrder(jobs[a], jobs[b]); r != 0 {
+				return r
+			}
+			// Otherwise drop jobs later in the batch first.
+			return cmp.Compare(b, a)
+		})
+	}
+	drop := make(map[int]bool, excess)
+	dropped := make([]job, 0, excess)
+	games := make([]common.Address, 0, excess)
+	for _, i := range order[:excess] {
+		drop[i] = true
+		dropped = append(dropped, jobs[i])
+		games = append(games, jobs[i].addr)
+		c.tracer.Log(jobs[i].addr, "Dropping job to fit job queue", "policy", c.cfg.dropPolicy, "cycle", c.cycle)
+		c.skip(jobs[i].addr, SkipReasonDropped)
+	}
+	kept := make([]job, 0, len(jobs)-excess)
+	for i, j := range jobs {
+		if !drop[i] {
+			kept = append(kept, j)
+		}
+	}
+	c.logger.Warn("Job queue full, dropping jobs", "count", excess, "policy", c.cfg.dropPolicy, "cycle", c.cycle, "games", games)
+	c.m.RecordJobsDropped(excess)
+	c.releaseJobs(dropped)
+	return kept
+}
+
+// compareDropOrder returns a negative number if job a should be dropped before job b according to the policy,
+// a positive number if b should be dropped first or zero if the policy doesn't distinguish them.
+// The lock must be held.
+func (c *coordinator) compareDropOrder(a, b job) int {
+	switch c.cfg.dropPolicy {
+	case DropOldestSeen:
+		return cmp.Compare(c.firstSeenCycle(a.addr), c.firstSeenCycle(b.addr))
+	case DropLeastActive:
+		return cmp.Compare(c.activity(a.addr), c.activity(b.addr))
+	default:
+		return cmp.Compare(a.priority, b.priority)
+	}
+}
+
+func (c *coordinator) firstSeenCycle(addr common.Address) uint64 {
+	if entry, ok := c.firstSeen[addr]; ok {
+		return entry.cycle
+	}
+	return c.cycle
+}
+
+func (c *coordinator) activity(addr common.Address) float64 {
+	if state, ok := c.states[addr]; ok {
+		return state.activity
+	}
+	return 0
+}
+
+func newDropRand(cfg config) *rand.Rand {
+	if cfg.dropPolicy != DropRandom {
+		return nil
+	}
+	return rand.New(rand.NewSource(cfg.clock.Now().UnixNano()))
+}
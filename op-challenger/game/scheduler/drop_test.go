@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDropPolicies(t *testing.T) {
+	games := []common.Address{{0x01}, {0x02}, {0x03}, {0x04}, {0x05}}
+	tests := []struct {
+		policy DropPolicy
+		// setup prepares the game states after the first cycle, in which every game is progressed.
+		setup    func(c *coordinator)
+		batch    []common.Address
+		expected []common.Address
+	}{
+		{
+			policy: DropLowestPriority,
+			setup: func(c *coordinator) {
+				c.cfg.failureDemotion = failureDemotion{threshold: 1, amount: 1}
+				c.states[games[0]].progressFailures = 1
+				c.states[games[1]].progressFailures = 1
+			},
+			batch: games,
+			// Demoted games are dropped first, then the games latest in the batch.
+			expected: []common.Address{games[2], games[3]},
+		},
+		{
+			policy:   DropOldestSeen,
+			setup:    func(c *coordinator) {},
+			batch:    append([]common.Address{{0x06}, {0x07}}, games...),
+			expected: []common.Address{{0x06}, {0x07}},
+		},
+		{
+			policy: DropLeastActive,
+			setup: func(c *coordinator) {
+				for i, activity := range []float64{0.1, 0.9, 0.5, 0.2, 1} {
+					c.states[games[i]].activity = activity
+				}
+			},
+			batch:    games,
+			expected: []common.Address{games[1], games[4]},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.policy.String(), func(t *testing.T) {
+			c, workQueue, _, _, _, logs := setupCoordinatorTest(t, 5)
+			c.cfg.queueFullStrategy = QueueFullDrop
+			c.cfg.dropPolicy = test.policy
+			m := c.m.(*stubSchedulerMetrics)
+			ctx := context.Background()
+			require.NoError(t, c.schedule(ctx, asGames(games...), 0))
+			for len(workQueue) > 0 {
+				require.NoError(t, c.processResult(<-workQueue))
+			}
+			test.setup(c)
+
+			// Fill the job queue so there is only room for two jobs.
+			c.jobQueue <- job{}
+			c.jobQueue <- job{}
+			c.jobQueue <- job{}
+			require.NoError(t, c.schedule(ctx, asGames(test.batch...), 1))
+			for i := 0; i < 3; i++ {
+				<-workQueue
+			}
+			var progressed []common.Address
+			for len(workQueue) > 0 {
+				progressed = append(progressed, (<-workQueue).addr)
+			}
+			require.ElementsMatch(t, test.expected, progressed)
+			require.Equal(t, len(test.batch)-2, m.droppedJobs)
+			for _, addr := range test.batch {
+				require.Equal(t, contains(progressed, addr), c.states[addr].inflight, "dropped games should be released")
+			}
+			require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Job queue full, dropping jobs"), testlog.NewAttributesFilter("policy", test.policy.String())))
+		})
+	}
+}
+
+func TestDropRandom(t *testing.T) {
+	const cycles = 500
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 2)
+	c.cfg.queueFullStrategy = QueueFullDrop
+	c.cfg.dropPolicy = DropRandom
+	c.dropRand = rand.New(rand.NewSource(1))
+	m := c.m.(*stubSchedulerMetrics)
+	var addrs []common.Address
+	for i := 0; i < 10; i++ {
+		addrs = append(addrs, common.Address{byte(i + 1)})
+	}
+	counts := make(map[common.Address]int)
+	ctx := context.Background()
+	for i := uint64(0); i < cycles; i++ {
+		require.NoError(t, c.schedule(ctx, asGames(addrs...), i))
+		require.Len(t, workQueue, 2)
+		for len(workQueue) > 0 {
+			j := <-workQueue
+			counts[j.addr]++
+			require.NoError(t, c.processResult(j))
+		}
+	}
+	require.Equal(t, cycles*8, m.droppedJobs)
+	// Each game has a 1 in 5 chance of being kept each cycle
+	expected := cycles / 5
+	for _, addr := range addrs {
+		require.InDelta(t, expected, counts[addr], float64(expected)/2, "game %v", addr)
+	}
+}
+
+func TestDefaultDropPolicy(t *testing.T) {
+	require.Equal(t, DropLowestPriority, defaultConfig().dropPolicy)
+}
+
+func contains(addrs []common.Address, addr common.Address) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+package scheduler
+
+import (
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Config describes the settings currently in effect for a Scheduler, including any changes made at runtime.
+// See the corresponding SchedulerOption for the meaning of each setting.
+type Config struct {
+	MaxConcurrency uint
+	// LiveWorkers is the number of workers currently running, which is less than MaxConcurrency during ramp up or after DrainTo.
+	LiveWorkers int
+	// DrainedTo is the reduced number of workers set by DrainTo, or 0 if not drained.
+	DrainedTo            uint
+	AllowInvalidPrestate bool
+
+	RampUp       time.Duration
+	MaxFollowUps uint
+
+	MaxTracedGames int
+	// TracedGames are the games with tracing currently enabled, ordered by address.
+	TracedGames []common.Address
+
+	ActivityDecayFactor      float64
+	ActivityDecayMaxInterval uint64
+
+	CaptureSnapshots bool
+	ActionCooldown   time.Duration
+	MaxTotalJobs     uint64
+
+	QueueFullStrategy QueueFullStrategy
+
+	FailureDemotionThreshold uint
+	FailureDemotionAmount    uint
+
+	// ScheduleTransform is true if a schedule transform is set.
+	ScheduleTransform bool
+	ScheduleBuckets   int
+
+	GasBudget       uint64
+	GasBudgetWindow time.Duration
+
+	// Dependencies maps games to their prerequisite games, see WithDependencies.
+	Dependencies map[common.Address][]common.Address
+
+	ErrorLogWindow time.Duration
+
+	// ResultSink is true if a result sink is set.
+	ResultSink bool
+	// WorkerStateListener is true if a worker state listener is set.
+	WorkerStateListener bool
+
+	ResultsHighWater uint
+	ResultsLowWater  uint
+
+	GlobalBackoffThreshold float64
+	GlobalBackoffInitial   time.Duration
+	GlobalBackoffMax       time.Duration
+
+	ResolvedRetention time.Duration
+	RecentResults     int
+	RandomizeDispatch bool
+	// ReadinessCheck is true if a readiness check is set.
+	ReadinessCheck  bool
+	MaxTrackedGames int
+	// ResultClassifier is true if a result classifier is set.
+	ResultClassifier    bool
+	InvalidResultPolicy InvalidResultPolicy
+
+	DiskReconcileInterval time.Duration
+	DiskReconcileRepair   bool
+
+	MaxRetryAge time.Duration
+	// AuditSink is true if an audit sink is set.
+	AuditSink  bool
+	DropPolicy DropPolicy
+	// EventPublisher is true if an event publisher is set.
+	EventPublisher bool
+	UrgentWorkers  uint
+
+	InFlightWarnThreshold time.Duration
+	// BatchGate is true if a batch gate is set.
+	BatchGate bool
+	// StableOrder is true if batches are processed in a stable order.
+	StableOrder bool
+
+	MaxAcceptedBatchSize int
+
+	LatencyWindow      int
+	LatencyPercentiles []float64
+
+	// GameTypes holds the overrides of global settings for each game type, see WithGameTypeConfig.
+	GameTypes map[uint32]GameTypeConfig
+
+	PlayerInitPolicy         PlayerInitPolicy
+	PlayerInitBackoffInitial time.Duration
+	PlayerInitBackoffMax     time.Duration
+
+	UpstreamConcurrency uint
+	DecisionTTL         time.Duration
+	// GameFilter is true if a game filter is currently set, see SetGameFilter.
+	GameFilter bool
+	// DeadlineResolver is true if a deadline resolver is set.
+	DeadlineResolver bool
+
+	MemoryHighWater uint64
+	MemoryLowWater  uint64
+	MemoryForceGC   bool
+	// AckSink is true if an ack sink is set.
+	AckSink bool
+	// PriorityDispatch is true if waiting jobs are dispatched most urgent first, see WithPriorityDispatch.
+	PriorityDispatch bool
+
+	CheckpointPath     string
+	CheckpointInterval time.Duration
+
+	FailureBackoffInitial time.Duration
+	FailureBackoffMax     time.Duration
+	BreakerThreshold      uint
+	BreakerCooldown       time.Duration
+	ShadowMode            bool
+	JobTimeout            time.Duration
+	// ResolutionHandler is true if a handler is notified of resolved games, see WithResolutionHandler.
+	ResolutionHandler bool
+}
+
+// EffectiveConfig returns a copy of the settings currently in effect.
+func (s *Scheduler) EffectiveConfig() Config {
+	cfg := s.cfg
+	s.workersLock.Lock()
+	maxConcurrency, drainedTo := s.maxConcurrency, s.drainedTo
+	s.workersLock.Unlock()
+	return Config{
+		MaxConcurrency:           maxConcurrency,
+		LiveWorkers:              int(s.liveWorkers.Load()),
+		DrainedTo:                drainedTo,
+		AllowInvalidPrestate:     s.coordinator.allowInvalidPrestate,
+		RampUp:                   cfg.rampUp,
+		MaxFollowUps:             cfg.maxFollowUps,
+		MaxTracedGames:           cfg.maxTracedGames,
+		TracedGames:              s.coordinator.tracer.Games(),
+		ActivityDecayFactor:      cfg.activityDecay.factor,
+		ActivityDecayMaxInterval: cfg.activityDecay.maxInterval,
+		CaptureSnapshots:         cfg.captureSnapshots,
+		ActionCooldown:           cfg.actionCooldown,
+		MaxTotalJobs:             cfg.maxTotalJobs,
+		QueueFullStrategy:        cfg.queueFullStrategy,
+		FailureDemotionThreshold: cfg.failureDemotion.threshold,
+		FailureDemotionAmount:    cfg.failureDemotion.amount,
+		ScheduleTransform:        cfg.scheduleTransform != nil,
+		ScheduleBuckets:          cfg.scheduleBuckets,
+		GasBudget:                cfg.gasBudget,
+		GasBudgetWindow:          cfg.gasBudgetWindow,
+		Dependencies:             cloneDependencies(cfg.dependencies),
+		ErrorLogWindow:           cfg.errorLogWindow,
+		ResultSink:               cfg.resultSink != nil,
+		WorkerStateListener:      cfg.workerStateListener != nil,
+		ResultsHighWater:         uint(s.coordinator.backpressure.high),
+		ResultsLowWater:          uint(s.coordinator.backpressure.low),
+		GlobalBackoffThreshold:   cfg.backoffThreshold,
+		GlobalBackoffInitial:     cfg.backoffInitial,
+		GlobalBackoffMax:         cfg.backoffMax,
+		ResolvedRetention:        cfg.resolvedRetention,
+		RecentResults:            cfg.recentResults,
+		RandomizeDispatch:        cfg.randomizeDispatch,
+		ReadinessCheck:           cfg.readinessCheck != nil,
+		MaxTrackedGames:          cfg.maxTrackedGames,
+		ResultClassifier:         cfg.resultClassifier != nil,
+		InvalidResultPolicy:      cfg.invalidResultPolicy,
+		DiskReconcileInterval:    cfg.reconcileInterval,
+		DiskReconcileRepair:      cfg.reconcileRepair,
+		MaxRetryAge:              cfg.maxRetryAge,
+		AuditSink:                cfg.auditSink != nil,
+		DropPolicy:               cfg.dropPolicy,
+		EventPublisher:           cfg.eventPublisher != nil,
+		UrgentWorkers:            cfg.urgentWorkers,
+		InFlightWarnThreshold:    cfg.inFlightWarnThreshold,
+		BatchGate:                cfg.batchGate != nil,
+		StableOrder:              cfg.stableOrder != nil,
+		MaxAcceptedBatchSize:     cfg.maxAcceptedBatchSize,
+		LatencyWindow:            cfg.latencyWindow,
+		LatencyPercentiles:       slices.Clone(cfg.latencyPercentiles),
+		GameTypes:                cloneGameTypes(cfg.gameTypes),
+		PlayerInitPolicy:         cfg.playerInitPolicy,
+		PlayerInitBackoffInitial: cfg.playerInitBackoff.initial,
+		PlayerInitBackoffMax:     cfg.playerInitBackoff.max,
+		UpstreamConcurrency:      cfg.upstreamConcurrency,
+		DecisionTTL:              cfg.decisionTTL,
+		GameFilter:               s.coordinator.hasGameFilter(),
+		DeadlineResolver:         cfg.deadlineResolver != nil,
+		MemoryHighWater:          cfg.memoryHighWater,
+		MemoryLowWater:           min(cfg.memoryLowWater, cfg.memoryHighWater),
+		MemoryForceGC:            cfg.memoryForceGC,
+		AckSink:                  cfg.ackSink != nil,
+		PriorityDispatch:         cfg.priorityDispatch,
+		CheckpointPath:           cfg.checkpointPath,
+		CheckpointInterval:       cfg.checkpointInterval,
+		FailureBackoffInitial:    cfg.failureBackoff.initial,
+		FailureBackoffMax:        cfg.failureBackoff.max,
+		BreakerThreshold:         cfg.breakerThreshold,
+		BreakerCooldown:          cfg.breakerCooldown,
+		ShadowMode:               cfg.shadowMode,
+		JobTimeout:               cfg.jobTimeout,
+		ResolutionHandler:        cfg.resolutionHandler != nil,
+	}
+}
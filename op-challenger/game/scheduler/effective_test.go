@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveConfig(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 4, createPlayer, true,
+		WithMaxFollowUps(3),
+		WithActivityDecay(0.5, 8),
+		WithActionCooldown(time.Minute),
+		WithQueueFullStrategy(QueueFullDrop),
+		WithGasBudget(1000, time.Hour))
+
+	require.Equal(t, Config{
+		MaxConcurrency:           4,
+		AllowInvalidPrestate:     true,
+		MaxFollowUps:             3,
+		MaxTracedGames:           defaultMaxTracedGames,
+		TracedGames:              []common.Address{},
+		ActivityDecayFactor:      0.5,
+		ActivityDecayMaxInterval: 8,
+		ActionCooldown:           time.Minute,
+		QueueFullStrategy:        QueueFullDrop,
+		GasBudget:                1000,
+		GasBudgetWindow:          time.Hour,
+		ErrorLogWindow:           defaultErrorLogWindow,
+		LatencyWindow:            defaultLatencyWindow,
+		LatencyPercentiles:       defaultLatencyPercentiles,
+		DecisionTTL:              defaultDecisionTTL,
+	}, s.EffectiveConfig())
+}
+
+func TestEffectiveConfigReflectsRuntimeChanges(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{}, nil
+	}
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false, WithClock(cl), WithRampUp(time.Second))
+	s.Start(context.Background())
+	defer s.Close()
+
+	cfg := s.EffectiveConfig()
+	require.Equal(t, 1, cfg.LiveWorkers)
+	require.Empty(t, cfg.TracedGames)
+
+	require.NoError(t, s.TraceGame(common.Address{0xbb}, true))
+	require.NoError(t, s.TraceGame(common.Address{0xaa}, true))
+	cl.AdvanceTime(time.Second)
+	require.Eventually(t, func() bool {
+		return s.EffectiveConfig().LiveWorkers == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	cfg = s.EffectiveConfig()
+	require.Equal(t, []common.Address{{0xaa}, {0xbb}}, cfg.TracedGames)
+
+	// Returned config is a copy
+	cfg.TracedGames[0] = common.Address{0xcc}
+	require.Equal(t, []common.Address{{0xaa}, {0xbb}}, s.EffectiveConfig().TracedGames)
+}
@@ -0,0 +1,197 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// eventBufferSize is the number of events buffered for the publisher before further events are dropped.
+const eventBufferSize = 1000
+
+// EventType identifies a transition in the lifecycle of a game's jobs.
+type EventType string
+
+const (
+	// EventScheduled is published when a job to progress the game is created.
+	EventScheduled EventType = "scheduled"
+	// EventStarted is published when a worker starts progressing the game.
+	EventStarted EventType = "started"
+	// EventCompleted is published when the result of a successful progression is processed.
+	EventCompleted EventType = "completed"
+	// EventFailed is published when the result of a failed progression is processed.
+	EventFailed EventType = "failed"
+	// EventResolved is published when a progression finds the game has resolved.
+	EventResolved EventType = "resolved"
+	// EventAbandoned is published when the game is abandoned, see WithMaxRetryAge.
+	EventAbandoned EventType = "abandoned"
+	// EventStatusChanged is published when a progression finds the game's status has changed.
+	EventStatusChanged EventType = "status_changed"
+	// EventDefenderWon is published after EventResolved when the game resolved in favour of the defender.
+	EventDefenderWon EventType = "defender_won"
+	// EventChallengerWon is published after EventResolved when the game resolved in favour of the challenger.
+	EventChallengerWon EventType = "challenger_won"
+)
+
+// Event describes a lifecycle transition of a game, see WithEventPublisher.
+type Event struct {
+	Game common.Address `json:"game"`
+	Type EventType      `json:"type"`
+	Time time.Time      `json:"time"`
+	// Cycle is the scheduling cycle in which the job was created, or the current cycle for EventAbandoned.
+	Cycle uint64 `json:"cycle"`
+	// CorrelationID is the correlation id of the batch that scheduled the game, see ScheduleCorrelated.
+	CorrelationID string `json:"correlationId,omitempty"`
+	// Status is the game's new status for EventStatusChanged, or nil for other events.
+	Status *types.GameStatus `json:"status,omitempty"`
+	// Error describes why the progression failed for EventFailed, or is empty for other events.
+	Error string `json:"error,omitempty"`
+}
+
+// EventPublisher receives lifecycle events, for example to forward them to an external event bus.
+type EventPublisher interface {
+	Publish(event Event)
+}
+
+type EventMetricer interface {
+	RecordEventDropped()
+}
+
+// eventQueue forwards events to an EventPublisher, if set, and the subscribers added by Subscribe from its own
+// goroutine so that a slow publisher or subscriber doesn't delay the scheduler. Events are buffered and dropped if
+// the buffer is full. Events aren't queued while there is no publisher or subscriber.
+// A nil eventQueue discards events so callers don't need to check whether a publisher is set.
+type eventQueue struct {
+	logger    log.Logger
+	m         EventMetricer
+	clock     clock.Clock
+	publisher EventPublisher
+	queue     chan Event
+
+	// subscribersLock guards subscribers and closed, which is set once run returns and no more events will be
+	// delivered. subscriberCount is the number of subscribers, read without the lock by emit.
+	subscribersLock sync.Mutex
+	subscribers     map[chan Event]struct{}
+	closed          bool
+	subscriberCount atomic.Int32
+}
+
+func newEventQueue(logger log.Logger, m EventMetricer, cl clock.Clock, publisher EventPublisher) *eventQueue {
+	return &eventQueue{
+		logger:      logger,
+		m:           m,
+		clock:       cl,
+		publisher:   publisher,
+		queue:       make(chan Event, eventBufferSize),
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Emit queues an event of the specified type for the game without blocking. Safe for concurrent use.
+func (q *eventQueue) Emit(addr common.Address, eventType EventType, cycle uint64, correlationID string) {
+	q.emit(Event{Game: addr, Type: eventType, Cycle: cycle, CorrelationID: correlationID})
+}
+
+// EmitStatus queues an event of the specified type reporting the game's new status without blocking.
+func (q *eventQueue) EmitStatus(addr common.Address, eventType EventType, status types.GameStatus, cycle uint64, correlationID string) {
+	q.emit(Event{Game: addr, Type: eventType, Cycle: cycle, CorrelationID: correlationID, Status: &status})
+}
+
+// EmitFailed queues an EventFailed for the game reporting why its progression failed without blocking.
+func (q *eventQueue) EmitFailed(addr common.Address, err error, cycle uint64, correlationID string) {
+	q.emit(Event{Game: addr, Type: EventFailed, Cycle: cycle, CorrelationID: correlationID, Error: err.Error()})
+}
+
+func (q *eventQueue) emit(event Event) {
+	if q == nil || (q.publisher == nil && q.subscriberCount.Load() == 0) {
+		return
+	}
+	event.Time = q.clock.Now()
+	select {
+	case q.queue <- event:
+	default:
+		q.m.RecordEventDropped()
+		q.logger.Warn("Event buffer full, dropping event", "game", event.Game, "type", event.Type)
+	}
+}
+
+// run sends queued events to the publisher and subscribers until ctx is done, then closes the subscribers' channels.
+func (q *eventQueue) run(ctx context.Context) {
+	defer q.closeSubscribers()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-q.queue:
+			if q.publisher != nil {
+				q.publisher.Publish(event)
+			}
+			q.deliver(event)
+		}
+	}
+}
+
+// deliver sends the event to each subscriber with room in its buffer, dropping it for the others.
+func (q *eventQueue) deliver(event Event) {
+	q.subscribersLock.Lock()
+	defer q.subscribersLock.Unlock()
+	for ch := range q.subscribers {
+		select {
+		case ch <- event:
+		default:
+			q.m.RecordEventDropped()
+			q.logger.Warn("Subscriber too slow, dropping event", "game", event.Game, "type", event.Type)
+		}
+	}
+}
+
+// subscribe adds a subscriber receiving events with a buffer of the specified size. The returned function removes
+// the subscriber and closes its channel.
+func (q *eventQueue) subscribe(buffer int) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+	q.subscribersLock.Lock()
+	defer q.subscribersLock.Unlock()
+	if q.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	q.subscribers[ch] = struct{}{}
+	q.subscriberCount.Add(1)
+	return ch, func() {
+		q.subscribersLock.Lock()
+		defer q.subscribersLock.Unlock()
+		if _, ok := q.subscribers[ch]; ok {
+			delete(q.subscribers, ch)
+			q.subscriberCount.Add(-1)
+			close(ch)
+		}
+	}
+}
+
+func (q *eventQueue) closeSubscribers() {
+	q.subscribersLock.Lock()
+	defer q.subscribersLock.Unlock()
+	q.closed = true
+	for ch := range q.subscribers {
+		delete(q.subscribers, ch)
+		close(ch)
+	}
+	q.subscriberCount.Store(0)
+}
+
+// Subscribe returns a channel that receives an Event at each transition in the lifecycle of a game, such as it
+// being scheduled, completing an update, changing status, being won by either side or failing, so components like
+// alerting, bond claiming or dashboards can react without polling the chain. Events are delivered in order from a
+// separate goroutine so a slow subscriber never stalls scheduling. Events are dropped for a subscriber, and the drop
+// recorded, once it falls buffer events behind. The channel is closed when the returned function is called or the
+// scheduler stops. Subscribers receive the same events as the publisher set by WithEventPublisher.
+func (s *Scheduler) Subscribe(buffer int) (<-chan Event, func()) {
+	return s.coordinator.events.subscribe(max(buffer, 1))
+}
@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventPublisher(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	publisher := &fakeEventPublisher{events: make(chan Event, 100)}
+	game := common.Address{0xaa}
+	player := &test.StubGamePlayer{StatusValue: types.GameStatusInProgress, ProgressErr: errors.New("boom")}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return player, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, WithClock(cl), WithEventPublisher(publisher))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	progress := func(block uint64) {
+		require.NoError(t, s.ScheduleCorrelated(asGames(game), block, "batch"))
+		require.NoError(t, s.WaitIdle(ctx))
+	}
+	progress(1)
+	player.ProgressErr = nil
+	progress(2)
+	player.StatusValue = types.GameStatusDefenderWon
+	progress(3)
+
+	defenderWon := types.GameStatusDefenderWon
+	expected := []Event{
+		{Game: game, Type: EventScheduled, Time: cl.Now(), Cycle: 1, CorrelationID: "batch"},
+		{Game: game, Type: EventStarted, Time: cl.Now(), Cycle: 1, CorrelationID: "batch"},
+		{Game: game, Type: EventFailed, Time: cl.Now(), Cycle: 1, CorrelationID: "batch", Error: "boom"},
+		{Game: game, Type: EventScheduled, Time: cl.Now(), Cycle: 2, CorrelationID: "batch"},
+		{Game: game, Type: EventStarted, Time: cl.Now(), Cycle: 2, CorrelationID: "batch"},
+		{Game: game, Type: EventCompleted, Time: cl.Now(), Cycle: 2, CorrelationID: "batch"},
+		{Game: game, Type: EventScheduled, Time: cl.Now(), Cycle: 3, CorrelationID: "batch"},
+		{Game: game, Type: EventStarted, Time: cl.Now(), Cycle: 3, CorrelationID: "batch"},
+		{Game: game, Type: EventCompleted, Time: cl.Now(), Cycle: 3, CorrelationID: "batch"},
+		{Game: game, Type: EventStatusChanged, Time: cl.Now(), Cycle: 3, CorrelationID: "batch", Status: &defenderWon},
+		{Game: game, Type: EventResolved, Time: cl.Now(), Cycle: 3, CorrelationID: "batch"},
+		{Game: game, Type: EventDefenderWon, Time: cl.Now(), Cycle: 3, CorrelationID: "batch"},
+	}
+	for i, event := range expected {
+		require.Equal(t, event, readWithTimeout(t, publisher.events), "event %v", i)
+	}
+	require.Equal(t, true, s.EffectiveConfig().EventPublisher)
+}
+
+func TestEventPublisherAbandoned(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	c.cfg.maxRetryAge = time.Minute
+	c.events = newEventQueue(c.logger, &eventMetrics{}, cl, &fakeEventPublisher{})
+	game := common.Address{0xaa}
+	ctx := context.Background()
+	for i := uint64(0); i < 3; i++ {
+		require.NoError(t, c.schedule(ctx, asGames(game), i))
+		j := <-workQueue
+		j.err = errors.New("boom")
+		require.NoError(t, c.processResult(j))
+		cl.AdvanceTime(time.Minute)
+	}
+	var eventTypes []EventType
+	for len(c.events.queue) > 0 {
+		eventTypes = append(eventTypes, (<-c.events.queue).Type)
+	}
+	require.Equal(t, []EventType{
+		EventScheduled, EventFailed,
+		EventScheduled, EventFailed,
+		EventScheduled, EventFailed, EventAbandoned,
+	}, eventTypes)
+}
+
+func TestSubscribe(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	game := common.Address{0xaa}
+	player := &test.StubGamePlayer{StatusValue: types.GameStatusInProgress}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return player, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false)
+	events, unsubscribe := s.Subscribe(100)
+	slow, _ := s.Subscribe(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+
+	require.NoError(t, s.Schedule(asGames(game), 1))
+	require.NoError(t, s.WaitIdle(ctx))
+	player.StatusValue = types.GameStatusChallengerWon
+	require.NoError(t, s.Schedule(asGames(game), 2))
+	require.NoError(t, s.WaitIdle(ctx))
+
+	var eventTypes []EventType
+	for len(eventTypes) < 8 {
+		eventTypes = append(eventTypes, readWithTimeout(t, events).Type)
+	}
+	require.Equal(t, []EventType{
+		EventScheduled, EventStarted, EventCompleted,
+		EventScheduled, EventStarted, EventCompleted, EventStatusChanged, EventResolved,
+	}, eventTypes)
+	require.Equal(t, EventChallengerWon, readWithTimeout(t, events).Type)
+	unsubscribe()
+	_, ok := <-events
+	require.False(t, ok, "channel should be closed when unsubscribed")
+
+	// The slow subscriber only received the first event, the rest were dropped, and its channel is closed on stop.
+	require.Equal(t, EventScheduled, readWithTimeout(t, slow).Type)
+	require.NoError(t, s.Close())
+	_, ok = <-slow
+	require.False(t, ok, "channel should be closed when the scheduler stops")
+}
+
+func TestEventQueueDropsWhenFull(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	m := &eventMetrics{}
+	publisher := &fakeEventPublisher{events: make(chan Event, 10)}
+	q := newEventQueue(logger, m, clock.NewDeterministicClock(time.Unix(1000, 0)), publisher)
+	q.queue = make(chan Event, 2)
+
+	q.Emit(common.Address{0x01}, EventScheduled, 1, "")
+	q.Emit(common.Address{0x02}, EventScheduled, 1, "")
+	q.Emit(common.Address{0x03}, EventScheduled, 1, "")
+	require.Equal(t, 1, m.dropped)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.run(ctx)
+	}()
+	require.Equal(t, common.Address{0x01}, readWithTimeout(t, publisher.events).Game)
+	require.Equal(t, common.Address{0x02}, readWithTimeout(t, publisher.events).Game)
+	cancel()
+	<-done
+}
+
+func TestNilEventQueueDiscardsEvents(t *testing.T) {
+	var q *eventQueue
+	require.NotPanics(t, func() {
+		q.Emit(common.Address{0x01}, EventScheduled, 1, "")
+	})
+}
+
+func TestEventQueueSkipsEventsWithoutListeners(t *testing.T) {
+	q := newEventQueue(testlog.Logger(t, log.LevelInfo), &eventMetrics{}, clock.NewDeterministicClock(time.Unix(1000, 0)), nil)
+	q.Emit(common.Address{0x01}, EventScheduled, 1, "")
+	require.Empty(t, q.queue)
+	_, unsubscribe := q.subscribe(1)
+	q.Emit(common.Address{0x01}, EventScheduled, 1, "")
+	require.Len(t, q.queue, 1)
+	unsubscribe()
+	unsubscribe()
+	q.Emit(common.Address{0x01}, EventScheduled, 1, "")
+	require.Len(t, q.queue, 1)
+}
+
+type fakeEventPublisher struct {
+	events chan Event
+}
+
+func (p *fakeEventPublisher) Publish(event Event) {
+	p.events <- event
+}
+
+type eventMetrics struct {
+	metrics.NoopMetricsImpl
+	dropped int
+}
+
+func (m *eventMetrics) RecordEventDropped() {
+	m.dropped++
+}
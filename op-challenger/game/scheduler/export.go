@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"bytes"
+	"slices"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StateExportVersion is the version of the schema produced by Scheduler.ExportState.
+// The version is incremented whenever a field is removed or its meaning changes. New fields may be added
+// without changing the version, so consumers should ignore fields they don't recognise.
+const StateExportVersion = 1
+
+// ExportedState is the JSON document produced by Scheduler.ExportState.
+// It is a consistent point-in-time view of all games known to the scheduler.
+type ExportedState struct {
+	// Version is the schema version, see StateExportVersion.
+	Version uint `json:"version"`
+	// ExportedAt is the time the state was captured.
+	ExportedAt time.Time `json:"exportedAt"`
+	// Cycle is the number of batches of games that have been scheduled.
+	Cycle uint64 `json:"cycle"`
+	// LastScheduledBlock is the L1 block number of the most recently scheduled batch.
+	LastScheduledBlock uint64 `json:"lastScheduledBlock"`
+	// Games contains an entry for every known game, ordered by address.
+	Games []ExportedGame `json:"games"`
+}
+
+// ExportedGame is the state of a single game within an ExportedState.
+type ExportedGame struct {
+	Game common.Address `json:"game"`
+	// Status is the last known status of the game, e.g. "In Progress" or "Defender Won".
+	Status string `json:"status"`
+	// InFlight is true when a job for the game has been scheduled and its result not yet processed.
+	InFlight bool `json:"inFlight"`
+	// Queued is true when the game is in flight but not yet picked up by a worker.
+	Queued bool `json:"queued"`
+	// WorkerID is the id of the worker currently progressing the game, omitted if no worker is.
+	WorkerID int `json:"workerId,omitempty"`
+	// LastProcessedBlock is the L1 block number of the last processed result for the game.
+	LastProcessedBlock uint64 `json:"lastProcessedBlock"`
+	// FollowUps is the number of consecutive follow up passes enqueued for the game.
+	FollowUps uint `json:"followUps"`
+	// Activity is the game's activity score, between 0 and 1. See WithActivityDecay.
+	Activity float64 `json:"activity"`
+	// CoolingDownUntil is the time until which the game won't be scheduled, omitted if not cooling down.
+	CoolingDownUntil *time.Time `json:"coolingDownUntil,omitempty"`
+	// LastError is the most recent error creating a job for the game, omitted if there has been none.
+	LastError string `json:"lastError,omitempty"`
+	// LastErrorAt is the time LastError occurred.
+	LastErrorAt *time.Time `json:"lastErrorAt,omitempty"`
+	// Retries is the number of consecutive cycles in which creating a job for the game failed.
+	Retries uint `json:"retries"`
+	// SkipReason describes why the game is not being scheduled because of its dependencies, if it isn't.
+	SkipReason string `json:"skipReason,omitempty"`
+}
+
+// exportState captures the state of all games. The coordinator lock is held for the duration so the
+// result is consistent, including the jobs in flight on workers.
+func (c *coordinator) exportState(inFlight *inFlightTracker) ExportedState {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.cfg.clock.Now()
+	workers := make(map[common.Address]int)
+	for _, j := range inFlight.Jobs() {
+		workers[j.Game] = j.WorkerID
+	}
+	games := make([]ExportedGame, 0, len(c.states))
+	for addr, state := range c.states {
+		// Resolved games are also flagged as in flight to prevent them being rescheduled but have no job.
+		inflight := state.inflight && state.status == types.GameStatusInProgress
+		game := ExportedGame{
+			Game:               addr,
+			Status:             state.status.String(),
+			InFlight:           inflight,
+			LastProcessedBlock: state.lastProcessedBlockNum,
+			FollowUps:          state.followUps,
+			Activity:           state.activity,
+			Retries:            state.retries,
+			SkipReason:         state.skipReason,
+		}
+		if workerID, ok := workers[addr]; ok && inflight {
+			game.WorkerID = workerID
+		} else {
+			game.Queued = inflight
+		}
+		if until := c.cooldown.coolingDownUntil(addr); now.Before(until) {
+			game.CoolingDownUntil = &until
+		}
+		if state.lastErr != nil {
+			errTime := state.lastErrTime
+			game.LastError = state.lastErr.Error()
+			game.LastErrorAt = &errTime
+		}
+		games = append(games, game)
+	}
+	slices.SortFunc(games, func(a, b ExportedGame) int {
+		return bytes.Compare(a.Game[:], b.Game[:])
+	})
+	return ExportedState{
+		Version:            StateExportVersion,
+		ExportedAt:         now,
+		Cycle:              c.cycle,
+		LastScheduledBlock: c.lastScheduledBlockNum,
+		Games:              games,
+	}
+}
@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportState(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0).UTC())
+	c.cfg.clock = cl
+	c.cfg.actionCooldown = time.Minute
+	tracker := newInFlightTracker(cl, 10)
+	runningGame := common.Address{0xaa}
+	queuedGame := common.Address{0xbb}
+	failingGame := common.Address{0xcc}
+	coolingGame := common.Address{0xdd}
+	resolvedGame := common.Address{0xee}
+	games.creationFails = failingGame
+	games.createCompleted = resolvedGame
+	ctx := context.Background()
+
+	require.Error(t, c.schedule(ctx, asGames(coolingGame, resolvedGame, failingGame), 1))
+	games.created[coolingGame].ActionTakenValue = true
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	cl.AdvanceTime(time.Second)
+	require.Error(t, c.schedule(ctx, asGames(runningGame, queuedGame, coolingGame, resolvedGame, failingGame), 2))
+	tracker.Start(3, runningGame)
+
+	state := c.exportState(tracker)
+	now := cl.Now()
+	cooldown := time.Unix(1000, 0).UTC().Add(time.Minute)
+	require.Equal(t, ExportedState{
+		Version:            StateExportVersion,
+		ExportedAt:         now,
+		Cycle:              2,
+		LastScheduledBlock: 2,
+		Games: []ExportedGame{
+			{Game: runningGame, Status: "In Progress", InFlight: true, WorkerID: 3, LastProcessedBlock: 1, Activity: 1},
+			{Game: queuedGame, Status: "In Progress", InFlight: true, Queued: true, LastProcessedBlock: 1, Activity: 1},
+			{Game: failingGame, Status: "In Progress", Activity: 1, LastError: state.Games[2].LastError, LastErrorAt: &now, Retries: 2},
+			{Game: coolingGame, Status: "In Progress", LastProcessedBlock: 1, Activity: 1, CoolingDownUntil: &cooldown},
+			{Game: resolvedGame, Status: "Defender Won", Activity: 1},
+		},
+	}, state)
+	require.Contains(t, state.Games[2].LastError, "refusing to create player")
+}
+
+func TestSchedulerExportStateJSON(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return nil, errors.New("boom")
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false)
+	require.Error(t, s.coordinator.schedule(context.Background(), asGames(common.Address{0xaa}), 5))
+
+	data, err := s.ExportState()
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.EqualValues(t, StateExportVersion, decoded["version"])
+	require.EqualValues(t, 5, decoded["lastScheduledBlock"])
+	gamesList := decoded["games"].([]any)
+	require.Len(t, gamesList, 1)
+	game := gamesList[0].(map[string]any)
+	require.Equal(t, common.Address{0xaa}.Hex(), game["game"])
+	require.EqualValues(t, 1, game["retries"])
+	require.Contains(t, game["lastError"], "boom")
+	require.NotContains(t, game, "workerId")
+}
@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrInvalidGameAddress = errors.New("invalid game address")
+
+// InvalidLinePolicy determines how lines that aren't valid game addresses are handled when loading games from a file.
+type InvalidLinePolicy int
+
+const (
+	// InvalidLineFail fails the load, without scheduling any games, if any line is invalid.
+	InvalidLineFail InvalidLinePolicy = iota
+	// InvalidLineSkip skips invalid lines, counting them in the summary.
+	InvalidLineSkip
+)
+
+// FileScheduleSummary reports the outcome of loading games from a file.
+type FileScheduleSummary struct {
+	// Loaded is the number of non-empty, non-comment lines read from the file.
+	Loaded int
+	// Scheduled is the number of distinct games scheduled.
+	Scheduled int
+	// Skipped is the number of lines that were invalid or duplicated an earlier line.
+	Skipped int
+}
+
+// LoadGamesFromFile reads newline-separated game addresses from path, returning one game of the specified type
+// for each distinct valid address in the order they first appear. Empty lines and lines starting with # are ignored.
+// Invalid lines are handled according to policy and duplicate addresses are skipped.
+func LoadGamesFromFile(path string, gameType uint32, policy InvalidLinePolicy) ([]types.GameMetadata, FileScheduleSummary, error) {
+	var summary FileScheduleSummary
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, summary, fmt.Errorf("failed to open games file: %w", err)
+	}
+	defer f.Close()
+
+	var games []types.GameMetadata
+	seen := make(map[common.Address]bool)
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		summary.Loaded++
+		if !common.IsHexAddress(line) {
+			if policy != InvalidLineSkip {
+				return nil, summary, fmt.Errorf("line %v: %w: %q", lineNum, ErrInvalidGameAddress, line)
+			}
+			summary.Skipped++
+			continue
+		}
+		addr := common.HexToAddress(line)
+		if seen[addr] {
+			summary.Skipped++
+			continue
+		}
+		seen[addr] = true
+		games = append(games, types.GameMetadata{GameType: gameType, Proxy: addr})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, summary, fmt.Errorf("failed to read games file: %w", err)
+	}
+	summary.Scheduled = len(games)
+	return games, summary, nil
+}
+
+// ScheduleFromFile schedules a single batch containing the games listed in the file at path, as loaded by
+// LoadGamesFromFile, for offline processing such as backtesting or reprocessing specific games.
+// Unlike Schedule, it waits for the scheduler to accept the batch rather than merging it into a waiting batch.
+// Returns ErrStopped if the scheduler stops while waiting.
+// Use WaitIdle to wait for the games to be progressed.
+func (s *Scheduler) ScheduleFromFile(ctx context.Context, path string, gameType uint32, blockNumber uint64, policy InvalidLinePolicy) (FileScheduleSummary, error) {
+	games, summary, err := LoadGamesFromFile(path, gameType, policy)
+	if err != nil {
+		return summary, err
+	}
+	if !s.started.Load() {
+		return summary, ErrNotStarted
+	}
+	if s.coordinator.jobLimitReached.Load() {
+		return summary, ErrJobLimitReached
+	}
+	if err := s.checkBatchSize(len(games)); err != nil {
+		return summary, err
+	}
+	if !s.beginSend() {
+		return summary, ErrStopped
+	}
+	defer s.endSend()
+	s.coordinator.idle.Add(1)
+	select {
+	case s.scheduleQueue <- blockGames{blockNumber: blockNumber, games: games}:
+	case <-s.stopped:
+		s.coordinator.idle.Done()
+		return summary, ErrStopped
+	case <-ctx.Done():
+		s.coordinator.idle.Done()
+		return summary, ctx.Err()
+	}
+	s.m.RecordBatchSize(len(games))
+	s.logger.Info("Scheduled games from file", "path", path, "loaded", summary.Loaded, "scheduled", summary.Scheduled, "skipped", summary.Skipped)
+	return summary, nil
+}
@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fileGame1 = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	fileGame2 = common.HexToAddress("0x2222222222222222222222222222222222222222")
+)
+
+func writeGamesFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "games.txt")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+const mixedGamesFile = `# games to reprocess
+0x1111111111111111111111111111111111111111
+
+not-an-address
+0x2222222222222222222222222222222222222222
+0x1111111111111111111111111111111111111111
+0x12345
+`
+
+func TestLoadGamesFromFile(t *testing.T) {
+	t.Run("SkipInvalid", func(t *testing.T) {
+		games, summary, err := LoadGamesFromFile(writeGamesFile(t, mixedGamesFile), 3, InvalidLineSkip)
+		require.NoError(t, err)
+		require.Equal(t, []types.GameMetadata{
+			{GameType: 3, Proxy: fileGame1},
+			{GameType: 3, Proxy: fileGame2},
+		}, games)
+		require.Equal(t, FileScheduleSummary{Loaded: 5, Scheduled: 2, Skipped: 3}, summary)
+	})
+
+	t.Run("FailOnInvalid", func(t *testing.T) {
+		games, _, err := LoadGamesFromFile(writeGamesFile(t, mixedGamesFile), 3, InvalidLineFail)
+		require.ErrorIs(t, err, ErrInvalidGameAddress)
+		require.ErrorContains(t, err, "line 4")
+		require.Nil(t, games)
+	})
+
+	t.Run("DuplicatesAllowedWhenFailingOnInvalid", func(t *testing.T) {
+		path := writeGamesFile(t, fileGame1.Hex()+"\n"+fileGame1.Hex()+"\n")
+		games, summary, err := LoadGamesFromFile(path, 0, InvalidLineFail)
+		require.NoError(t, err)
+		require.Len(t, games, 1)
+		require.Equal(t, FileScheduleSummary{Loaded: 2, Scheduled: 1, Skipped: 1}, summary)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		_, _, err := LoadGamesFromFile(filepath.Join(t.TempDir(), "missing.txt"), 0, InvalidLineSkip)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestScheduleFromFile(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	var lock sync.Mutex
+	var created []types.GameMetadata
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		created = append(created, game)
+		return &test.StubGamePlayer{}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	summary, err := s.ScheduleFromFile(ctx, writeGamesFile(t, mixedGamesFile), 3, 10, InvalidLineSkip)
+	require.NoError(t, err)
+	require.Equal(t, FileScheduleSummary{Loaded: 5, Scheduled: 2, Skipped: 3}, summary)
+	require.NoError(t, s.WaitIdle(ctx))
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.ElementsMatch(t, []types.GameMetadata{
+		{GameType: 3, Proxy: fileGame1},
+		{GameType: 3, Proxy: fileGame2},
+	}, created)
+}
@@ -0,0 +1,208 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// GameFilter reports whether a game should be progressed, see WithGameFilter and Scheduler.SetGameFilter.
+type GameFilter func(game types.GameMetadata) bool
+
+type FilterMetricer interface {
+	RecordFilterReconciled(cancelled, added int)
+}
+
+// FilterReconciliation reports the outcome of applying a new filter with Scheduler.SetGameFilter.
+type FilterReconciliation struct {
+	// Cancelled is the number of queued or in flight jobs cancelled because their game is now excluded.
+	Cancelled int
+	// Added is the number of known games that are now included and were scheduled immediately.
+	Added int
+}
+
+// filterRequest asks the scheduler loop to apply a new game filter, see SetGameFilter.
+type filterRequest struct {
+	filter GameFilter
+	result chan FilterReconciliation
+}
+
+// SetGameFilter replaces the filter set by WithGameFilter, or removes it if filter is nil, and reconciles the
+// games the scheduler knows about with it so the change takes effect immediately rather than from the next batch.
+// Jobs for games that are now excluded are cancelled: jobs not yet picked up by a worker are dropped and the
+// context passed to players already progressing an excluded game is cancelled. Games from the most recent
+// batches that were excluded by the previous filter and are now included are scheduled straight away, subject
+// to the usual scheduling checks. Returns the number of jobs cancelled and games added.
+func (s *Scheduler) SetGameFilter(ctx context.Context, filter GameFilter) (FilterReconciliation, error) {
+	if !s.started.Load() {
+		return FilterReconciliation{}, ErrNotStarted
+	}
+	req := filterRequest{filter: filter, result: make(chan FilterReconciliation, 1)}
+	select {
+	case s.filterRequests <- req:
+	case <-s.stopped:
+		return FilterReconciliation{}, ErrStopped
+	case <-ctx.Done():
+		return FilterReconciliation{}, ctx.Err()
+	}
+	select {
+	case result := <-req.result:
+		return result, nil
+	case <-s.stopped:
+		return FilterReconciliation{}, ErrStopped
+	case <-ctx.Done():
+		return FilterReconciliation{}, ctx.Err()
+	}
+}
+
+func (s *Scheduler) handleFilter(ctx context.Context, req filterRequest) {
+	result := s.coordinator.setGameFilter(ctx, req.filter)
+	s.m.RecordFilterReconciled(result.Cancelled, result.Added)
+	req.result <- result
+}
+
+func (c *coordinator) hasGameFilter() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.filter != nil
+}
+
+// included returns true if the game passes the current filter. The lock must be held.
+func (c *coordinator) included(game types.GameMetadata) bool {
+	return c.filter == nil || c.filter(game)
+}
+
+// setGameFilter replaces the filter, cancels the jobs of games it excludes and schedules known games that it
+// newly includes.
+func (c *coordinator) setGameFilter(ctx context.Context, filter GameFilter) FilterReconciliation {
+	var result FilterReconciliation
+	var jobs []job
+	c.lock.Lock()
+	c.filter = filter
+	var excluded []job
+	c.deferred = slices.DeleteFunc(c.deferred, func(j job) bool {
+		state, ok := c.states[j.addr]
+		if ok && !c.included(state.metadata(j.addr)) {
+			excluded = append(excluded, j)
+			return true
+		}
+		return false
+	})
+	c.releaseJobs(excluded)
+	result.Cancelled += len(excluded)
+	var included []types.GameMetadata
+	for addr, state := range c.states {
+		game := state.metadata(addr)
+		if !c.included(game) {
+			state.filtered = true
+			if state.pendingJobID != 0 {
+				c.tracer.Log(addr, "Cancelling job excluded by game filter", "job", state.pendingJobID)
+				c.canceller.cancel(state.pendingJobID)
+				result.Cancelled++
+			}
+			continue
+		}
+		if state.filtered {
+			included = append(included, game)
+		}
+	}
+	c.lock.Unlock()
+	inits := c.initPlayers(ctx, included)
+	c.lock.Lock()
+	for _, game := range included {
+		addr := game.Proxy
+		state, ok := c.states[addr]
+		if !ok || !state.filtered || !c.included(game) {
+			// Changed while the players were created.
+			continue
+		}
+		state.filtered = false
+		j, err := c.createJob(game, c.lastScheduledBlockNum, inits)
+		if err != nil {
+			c.errLog.Log(log.LevelWarn, "filter", "Failed to create job for game included by filter", err, "game", addr)
+			c.recordDecision(addr, DecisionFailed, err.Error())
+			continue
+		}
+		if j != nil {
+			jobs = append(jobs, *j)
+			c.idle.Add(1)
+			c.m.RecordGameUpdateScheduled()
+			c.events.Emit(j.addr, EventScheduled, j.cycle, j.correlationID)
+			c.recordDecision(j.addr, DecisionScheduled, "")
+			result.Added++
+		}
+	}
+	c.lock.Unlock()
+	c.writeSnapshots(ctx)
+
+	for i, j := range jobs {
+		if err := c.enqueueJob(ctx, j); err != nil {
+			c.abandonJobs(jobs[i:])
+			c.logger.Warn("Failed to enqueue jobs for games included by filter", "remaining", len(jobs)-i, "err", err)
+			break
+		}
+		c.tracer.Log(j.addr, "Enqueued job for game included by filter", "block", j.block)
+	}
+	c.logger.Info("Applied game filter", "cancelled", result.Cancelled, "added", result.Added)
+	return result
+}
+
+// jobCanceller cancels the context of jobs that are queued or being progressed by a worker, see SetGameFilter.
+// Safe for concurrent use.
+type jobCanceller struct {
+	lock sync.Mutex
+	// running holds the function to cancel the context of each job currently being progressed.
+	running map[uint64]context.CancelFunc
+	// cancelled holds the jobs cancelled before a worker started progressing them.
+	cancelled map[uint64]bool
+}
+
+func newJobCanceller() *jobCanceller {
+	return &jobCanceller{running: make(map[uint64]context.CancelFunc), cancelled: make(map[uint64]bool)}
+}
+
+// start returns the context to progress the job with, which is already cancelled if the job was cancelled while
+// queued. The returned function must be called once the job completes and reports whether it was cancelled.
+// A nil jobCanceller never cancels jobs.
+func (c *jobCanceller) start(ctx context.Context, id uint64) (context.Context, func() bool) {
+	if c == nil {
+		return ctx, func() bool { return false }
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.cancelled[id] {
+		cancel()
+	} else {
+		c.running[id] = cancel
+	}
+	return ctx, func() bool {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		delete(c.running, id)
+		cancelled := c.cancelled[id]
+		delete(c.cancelled, id)
+		cancel()
+		return cancelled
+	}
+}
+
+// cancel cancels the job if it is being progressed, or when a worker starts progressing it.
+func (c *jobCanceller) cancel(id uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.cancelled[id] = true
+	if cancel, ok := c.running[id]; ok {
+		cancel()
+	}
+}
+
+// forget discards the cancellation of a job whose result has been processed.
+func (c *jobCanceller) forget(id uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.cancelled, id)
+}
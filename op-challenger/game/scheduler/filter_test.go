@@ -0,0 +1,266 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetGameFilterCancelsInFlightExcludedGames(t *testing.T) {
+	excluded := common.Address{0xaa}
+	kept := common.Address{0xbb}
+	release := make(chan struct{})
+	players := newFilterPlayers(release)
+	s := newFilterTestScheduler(t, 2, players)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	require.NoError(t, s.Schedule(asGames(excluded, kept), 0))
+	players.awaitStarted(t, excluded)
+	players.awaitStarted(t, kept)
+
+	result, err := s.SetGameFilter(ctx, func(game types.GameMetadata) bool {
+		return game.Proxy != excluded
+	})
+	require.NoError(t, err)
+	require.Equal(t, FilterReconciliation{Cancelled: 1}, result)
+	require.ErrorIs(t, players.awaitFinished(t, excluded), context.Canceled)
+
+	close(release)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.NoError(t, players.awaitFinished(t, kept))
+
+	// The cancelled progression isn't counted as a failure and the excluded game isn't progressed again.
+	s.coordinator.lock.Lock()
+	require.Zero(t, s.coordinator.states[excluded].progressFailures)
+	s.coordinator.lock.Unlock()
+	decision, reason, _ := s.LastDecision(excluded)
+	require.Equal(t, DecisionScheduled, decision)
+	require.Empty(t, reason)
+	require.NoError(t, s.Schedule(asGames(excluded, kept), 1))
+	require.NoError(t, s.WaitIdle(ctx))
+	decision, reason, _ = s.LastDecision(excluded)
+	require.Equal(t, DecisionSkipped, decision)
+	require.Equal(t, SkipReasonFiltered, reason)
+	require.Equal(t, 1, players.runs(excluded))
+	require.Equal(t, 2, players.runs(kept))
+	require.True(t, s.EffectiveConfig().GameFilter)
+}
+
+func TestSetGameFilterSchedulesNewlyIncludedGames(t *testing.T) {
+	game := common.Address{0xaa}
+	release := make(chan struct{})
+	close(release)
+	players := newFilterPlayers(release)
+	s := newFilterTestScheduler(t, 1, players, WithGameFilter(func(g types.GameMetadata) bool {
+		return g.GameType != 1
+	}))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	require.NoError(t, s.Schedule([]types.GameMetadata{{Proxy: game, GameType: 1}}, 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Zero(t, players.runs(game))
+	decision, reason, _ := s.LastDecision(game)
+	require.Equal(t, DecisionSkipped, decision)
+	require.Equal(t, SkipReasonFiltered, reason)
+
+	// Removing the filter schedules the game without waiting for the next batch
+	result, err := s.SetGameFilter(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, FilterReconciliation{Added: 1}, result)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Equal(t, 1, players.runs(game))
+	require.False(t, s.EffectiveConfig().GameFilter)
+
+	// Games that were already included aren't scheduled again
+	result, err = s.SetGameFilter(ctx, func(g types.GameMetadata) bool { return true })
+	require.NoError(t, err)
+	require.Equal(t, FilterReconciliation{}, result)
+	require.Equal(t, []FilterReconciliation{{Added: 1}, {}}, s.m.(*filterMetrics).reconciled())
+}
+
+func TestSetGameFilterReleasesQueuedJobs(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 1)
+	queued := common.Address{0xaa}
+	deferred := common.Address{0xbb}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(queued, deferred), 0))
+	require.Len(t, workQueue, 1)
+	require.Len(t, c.deferred, 1)
+
+	result := c.setGameFilter(ctx, func(game types.GameMetadata) bool { return false })
+	require.Equal(t, FilterReconciliation{Cancelled: 2}, result)
+	require.Empty(t, c.deferred)
+	require.False(t, c.states[deferred].inflight)
+
+	// The job already in the queue is cancelled when a worker picks it up
+	j := <-workQueue
+	jobCtx, done := c.canceller.start(ctx, j.id)
+	require.ErrorIs(t, jobCtx.Err(), context.Canceled)
+	j = runJob(jobCtx, j)
+	j.cancelled = done()
+	require.True(t, j.cancelled)
+	require.NoError(t, c.processResult(j))
+	require.True(t, c.idle.IsIdle())
+	require.Zero(t, c.states[queued].progressFailures)
+	require.Empty(t, c.canceller.cancelled)
+}
+
+func TestJobCanceller(t *testing.T) {
+	canceller := newJobCanceller()
+	ctx := context.Background()
+
+	jobCtx, done := canceller.start(ctx, 1)
+	require.NoError(t, jobCtx.Err())
+	canceller.cancel(1)
+	require.ErrorIs(t, jobCtx.Err(), context.Canceled)
+	require.True(t, done())
+
+	jobCtx, done = canceller.start(ctx, 2)
+	require.NoError(t, jobCtx.Err())
+	require.False(t, done())
+	require.Empty(t, canceller.running)
+	require.Empty(t, canceller.cancelled)
+
+	// Cancelling a job whose result is awaiting processing is forgotten once it is processed
+	canceller.cancel(3)
+	canceller.forget(3)
+	require.Empty(t, canceller.cancelled)
+
+	var nilCanceller *jobCanceller
+	jobCtx, done = nilCanceller.start(ctx, 4)
+	require.NoError(t, jobCtx.Err())
+	require.False(t, done())
+}
+
+func newFilterTestScheduler(t *testing.T, workers uint, players *filterPlayers, opts ...SchedulerOption) *Scheduler {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return players.create(game.Proxy), nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	return NewScheduler(logger, &filterMetrics{}, disk, workers, createPlayer, false, opts...)
+}
+
+// filterPlayers creates players that progress games until released or their context is cancelled.
+type filterPlayers struct {
+	release  <-chan struct{}
+	lock     sync.Mutex
+	players  map[common.Address]*filterPlayer
+	started  map[common.Address]chan struct{}
+	finished map[common.Address]chan error
+}
+
+func newFilterPlayers(release <-chan struct{}) *filterPlayers {
+	return &filterPlayers{
+		release:  release,
+		players:  make(map[common.Address]*filterPlayer),
+		started:  make(map[common.Address]chan struct{}, 10),
+		finished: make(map[common.Address]chan error, 10),
+	}
+}
+
+func (p *filterPlayers) create(addr common.Address) *filterPlayer {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.started[addr] = make(chan struct{}, 10)
+	p.finished[addr] = make(chan error, 10)
+	player := &filterPlayer{release: p.release, started: p.started[addr], finished: p.finished[addr]}
+	p.players[addr] = player
+	return player
+}
+
+func (p *filterPlayers) awaitStarted(t *testing.T, addr common.Address) {
+	require.Eventually(t, func() bool {
+		p.lock.Lock()
+		defer p.lock.Unlock()
+		return p.started[addr] != nil
+	}, 10*time.Second, time.Millisecond)
+	p.lock.Lock()
+	started := p.started[addr]
+	p.lock.Unlock()
+	readWithTimeout(t, started)
+}
+
+func (p *filterPlayers) awaitFinished(t *testing.T, addr common.Address) error {
+	p.lock.Lock()
+	finished := p.finished[addr]
+	p.lock.Unlock()
+	return readWithTimeout(t, finished)
+}
+
+func (p *filterPlayers) runs(addr common.Address) int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if player, ok := p.players[addr]; ok {
+		return int(player.runs.Load())
+	}
+	return 0
+}
+
+type filterPlayer struct {
+	release  <-chan struct{}
+	started  chan struct{}
+	finished chan error
+	runs     atomic.Int32
+	err      error
+}
+
+func (p *filterPlayer) ValidatePrestate(_ context.Context) error {
+	return nil
+}
+
+func (p *filterPlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	p.runs.Add(1)
+	p.started <- struct{}{}
+	select {
+	case <-p.release:
+		p.err = nil
+	case <-ctx.Done():
+		p.err = ctx.Err()
+	}
+	p.finished <- p.err
+	return types.GameStatusInProgress
+}
+
+func (p *filterPlayer) ProgressError() error {
+	return p.err
+}
+
+func (p *filterPlayer) Status() types.GameStatus {
+	return types.GameStatusInProgress
+}
+
+type filterMetrics struct {
+	metrics.NoopMetricsImpl
+	lock    sync.Mutex
+	changes []FilterReconciliation
+}
+
+func (m *filterMetrics) RecordFilterReconciled(cancelled, added int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.changes = append(m.changes, FilterReconciliation{Cancelled: cancelled, Added: added})
+}
+
+func (m *filterMetrics) reconciled() []FilterReconciliation {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return slices.Clone(m.changes)
+}
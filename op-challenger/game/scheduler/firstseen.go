@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// firstSeenRetention is how long the first seen record of a game is kept after it resolves or is no longer scheduled.
+const firstSeenRetention = time.Hour
+
+// firstSeen records the cycle and time in which a game was first scheduled.
+type firstSeen struct {
+	cycle uint64
+	time  time.Time
+	// expiry is the time after which the record is pruned, or zero while the game is in progress and scheduled.
+	expiry time.Time
+}
+
+// recordFirstSeen records the current cycle as the first seen cycle for each game without an existing record and
+// prunes records of games that resolved or stopped being scheduled more than firstSeenRetention ago.
+// The lock must be held.
+func (c *coordinator) recordFirstSeen(games []types.GameMetadata) {
+	now := c.cfg.clock.Now()
+	for _, game := range games {
+		if _, ok := c.firstSeen[game.Proxy]; !ok {
+			c.firstSeen[game.Proxy] = &firstSeen{cycle: c.cycle, time: now}
+		}
+	}
+	for addr, entry := range c.firstSeen {
+		state, ok := c.states[addr]
+		switch {
+		case ok && state.status == types.GameStatusInProgress:
+			entry.expiry = time.Time{}
+		case entry.expiry.IsZero():
+			entry.expiry = now.Add(firstSeenRetention)
+		case !now.Before(entry.expiry):
+			delete(c.firstSeen, addr)
+		}
+	}
+}
+
+// FirstSeen returns the id of the scheduling cycle and the time in which the game was first scheduled.
+// Returns false if the game is unknown, or resolved or stopped being scheduled more than an hour ago.
+func (s *Scheduler) FirstSeen(addr common.Address) (uint64, time.Time, bool) {
+	c := s.coordinator
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.firstSeen[addr]
+	if !ok {
+		return 0, time.Time{}, false
+	}
+	return entry.cycle, entry.time, true
+}
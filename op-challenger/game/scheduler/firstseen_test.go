@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstSeen(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	s := &Scheduler{coordinator: c}
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	ctx := context.Background()
+	processAll := func(status types.GameStatus) {
+		for len(workQueue) > 0 {
+			j := <-workQueue
+			j.status = status
+			require.NoError(t, c.processResult(j))
+		}
+	}
+
+	_, _, ok := s.FirstSeen(gameAddr1)
+	require.False(t, ok)
+
+	start := cl.Now()
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1), 0))
+	processAll(types.GameStatusInProgress)
+	cl.AdvanceTime(time.Minute)
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2), 1))
+	processAll(types.GameStatusInProgress)
+	cl.AdvanceTime(time.Minute)
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2), 2))
+
+	cycle, seen, ok := s.FirstSeen(gameAddr1)
+	require.True(t, ok)
+	require.Equal(t, uint64(1), cycle)
+	require.Equal(t, start, seen)
+	cycle, seen, ok = s.FirstSeen(gameAddr2)
+	require.True(t, ok)
+	require.Equal(t, uint64(2), cycle)
+	require.Equal(t, start.Add(time.Minute), seen)
+
+	// Retained for a while after the game resolves, then pruned
+	processAll(types.GameStatusDefenderWon)
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2), 3))
+	cl.AdvanceTime(firstSeenRetention - time.Second)
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2), 4))
+	cycle, _, ok = s.FirstSeen(gameAddr1)
+	require.True(t, ok)
+	require.Equal(t, uint64(1), cycle)
+
+	cl.AdvanceTime(time.Second)
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2), 5))
+	_, _, ok = s.FirstSeen(gameAddr1)
+	require.False(t, ok)
+	_, _, ok = s.FirstSeen(gameAddr2)
+	require.False(t, ok)
+}
+
+func TestFirstSeenRetainedAfterGameNoLongerScheduled(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	s := &Scheduler{coordinator: c}
+	gameAddr1 := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1), 0))
+	require.NoError(t, c.processResult(<-workQueue))
+	require.NoError(t, c.schedule(ctx, nil, 1))
+	require.NotContains(t, c.states, gameAddr1)
+	_, _, ok := s.FirstSeen(gameAddr1)
+	require.True(t, ok)
+
+	// Rescheduling before the record expires keeps the original first seen cycle
+	delete(games.created, gameAddr1)
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1), 2))
+	cycle, _, ok := s.FirstSeen(gameAddr1)
+	require.True(t, ok)
+	require.Equal(t, uint64(1), cycle)
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// FlushableStateStore is an optional interface a StateStore can implement if it buffers writes in memory,
+// allowing Scheduler.Flush to persist them on demand.
+type FlushableStateStore interface {
+	StateStore
+	// Flush persists all buffered writes.
+	Flush(ctx context.Context) error
+}
+
+// Flush synchronously persists any durable state buffered in memory without stopping the scheduler, so a
+// durability point can be forced before a risky operation. Writes the checkpoint if WithCheckpoint is set, including
+// the pending games and retry history, then flushes the StateStore if it implements FlushableStateStore and the
+// audit sink if it implements Flush() error, such as a *bufio.Writer.
+// Safe to call concurrently with normal operation. Returns the errors from any flushes that failed.
+// Close also calls Flush once the scheduler has stopped.
+func (s *Scheduler) Flush(ctx context.Context) error {
+	var errs []error
+	if s.cfg.checkpoint {
+		if err := s.writeCheckpoint(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to write checkpoint: %w", err))
+		}
+	}
+	if store, ok := s.cfg.stateStore.(FlushableStateStore); ok {
+		if err := store.Flush(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to flush state store: %w", err))
+		}
+	}
+	if audit, ok := s.coordinator.audit.(*auditLog); ok {
+		if err := s.flushAudit(audit); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *Scheduler) flushAudit(audit *auditLog) error {
+	flusher, ok := audit.sink.(interface{ Flush() error })
+	if !ok {
+		return nil
+	}
+	// Audit records are written while processing results so hold the lock to avoid interleaving with a write.
+	s.coordinator.lock.Lock()
+	defer s.coordinator.lock.Unlock()
+	if err := flusher.Flush(); err != nil {
+		return fmt.Errorf("failed to flush audit sink: %w", err)
+	}
+	return nil
+}
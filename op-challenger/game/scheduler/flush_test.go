@@ -0,0 +1,173 @@
+package scheduler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlushPersistsStateForFreshScheduler(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	dir := t.TempDir()
+	disk := &tempDirDiskManager{dir: dir}
+	path := filepath.Join(dir, "checkpoint.json")
+	failing := common.Address{0xaa}
+	pending := common.Address{0xbb}
+	release := make(chan struct{})
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		if g.Proxy == pending {
+			return &blockingPlayer{release: release}, nil
+		}
+		return &test.StubGamePlayer{Addr: g.Proxy, StatusValue: types.GameStatusInProgress, ProgressErr: errors.New("boom")}, nil
+	}
+	opts := func() []SchedulerOption {
+		store := newBufferedStateStore(NewDiskStateStore(disk, path))
+		return []SchedulerOption{WithStateStore(store), WithCheckpoint(path, 0), WithFailureBackoff(time.Hour, time.Hour)}
+	}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false, opts()...)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer func() {
+		close(release)
+		require.NoError(t, s.Close())
+	}()
+	require.NoError(t, s.Schedule(asGames(failing, pending), 0))
+	require.Eventually(t, func() bool {
+		s.coordinator.lock.Lock()
+		defer s.coordinator.lock.Unlock()
+		state, ok := s.coordinator.states[failing]
+		return ok && !state.inflight && s.coordinator.failures.state(failing).streak == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoFileExists(t, path, "checkpoint should not be written until flushed")
+
+	require.NoError(t, s.Flush(ctx))
+	require.FileExists(t, path)
+
+	// A fresh scheduler restores the checkpoint written by Flush while the pending game was still in flight.
+	progressed := make(chan common.Address, 10)
+	createPlayer = func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &riskPlayer{StubGamePlayer: &test.StubGamePlayer{Addr: g.Proxy, StatusValue: types.GameStatusInProgress}, progressed: progressed}, nil
+	}
+	fresh := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, opts()...)
+	fresh.Start(ctx)
+	defer fresh.Close()
+	require.Equal(t, pending, readWithTimeout(t, progressed), "should resume pending game")
+	require.NoError(t, fresh.WaitIdle(ctx))
+	data, err := fresh.ExportFullState()
+	require.NoError(t, err)
+	var state fullState
+	require.NoError(t, json.Unmarshal(data, &state))
+	require.Len(t, state.Games, 2)
+	require.Equal(t, failing, state.Games[0].Game)
+	require.EqualValues(t, 1, state.Games[0].FailureStreak, "should restore retry history")
+}
+
+func TestFlushAuditSink(t *testing.T) {
+	var out bytes.Buffer
+	sink := bufio.NewWriterSize(&out, 64*1024)
+	s := newFlushTestScheduler(t, &tempDirDiskManager{dir: t.TempDir()}, WithAuditSink(sink))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Zero(t, out.Len(), "records should be buffered until flushed")
+
+	require.NoError(t, s.Flush(ctx))
+	written := out.Len()
+	require.NotZero(t, written)
+
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 1))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.NoError(t, s.Close())
+	require.Greater(t, out.Len(), written, "close should flush buffered records")
+}
+
+func TestFlushAggregatesErrors(t *testing.T) {
+	storeErr := errors.New("store failed")
+	auditErr := errors.New("audit failed")
+	store := newBufferedStateStore(NewDiskStateStore(&tempDirDiskManager{dir: t.TempDir()}, ""))
+	store.flushErr = storeErr
+	s := newFlushTestScheduler(t, &tempDirDiskManager{dir: t.TempDir()}, WithStateStore(store), WithAuditSink(&failingFlusher{err: auditErr}))
+
+	err := s.Flush(context.Background())
+	require.ErrorIs(t, err, storeErr)
+	require.ErrorIs(t, err, auditErr)
+}
+
+func TestFlushWithoutBufferedState(t *testing.T) {
+	s := newFlushTestScheduler(t, &tempDirDiskManager{dir: t.TempDir()}, WithAuditSink(&bytes.Buffer{}))
+	require.NoError(t, s.Flush(context.Background()))
+}
+
+func newFlushTestScheduler(t *testing.T, disk DiskManager, opts ...SchedulerOption) *Scheduler {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{StatusValue: types.GameStatusInProgress, ActionTakenValue: true}, nil
+	}
+	return NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, opts...)
+}
+
+// bufferedStateStore holds saved state in memory until flushed to the underlying store.
+type bufferedStateStore struct {
+	StateStore
+	lock     sync.Mutex
+	pending  map[common.Address]map[string][]byte
+	flushErr error
+}
+
+func newBufferedStateStore(store StateStore) *bufferedStateStore {
+	return &bufferedStateStore{StateStore: store, pending: make(map[common.Address]map[string][]byte)}
+}
+
+func (b *bufferedStateStore) Save(_ context.Context, game common.Address, key string, data []byte) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.pending[game] == nil {
+		b.pending[game] = make(map[string][]byte)
+	}
+	b.pending[game][key] = data
+	return nil
+}
+
+func (b *bufferedStateStore) Flush(ctx context.Context) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.flushErr != nil {
+		return b.flushErr
+	}
+	for game, entries := range b.pending {
+		for key, data := range entries {
+			if err := b.StateStore.Save(ctx, game, key, data); err != nil {
+				return err
+			}
+		}
+	}
+	b.pending = make(map[common.Address]map[string][]byte)
+	return nil
+}
+
+type failingFlusher struct {
+	bytes.Buffer
+	err error
+}
+
+func (f *failingFlusher) Flush() error {
+	return f.err
+}
@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrGameNotScheduled = errors.New("game not scheduled")
+	ErrGameResolved     = errors.New("game resolved")
+)
+
+type ForceScheduleMetricer interface {
+	RecordForcedSchedule()
+}
+
+// forceRequest asks the scheduler loop to force the game to be progressed, see ForceSchedule.
+type forceRequest struct {
+	addr   common.Address
+	result chan error
+}
+
+// ForceSchedule immediately progresses the game, bypassing the checks that would otherwise skip it such as
+// cooldown after an action, gas budget, idle game backoff, unmet dependencies and the schedule shard.
+// It is intended for operators manually intervening in a game during an incident.
+// Only one job progresses a game at a time, so if the game already has a job in flight another pass is
+// started as soon as it completes. The game of the primary factory with the address is progressed, or if it isn't
+// tracking one, the first source registered with RegisterSource that is, ordered by factory address. Returns
+// ErrGameNotScheduled if the game has not been scheduled, or ErrGameResolved if it has already resolved.
+func (s *Scheduler) ForceSchedule(ctx context.Context, addr common.Address) error {
+	if !s.started.Load() {
+		return ErrNotStarted
+	}
+	if s.coordinator.jobLimitReached.Load() {
+		return ErrJobLimitReached
+	}
+	req := forceRequest{addr: addr, result: make(chan error, 1)}
+	select {
+	case s.forceQueue <- req:
+	case <-s.stopped:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-req.result:
+		return err
+	case <-s.stopped:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) handleForce(ctx context.Context, req forceRequest) {
+	// Force the game from the first source tracking it, starting with the primary factory.
+	var err error
+	for _, c := range s.coordinators() {
+		err = c.forceSchedule(ctx, req.addr)
+		if !errors.Is(err, ErrGameNotScheduled) {
+			break
+		}
+	}
+	if err == nil {
+		s.m.RecordForcedSchedule()
+	}
+	req.result <- err
+}
+
+// forceSchedule enqueues a job to progress the game without applying the usual scheduling checks.
+// If the game already has a job pending, the forced job is enqueued once its result is processed.
+func (c *coordinator) forceSchedule(ctx context.Context, addr common.Address) error {
+	c.lock.Lock()
+	state, ok := c.states[addr]
+	if !ok || state.player == nil {
+		c.lock.Unlock()
+		return fmt.Errorf("%w: %v", ErrGameNotScheduled, addr)
+	}
+	if state.status != types.GameStatusInProgress {
+		c.lock.Unlock()
+		return fmt.Errorf("%w: %v", ErrGameResolved, addr)
+	}
+	if state.pendingJobID != 0 {
+		c.logger.Info("Forcing progression of game once in-flight job completes", "game", addr)
+		c.tracer.Log(addr, "Forced schedule waiting for in-flight job", "job", state.pendingJobID)
+		state.forced = true
+		c.lock.Unlock()
+		return nil
+	}
+	j := c.newForcedJob(addr, state)
+	c.lock.Unlock()
+
+	c.logger.Info("Forcing progression of game", "game", addr)
+	if err := c.enqueueJob(ctx, *j); err != nil {
+		c.abandonJobs([]job{*j})
+		return fmt.Errorf("failed to enqueue forced job for game %v: %w", addr, err)
+	}
+	c.tracer.Log(addr, "Enqueued forced job", "block", j.block)
+	return nil
+}
+
+// enqueueForced enqueues the forced job requested while the game's previous job was in flight.
+// The job is deferred if the job queue is full. The lock must be held.
+func (c *coordinator) enqueueForced(addr common.Address, state *gameState) {
+	state.forced = false
+	if state.inflight || state.status != types.GameStatusInProgress {
+		// Either a follow up pass is already progressing the game or there is nothing left to do.
+		return
+	}
+	c.deferred = append(c.deferred, *c.newForcedJob(addr, state))
+	c.enqueueDeferred()
+}
+
+// newForcedJob creates a job to progress the game and records it as outstanding work. The lock must be held.
+func (c *coordinator) newForcedJob(addr common.Address, state *gameState) *job {
+	j := c.newJob(c.lastScheduledBlockNum, addr, state)
+	state.inflight = true
+	c.idle.Add(1)
+	c.m.RecordGameUpdateScheduled()
+	c.events.Emit(addr, EventScheduled, j.cycle, j.correlationID)
+	return j
+}
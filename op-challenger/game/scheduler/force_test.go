@@ -0,0 +1,110 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForceScheduleGameCoolingDown(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	c.cfg.actionCooldown = time.Minute
+	m := c.m.(*stubSchedulerMetrics)
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	games.created[gameAddr].ActionTakenValue = true
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 1))
+	require.Empty(t, workQueue, "should not schedule game cooling down")
+	require.Equal(t, 1, m.coolingDown)
+
+	require.NoError(t, c.forceSchedule(ctx, gameAddr))
+	require.Len(t, workQueue, 1)
+	j := <-workQueue
+	require.Equal(t, gameAddr, j.addr)
+	require.Equal(t, uint64(1), j.block)
+	require.NoError(t, c.processResult(runJob(ctx, j)))
+	require.Equal(t, 2, games.created[gameAddr].ProgressCount)
+	require.True(t, c.idle.IsIdle())
+}
+
+func TestForceScheduleWaitsForInFlightJob(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	inflight := <-workQueue
+
+	require.NoError(t, c.forceSchedule(ctx, gameAddr))
+	require.Empty(t, workQueue, "should not progress game while a job is in flight")
+
+	require.NoError(t, c.processResult(runJob(ctx, inflight)))
+	require.Len(t, workQueue, 1, "should progress game once in-flight job completes")
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.Empty(t, workQueue, "should only force one additional pass")
+	require.Equal(t, 2, games.created[gameAddr].ProgressCount)
+	require.True(t, c.idle.IsIdle())
+}
+
+func TestForceScheduleRejectsUnknownAndResolvedGames(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	resolvedAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.ErrorIs(t, c.forceSchedule(ctx, common.Address{0xbb}), ErrGameNotScheduled)
+
+	require.NoError(t, c.schedule(ctx, asGames(resolvedAddr), 0))
+	j := <-workQueue
+	j.status = types.GameStatusDefenderWon
+	require.NoError(t, c.processResult(j))
+	require.ErrorIs(t, c.forceSchedule(ctx, resolvedAddr), ErrGameResolved)
+	require.Empty(t, workQueue)
+}
+
+func TestSchedulerForceSchedule(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	player := &test.StubGamePlayer{StatusValue: types.GameStatusInProgress, ActionTakenValue: true}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return player, nil
+	}
+	m := &forceMetrics{}
+	s := NewScheduler(logger, m, &stubDiskManager{gameDirExists: make(map[common.Address]bool)}, 1, createPlayer, false, WithActionCooldown(time.Hour))
+	s.Start(ctx)
+	defer s.Close()
+	gameAddr := common.Address{0xaa}
+
+	require.ErrorIs(t, s.ForceSchedule(ctx, gameAddr), ErrGameNotScheduled)
+	require.Zero(t, m.forced.Load(), "should not record rejected force")
+	require.NoError(t, s.Schedule(asGames(gameAddr), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.NoError(t, s.ForceSchedule(ctx, gameAddr))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Equal(t, 2, player.ProgressCount)
+	require.EqualValues(t, 1, m.forced.Load())
+}
+
+type forceMetrics struct {
+	metrics.NoopMetricsImpl
+	forced atomic.Int32
+}
+
+func (m *forceMetrics) RecordForcedSchedule() {
+	m.forced.Add(1)
+}
@@ -0,0 +1,367 @@
+package scheduler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrUnsupportedFullState = errors.New("unsupported full state version")
+	ErrInvalidFullState     = errors.New("invalid full state")
+	ErrAlreadyStarted       = errors.New("scheduler already started")
+)
+
+// FullStateVersion is the version of the document produced by Scheduler.ExportFullState.
+// The version is incremented whenever a field is removed or its meaning changes, and ImportFullState continues to
+// accept documents of every earlier version. New fields may be added without changing the version, in which case
+// they are left at their zero value when importing a document produced before they were added.
+const FullStateVersion = 1
+
+// fullState is the document produced by Scheduler.ExportFullState.
+type fullState struct {
+	Version            uint                `json:"version"`
+	ExportedAt         time.Time           `json:"exportedAt"`
+	Cycle              uint64              `json:"cycle"`
+	LastScheduledBlock uint64              `json:"lastScheduledBlock"`
+	GasSpent           uint64              `json:"gasSpent"`
+	GasWindowStart     time.Time           `json:"gasWindowStart"`
+	BackoffDelay       time.Duration       `json:"backoffDelay"`
+	Games              []fullGameState     `json:"games"`
+	FirstSeen          []fullFirstSeen     `json:"firstSeen"`
+	Abandoned          []fullAbandonedGame `json:"abandoned"`
+	RecentResults      []fullGameResults   `json:"recentResults"`
+	// Sources holds the state of each source registered with RegisterSource, ordered by factory. Only set for the
+	// document as a whole, not the state of each source.
+	Sources []fullSourceState `json:"sources,omitempty"`
+}
+
+// fullSourceState is the state of the games of a source registered with RegisterSource.
+type fullSourceState struct {
+	Factory common.Address `json:"factory"`
+	State   fullState      `json:"state"`
+}
+
+// fullGameState is the durable part of a gameState. Jobs in flight and the player are transient and not included.
+type fullGameState struct {
+	Game               common.Address   `json:"game"`
+	GameType           uint32           `json:"gameType"`
+	Timestamp          uint64           `json:"timestamp"`
+	Status             types.GameStatus `json:"status"`
+	LastProcessedBlock uint64           `json:"lastProcessedBlock"`
+	Activity           float64          `json:"activity"`
+	LastScheduledCycle uint64           `json:"lastScheduledCycle"`
+	LastSeenCycle      uint64           `json:"lastSeenCycle"`
+	CoolingDownUntil   *time.Time       `json:"coolingDownUntil,omitempty"`
+	LastError          string           `json:"lastError,omitempty"`
+	LastErrorAt        *time.Time       `json:"lastErrorAt,omitempty"`
+	Retries            uint             `json:"retries"`
+	FirstFailure       *time.Time       `json:"firstFailure,omitempty"`
+	Scratchpad         Scratchpad       `json:"scratchpad,omitempty"`
+	ProgressFailures   uint             `json:"progressFailures"`
+	LastActed          bool             `json:"lastActed"`
+	Succeeded          bool             `json:"succeeded"`
+	SkipReason         string           `json:"skipReason,omitempty"`
+	ResolvedAt         *time.Time       `json:"resolvedAt,omitempty"`
+	InitFailures       uint             `json:"initFailures"`
+	InitRetryAt        *time.Time       `json:"initRetryAt,omitempty"`
+	FailureStreak      uint             `json:"failureStreak,omitempty"`
+	FailureRetryAt     *time.Time       `json:"failureRetryAt,omitempty"`
+	QuarantinedUntil   *time.Time       `json:"quarantinedUntil,omitempty"`
+	// Pending is set if the game had a job queued or in flight when exported.
+	Pending bool `json:"pending,omitempty"`
+}
+
+type fullFirstSeen struct {
+	Game   common.Address `json:"game"`
+	Cycle  uint64         `json:"cycle"`
+	Time   time.Time      `json:"time"`
+	Expiry *time.Time     `json:"expiry,omitempty"`
+}
+
+type fullAbandonedGame struct {
+	Game         common.Address `json:"game"`
+	Factory      common.Address `json:"factory"`
+	Reason       string         `json:"reason"`
+	FirstFailure time.Time      `json:"firstFailure"`
+	Time         time.Time      `json:"time"`
+}
+
+type fullGameResults struct {
+	Game    common.Address `json:"game"`
+	Results []fullResult   `json:"results"`
+	Updated time.Time      `json:"updated"`
+	Expiry  *time.Time     `json:"expiry,omitempty"`
+}
+
+type fullResult struct {
+	Block    uint64           `json:"block"`
+	Status   types.GameStatus `json:"status"`
+	Acted    bool             `json:"acted"`
+	FollowUp bool             `json:"followUp"`
+}
+
+// ExportFullState returns a JSON encoded, point-in-time snapshot of the complete operational state of the
+// scheduler, so that a standby instance can be primed with ImportFullState for a fast failover without cold
+// starting. This includes the state of every known game, such as its status, retries and cooldown, as well as the
+// first seen, abandoned and recent result records and the gas budget and global backoff, for the games of the
+// primary factory and of every source registered with RegisterSource. Queued and in flight
+// jobs are not included and games with a job in flight are exported as of their most recently processed result,
+// flagged as pending so they are progressed again once imported.
+// Unlike ExportState, the document is intended to be consumed by ImportFullState rather than inspected.
+func (s *Scheduler) ExportFullState() ([]byte, error) {
+	state := s.coordinator.exportFullState()
+	for _, c := range s.coordinators()[1:] {
+		state.Sources = append(state.Sources, fullSourceState{Factory: c.factory, State: c.exportFullState()})
+	}
+	return json.Marshal(state)
+}
+
+// ImportFullState replaces the operational state of the scheduler with the state exported by ExportFullState,
+// possibly from a different instance. Must be called before Start and may be called repeatedly, for example to
+// keep a standby primed from the active instance's periodic exports. Players are created again when each game is
+// next scheduled, except for games that had a job queued or in flight when exported which are progressed as soon as
+// the scheduler starts, subject to the usual scheduling checks, rather than waiting for the next batch. Sources must
+// be registered with RegisterSource before importing for the state of their games to be imported, and the state of
+// sources that aren't registered is ignored. Returns ErrAlreadyStarted once the scheduler has started,
+// ErrUnsupportedFullState if the document is from a newer version and an error wrapping ErrInvalidFullState if it is
+// inconsistent, in which case the existing state is left unchanged.
+func (s *Scheduler) ImportFullState(data []byte) error {
+	if s.started.Load() {
+		return ErrAlreadyStarted
+	}
+	return s.importFullState(data)
+}
+
+// importFullState decodes the document exported by ExportFullState and replaces the state of the scheduler with it.
+func (s *Scheduler) importFullState(data []byte) error {
+	var state fullState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFullState, err)
+	}
+	if state.Version == 0 || state.Version > FullStateVersion {
+		return fmt.Errorf("%w: %v", ErrUnsupportedFullState, state.Version)
+	}
+	apply, err := s.coordinator.importFullState(state)
+	if err != nil {
+		return err
+	}
+	// Validate the state of every source before replacing any so an invalid document leaves all state unchanged.
+	applies := []func(){apply}
+	for _, source := range state.Sources {
+		c, ok := s.sources[source.Factory]
+		if !ok {
+			s.logger.Warn("Ignoring imported state of unregistered game source", "factory", source.Factory)
+			continue
+		}
+		apply, err := c.importFullState(source.State)
+		if err != nil {
+			return fmt.Errorf("source %v: %w", source.Factory, err)
+		}
+		applies = append(applies, apply)
+	}
+	for _, apply := range applies {
+		apply()
+	}
+	s.logger.Info("Imported scheduler state", "exportedAt", state.ExportedAt, "cycle", state.Cycle, "games", len(state.Games))
+	return nil
+}
+
+func (c *coordinator) exportFullState() fullState {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	state := fullState{
+		Version:            FullStateVersion,
+		ExportedAt:         c.cfg.clock.Now(),
+		Cycle:              c.cycle,
+		LastScheduledBlock: c.lastScheduledBlockNum,
+		GasSpent:           c.gas.spent,
+		GasWindowStart:     c.gas.windowStart,
+		BackoffDelay:       c.backoff.delay,
+		Games:              make([]fullGameState, 0, len(c.states)),
+		FirstSeen:          make([]fullFirstSeen, 0, len(c.firstSeen)),
+		Abandoned:          make([]fullAbandonedGame, 0, len(c.abandoned)),
+		RecentResults:      make([]fullGameResults, 0, len(c.history.games)),
+	}
+	for addr, game := range c.states {
+		failures := c.failures.state(addr)
+		exported := fullGameState{
+			Game:               addr,
+			GameType:           game.gameType,
+			Timestamp:          game.timestamp,
+			Status:             game.status,
+			LastProcessedBlock: game.lastProcessedBlockNum,
+			Activity:           game.activity,
+			LastScheduledCycle: game.lastScheduledCycle,
+			LastSeenCycle:      game.lastSeenCycle,
+			CoolingDownUntil:   optionalTime(c.cooldown.coolingDownUntil(addr)),
+			LastErrorAt:        optionalTime(game.lastErrTime),
+			Retries:            game.retries,
+			FirstFailure:       optionalTime(game.firstFailure),
+			Scratchpad:         game.scratchpad.clone(),
+			ProgressFailures:   game.progressFailures,
+			LastActed:          game.lastActed,
+			Succeeded:          game.succeeded,
+			SkipReason:         game.skipReason,
+			ResolvedAt:         optionalTime(game.resolvedAt),
+			InitFailures:       game.initFailures,
+			InitRetryAt:        optionalTime(game.initRetryAt),
+			FailureStreak:      failures.streak,
+			FailureRetryAt:     optionalTime(failures.retryAt),
+			QuarantinedUntil:   optionalTime(failures.quarantinedUntil),
+			Pending:            game.inflight,
+		}
+		if game.lastErr != nil {
+			exported.LastError = game.lastErr.Error()
+		}
+		state.Games = append(state.Games, exported)
+	}
+	slices.SortFunc(state.Games, func(a, b fullGameState) int {
+		return bytes.Compare(a.Game[:], b.Game[:])
+	})
+	for addr, entry := range c.firstSeen {
+		state.FirstSeen = append(state.FirstSeen, fullFirstSeen{Game: addr, Cycle: entry.cycle, Time: entry.time, Expiry: optionalTime(entry.expiry)})
+	}
+	slices.SortFunc(state.FirstSeen, func(a, b fullFirstSeen) int {
+		return bytes.Compare(a.Game[:], b.Game[:])
+	})
+	for _, game := range c.abandoned {
+		state.Abandoned = append(state.Abandoned, fullAbandonedGame(game))
+	}
+	slices.SortFunc(state.Abandoned, func(a, b fullAbandonedGame) int {
+		return bytes.Compare(a.Game[:], b.Game[:])
+	})
+	for addr, entry := range c.history.games {
+		results := make([]fullResult, len(entry.results))
+		for i, result := range entry.results {
+			results[i] = fullResult{Block: result.Block, Status: result.Status, Acted: result.Acted, FollowUp: result.FollowUp}
+		}
+		state.RecentResults = append(state.RecentResults, fullGameResults{Game: addr, Results: results, Updated: entry.updated, Expiry: optionalTime(entry.expiry)})
+	}
+	slices.SortFunc(state.RecentResults, func(a, b fullGameResults) int {
+		return bytes.Compare(a.Game[:], b.Game[:])
+	})
+	return state
+}
+
+// importFullState validates the state and returns a function that replaces the coordinator's state with it.
+func (c *coordinator) importFullState(state fullState) (func(), error) {
+	states := make(map[common.Address]*gameState, len(state.Games))
+	cooldowns := make(map[common.Address]time.Time)
+	failures := make(map[common.Address]*failureState)
+	for _, game := range state.Games {
+		if _, ok := states[game.Game]; ok {
+			return nil, fmt.Errorf("%w: duplicate game %v", ErrInvalidFullState, game.Game)
+		}
+		if _, err := types.GameStatusFromUint8(uint8(game.Status)); err != nil {
+			return nil, fmt.Errorf("%w: game %v: %w", ErrInvalidFullState, game.Game, err)
+		}
+		if game.Activity <= 0 || game.Activity > 1 {
+			return nil, fmt.Errorf("%w: game %v: activity %v out of range", ErrInvalidFullState, game.Game, game.Activity)
+		}
+		imported := &gameState{
+			gameType:              game.GameType,
+			timestamp:             game.Timestamp,
+			status:                game.Status,
+			lastProcessedBlockNum: game.LastProcessedBlock,
+			activity:              game.Activity,
+			lastScheduledCycle:    game.LastScheduledCycle,
+			lastSeenCycle:         game.LastSeenCycle,
+			lastErrTime:           fromOptionalTime(game.LastErrorAt),
+			retries:               game.Retries,
+			firstFailure:          fromOptionalTime(game.FirstFailure),
+			scratchpad:            game.Scratchpad.clone(),
+			progressFailures:      game.ProgressFailures,
+			lastActed:             game.LastActed,
+			succeeded:             game.Succeeded,
+			skipReason:            game.SkipReason,
+			resolvedAt:            fromOptionalTime(game.ResolvedAt),
+			initFailures:          game.InitFailures,
+			initRetryAt:           fromOptionalTime(game.InitRetryAt),
+		}
+		if game.LastError != "" {
+			imported.lastErr = errors.New(game.LastError)
+		}
+		states[game.Game] = imported
+		if game.CoolingDownUntil != nil {
+			cooldowns[game.Game] = *game.CoolingDownUntil
+		}
+		if game.FailureStreak > 0 {
+			failures[game.Game] = &failureState{
+				streak:           game.FailureStreak,
+				retryAt:          fromOptionalTime(game.FailureRetryAt),
+				quarantinedUntil: fromOptionalTime(game.QuarantinedUntil),
+			}
+		}
+	}
+	firstSeenRecords := make(map[common.Address]*firstSeen, len(state.FirstSeen))
+	for _, entry := range state.FirstSeen {
+		firstSeenRecords[entry.Game] = &firstSeen{cycle: entry.Cycle, time: entry.Time, expiry: fromOptionalTime(entry.Expiry)}
+	}
+	pending := make(map[common.Address]bool)
+	for _, game := range state.Games {
+		if game.Pending {
+			pending[game.Game] = true
+		}
+	}
+	abandoned := make(map[common.Address]AbandonedGame, len(state.Abandoned))
+	for _, game := range state.Abandoned {
+		abandoned[game.Game] = AbandonedGame(game)
+	}
+	history := newResultHistory(c.cfg.recentResults)
+	if history.depth > 0 {
+		for _, entry := range state.RecentResults {
+			// Keep the most recent results if fewer are kept by this instance.
+			results := entry.Results[max(0, len(entry.Results)-history.depth):]
+			summaries := make([]ResultSummary, 0, history.depth)
+			for _, result := range results {
+				summaries = append(summaries, ResultSummary{Game: entry.Game, Block: result.Block, Status: result.Status, Acted: result.Acted, FollowUp: result.FollowUp})
+			}
+			history.games[entry.Game] = &gameResults{results: summaries, updated: entry.Updated, expiry: fromOptionalTime(entry.Expiry)}
+			history.total += len(summaries)
+		}
+		for history.total > maxRecentResults {
+			history.evictLeastRecent()
+		}
+	}
+
+	return func() {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		c.states = states
+		c.cooldown.until = cooldowns
+		c.failures.games = failures
+		c.priorities = make(gamePriorities)
+		c.firstSeen = firstSeenRecords
+		c.abandoned = abandoned
+		c.pendingResume = pending
+		c.history = history
+		c.cycle = state.Cycle
+		c.lastScheduledBlockNum = state.LastScheduledBlock
+		c.gas.spent = state.GasSpent
+		c.gas.windowStart = state.GasWindowStart
+		if c.backoff.enabled() {
+			c.backoff.delay = min(state.BackoffDelay, c.backoff.max)
+		}
+	}, nil
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func fromOptionalTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
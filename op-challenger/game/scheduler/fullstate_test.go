@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFullStateFailoverRespectsCooldown(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	game := common.Address{0xaa}
+	failing := common.Address{0xbb}
+
+	activeClock := clock.NewDeterministicClock(time.Unix(1000, 0))
+	active, _ := newFullStateTestScheduler(t, activeClock, failing)
+	active.Start(ctx)
+	defer active.Close()
+	require.NoError(t, active.Schedule(asGames(game, failing), 0))
+	require.NoError(t, active.WaitIdle(ctx))
+	data, err := active.ExportFullState()
+	require.NoError(t, err)
+
+	standbyClock := clock.NewDeterministicClock(time.Unix(1000, 0))
+	standby, players := newFullStateTestScheduler(t, standbyClock, failing)
+	require.NoError(t, standby.ImportFullState(data))
+	standby.Start(ctx)
+	defer standby.Close()
+
+	// The standby has the same state as the active instance
+	reexported, err := standby.ExportFullState()
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(reexported))
+	cycle, _, ok := standby.FirstSeen(game)
+	require.True(t, ok)
+	require.Equal(t, uint64(1), cycle)
+	require.Len(t, standby.RecentResults(game), 1)
+
+	// The game is still cooling down after the action taken by the active instance
+	require.NoError(t, standby.Schedule(asGames(game, failing), 1))
+	require.NoError(t, standby.WaitIdle(ctx))
+	require.Zero(t, players.progressCount(game))
+	decision, reason, cycle := standby.LastDecision(game)
+	require.Equal(t, DecisionSkipped, decision)
+	require.Equal(t, SkipReasonCoolingDown, reason)
+	require.Equal(t, uint64(2), cycle, "cycles continue from the active instance")
+
+	exported := exportedGame(t, standby, failing)
+	require.Equal(t, uint(2), exported.Retries, "retries continue from the active instance")
+
+	standbyClock.AdvanceTime(time.Hour)
+	require.NoError(t, standby.Schedule(asGames(game, failing), 2))
+	require.NoError(t, standby.WaitIdle(ctx))
+	require.Equal(t, 1, players.progressCount(game))
+}
+
+func TestImportFullStateRejected(t *testing.T) {
+	s, _ := newFullStateTestScheduler(t, clock.NewDeterministicClock(time.Unix(1000, 0)), common.Address{})
+	valid, err := s.ExportFullState()
+	require.NoError(t, err)
+
+	require.ErrorIs(t, s.ImportFullState([]byte("not json")), ErrInvalidFullState)
+	require.ErrorIs(t, s.ImportFullState([]byte(`{"version":0}`)), ErrUnsupportedFullState)
+	require.ErrorIs(t, s.ImportFullState([]byte(`{"version":2}`)), ErrUnsupportedFullState)
+	require.ErrorIs(t, s.ImportFullState([]byte(`{"version":1,"games":[{"game":"0xaa00000000000000000000000000000000000000","status":9,"activity":1}]}`)), ErrInvalidFullState)
+	require.ErrorIs(t, s.ImportFullState([]byte(`{"version":1,"games":[{"game":"0xaa00000000000000000000000000000000000000","activity":0}]}`)), ErrInvalidFullState)
+	duplicate := `{"game":"0xaa00000000000000000000000000000000000000","activity":1}`
+	require.ErrorIs(t, s.ImportFullState([]byte(`{"version":1,"games":[`+duplicate+`,`+duplicate+`]}`)), ErrInvalidFullState)
+	require.Empty(t, s.coordinator.states, "rejected imports should not change state")
+
+	require.NoError(t, s.ImportFullState(valid))
+	s.Start(context.Background())
+	defer s.Close()
+	require.ErrorIs(t, s.ImportFullState(valid), ErrAlreadyStarted)
+}
+
+func TestImportFullStateIgnoresUnknownFields(t *testing.T) {
+	s, _ := newFullStateTestScheduler(t, clock.NewDeterministicClock(time.Unix(1000, 0)), common.Address{})
+	data := `{"version":1,"cycle":5,"futureField":true,"games":[{"game":"0xaa00000000000000000000000000000000000000","status":1,"activity":0.5,"futureField":1}]}`
+	require.NoError(t, s.ImportFullState([]byte(data)))
+	require.Equal(t, uint64(5), s.coordinator.cycle)
+	state := s.coordinator.states[common.Address{0xaa}]
+	require.Equal(t, types.GameStatusChallengerWon, state.status)
+	require.Equal(t, 0.5, state.activity)
+	require.False(t, state.inflight)
+	require.Nil(t, state.player)
+}
+
+func newFullStateTestScheduler(t *testing.T, cl *clock.DeterministicClock, failing common.Address) (*Scheduler, *fullStatePlayers) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	players := &fullStatePlayers{created: make(map[common.Address]*test.StubGamePlayer)}
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		if game.Proxy == failing {
+			return nil, errors.New("failed to create player")
+		}
+		players.lock.Lock()
+		defer players.lock.Unlock()
+		player := &test.StubGamePlayer{Addr: game.Proxy, StatusValue: types.GameStatusInProgress, ActionTakenValue: true}
+		players.created[game.Proxy] = player
+		return player, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, WithClock(cl), WithActionCooldown(time.Hour), WithRecentResults(5))
+	return s, players
+}
+
+type fullStatePlayers struct {
+	lock    sync.Mutex
+	created map[common.Address]*test.StubGamePlayer
+}
+
+func (p *fullStatePlayers) progressCount(addr common.Address) int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if player, ok := p.created[addr]; ok {
+		return player.ProgressCount
+	}
+	return 0
+}
+
+func exportedGame(t *testing.T, s *Scheduler, addr common.Address) ExportedGame {
+	data, err := s.ExportState()
+	require.NoError(t, err)
+	var state ExportedState
+	require.NoError(t, json.Unmarshal(data, &state))
+	for _, game := range state.Games {
+		if game.Game == addr {
+			return game
+		}
+	}
+	t.Fatalf("game %v not exported", addr)
+	return ExportedGame{}
+}
@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"maps"
+	"time"
+)
+
+// GameTypeConfig overrides global settings for games of a single type, see WithGameTypeConfig.
+// A zero value uses the global setting and a negative value disables the setting for the game type.
+type GameTypeConfig struct {
+	// Timeout limits how long each progression may run, see ScheduledGame.Timeout. There is no global timeout
+	// so zero means progressions aren't limited. A timeout set for an individual game takes precedence.
+	Timeout time.Duration
+	// ActionCooldown overrides the cooldown set by WithActionCooldown.
+	ActionCooldown time.Duration
+	// MaxRetryAge overrides the limit set by WithMaxRetryAge.
+	MaxRetryAge time.Duration
+}
+
+// gameTypeSettings returns the settings for games of the type, applying any overrides set by WithGameTypeConfig
+// to the global settings. Disabled settings are zero.
+func (c *coordinator) gameTypeSettings(gameType uint32) GameTypeConfig {
+	return c.cfg.gameTypeSettings(gameType)
+}
+
+func (cfg *config) gameTypeSettings(gameType uint32) GameTypeConfig {
+	settings := GameTypeConfig{
+		ActionCooldown: cfg.actionCooldown,
+		MaxRetryAge:    cfg.maxRetryAge,
+	}
+	override, ok := cfg.gameTypes[gameType]
+	if !ok {
+		return settings
+	}
+	settings.Timeout = overrideSetting(settings.Timeout, override.Timeout)
+	settings.ActionCooldown = overrideSetting(settings.ActionCooldown, override.ActionCooldown)
+	settings.MaxRetryAge = overrideSetting(settings.MaxRetryAge, override.MaxRetryAge)
+	return settings
+}
+
+func overrideSetting(global, override time.Duration) time.Duration {
+	switch {
+	case override < 0:
+		return 0
+	case override > 0:
+		return override
+	default:
+		return global
+	}
+}
+
+func cloneGameTypes(gameTypes map[uint32]GameTypeConfig) map[uint32]GameTypeConfig {
+	if gameTypes == nil {
+		return nil
+	}
+	return maps.Clone(gameTypes)
+}
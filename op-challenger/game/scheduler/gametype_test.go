@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testFaultGameType        uint32 = 0
+	testPermissionedGameType uint32 = 1
+)
+
+func TestGameTypeTimeouts(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	WithGameTypeConfig(testFaultGameType, GameTypeConfig{Timeout: time.Minute})(&c.cfg)
+	WithGameTypeConfig(testPermissionedGameType, GameTypeConfig{Timeout: time.Hour})(&c.cfg)
+	faultGame := common.Address{0xaa}
+	permissionedGame := common.Address{0xbb}
+	otherGame := common.Address{0xcc}
+	games := []types.GameMetadata{
+		{Proxy: faultGame, GameType: testFaultGameType},
+		{Proxy: permissionedGame, GameType: testPermissionedGameType},
+		{Proxy: otherGame, GameType: 2},
+	}
+
+	require.NoError(t, c.schedule(context.Background(), games, 0))
+	timeouts := make(map[common.Address]time.Duration)
+	for len(workQueue) > 0 {
+		j := <-workQueue
+		timeouts[j.addr] = j.timeout
+	}
+	require.Equal(t, map[common.Address]time.Duration{
+		faultGame:        time.Minute,
+		permissionedGame: time.Hour,
+		otherGame:        0,
+	}, timeouts)
+}
+
+func TestGameTimeoutTakesPrecedenceOverGameType(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	WithGameTypeConfig(testFaultGameType, GameTypeConfig{Timeout: time.Minute})(&c.cfg)
+	gameAddr := common.Address{0xaa}
+	c.setTimeouts(map[common.Address]time.Duration{gameAddr: time.Second})
+
+	require.NoError(t, c.schedule(context.Background(), asGames(gameAddr), 0))
+	require.Equal(t, time.Second, (<-workQueue).timeout)
+}
+
+func TestGameTypeActionCooldown(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.actionCooldown = time.Minute
+	WithGameTypeConfig(testPermissionedGameType, GameTypeConfig{ActionCooldown: -1})(&c.cfg)
+	faultGame := common.Address{0xaa}
+	permissionedGame := common.Address{0xbb}
+	batch := []types.GameMetadata{
+		{Proxy: faultGame, GameType: testFaultGameType},
+		{Proxy: permissionedGame, GameType: testPermissionedGameType},
+	}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, batch, 0))
+	for len(workQueue) > 0 {
+		j := <-workQueue
+		games.created[j.addr].ActionTakenValue = true
+		require.NoError(t, c.processResult(runJob(ctx, j)))
+	}
+	require.NoError(t, c.schedule(ctx, batch, 1))
+	require.Len(t, workQueue, 1, "only the game type with the cooldown disabled should be progressed")
+	require.Equal(t, permissionedGame, (<-workQueue).addr)
+}
+
+func TestGameTypeMaxRetryAge(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	WithGameTypeConfig(testPermissionedGameType, GameTypeConfig{MaxRetryAge: time.Minute})(&c.cfg)
+	faultGame := common.Address{0xaa}
+	permissionedGame := common.Address{0xbb}
+	batch := []types.GameMetadata{
+		{Proxy: faultGame, GameType: testFaultGameType},
+		{Proxy: permissionedGame, GameType: testPermissionedGameType},
+	}
+	ctx := context.Background()
+
+	for i := uint64(0); i < 3; i++ {
+		require.NoError(t, c.schedule(ctx, batch, i))
+		for len(workQueue) > 0 {
+			j := <-workQueue
+			j.err = errors.New("boom")
+			require.NoError(t, c.processResult(j))
+		}
+		cl.AdvanceTime(time.Minute)
+	}
+	require.Contains(t, c.abandoned, permissionedGame)
+	require.NotContains(t, c.abandoned, faultGame, "global retry age is unlimited")
+}
+
+func TestGameTypeSettingsPrecedence(t *testing.T) {
+	c, _, _, _, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.actionCooldown = time.Minute
+	c.cfg.maxRetryAge = time.Hour
+	WithGameTypeConfig(testPermissionedGameType, GameTypeConfig{Timeout: time.Second, ActionCooldown: -1, MaxRetryAge: 2 * time.Hour})(&c.cfg)
+
+	require.Equal(t, GameTypeConfig{ActionCooldown: time.Minute, MaxRetryAge: time.Hour}, c.gameTypeSettings(testFaultGameType))
+	require.Equal(t, GameTypeConfig{Timeout: time.Second, MaxRetryAge: 2 * time.Hour}, c.gameTypeSettings(testPermissionedGameType))
+}
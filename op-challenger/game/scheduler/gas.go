@@ -0,0 +1,44 @@
+package scheduler
+
+import "time"
+
+type GasBudgetMetricer interface {
+	RecordGasBudgetDeferred()
+}
+
+// GasReporter is an optional interface a GamePlayer can implement to report the estimated gas spent by
+// transactions sent during its most recent ProgressGame call. Used to enforce the budget set by WithGasBudget.
+type GasReporter interface {
+	GasSpent() uint64
+}
+
+// gasBudget tracks the estimated gas spent within the current window against a budget.
+// A window of 0 resets the budget at the start of each scheduling cycle.
+type gasBudget struct {
+	budget uint64
+	window time.Duration
+
+	spent       uint64
+	windowStart time.Time
+}
+
+func (g *gasBudget) enabled() bool {
+	return g.budget > 0
+}
+
+// startCycle is called at the start of each scheduling cycle and begins a new window if the current has ended.
+func (g *gasBudget) startCycle(now time.Time) {
+	if g.window == 0 || !now.Before(g.windowStart.Add(g.window)) {
+		g.spent = 0
+		g.windowStart = now
+	}
+}
+
+func (g *gasBudget) record(gas uint64) {
+	g.spent += gas
+}
+
+// exhausted returns true if the budget for the current window has been used.
+func (g *gasBudget) exhausted() bool {
+	return g.enabled() && g.spent >= g.budget
+}
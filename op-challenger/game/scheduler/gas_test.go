@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasBudgetPerCycle(t *testing.T) {
+	budget := gasBudget{budget: 100}
+	now := time.Unix(1000, 0)
+	budget.startCycle(now)
+	require.False(t, budget.exhausted())
+	budget.record(100)
+	require.True(t, budget.exhausted())
+	budget.startCycle(now)
+	require.False(t, budget.exhausted())
+}
+
+func TestGasBudgetDisabled(t *testing.T) {
+	budget := gasBudget{}
+	budget.startCycle(time.Unix(1000, 0))
+	budget.record(1_000_000)
+	require.False(t, budget.exhausted())
+}
+
+func TestDeferActingGamesWhenGasBudgetUsed(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	c.gas = gasBudget{budget: 100, window: time.Minute}
+	m := c.m.(*stubSchedulerMetrics)
+	actingGame1 := common.Address{0xaa}
+	actingGame2 := common.Address{0xbb}
+	readOnlyGame := common.Address{0xcc}
+	players := map[common.Address]*gasPlayer{
+		actingGame1:  {StubGamePlayer: test.StubGamePlayer{ActionTakenValue: true}, gas: 60},
+		actingGame2:  {StubGamePlayer: test.StubGamePlayer{ActionTakenValue: true}, gas: 60},
+		readOnlyGame: {},
+	}
+	c.createPlayer = func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return players[game.Proxy], nil
+	}
+	ctx := context.Background()
+	runCycle := func(block uint64) []common.Address {
+		require.NoError(t, c.schedule(ctx, asGames(actingGame1, actingGame2, readOnlyGame), block))
+		var scheduled []common.Address
+		for len(workQueue) > 0 {
+			j := <-workQueue
+			scheduled = append(scheduled, j.addr)
+			require.NoError(t, c.processResult(runJob(ctx, j)))
+		}
+		return scheduled
+	}
+
+	require.Equal(t, []common.Address{actingGame1, actingGame2, readOnlyGame}, runCycle(0))
+	require.Zero(t, m.gasDeferred)
+
+	// Budget is used so only the read-only game is scheduled for the rest of the window
+	cl.AdvanceTime(30 * time.Second)
+	require.Equal(t, []common.Address{readOnlyGame}, runCycle(1))
+	require.Equal(t, 2, m.gasDeferred)
+	cl.AdvanceTime(29 * time.Second)
+	require.Equal(t, []common.Address{readOnlyGame}, runCycle(2))
+	require.Equal(t, 4, m.gasDeferred)
+
+	// New window
+	cl.AdvanceTime(time.Second)
+	require.Equal(t, []common.Address{actingGame1, actingGame2, readOnlyGame}, runCycle(3))
+	require.Equal(t, 4, m.gasDeferred)
+}
+
+type gasPlayer struct {
+	test.StubGamePlayer
+	gas uint64
+}
+
+func (g *gasPlayer) GasSpent() uint64 {
+	return g.gas
+}
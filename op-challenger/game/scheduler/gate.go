@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type BatchGateMetricer interface {
+	RecordCycleGated()
+}
+
+// BatchGate reports whether a scheduling cycle should proceed given the games in the batch, see WithBatchGate.
+type BatchGate func(ctx context.Context, games []common.Address) (bool, error)
+
+// checkBatchGate runs the gate set by WithBatchGate and returns true if the cycle should proceed.
+// The cycle is skipped if the gate fails. Must be called without the lock held as the gate may be slow.
+func (c *coordinator) checkBatchGate(ctx context.Context, games []types.GameMetadata) bool {
+	if c.cfg.batchGate == nil {
+		return true
+	}
+	addrs := make([]common.Address, len(games))
+	for i, game := range games {
+		addrs[i] = game.Proxy
+	}
+	proceed, err := c.cfg.batchGate(ctx, addrs)
+	if err != nil {
+		c.errLog.Log(log.LevelWarn, "gate", "Failed to evaluate batch gate, skipping cycle", err, "games", len(games))
+		proceed = false
+	}
+	if !proceed {
+		c.logger.Info("Batch gate closed, skipping cycle", "games", len(games))
+		c.m.RecordCycleGated()
+	}
+	return proceed
+}
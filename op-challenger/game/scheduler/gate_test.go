@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchGate(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	m := c.m.(*stubSchedulerMetrics)
+	proceed := false
+	var gated [][]common.Address
+	c.cfg.batchGate = func(ctx context.Context, games []common.Address) (bool, error) {
+		gated = append(gated, games)
+		return proceed, nil
+	}
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(game1, game2), 0))
+	require.Empty(t, workQueue, "should skip cycle while gate is closed")
+	require.Empty(t, c.states, "should not create any jobs while gate is closed")
+	require.Zero(t, c.cycle)
+	require.Equal(t, 1, m.gatedCycles)
+	require.True(t, c.idle.IsIdle())
+
+	proceed = true
+	require.NoError(t, c.schedule(ctx, asGames(game1, game2), 1))
+	require.Len(t, workQueue, 2, "should schedule games once gate opens")
+	require.Equal(t, uint64(1), c.cycle)
+	require.Equal(t, 1, m.gatedCycles)
+	require.Equal(t, [][]common.Address{{game1, game2}, {game1, game2}}, gated)
+}
+
+func TestBatchGateErrorSkipsCycle(t *testing.T) {
+	c, workQueue, _, _, _, logs := setupCoordinatorTest(t, 10)
+	m := c.m.(*stubSchedulerMetrics)
+	c.cfg.batchGate = func(ctx context.Context, games []common.Address) (bool, error) {
+		return true, errors.New("boom")
+	}
+
+	require.NoError(t, c.schedule(context.Background(), asGames(common.Address{0xaa}), 0))
+	require.Empty(t, workQueue)
+	require.Equal(t, 1, m.gatedCycles)
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Failed to evaluate batch gate, skipping cycle")))
+}
@@ -0,0 +1,123 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrJobAbandoned = errors.New("job abandoned")
+
+// GameResult is the result of progressing a game as part of a group, see ScheduleGroupAndWait.
+type GameResult struct {
+	Game   common.Address
+	Result ResultSummary
+	// Err is the error reported when progressing the game (see ErrorReporter), or the reason it couldn't be
+	// progressed, in which case Result is empty.
+	Err error
+}
+
+// groupRequest asks the scheduler loop to progress a group of games, see ScheduleGroupAndWait.
+type groupRequest struct {
+	games  []common.Address
+	result chan []chan GameResult
+}
+
+// ScheduleGroupAndWait progresses each of the games once and waits for them all to complete, returning their
+// results in the same order as games. It is intended for tooling that needs to synchronously progress a specific
+// set of games, for example to verify a fix. Like ForceSchedule, the usual scheduling checks are bypassed.
+// Jobs share the job queue and workers with regularly scheduled games rather than taking priority over them.
+// If a game already has a job in flight, the result of that job is returned rather than progressing it again.
+// Errors for individual games are reported in their GameResult: ErrGameNotScheduled if the game has not been
+// scheduled, ErrGameResolved if it has already resolved or ErrJobAbandoned if its job was not run.
+// Returns an error without any results if ctx is done or the scheduler stops before all games complete.
+func (s *Scheduler) ScheduleGroupAndWait(ctx context.Context, games []common.Address) ([]GameResult, error) {
+	if !s.started.Load() {
+		return nil, ErrNotStarted
+	}
+	if s.coordinator.jobLimitReached.Load() {
+		return nil, ErrJobLimitReached
+	}
+	req := groupRequest{games: games, result: make(chan []chan GameResult, 1)}
+	select {
+	case s.groupQueue <- req:
+	case <-s.stopped:
+		return nil, ErrStopped
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	var waiters []chan GameResult
+	select {
+	case waiters = <-req.result:
+	case <-s.stopped:
+		return nil, ErrStopped
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	results := make([]GameResult, len(games))
+	for i, waiter := range waiters {
+		select {
+		case results[i] = <-waiter:
+		case <-s.stopped:
+			return nil, ErrStopped
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return results, nil
+}
+
+func (s *Scheduler) handleGroup(ctx context.Context, req groupRequest) {
+	req.result <- s.coordinator.scheduleGroup(ctx, req.games)
+}
+
+// scheduleGroup enqueues a job to progress each of the games without applying the usual scheduling checks and
+// returns a channel for each game that receives its result. Games that already have a job pending receive the
+// result of that job instead.
+func (c *coordinator) scheduleGroup(ctx context.Context, games []common.Address) []chan GameResult {
+	waiters := make([]chan GameResult, len(games))
+	var jobs []job
+	c.lock.Lock()
+	for i, addr := range games {
+		waiter := make(chan GameResult, 1)
+		waiters[i] = waiter
+		state, ok := c.states[addr]
+		if !ok || state.player == nil {
+			waiter <- GameResult{Game: addr, Err: fmt.Errorf("%w: %v", ErrGameNotScheduled, addr)}
+			continue
+		}
+		if state.status != types.GameStatusInProgress {
+			waiter <- GameResult{Game: addr, Err: fmt.Errorf("%w: %v", ErrGameResolved, addr)}
+			continue
+		}
+		c.waiters[addr] = append(c.waiters[addr], waiter)
+		if state.pendingJobID != 0 {
+			c.tracer.Log(addr, "Group member waiting for in-flight job", "job", state.pendingJobID)
+			continue
+		}
+		jobs = append(jobs, *c.newForcedJob(addr, state))
+	}
+	c.lock.Unlock()
+
+	c.logger.Info("Scheduling group of games", "games", len(games), "jobs", len(jobs))
+	for i, j := range jobs {
+		if err := c.enqueueJob(ctx, j); err != nil {
+			c.logger.Warn("Failed to enqueue group jobs", "remaining", len(jobs)-i, "err", err)
+			c.abandonJobs(jobs[i:])
+			break
+		}
+		c.tracer.Log(j.addr, "Enqueued group job", "block", j.block)
+	}
+	return waiters
+}
+
+// notifyWaiters sends the result to everything waiting for the game's current job. The lock must be held.
+func (c *coordinator) notifyWaiters(result GameResult) {
+	for _, waiter := range c.waiters[result.Game] {
+		waiter <- result
+	}
+	delete(c.waiters, result.Game)
+}
@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleGroup(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	inflightGame := common.Address{0xcc}
+	unknownGame := common.Address{0xdd}
+	resolvedGame := common.Address{0xee}
+	games.createCompleted = resolvedGame
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(game1, game2, inflightGame, resolvedGame), 0))
+	require.Len(t, workQueue, 3)
+	var inflight job
+	for i := 0; i < 3; i++ {
+		j := <-workQueue
+		if j.addr == inflightGame {
+			inflight = j
+			continue
+		}
+		require.NoError(t, c.processResult(runJob(ctx, j)))
+	}
+	progressErr := errors.New("boom")
+	games.created[game2].ProgressErr = progressErr
+
+	waiters := c.scheduleGroup(ctx, []common.Address{game1, unknownGame, inflightGame, resolvedGame, game2})
+	require.Len(t, waiters, 5)
+	require.Len(t, workQueue, 2, "should not progress game with a job in flight again")
+	for i := 0; i < 2; i++ {
+		require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	}
+	require.Empty(t, waiters[2], "should wait for in-flight job")
+	games.created[inflightGame].ActionTakenValue = true
+	require.NoError(t, c.processResult(runJob(ctx, inflight)))
+
+	results := make([]GameResult, len(waiters))
+	for i, waiter := range waiters {
+		require.Len(t, waiter, 1)
+		results[i] = <-waiter
+	}
+	require.Equal(t, GameResult{Game: game1, Result: ResultSummary{Game: game1, Status: types.GameStatusInProgress}}, results[0])
+	require.Equal(t, unknownGame, results[1].Game)
+	require.ErrorIs(t, results[1].Err, ErrGameNotScheduled)
+	require.Equal(t, GameResult{Game: inflightGame, Result: ResultSummary{Game: inflightGame, Status: types.GameStatusInProgress, Acted: true}}, results[2])
+	require.Equal(t, resolvedGame, results[3].Game)
+	require.ErrorIs(t, results[3].Err, ErrGameResolved)
+	require.Equal(t, game2, results[4].Game)
+	require.ErrorIs(t, results[4].Err, progressErr)
+
+	require.Equal(t, 2, games.created[game1].ProgressCount)
+	require.Equal(t, 1, games.created[inflightGame].ProgressCount, "deduped game should only be progressed once")
+	require.Empty(t, c.waiters)
+	require.True(t, c.idle.IsIdle())
+}
+
+func TestScheduleGroupDuplicateMembers(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+
+	waiters := c.scheduleGroup(ctx, []common.Address{gameAddr, gameAddr})
+	require.Len(t, workQueue, 1)
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.Equal(t, <-waiters[0], <-waiters[1])
+	require.Equal(t, 2, games.created[gameAddr].ProgressCount)
+}
+
+func TestScheduleGroupAbandonedJobs(t *testing.T) {
+	c, _, _, _, _, _ := setupCoordinatorTest(t, 0)
+	gameAddr := common.Address{0xaa}
+	require.NoError(t, c.schedule(context.Background(), nil, 0))
+	c.states[gameAddr] = &gameState{player: &blockingPlayer{}, status: types.GameStatusInProgress}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	waiters := c.scheduleGroup(ctx, []common.Address{gameAddr})
+	result := <-waiters[0]
+	require.ErrorIs(t, result.Err, ErrJobAbandoned)
+	require.Zero(t, c.states[gameAddr].pendingJobID)
+	require.True(t, c.idle.IsIdle())
+}
+
+func TestScheduleGroupAndWait(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	release := make(chan struct{})
+	blocked := common.Address{0xcc}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		if g.Proxy == blocked {
+			return &blockingPlayer{release: release}, nil
+		}
+		return &test.StubGamePlayer{Addr: g.Proxy, StatusValue: types.GameStatusInProgress}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 100)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false)
+	s.Start(context.Background())
+	defer s.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	require.NoError(t, s.ScheduleCorrelated(asGames(game1, game2), 1, "batch"))
+	require.NoError(t, s.WaitIdle(ctx))
+
+	results, err := s.ScheduleGroupAndWait(ctx, []common.Address{game2, game1})
+	require.NoError(t, err)
+	require.Equal(t, []GameResult{
+		{Game: game2, Result: ResultSummary{Game: game2, Block: 1, Status: types.GameStatusInProgress, CorrelationID: "batch"}},
+		{Game: game1, Result: ResultSummary{Game: game1, Block: 1, Status: types.GameStatusInProgress, CorrelationID: "batch"}},
+	}, results)
+
+	// Waiting respects cancellation
+	require.NoError(t, s.Schedule(asGames(blocked), 2))
+	shortCtx, shortCancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer shortCancel()
+	_, err = s.ScheduleGroupAndWait(shortCtx, []common.Address{game1, blocked})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	results, err = s.ScheduleGroupAndWait(ctx, []common.Address{blocked})
+	require.NoError(t, err)
+	require.Equal(t, blocked, results[0].Game)
+	require.NoError(t, s.WaitIdle(ctx))
+}
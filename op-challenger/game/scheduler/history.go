@@ -0,0 +1,105 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// recentResultsRetention is how long the recent results of a game are kept after it resolves or stops being scheduled.
+const recentResultsRetention = time.Hour
+
+// maxRecentResults bounds the total number of recent results kept across all games. Once reached, the results of
+// the game updated least recently are discarded.
+const maxRecentResults = 10_000
+
+// gameResults holds the most recent results for a single game, oldest first.
+type gameResults struct {
+	results []ResultSummary
+	updated time.Time
+	// expiry is the time after which the results are pruned, or zero while the game is in progress and scheduled.
+	expiry time.Time
+}
+
+// resultHistory keeps the most recent results of each game, see WithRecentResults.
+type resultHistory struct {
+	depth int
+	games map[common.Address]*gameResults
+	total int
+}
+
+func newResultHistory(depth int) resultHistory {
+	return resultHistory{depth: depth, games: make(map[common.Address]*gameResults)}
+}
+
+// record adds the result to the game's history, discarding its oldest result once depth results are held.
+func (h *resultHistory) record(result ResultSummary, now time.Time) {
+	if h.depth <= 0 {
+		return
+	}
+	entry, ok := h.games[result.Game]
+	if !ok {
+		entry = &gameResults{results: make([]ResultSummary, 0, h.depth)}
+		h.games[result.Game] = entry
+	}
+	if len(entry.results) == h.depth {
+		copy(entry.results, entry.results[1:])
+		entry.results = entry.results[:h.depth-1]
+		h.total--
+	}
+	entry.results = append(entry.results, result)
+	entry.updated = now
+	h.total++
+	for h.total > maxRecentResults {
+		h.evictLeastRecent()
+	}
+}
+
+func (h *resultHistory) evictLeastRecent() {
+	var oldest common.Address
+	var oldestTime time.Time
+	for addr, entry := range h.games {
+		if oldestTime.IsZero() || entry.updated.Before(oldestTime) {
+			oldest, oldestTime = addr, entry.updated
+		}
+	}
+	h.remove(oldest)
+}
+
+func (h *resultHistory) remove(addr common.Address) {
+	if entry, ok := h.games[addr]; ok {
+		h.total -= len(entry.results)
+		delete(h.games, addr)
+	}
+}
+
+// pruneRecentResults removes the recent results of games that resolved or stopped being scheduled more than
+// recentResultsRetention ago. The lock must be held.
+func (c *coordinator) pruneRecentResults() {
+	now := c.cfg.clock.Now()
+	for addr, entry := range c.history.games {
+		state, ok := c.states[addr]
+		switch {
+		case ok && state.status == types.GameStatusInProgress:
+			entry.expiry = time.Time{}
+		case entry.expiry.IsZero():
+			entry.expiry = now.Add(recentResultsRetention)
+		case !now.Before(entry.expiry):
+			c.history.remove(addr)
+		}
+	}
+}
+
+// RecentResults returns the most recent results for the game, oldest first, up to the depth set by
+// WithRecentResults. Returns nil if recent results aren't being kept or the game has no results.
+func (s *Scheduler) RecentResults(addr common.Address) []ResultSummary {
+	c := s.coordinator
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	entry, ok := c.history.games[addr]
+	if !ok {
+		return nil
+	}
+	return append([]ResultSummary(nil), entry.results...)
+}
@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecentResults(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{StatusValue: types.GameStatusInProgress}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, WithRecentResults(3))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+	gameAddr := common.Address{0xaa}
+
+	require.Nil(t, s.RecentResults(gameAddr))
+	for block := uint64(1); block <= 5; block++ {
+		require.NoError(t, s.Schedule(asGames(gameAddr), block))
+		require.NoError(t, s.WaitIdle(ctx))
+	}
+	results := s.RecentResults(gameAddr)
+	require.Len(t, results, 3)
+	for i, result := range results {
+		require.Equal(t, gameAddr, result.Game)
+		require.Equal(t, uint64(i+3), result.Block, "should hold the most recent results in order")
+	}
+}
+
+func TestRecentResultsDisabledByDefault(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	gameAddr := common.Address{0xaa}
+	require.NoError(t, c.schedule(context.Background(), asGames(gameAddr), 0))
+	require.NoError(t, c.processResult(<-workQueue))
+	require.Empty(t, c.history.games)
+}
+
+func TestPruneRecentResultsAfterResolution(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	c.history = newResultHistory(3)
+	resolvedGame := common.Address{0xaa}
+	activeGame := common.Address{0xbb}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(resolvedGame, activeGame), 0))
+	for i := 0; i < 2; i++ {
+		j := <-workQueue
+		if j.addr == resolvedGame {
+			j.status = types.GameStatusDefenderWon
+		}
+		require.NoError(t, c.processResult(j))
+	}
+
+	require.NoError(t, c.schedule(ctx, asGames(resolvedGame, activeGame), 1))
+	require.NoError(t, c.processResult(<-workQueue))
+	require.Contains(t, c.history.games, resolvedGame, "should keep results after resolution")
+
+	cl.AdvanceTime(recentResultsRetention)
+	require.NoError(t, c.schedule(ctx, asGames(resolvedGame, activeGame), 2))
+	require.NoError(t, c.processResult(<-workQueue))
+	require.NotContains(t, c.history.games, resolvedGame, "should prune results once retention expires")
+	require.Len(t, c.history.games[activeGame].results, 3, "should keep results for in progress games")
+	require.Equal(t, 3, c.history.total)
+}
+
+func TestRecentResultsEvictLeastRecentlyUpdated(t *testing.T) {
+	h := newResultHistory(2)
+	now := time.Unix(1000, 0)
+	h.record(ResultSummary{Game: common.Address{0xaa}}, now)
+	h.record(ResultSummary{Game: common.Address{0xbb}}, now.Add(time.Second))
+	h.record(ResultSummary{Game: common.Address{0xbb}}, now.Add(2*time.Second))
+	require.Equal(t, 3, h.total)
+
+	h.evictLeastRecent()
+	require.NotContains(t, h.games, common.Address{0xaa})
+	require.Len(t, h.games[common.Address{0xbb}].results, 2)
+	require.Equal(t, 2, h.total)
+}
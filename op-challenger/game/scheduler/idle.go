@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// idleTracker counts outstanding units of work (pending schedule batches and jobs that have not yet had
+// their result processed) and allows waiting until there is no outstanding work.
+type idleTracker struct {
+	logger  log.Logger
+	lock    sync.Mutex
+	pending int
+	// idle is closed whenever pending is zero and replaced with a new channel when work is added.
+	idle chan struct{}
+}
+
+func newIdleTracker(logger log.Logger) *idleTracker {
+	idle := make(chan struct{})
+	close(idle)
+	return &idleTracker{logger: logger, idle: idle}
+}
+
+// Add adjusts the amount of outstanding work by delta. More work completing than was added indicates a bug, which
+// is logged and the count reset to zero so waiters are released rather than blocked forever.
+func (t *idleTracker) Add(delta int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	wasIdle := t.pending == 0
+	t.pending += delta
+	if t.pending < 0 {
+		t.logger.Error("Negative pending work count", "pending", t.pending, "delta", delta)
+		t.pending = 0
+	}
+	if wasIdle && t.pending > 0 {
+		t.idle = make(chan struct{})
+	} else if !wasIdle && t.pending == 0 {
+		close(t.idle)
+	}
+}
+
+// Done marks a single unit of outstanding work as complete.
+func (t *idleTracker) Done() {
+	t.Add(-1)
+}
+
+// IsIdle returns true if there is no outstanding work.
+func (t *idleTracker) IsIdle() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.pending == 0
+}
+
+// Wait blocks until there is no outstanding work or the context is done.
+func (t *idleTracker) Wait(ctx context.Context) error {
+	t.lock.Lock()
+	idle := t.idle
+	t.lock.Unlock()
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
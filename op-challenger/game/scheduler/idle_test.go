@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdleTracker(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	tracker := newIdleTracker(testlog.Logger(t, log.LevelInfo))
+	require.NoError(t, tracker.Wait(ctx), "should be idle initially")
+
+	tracker.Add(2)
+	waitResult := make(chan error, 1)
+	go func() {
+		waitResult <- tracker.Wait(ctx)
+	}()
+
+	tracker.Done()
+	select {
+	case <-waitResult:
+		t.Fatal("should not be idle with outstanding work")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Completing the last unit of work should unblock the waiter immediately
+	tracker.Done()
+	require.NoError(t, readWithTimeout(t, waitResult))
+
+	tracker.Add(1)
+	timeoutCtx, timeoutCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer timeoutCancel()
+	require.ErrorIs(t, tracker.Wait(timeoutCtx), context.DeadlineExceeded)
+}
+
+func TestIdleTrackerNegativeCount(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	tracker := newIdleTracker(logger)
+	tracker.Add(1)
+	tracker.Add(-2)
+	require.True(t, tracker.IsIdle())
+	require.NoError(t, tracker.Wait(context.Background()), "should release waiters")
+	require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageFilter("Negative pending work count")))
+
+	// Work added afterwards is still tracked
+	tracker.Add(1)
+	require.False(t, tracker.IsIdle())
+	tracker.Done()
+	require.True(t, tracker.IsIdle())
+}
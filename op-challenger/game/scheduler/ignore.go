@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ignoreRequest asks the scheduler loop to stop or resume scheduling a game, see IgnoreGame and UnignoreGame.
+type ignoreRequest struct {
+	addr   common.Address
+	ignore bool
+	result chan struct{}
+}
+
+// IgnoreGame stops the game from being scheduled until UnignoreGame is called, for operators to take a
+// misbehaving game out of rotation while the challenger is running. A job for the game that hasn't been picked
+// up by a worker is dropped and the context passed to a player already progressing it is cancelled. The game
+// doesn't need to have been scheduled yet, so a game can be ignored before it is first seen. Games with the address
+// from any source registered with RegisterSource are also ignored.
+func (s *Scheduler) IgnoreGame(ctx context.Context, addr common.Address) error {
+	return s.sendIgnore(ctx, addr, true)
+}
+
+// UnignoreGame allows a game ignored by IgnoreGame to be scheduled again from the next batch.
+func (s *Scheduler) UnignoreGame(ctx context.Context, addr common.Address) error {
+	return s.sendIgnore(ctx, addr, false)
+}
+
+func (s *Scheduler) sendIgnore(ctx context.Context, addr common.Address, ignore bool) error {
+	if !s.started.Load() {
+		return ErrNotStarted
+	}
+	req := ignoreRequest{addr: addr, ignore: ignore, result: make(chan struct{}, 1)}
+	select {
+	case s.ignoreRequests <- req:
+	case <-s.stopped:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-req.result:
+		return nil
+	case <-s.stopped:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) handleIgnore(req ignoreRequest) {
+	// Games are identified only by address so the game is ignored for every source.
+	for _, c := range s.coordinators() {
+		if req.ignore {
+			c.ignoreGame(req.addr)
+		} else {
+			c.unignoreGame(req.addr)
+		}
+	}
+	req.result <- struct{}{}
+}
+
+// ignoreGame marks the game as ignored and cancels its queued or in flight job.
+func (c *coordinator) ignoreGame(addr common.Address) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.ignored[addr] {
+		return
+	}
+	c.ignored[addr] = true
+	var dropped []job
+	c.deferred = slices.DeleteFunc(c.deferred, func(j job) bool {
+		if j.addr == addr {
+			dropped = append(dropped, j)
+			return true
+		}
+		return false
+	})
+	c.releaseJobs(dropped)
+	if state, ok := c.states[addr]; ok && state.pendingJobID != 0 {
+		c.tracer.Log(addr, "Cancelling job of ignored game", "job", state.pendingJobID)
+		c.canceller.cancel(state.pendingJobID)
+	}
+	c.logger.Info("Ignoring game", "game", addr)
+	c.tracer.Log(addr, "Ignoring game")
+}
+
+func (c *coordinator) unignoreGame(addr common.Address) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.ignored[addr] {
+		return
+	}
+	delete(c.ignored, addr)
+	c.logger.Info("No longer ignoring game", "game", addr)
+	c.tracer.Log(addr, "No longer ignoring game")
+}
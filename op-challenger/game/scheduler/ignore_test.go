@@ -0,0 +1,84 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreGame(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.decisionTTL = time.Hour
+	ignored := common.Address{0xaa}
+	other := common.Address{0xbb}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(ignored, other), 0))
+	require.Len(t, workQueue, 2)
+	pendingJobID := c.states[ignored].pendingJobID
+	c.ignoreGame(ignored)
+	c.ignoreGame(ignored)
+	require.True(t, c.canceller.cancelled[pendingJobID], "should cancel the in flight job")
+	for len(workQueue) > 0 {
+		require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	}
+
+	require.NoError(t, c.schedule(ctx, asGames(ignored, other), 1))
+	require.Len(t, workQueue, 1)
+	require.Equal(t, other, (<-workQueue).addr)
+	require.Equal(t, SkipReasonIgnored, c.decisions[ignored].reason)
+
+	c.unignoreGame(ignored)
+	require.NoError(t, c.schedule(ctx, asGames(ignored, other), 2))
+	require.Len(t, workQueue, 1, "should schedule the game once it is no longer ignored")
+	j := <-workQueue
+	require.Equal(t, ignored, j.addr)
+	require.NoError(t, c.processResult(runJob(ctx, j)))
+	require.Equal(t, 2, games.created[ignored].ProgressCount)
+}
+
+func TestIgnoreGameDropsDeferredJob(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	addr := common.Address{0xaa}
+	ctx := context.Background()
+	require.NoError(t, c.schedule(ctx, asGames(addr), 0))
+	j := <-workQueue
+	c.deferred = append(c.deferred, j)
+
+	c.ignoreGame(addr)
+	require.Empty(t, c.deferred)
+	require.False(t, c.states[addr].inflight)
+}
+
+func TestSchedulerIgnoreGame(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	player := &test.StubGamePlayer{StatusValue: types.GameStatusInProgress}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return player, nil
+	}
+	s := NewScheduler(logger, metrics.NoopMetrics, &stubDiskManager{gameDirExists: make(map[common.Address]bool)}, 1, createPlayer, false)
+	gameAddr := common.Address{0xaa}
+	require.ErrorIs(t, s.IgnoreGame(ctx, gameAddr), ErrNotStarted)
+	s.Start(ctx)
+	defer s.Close()
+
+	require.NoError(t, s.IgnoreGame(ctx, gameAddr))
+	require.NoError(t, s.Schedule(asGames(gameAddr), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Zero(t, player.ProgressCount)
+
+	require.NoError(t, s.UnignoreGame(ctx, gameAddr))
+	require.NoError(t, s.Schedule(asGames(gameAddr), 1))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Equal(t, 1, player.ProgressCount)
+}
@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type InFlightMetricer interface {
+	RecordOldestInFlightAge(age time.Duration)
+	RecordLongRunningJob(addr common.Address, elapsed time.Duration)
+}
+
+// oldestInFlightInterval is how frequently the age of the oldest in-flight job is reported.
+const oldestInFlightInterval = 5 * time.Second
+
+// maxTrackedInFlight bounds the number of in-flight jobs tracked, protecting against unbounded growth
+// if workers fail to report jobs as finished.
+const maxTrackedInFlight = 1024
+
+// InFlightJob describes a job currently being progressed by a worker.
+type InFlightJob struct {
+	Game     common.Address
+	WorkerID int
+	Started  time.Time
+	Elapsed  time.Duration
+}
+
+// WorkerAssignment describes the game a worker is currently progressing.
+type WorkerAssignment struct {
+	WorkerID int
+	Game     common.Address
+	Started  time.Time
+}
+
+type inFlightEntry struct {
+	game    common.Address
+	started time.Time
+	// warned is set once the job has been reported by LongRunning.
+	warned bool
+}
+
+// inFlightTracker records which job each worker is currently progressing. Safe for concurrent use.
+type inFlightTracker struct {
+	clock clock.Clock
+	max   int
+	lock  sync.Mutex
+	jobs  map[int]inFlightEntry
+}
+
+func newInFlightTracker(cl clock.Clock, max int) *inFlightTracker {
+	return &inFlightTracker{
+		clock: cl,
+		max:   max,
+		jobs:  make(map[int]inFlightEntry),
+	}
+}
+
+// Start records that the worker has started progressing the game.
+func (t *inFlightTracker) Start(workerID int, game common.Address) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if _, ok := t.jobs[workerID]; !ok && len(t.jobs) >= t.max {
+		return
+	}
+	t.jobs[workerID] = inFlightEntry{game: game, started: t.clock.Now()}
+}
+
+// Finish records that the worker is no longer progressing a game.
+func (t *inFlightTracker) Finish(workerID int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.jobs, workerID)
+}
+
+// Jobs returns the current in-flight jobs, ordered from the longest running.
+func (t *inFlightTracker) Jobs() []InFlightJob {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.clock.Now()
+	jobs := make([]InFlightJob, 0, len(t.jobs))
+	for workerID, entry := range t.jobs {
+		jobs = append(jobs, InFlightJob{
+			Game:     entry.game,
+			WorkerID: workerID,
+			Started:  entry.started,
+			Elapsed:  now.Sub(entry.started),
+		})
+	}
+	slices.SortFunc(jobs, func(a, b InFlightJob) int {
+		if c := a.Started.Compare(b.Started); c != 0 {
+			return c
+		}
+		return a.WorkerID - b.WorkerID
+	})
+	return jobs
+}
+
+// Assignments returns the game each busy worker is progressing, keyed by worker id.
+func (t *inFlightTracker) Assignments() map[int]WorkerAssignment {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	assignments := make(map[int]WorkerAssignment, len(t.jobs))
+	for workerID, entry := range t.jobs {
+		assignments[workerID] = WorkerAssignment{WorkerID: workerID, Game: entry.game, Started: entry.started}
+	}
+	return assignments
+}
+
+// Oldest returns the longest running in-flight job, or false if there are no in-flight jobs.
+func (t *inFlightTracker) Oldest() (InFlightJob, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	var oldest InFlightJob
+	found := false
+	for workerID, entry := range t.jobs {
+		if !found || entry.started.Before(oldest.Started) || (entry.started.Equal(oldest.Started) && workerID < oldest.WorkerID) {
+			oldest = InFlightJob{Game: entry.game, WorkerID: workerID, Started: entry.started}
+			found = true
+		}
+	}
+	if found {
+		oldest.Elapsed = t.clock.Since(oldest.Started)
+	}
+	return oldest, found
+}
+
+// LongRunning returns the in-flight jobs that have been running for at least threshold and weren't returned by a
+// previous call, ordered from the longest running. Each job is only returned once.
+func (t *inFlightTracker) LongRunning(threshold time.Duration) []InFlightJob {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.clock.Now()
+	var jobs []InFlightJob
+	for workerID, entry := range t.jobs {
+		elapsed := now.Sub(entry.started)
+		if entry.warned || elapsed < threshold {
+			continue
+		}
+		entry.warned = true
+		t.jobs[workerID] = entry
+		jobs = append(jobs, InFlightJob{Game: entry.game, WorkerID: workerID, Started: entry.started, Elapsed: elapsed})
+	}
+	slices.SortFunc(jobs, func(a, b InFlightJob) int {
+		if c := a.Started.Compare(b.Started); c != 0 {
+			return c
+		}
+		return a.WorkerID - b.WorkerID
+	})
+	return jobs
+}
+
+// warnLongRunningJobs logs and records each in-flight job that has exceeded the threshold set by
+// WithInFlightWarnThreshold. The jobs are left running.
+func (s *Scheduler) warnLongRunningJobs() {
+	if s.cfg.inFlightWarnThreshold <= 0 {
+		return
+	}
+	for _, j := range s.inFlight.LongRunning(s.cfg.inFlightWarnThreshold) {
+		s.logger.Warn("Job running longer than expected", "game", j.Game, "worker", j.WorkerID, "elapsed", j.Elapsed, "threshold", s.cfg.inFlightWarnThreshold)
+		s.m.RecordLongRunningJob(j.Game, j.Elapsed)
+	}
+}
@@ -0,0 +1,324 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInFlightTracker(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	tracker := newInFlightTracker(cl, 2)
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	game3 := common.Address{0xcc}
+	start := cl.Now()
+
+	require.Empty(t, tracker.Jobs())
+	tracker.Start(2, game1)
+	cl.AdvanceTime(time.Second)
+	tracker.Start(1, game2)
+	cl.AdvanceTime(time.Second)
+
+	require.Equal(t, []InFlightJob{
+		{Game: game1, WorkerID: 2, Started: start, Elapsed: 2 * time.Second},
+		{Game: game2, WorkerID: 1, Started: start.Add(time.Second), Elapsed: time.Second},
+	}, tracker.Jobs())
+
+	// Limit reached so new workers aren't tracked
+	tracker.Start(3, game3)
+	require.Len(t, tracker.Jobs(), 2)
+
+	tracker.Finish(2)
+	require.Equal(t, []InFlightJob{
+		{Game: game2, WorkerID: 1, Started: start.Add(time.Second), Elapsed: time.Second},
+	}, tracker.Jobs())
+
+	// Worker picks up a new job
+	tracker.Finish(1)
+	tracker.Start(1, game3)
+	require.Equal(t, []InFlightJob{
+		{Game: game3, WorkerID: 1, Started: start.Add(2 * time.Second), Elapsed: 0},
+	}, tracker.Jobs())
+}
+
+func TestInFlightTrackerOldest(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	tracker := newInFlightTracker(cl, 10)
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	game3 := common.Address{0xcc}
+
+	_, ok := tracker.Oldest()
+	require.False(t, ok)
+
+	tracker.Start(2, game1)
+	cl.AdvanceTime(time.Second)
+	tracker.Start(1, game2)
+	cl.AdvanceTime(time.Second)
+	tracker.Start(3, game3)
+	cl.AdvanceTime(time.Second)
+
+	oldest, ok := tracker.Oldest()
+	require.True(t, ok)
+	require.Equal(t, game1, oldest.Game)
+	require.Equal(t, 3*time.Second, oldest.Elapsed)
+
+	tracker.Finish(2)
+	oldest, ok = tracker.Oldest()
+	require.True(t, ok)
+	require.Equal(t, game2, oldest.Game)
+	require.Equal(t, 2*time.Second, oldest.Elapsed)
+
+	tracker.Finish(1)
+	tracker.Finish(3)
+	_, ok = tracker.Oldest()
+	require.False(t, ok)
+}
+
+func TestSchedulerOldestInFlight(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	release := make(chan struct{})
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &blockingPlayer{release: release}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	m := &oldestInFlightMetrics{ages: make(chan time.Duration, 10)}
+	s := NewScheduler(logger, m, disk, 2, createPlayer, false, WithClock(cl))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	_, _, ok := s.OldestInFlight()
+	require.False(t, ok)
+
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	require.NoError(t, s.Schedule(asGames(game1), 0))
+	require.Eventually(t, func() bool {
+		return len(s.InFlight()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	cl.AdvanceTime(2 * time.Second)
+	require.NoError(t, s.Schedule(asGames(game1, game2), 1))
+	require.Eventually(t, func() bool {
+		return len(s.InFlight()) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	cl.AdvanceTime(oldestInFlightInterval - 2*time.Second)
+
+	game, age, ok := s.OldestInFlight()
+	require.True(t, ok)
+	require.Equal(t, game1, game)
+	require.Equal(t, oldestInFlightInterval, age)
+	require.Equal(t, oldestInFlightInterval, readWithTimeout(t, m.ages))
+
+	close(release)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Eventually(t, func() bool {
+		_, _, ok := s.OldestInFlight()
+		return !ok
+	}, 10*time.Second, 10*time.Millisecond)
+	cl.AdvanceTime(oldestInFlightInterval)
+	require.Equal(t, time.Duration(0), readWithTimeout(t, m.ages))
+}
+
+type oldestInFlightMetrics struct {
+	metrics.NoopMetricsImpl
+	ages chan time.Duration
+}
+
+func (m *oldestInFlightMetrics) RecordOldestInFlightAge(age time.Duration) {
+	m.ages <- age
+}
+
+func TestSchedulerInFlight(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	release := make(chan struct{})
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &blockingPlayer{release: release}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false, WithClock(cl))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	require.NoError(t, s.Schedule(asGames(game1, game2), 0))
+	require.Eventually(t, func() bool {
+		return len(s.InFlight()) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	cl.AdvanceTime(5 * time.Second)
+
+	jobs := s.InFlight()
+	require.ElementsMatch(t, []common.Address{game1, game2}, []common.Address{jobs[0].Game, jobs[1].Game})
+	require.NotEqual(t, jobs[0].WorkerID, jobs[1].WorkerID)
+	for _, j := range jobs {
+		require.Equal(t, 5*time.Second, j.Elapsed)
+	}
+
+	close(release)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Eventually(t, func() bool {
+		return len(s.InFlight()) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+// blockingPlayer is a GamePlayer that blocks in ProgressGame until release is closed.
+type blockingPlayer struct {
+	release <-chan struct{}
+}
+
+func (b *blockingPlayer) ValidatePrestate(_ context.Context) error {
+	return nil
+}
+
+func (b *blockingPlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	select {
+	case <-b.release:
+	case <-ctx.Done():
+	}
+	return types.GameStatusInProgress
+}
+
+func (b *blockingPlayer) Status() types.GameStatus {
+	return types.GameStatusInProgress
+}
+
+func TestSchedulerWorkerAssignments(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	release := make(chan struct{})
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &blockingPlayer{release: release}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 3, createPlayer, false, WithClock(cl))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	require.Empty(t, s.WorkerAssignments())
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	require.NoError(t, s.Schedule(asGames(game1, game2), 0))
+	require.Eventually(t, func() bool {
+		return len(s.WorkerAssignments()) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	assignments := s.WorkerAssignments()
+	var games []common.Address
+	for workerID, assignment := range assignments {
+		require.Equal(t, workerID, assignment.WorkerID)
+		require.Equal(t, cl.Now(), assignment.Started)
+		games = append(games, assignment.Game)
+	}
+	require.ElementsMatch(t, []common.Address{game1, game2}, games)
+
+	// Returned map is a copy
+	for workerID := range assignments {
+		delete(assignments, workerID)
+	}
+	require.Len(t, s.WorkerAssignments(), 2)
+
+	close(release)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Eventually(t, func() bool {
+		return len(s.WorkerAssignments()) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+func TestInFlightTrackerLongRunning(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	tracker := newInFlightTracker(cl, 10)
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	start := cl.Now()
+
+	tracker.Start(1, game1)
+	cl.AdvanceTime(5 * time.Second)
+	tracker.Start(2, game2)
+	require.Empty(t, tracker.LongRunning(10*time.Second))
+
+	cl.AdvanceTime(5 * time.Second)
+	require.Equal(t, []InFlightJob{
+		{Game: game1, WorkerID: 1, Started: start, Elapsed: 10 * time.Second},
+	}, tracker.LongRunning(10*time.Second))
+	require.Empty(t, tracker.LongRunning(10*time.Second), "should only report each job once")
+
+	cl.AdvanceTime(5 * time.Second)
+	require.Equal(t, []InFlightJob{
+		{Game: game2, WorkerID: 2, Started: start.Add(5 * time.Second), Elapsed: 10 * time.Second},
+	}, tracker.LongRunning(10*time.Second))
+
+	// A new job on the same worker is reported again
+	tracker.Finish(1)
+	tracker.Start(1, game1)
+	cl.AdvanceTime(10 * time.Second)
+	require.Equal(t, []InFlightJob{
+		{Game: game1, WorkerID: 1, Started: start.Add(15 * time.Second), Elapsed: 10 * time.Second},
+	}, tracker.LongRunning(10*time.Second))
+}
+
+func TestSchedulerInFlightWarnThreshold(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	release := make(chan struct{})
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &blockingPlayer{release: release}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	m := &longRunningMetrics{
+		oldestInFlightMetrics: oldestInFlightMetrics{ages: make(chan time.Duration, 10)},
+		longRunning:           make(chan time.Duration, 10),
+	}
+	s := NewScheduler(logger, m, disk, 1, createPlayer, false, WithClock(cl), WithInFlightWarnThreshold(2*oldestInFlightInterval))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	game := common.Address{0xaa}
+	require.NoError(t, s.Schedule(asGames(game), 0))
+	require.Eventually(t, func() bool {
+		return len(s.InFlight()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	cl.AdvanceTime(oldestInFlightInterval)
+	readWithTimeout(t, m.ages)
+	require.Empty(t, m.longRunning, "should not warn before threshold")
+
+	cl.AdvanceTime(oldestInFlightInterval)
+	readWithTimeout(t, m.ages)
+	require.Equal(t, 2*oldestInFlightInterval, readWithTimeout(t, m.longRunning))
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Job running longer than expected"), testlog.NewAttributesFilter("game", game.Hex())))
+
+	cl.AdvanceTime(oldestInFlightInterval)
+	readWithTimeout(t, m.ages)
+	require.Empty(t, m.longRunning, "should only warn once per job")
+	require.Len(t, s.InFlight(), 1, "should not cancel the job")
+	close(release)
+	require.NoError(t, s.WaitIdle(ctx))
+}
+
+type longRunningMetrics struct {
+	oldestInFlightMetrics
+	longRunning chan time.Duration
+}
+
+func (m *longRunningMetrics) RecordLongRunningJob(_ common.Address, elapsed time.Duration) {
+	m.longRunning <- elapsed
+}
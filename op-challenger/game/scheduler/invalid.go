@@ -0,0 +1,73 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+)
+
+var errInvalidResult = errors.New("invalid result")
+
+type InvalidGameMetricer interface {
+	RecordInvalidGameFiltered()
+	RecordInvalidResult()
+}
+
+// InvalidResultPolicy determines how results that fail validation, e.g. because the player returned an unknown
+// game status, are handled. The game's state is never updated from an invalid result.
+type InvalidResultPolicy int
+
+const (
+	// InvalidResultDrop discards the result. The game is progressed again the next time it is scheduled.
+	// This is the default.
+	InvalidResultDrop InvalidResultPolicy = iota
+	// InvalidResultRetryOnce immediately progresses the game again, discarding the result if the retry is
+	// also invalid.
+	InvalidResultRetryOnce
+)
+
+func (p InvalidResultPolicy) String() string {
+	switch p {
+	case InvalidResultDrop:
+		return "drop"
+	case InvalidResultRetryOnce:
+		return "retry-once"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(p))
+	}
+}
+
+// validateResult checks that the result of a completed job can be safely applied to the game's state.
+func validateResult(j job) error {
+	if j.player == nil {
+		return fmt.Errorf("%w: no player", errInvalidResult)
+	}
+	if _, err := types.GameStatusFromUint8(uint8(j.status)); err != nil {
+		return fmt.Errorf("%w: %w", errInvalidResult, err)
+	}
+	return nil
+}
+
+// handleInvalidResult discards the result of the game's pending job, retrying the game if required by the
+// policy set by WithInvalidResultPolicy, and returns an error describing the result. The lock must be held.
+func (c *coordinator) handleInvalidResult(j job, state *gameState, err error) error {
+	c.m.RecordInvalidResult()
+	c.tracer.Log(j.addr, "Discarding invalid result", "job", j.id, "err", err)
+	retry := c.cfg.invalidResultPolicy == InvalidResultRetryOnce && !j.retriedInvalid && !c.jobLimitReached.Load()
+	if !retry {
+		c.releaseJobs([]job{j})
+		return fmt.Errorf("game %v discarded result of job %v: %w", j.addr, j.id, err)
+	}
+	retryJob := c.newJob(j.block, j.addr, state)
+	retryJob.retriedInvalid = true
+	// The retry acknowledges the scheduled game in place of the discarded job.
+	retryJob.ackCycle = j.ackCycle
+	// Replace the discarded job with the retry so the game remains in flight.
+	c.m.RecordGameUpdateCompleted()
+	c.m.RecordGameUpdateScheduled()
+	c.events.Emit(j.addr, EventScheduled, retryJob.cycle, retryJob.correlationID)
+	c.deferred = append(c.deferred, *retryJob)
+	c.enqueueDeferred()
+	return fmt.Errorf("game %v retrying after result of job %v: %w", j.addr, j.id, err)
+}
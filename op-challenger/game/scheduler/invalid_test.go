@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const invalidStatus = types.GameStatus(99)
+
+func TestDropInvalidResult(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	m := c.m.(*stubSchedulerMetrics)
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	games.created[gameAddr].StatusValue = invalidStatus
+	err := c.processResult(runJob(ctx, <-workQueue))
+	require.ErrorIs(t, err, errInvalidResult)
+	require.Equal(t, 1, m.invalid)
+	require.Empty(t, workQueue, "should not retry by default")
+	state := c.states[gameAddr]
+	require.Equal(t, types.GameStatusInProgress, state.status, "should not record invalid status")
+	require.Zero(t, state.pendingJobID)
+	require.False(t, state.inflight)
+	require.True(t, c.idle.IsIdle())
+
+	// The game is progressed again when next scheduled
+	games.created[gameAddr].StatusValue = types.GameStatusInProgress
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 1))
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.Equal(t, uint64(1), state.lastProcessedBlockNum)
+}
+
+func TestRejectResultWithoutPlayer(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	m := c.m.(*stubSchedulerMetrics)
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	j := <-workQueue
+	j.player = nil
+	require.ErrorIs(t, c.processResult(j), errInvalidResult)
+	require.Equal(t, 1, m.invalid)
+	require.NotNil(t, c.states[gameAddr].player)
+	require.True(t, c.idle.IsIdle())
+}
+
+func TestRetryInvalidResultOnce(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.invalidResultPolicy = InvalidResultRetryOnce
+	m := c.m.(*stubSchedulerMetrics)
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	games.created[gameAddr].StatusValue = invalidStatus
+	require.ErrorIs(t, c.processResult(runJob(ctx, <-workQueue)), errInvalidResult)
+	require.Len(t, workQueue, 1, "should retry game")
+	require.False(t, c.idle.IsIdle(), "retry should be outstanding")
+
+	// A second invalid result is discarded
+	require.ErrorIs(t, c.processResult(runJob(ctx, <-workQueue)), errInvalidResult)
+	require.Empty(t, workQueue, "should only retry once")
+	require.Equal(t, 2, m.invalid)
+	require.Equal(t, types.GameStatusInProgress, c.states[gameAddr].status)
+	require.True(t, c.idle.IsIdle())
+
+	// A valid retry is applied
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 1))
+	require.ErrorIs(t, c.processResult(runJob(ctx, <-workQueue)), errInvalidResult)
+	games.created[gameAddr].StatusValue = types.GameStatusDefenderWon
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.Equal(t, types.GameStatusDefenderWon, c.states[gameAddr].status)
+	require.Equal(t, 3, m.invalid)
+	require.True(t, c.idle.IsIdle())
+}
+
+func TestSchedulerSurvivesInvalidResult(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	invalidGame := common.Address{0xaa}
+	validGame := common.Address{0xbb}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		player := &test.StubGamePlayer{Addr: g.Proxy, StatusValue: types.GameStatusInProgress}
+		if g.Proxy == invalidGame {
+			return &invalidResultPlayer{StubGamePlayer: player}, nil
+		}
+		return player, nil
+	}
+	m := &invalidResultMetrics{}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 100)}
+	s := NewScheduler(logger, m, disk, 2, createPlayer, false)
+	s.Start(context.Background())
+	defer s.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	require.NoError(t, s.Schedule(asGames(invalidGame, validGame), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.EqualValues(t, 1, m.invalid.Load())
+
+	require.NoError(t, s.Schedule(asGames(invalidGame, validGame), 1))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.EqualValues(t, 2, m.invalid.Load())
+	data, err := s.ExportState()
+	require.NoError(t, err)
+	var export ExportedState
+	require.NoError(t, json.Unmarshal(data, &export))
+	require.Len(t, export.Games, 2)
+	for _, game := range export.Games {
+		require.Equal(t, types.GameStatusInProgress.String(), game.Status)
+	}
+}
+
+type invalidResultMetrics struct {
+	metrics.NoopMetricsImpl
+	invalid atomic.Int32
+}
+
+func (m *invalidResultMetrics) RecordInvalidResult() {
+	m.invalid.Add(1)
+}
+
+// invalidResultPlayer returns an invalid status from each progression.
+type invalidResultPlayer struct {
+	*test.StubGamePlayer
+}
+
+func (p *invalidResultPlayer) ProgressGame(_ context.Context) types.GameStatus {
+	return invalidStatus
+}
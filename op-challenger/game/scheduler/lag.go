@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+// resultLagInterval is how frequently the result processing lag is sampled.
+const resultLagInterval = 5 * time.Second
+
+// resultLagWarnSamples is the number of consecutive samples with a positive lag after which a warning is logged.
+const resultLagWarnSamples = 3
+
+type ResultLagMetricer interface {
+	RecordResultProcessingLag(lag float64)
+}
+
+// resultLag counts results as workers return them and as they are processed so the rate at which the backlog of
+// unprocessed results is growing can be sampled. Unlike the number of pending results, it shows whether result
+// processing is keeping up before the backlog is large enough to block workers.
+type resultLag struct {
+	arrived   atomic.Uint64
+	processed atomic.Uint64
+
+	// The remaining fields are only accessed by the sampling goroutine.
+	lastArrived   uint64
+	lastProcessed uint64
+	// positive is the number of consecutive samples with a positive lag.
+	positive int
+}
+
+// sample returns the rate results arrived minus the rate they were processed, in results per second, over the
+// elapsed time since the previous sample.
+func (l *resultLag) sample(elapsed time.Duration) float64 {
+	arrived := l.arrived.Load()
+	processed := l.processed.Load()
+	lag := (float64(arrived-l.lastArrived) - float64(processed-l.lastProcessed)) / elapsed.Seconds()
+	l.lastArrived = arrived
+	l.lastProcessed = processed
+	if lag > 0 {
+		l.positive++
+	} else {
+		l.positive = 0
+	}
+	return lag
+}
+
+// backlog returns the number of results returned by workers that haven't been processed yet.
+// Workers count a result as arrived just after returning it so it may briefly be counted as processed first.
+func (l *resultLag) backlog() uint64 {
+	processed := l.processed.Load()
+	return max(l.arrived.Load(), processed) - processed
+}
+
+// reportResultLag periodically records the result processing lag, warning each time it has been positive for
+// another resultLagWarnSamples consecutive samples.
+func (s *Scheduler) reportResultLag(ctx context.Context, ticker clock.Ticker) {
+	defer s.wg.Done()
+	defer ticker.Stop()
+	lag := &s.coordinator.resultLag
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Ch():
+			rate := lag.sample(resultLagInterval)
+			s.m.RecordResultProcessingLag(rate)
+			if lag.positive > 0 && lag.positive%resultLagWarnSamples == 0 {
+				s.logger.Warn("Result processing falling behind job completion",
+					"lag", rate, "samples", lag.positive, "backlog", lag.backlog())
+			}
+		}
+	}
+}
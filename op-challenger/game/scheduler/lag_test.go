@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultLagSample(t *testing.T) {
+	var lag resultLag
+	lag.arrived.Add(10)
+	lag.processed.Add(4)
+	require.Equal(t, 0.6, lag.sample(10*time.Second))
+	require.Equal(t, 1, lag.positive)
+	require.EqualValues(t, 6, lag.backlog())
+
+	lag.arrived.Add(5)
+	lag.processed.Add(5)
+	require.Zero(t, lag.sample(10*time.Second), "backlog is steady")
+	require.Zero(t, lag.positive)
+
+	lag.processed.Add(6)
+	require.Equal(t, -0.6, lag.sample(10*time.Second))
+	require.Zero(t, lag.positive)
+	require.Zero(t, lag.backlog())
+}
+
+func TestResultLagWarnsWhenProcessingFallsBehind(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	permits := make(chan struct{}, 10)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &permitPlayer{permits: permits}, nil
+	}
+	// Result processing is slowed by blocking the audit record written for the first result.
+	sink := &blockingWriter{release: make(chan struct{})}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	m := &resultLagMetrics{lags: make(chan float64, 10)}
+	s := NewScheduler(logger, m, disk, 4, createPlayer, false, WithClock(cl), WithAuditSink(sink))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	games := make([]common.Address, 5)
+	for i := range games {
+		games[i] = common.Address{byte(i + 1)}
+	}
+	require.NoError(t, s.Schedule(asGames(games...), 0))
+
+	complete := func(n int) {
+		arrived := s.coordinator.resultLag.arrived.Load()
+		for i := 0; i < n; i++ {
+			permits <- struct{}{}
+		}
+		require.Eventually(t, func() bool {
+			return s.coordinator.resultLag.arrived.Load() == arrived+uint64(n)
+		}, 10*time.Second, time.Millisecond)
+	}
+	warning := testlog.NewMessageFilter("Result processing falling behind job completion")
+
+	for i, n := range []int{1, 2, 2} {
+		complete(n)
+		cl.AdvanceTime(resultLagInterval)
+		require.Equal(t, float64(n)/resultLagInterval.Seconds(), readWithTimeout(t, m.lags))
+		if i < resultLagWarnSamples-1 {
+			require.Nil(t, logs.FindLog(warning), "should not warn until lag is sustained")
+		}
+	}
+	require.NotNil(t, logs.FindLog(warning, testlog.NewAttributesFilter("backlog", "5")))
+
+	close(sink.release)
+	require.NoError(t, s.WaitIdle(ctx))
+	cl.AdvanceTime(resultLagInterval)
+	require.Equal(t, -5/resultLagInterval.Seconds(), readWithTimeout(t, m.lags))
+}
+
+type resultLagMetrics struct {
+	metrics.NoopMetricsImpl
+	lags chan float64
+}
+
+func (m *resultLagMetrics) RecordResultProcessingLag(lag float64) {
+	m.lags <- lag
+}
+
+// permitPlayer takes action each time it progresses the game, waiting for a permit before completing.
+type permitPlayer struct {
+	permits <-chan struct{}
+}
+
+func (p *permitPlayer) ValidatePrestate(_ context.Context) error {
+	return nil
+}
+
+func (p *permitPlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	select {
+	case <-p.permits:
+	case <-ctx.Done():
+	}
+	return types.GameStatusInProgress
+}
+
+func (p *permitPlayer) Status() types.GameStatus {
+	return types.GameStatusInProgress
+}
+
+func (p *permitPlayer) ActionTaken() bool {
+	return true
+}
+
+// blockingWriter blocks each write until released.
+type blockingWriter struct {
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	<-w.release
+	return len(p), nil
+}
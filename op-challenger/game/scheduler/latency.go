@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// defaultLatencyWindow is the number of recent job latencies percentiles are computed from by default.
+const defaultLatencyWindow = 1000
+
+// defaultLatencyPercentiles are the percentiles reported by LatencyPercentiles by default.
+var defaultLatencyPercentiles = []float64{0.5, 0.95, 0.99}
+
+// latencyWindow keeps the latencies of the most recent jobs, from creation until their result is processed, in a
+// fixed size ring buffer so percentiles can be reported without a metrics backend.
+type latencyWindow struct {
+	samples []time.Duration
+	next    int
+	full    bool
+}
+
+func newLatencyWindow(size int) latencyWindow {
+	return latencyWindow{samples: make([]time.Duration, max(size, 0))}
+}
+
+// record adds a latency, replacing the oldest once the window is full.
+func (w *latencyWindow) record(latency time.Duration) {
+	if len(w.samples) == 0 {
+		return
+	}
+	w.samples[w.next] = latency
+	w.next++
+	if w.next == len(w.samples) {
+		w.next = 0
+		w.full = true
+	}
+}
+
+// percentiles returns the nearest rank value of each percentile, expressed as a fraction between 0 and 1, over
+// the latencies in the window. Returns an empty map if no latencies have been recorded.
+func (w *latencyWindow) percentiles(percentiles []float64) map[float64]time.Duration {
+	count := w.next
+	if w.full {
+		count = len(w.samples)
+	}
+	result := make(map[float64]time.Duration, len(percentiles))
+	if count == 0 {
+		return result
+	}
+	sorted := slices.Clone(w.samples[:count])
+	slices.Sort(sorted)
+	for _, p := range percentiles {
+		rank := int(math.Ceil(p * float64(count)))
+		result[p] = sorted[min(max(rank, 1), count)-1]
+	}
+	return result
+}
+
+// LatencyPercentiles returns the percentiles set by WithLatencyPercentiles of the time taken by recent jobs, from
+// being created until their result is processed, as a quick readout of latency where metrics can't be scraped.
+// Returns an empty map until a job has completed.
+func (s *Scheduler) LatencyPercentiles() map[float64]time.Duration {
+	c := s.coordinator
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.latencies.percentiles(c.cfg.latencyPercentiles)
+}
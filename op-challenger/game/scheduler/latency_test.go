@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyWindowPercentiles(t *testing.T) {
+	w := newLatencyWindow(1000)
+	require.Empty(t, w.percentiles(defaultLatencyPercentiles))
+
+	// Record latencies of 1ms to 1000ms in a random order
+	rng := rand.New(rand.NewSource(1))
+	for _, i := range rng.Perm(1000) {
+		w.record(time.Duration(i+1) * time.Millisecond)
+	}
+	percentiles := w.percentiles([]float64{0.5, 0.95, 0.99, 1})
+	require.Len(t, percentiles, 4)
+	tolerance := float64(5 * time.Millisecond)
+	require.InDelta(t, float64(500*time.Millisecond), float64(percentiles[0.5]), tolerance)
+	require.InDelta(t, float64(950*time.Millisecond), float64(percentiles[0.95]), tolerance)
+	require.InDelta(t, float64(990*time.Millisecond), float64(percentiles[0.99]), tolerance)
+	require.Equal(t, 1000*time.Millisecond, percentiles[1])
+}
+
+func TestLatencyWindowIsBounded(t *testing.T) {
+	w := newLatencyWindow(10)
+	for i := 0; i < 10; i++ {
+		w.record(time.Hour)
+	}
+	for i := 1; i <= 10; i++ {
+		w.record(time.Duration(i) * time.Second)
+	}
+	require.Len(t, w.samples, 10)
+	require.Equal(t, map[float64]time.Duration{0.5: 5 * time.Second, 0.99: 10 * time.Second}, w.percentiles([]float64{0.5, 0.99}))
+}
+
+func TestLatencyWindowDisabled(t *testing.T) {
+	w := newLatencyWindow(0)
+	w.record(time.Second)
+	require.Empty(t, w.percentiles(defaultLatencyPercentiles))
+}
+
+func TestRecordJobLatency(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	c.cfg.latencyPercentiles = []float64{0.5, 1}
+	c.latencies = newLatencyWindow(10)
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(common.Address{0xaa}, common.Address{0xbb}), 0))
+	cl.AdvanceTime(time.Second)
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	cl.AdvanceTime(2 * time.Second)
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.Equal(t, map[float64]time.Duration{0.5: time.Second, 1: 3 * time.Second}, c.latencies.percentiles(c.cfg.latencyPercentiles))
+}
+
+func TestSchedulerLatencyPercentiles(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{StatusValue: types.GameStatusInProgress}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false, WithLatencyPercentiles(100, 0.5, 0.9))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+	require.Empty(t, s.LatencyPercentiles())
+
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}, common.Address{0xbb}), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	percentiles := s.LatencyPercentiles()
+	require.Len(t, percentiles, 2)
+	require.Contains(t, percentiles, 0.5)
+	require.Contains(t, percentiles, 0.9)
+}
@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+)
+
+// TrackedGame describes a game tracked by the scheduler, see ListGames.
+type TrackedGame struct {
+	Game common.Address `json:"game"`
+	// Factory is the factory of the source the game is from, see RegisterSource, or zero for the primary factory.
+	Factory common.Address   `json:"factory"`
+	Status  types.GameStatus `json:"status"`
+	// LastUpdated is when the result of the game's most recent progression was processed, or zero if it hasn't
+	// been progressed yet.
+	LastUpdated time.Time `json:"lastUpdated"`
+	// Pending is true if the game has a job queued or in flight.
+	Pending bool `json:"pending"`
+	// InProgress is true if a worker is currently progressing the game.
+	InProgress bool `json:"inProgress"`
+	// Ignored is true if the game isn't scheduled because of IgnoreGame.
+	Ignored bool `json:"ignored"`
+}
+
+// listRequest asks the scheduler loop for the games it is tracking, see ListGames.
+type listRequest struct {
+	result chan []TrackedGame
+}
+
+// ListGames returns the games tracked by the scheduler, ordered by factory then address, for operators debugging a
+// stuck challenger. The games of the primary factory are listed first, followed by those of each source registered
+// with RegisterSource. Games ignored by IgnoreGame are included, as games of the primary factory, even if they
+// haven't been scheduled.
+func (s *Scheduler) ListGames(ctx context.Context) ([]TrackedGame, error) {
+	if !s.started.Load() {
+		return nil, ErrNotStarted
+	}
+	req := listRequest{result: make(chan []TrackedGame, 1)}
+	select {
+	case s.listRequests <- req:
+	case <-s.stopped:
+		return nil, ErrStopped
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case games := <-req.result:
+		return games, nil
+	case <-s.stopped:
+		return nil, ErrStopped
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *Scheduler) handleList(req listRequest) {
+	working := make(map[common.Address]bool)
+	for _, j := range s.inFlight.Jobs() {
+		working[j.Game] = true
+	}
+	var games []TrackedGame
+	for _, c := range s.coordinators() {
+		games = append(games, c.trackedGames(working)...)
+	}
+	req.result <- games
+}
+
+func (c *coordinator) trackedGames(working map[common.Address]bool) []TrackedGame {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	games := make([]TrackedGame, 0, len(c.states))
+	for addr, state := range c.states {
+		games = append(games, TrackedGame{
+			Game:        addr,
+			Factory:     c.factory,
+			Status:      state.status,
+			LastUpdated: state.lastUpdated,
+			Pending:     state.pendingJobID != 0,
+			InProgress:  working[addr],
+			Ignored:     c.ignored[addr],
+		})
+	}
+	for addr := range c.ignored {
+		// Ignored games are ignored by every source so those not yet seen are only listed once.
+		if _, ok := c.states[addr]; !ok && c.factory == (common.Address{}) {
+			games = append(games, TrackedGame{Game: addr, Ignored: true})
+		}
+	}
+	slices.SortFunc(games, func(a, b TrackedGame) int {
+		return compareAddresses(a.Game, b.Game)
+	})
+	return games
+}
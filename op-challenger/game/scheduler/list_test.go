@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListGames(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	resolved := common.Address{0xaa}
+	inProgress := common.Address{0xbb}
+	ignored := common.Address{0xcc}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		status := types.GameStatusInProgress
+		if g.Proxy == resolved {
+			status = types.GameStatusChallengerWon
+		}
+		return &test.StubGamePlayer{StatusValue: status}, nil
+	}
+	s := NewScheduler(logger, metrics.NoopMetrics, &stubDiskManager{gameDirExists: make(map[common.Address]bool)}, 1, createPlayer, false, WithClock(cl))
+	_, err := s.ListGames(ctx)
+	require.ErrorIs(t, err, ErrNotStarted)
+	s.Start(ctx)
+	defer s.Close()
+
+	require.NoError(t, s.Schedule(asGames(inProgress, resolved), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.NoError(t, s.IgnoreGame(ctx, ignored))
+	games, err := s.ListGames(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []TrackedGame{
+		// The game was already resolved when first seen so is never progressed
+		{Game: resolved, Status: types.GameStatusChallengerWon},
+		{Game: inProgress, Status: types.GameStatusInProgress, LastUpdated: cl.Now()},
+		{Game: ignored, Ignored: true},
+	}, games)
+}
+
+func TestTrackedGamesReportsPendingJobs(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	queued := common.Address{0xaa}
+	working := common.Address{0xbb}
+	require.NoError(t, c.schedule(context.Background(), asGames(queued, working), 0))
+	require.Len(t, workQueue, 2)
+
+	games := c.trackedGames(map[common.Address]bool{working: true})
+	require.Equal(t, []TrackedGame{
+		{Game: queued, Status: types.GameStatusInProgress, Pending: true},
+		{Game: working, Status: types.GameStatusInProgress, Pending: true, InProgress: true},
+	}, games)
+}
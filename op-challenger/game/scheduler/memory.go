@@ -0,0 +1,141 @@
+package scheduler
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// memoryCheckInterval is how frequently process memory is read when WithMemoryPressure is enabled.
+const memoryCheckInterval = time.Second
+
+type MemoryPressureMetricer interface {
+	RecordMemoryPressure(paused bool)
+}
+
+// readProcessMemory returns the memory obtained from the OS by the Go runtime that hasn't been returned to it,
+// which approximates the process's resident memory.
+func readProcessMemory() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.Sys - stats.HeapReleased
+}
+
+// memoryGuard pauses dispatching jobs while the actual memory used by the process is too high, as a backstop for
+// players whose memory use is higher than expected, see WithMemoryPressure.
+// Dispatch is paused once memory use reaches the high water mark and resumes once it drops below the low water mark.
+// A nil memoryGuard never pauses dispatch.
+type memoryGuard struct {
+	high    uint64
+	low     uint64
+	forceGC bool
+	// read returns the current memory use in bytes and gc forces a garbage collection. Replaced in tests.
+	read func() uint64
+	gc   func()
+
+	lock   sync.Mutex
+	paused bool
+	// resume is closed when dispatch resumes after being paused.
+	resume chan struct{}
+}
+
+func newMemoryGuard(high, low uint64, forceGC bool) *memoryGuard {
+	if high == 0 {
+		return nil
+	}
+	return &memoryGuard{
+		high:    high,
+		low:     min(low, high),
+		forceGC: forceGC,
+		read:    readProcessMemory,
+		gc:      runtime.GC,
+	}
+}
+
+// Paused returns true while dispatch is paused because memory use is too high.
+func (g *memoryGuard) Paused() bool {
+	if g == nil {
+		return false
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.paused
+}
+
+// resumed returns a channel that is closed once dispatch is no longer paused.
+func (g *memoryGuard) resumed() <-chan struct{} {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if !g.paused {
+		closed := make(chan struct{})
+		close(closed)
+		return closed
+	}
+	return g.resume
+}
+
+// update records the current memory use and returns true if the paused state changed.
+func (g *memoryGuard) update(usage uint64) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	switch {
+	case !g.paused && usage >= g.high:
+		g.paused = true
+		g.resume = make(chan struct{})
+		return true
+	case g.paused && usage < g.low:
+		g.paused = false
+		close(g.resume)
+		return true
+	}
+	return false
+}
+
+// monitorMemory periodically reads the process memory use, pausing and resuming dispatch as it crosses the
+// thresholds set by WithMemoryPressure.
+func (s *Scheduler) monitorMemory(ctx context.Context, ticker clock.Ticker) {
+	defer s.wg.Done()
+	defer ticker.Stop()
+	g := s.coordinator.memory
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Ch():
+			usage := g.read()
+			if !g.update(usage) {
+				continue
+			}
+			paused := g.Paused()
+			s.m.RecordMemoryPressure(paused)
+			if !paused {
+				s.logger.Info("Memory use reduced, resuming job dispatch", "usage", usage, "low", g.low)
+				continue
+			}
+			s.logger.Warn("Memory use too high, pausing job dispatch", "usage", usage, "high", g.high)
+			if g.forceGC {
+				g.gc()
+			}
+		}
+	}
+}
+
+// awaitMemory processes results, without dispatching any further jobs, while dispatch is paused because memory use
+// is too high. Returns immediately if ctx is done.
+func (c *coordinator) awaitMemory(ctx context.Context) {
+	for c.memory.Paused() {
+		select {
+		case result := <-c.resultQueue:
+			if err := c.processResult(result); err != nil {
+				c.errLog.Log(log.LevelError, "result", "Failed to process result", err)
+			}
+		case <-c.memory.resumed():
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryGuard(t *testing.T) {
+	g := newMemoryGuard(100, 50, false)
+	require.False(t, g.Paused())
+	select {
+	case <-g.resumed():
+	default:
+		t.Fatal("should not block while not paused")
+	}
+
+	require.False(t, g.update(99))
+	require.True(t, g.update(100))
+	require.True(t, g.Paused())
+	resumed := g.resumed()
+
+	// Stays paused until below the low water mark
+	require.False(t, g.update(50))
+	require.True(t, g.Paused())
+	require.True(t, g.update(49))
+	require.False(t, g.Paused())
+	select {
+	case <-resumed:
+	default:
+		t.Fatal("should signal resume")
+	}
+
+	require.Nil(t, newMemoryGuard(0, 0, true), "should be disabled without a high water mark")
+	var disabled *memoryGuard
+	require.False(t, disabled.Paused())
+	require.EqualValues(t, 100, newMemoryGuard(100, 200, false).low, "should cap low water mark at high")
+}
+
+func TestMemoryPressurePausesDispatch(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	progressed := make(chan struct{}, 10)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &signalPlayer{StubGamePlayer: &test.StubGamePlayer{StatusValue: types.GameStatusInProgress}, progressed: progressed}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	m := &memoryPressureMetrics{pauses: make(chan bool, 10)}
+	s := NewScheduler(logger, m, disk, 1, createPlayer, false, WithClock(cl), WithMemoryPressure(100, 50, true))
+	var usage atomic.Uint64
+	var gcs atomic.Int32
+	s.coordinator.memory.read = usage.Load
+	s.coordinator.memory.gc = func() { gcs.Add(1) }
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	usage.Store(150)
+	cl.AdvanceTime(memoryCheckInterval)
+	require.True(t, readWithTimeout(t, m.pauses))
+	require.EqualValues(t, 1, gcs.Load(), "should force GC when pausing")
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Memory use too high, pausing job dispatch")))
+
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 0))
+	require.Never(t, func() bool { return len(progressed) > 0 }, 100*time.Millisecond, 10*time.Millisecond, "should not dispatch while paused")
+
+	// Not yet below the low water mark
+	usage.Store(75)
+	cl.AdvanceTime(memoryCheckInterval)
+	require.Never(t, func() bool { return len(m.pauses) > 0 || len(progressed) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+
+	usage.Store(40)
+	cl.AdvanceTime(memoryCheckInterval)
+	require.False(t, readWithTimeout(t, m.pauses))
+	readWithTimeout(t, progressed)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Memory use reduced, resuming job dispatch")))
+	require.EqualValues(t, 1, gcs.Load())
+	require.Equal(t, uint64(100), s.EffectiveConfig().MemoryHighWater)
+}
+
+type memoryPressureMetrics struct {
+	metrics.NoopMetricsImpl
+	pauses chan bool
+}
+
+func (m *memoryPressureMetrics) RecordMemoryPressure(paused bool) {
+	m.pauses <- paused
+}
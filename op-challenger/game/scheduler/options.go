@@ -0,0 +1,768 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SchedulerOption configures optional behaviour of the Scheduler.
+type SchedulerOption func(cfg *config)
+
+// ScheduleTransform modifies the list of games in a batch before it is scheduled.
+type ScheduleTransform func(games []types.GameMetadata) []types.GameMetadata
+
+// QueueFullStrategy determines how jobs are handled when the job queue is full while scheduling a batch.
+type QueueFullStrategy int
+
+const (
+	// QueueFullBlock blocks scheduling until space is available in the job queue, processing results
+	// while waiting. New batches are not accepted until all jobs have been enqueued.
+	QueueFullBlock QueueFullStrategy = iota
+	// QueueFullDefer holds jobs that don't fit in the job queue and enqueues them as soon as results free up
+	// space, ahead of jobs from later batches. This is the default.
+	QueueFullDefer
+	// QueueFullDrop discards jobs that don't fit in the job queue, recording a metric. The games are
+	// scheduled again as part of the next batch.
+	QueueFullDrop
+)
+
+func (s QueueFullStrategy) String() string {
+	switch s {
+	case QueueFullBlock:
+		return "block"
+	case QueueFullDefer:
+		return "defer"
+	case QueueFullDrop:
+		return "drop"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// WorkerState is the state of a worker reported to a WorkerStateListener.
+type WorkerState int
+
+const (
+	// WorkerIdle indicates the worker finished progressing a game and is waiting for the next job.
+	WorkerIdle WorkerState = iota
+	// WorkerActive indicates the worker started progressing a game.
+	WorkerActive
+)
+
+func (s WorkerState) String() string {
+	switch s {
+	case WorkerIdle:
+		return "idle"
+	case WorkerActive:
+		return "active"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// WorkerStateListener is notified each time a worker transitions between idle and active, see WithWorkerStateListener.
+type WorkerStateListener func(workerID int, state WorkerState)
+
+// ResolutionHandler is called with a game and the status it resolved with, see WithResolutionHandler.
+type ResolutionHandler func(game types.GameMetadata, status types.GameStatus)
+
+type config struct {
+	clock        clock.Clock
+	rampUp       time.Duration
+	maxFollowUps uint
+	stateStore   StateStore
+	// customStateStore is true if stateStore was set by WithStateStore rather than defaulting to the disk.
+	customStateStore bool
+
+	maxTracedGames int
+	activityDecay  activityDecay
+
+	captureSnapshots bool
+
+	actionCooldown time.Duration
+
+	maxTotalJobs uint64
+
+	queueFullStrategy QueueFullStrategy
+
+	failureDemotion failureDemotion
+
+	scheduleTransform ScheduleTransform
+
+	scheduleBuckets int
+
+	gasBudget       uint64
+	gasBudgetWindow time.Duration
+
+	dependencies map[common.Address][]common.Address
+
+	errorLogWindow time.Duration
+
+	resultSink ResultSink
+
+	workerStateListener WorkerStateListener
+
+	resultsHighWater uint
+	resultsLowWater  uint
+
+	backoffThreshold float64
+	backoffInitial   time.Duration
+	backoffMax       time.Duration
+
+	resolvedRetention time.Duration
+
+	recentResults int
+
+	randomizeDispatch bool
+
+	readinessCheck ReadinessCheck
+
+	maxTrackedGames int
+
+	resultClassifier ResultClassifier
+
+	invalidResultPolicy InvalidResultPolicy
+
+	reconcileInterval time.Duration
+	reconcileRepair   bool
+
+	maxRetryAge time.Duration
+
+	auditSink io.Writer
+
+	dropPolicy DropPolicy
+
+	eventPublisher EventPublisher
+
+	urgentWorkers uint
+
+	inFlightWarnThreshold time.Duration
+
+	batchGate BatchGate
+
+	stableOrder func(a, b common.Address) int
+
+	maxAcceptedBatchSize int
+
+	latencyWindow      int
+	latencyPercentiles []float64
+
+	gameTypes map[uint32]GameTypeConfig
+
+	playerInitPolicy  PlayerInitPolicy
+	playerInitBackoff playerInitBackoff
+
+	upstreamConcurrency uint
+
+	decisionTTL time.Duration
+
+	gameFilter GameFilter
+
+	deadlineResolver DeadlineResolver
+
+	memoryHighWater uint64
+	memoryLowWater  uint64
+	memoryForceGC   bool
+
+	ackSink AckSink
+
+	priorityDispatch bool
+
+	checkpoint         bool
+	checkpointPath     string
+	checkpointInterval time.Duration
+
+	failureBackoff   playerInitBackoff
+	breakerThreshold uint
+	breakerCooldown  time.Duration
+
+	shadowMode bool
+
+	jobTimeout time.Duration
+
+	resolutionHandler ResolutionHandler
+}
+
+func defaultConfig() config {
+	return config{
+		clock:             clock.SystemClock,
+		maxTracedGames:    defaultMaxTracedGames,
+		queueFullStrategy: QueueFullDefer,
+		errorLogWindow:    defaultErrorLogWindow,
+
+		latencyWindow:      defaultLatencyWindow,
+		latencyPercentiles: defaultLatencyPercentiles,
+
+		decisionTTL: defaultDecisionTTL,
+	}
+}
+
+// WithClock sets the clock used by the scheduler for all time based behaviour.
+func WithClock(cl clock.Clock) SchedulerOption {
+	return func(cfg *config) {
+		cfg.clock = cl
+	}
+}
+
+// WithRampUp starts the scheduler with a single worker and gradually adds workers, evenly spaced over d,
+// until maxConcurrency workers are running. This avoids all workers hitting the backend at once while
+// caches are still cold. A duration of 0 (the default) starts all workers immediately.
+func WithRampUp(d time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.rampUp = d
+	}
+}
+
+// WithMaxFollowUps allows games whose player implements FollowUpRequester to be immediately re-enqueued
+// when they request another pass, up to n consecutive follow up passes. After n follow ups, the game
+// is left to be progressed again the next time it is scheduled. The default of 0 disables follow ups.
+func WithMaxFollowUps(n uint) SchedulerOption {
+	return func(cfg *config) {
+		cfg.maxFollowUps = n
+	}
+}
+
+// WithStateStore sets the backend used to persist durable scheduler state.
+// Defaults to storing state in each game's directory via the DiskManager.
+func WithStateStore(store StateStore) SchedulerOption {
+	return func(cfg *config) {
+		cfg.stateStore = store
+	}
+}
+
+// WithMaxTracedGames sets the maximum number of games that can have tracing enabled via TraceGame at once.
+func WithMaxTracedGames(n int) SchedulerOption {
+	return func(cfg *config) {
+		cfg.maxTracedGames = n
+	}
+}
+
+// WithActivityDecay schedules games that repeatedly take no action less frequently.
+// After each result where the player reported no action (see ActionReporter), the game's activity
+// score is multiplied by factor and the game is only scheduled every 1/score cycles, up to a maximum
+// of maxInterval cycles. Any action immediately restores the game to being scheduled every cycle.
+// Decay is disabled unless factor is in (0, 1) and maxInterval is greater than 1.
+func WithActivityDecay(factor float64, maxInterval uint64) SchedulerOption {
+	return func(cfg *config) {
+		cfg.activityDecay = activityDecay{factor: factor, maxInterval: maxInterval}
+	}
+}
+
+// WithSnapshotCapture captures a snapshot of each game's inputs to its directory before it is progressed
+// so that the progression can be reproduced offline with Scheduler.ReplayFromSnapshot.
+// Capturing adds disk I/O to every job so is disabled by default.
+func WithSnapshotCapture(enabled bool) SchedulerOption {
+	return func(cfg *config) {
+		cfg.captureSnapshots = enabled
+	}
+}
+
+// WithActionCooldown skips scheduling a game for d after a pass in which its player took an action
+// (see ActionReporter), giving any sent transactions time to be mined before the game is progressed again.
+// Players that don't implement ActionReporter are treated as having acted after every pass.
+// Follow up passes explicitly requested by the player are not affected. A few L1 block times is usually
+// a suitable duration. The default of 0 disables the cooldown.
+func WithActionCooldown(d time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.actionCooldown = d
+	}
+}
+
+// WithMaxTotalJobs stops the scheduler scheduling further jobs once the results of n jobs have been processed,
+// allowing the challenger to run a single reconciliation and exit. Once the limit is reached, Schedule returns
+// ErrJobLimitReached, jobs already queued are completed and then the channel returned by Scheduler.Done is closed.
+// The default of 0 runs without limit.
+func WithMaxTotalJobs(n uint64) SchedulerOption {
+	return func(cfg *config) {
+		cfg.maxTotalJobs = n
+	}
+}
+
+// WithQueueFullStrategy sets how jobs are handled when the job queue is full while scheduling a batch.
+// Defaults to QueueFullDefer so that a full job queue never stalls the scheduler loop.
+// With QueueFullDrop, the jobs dropped are chosen by the policy set by WithDropPolicy.
+func WithQueueFullStrategy(strategy QueueFullStrategy) SchedulerOption {
+	return func(cfg *config) {
+		cfg.queueFullStrategy = strategy
+	}
+}
+
+// WithFailureDemotion lowers the scheduling priority of games that repeatedly fail to progress (see ErrorReporter)
+// so that healthy games are serviced first. Once a game has failed threshold consecutive times, its priority is
+// lowered by amount for each consecutive failure from then on. Jobs within a batch are enqueued in priority order,
+// so demoted games are still progressed every cycle but only after healthier games. A successful progression
+// restores the game's priority. Demotion is disabled if threshold or amount is 0.
+func WithFailureDemotion(threshold uint, amount uint) SchedulerOption {
+	return func(cfg *config) {
+		cfg.failureDemotion = failureDemotion{threshold: threshold, amount: amount}
+	}
+}
+
+// WithScheduleTransform applies transform to the games in each batch before they are scheduled, allowing games
+// to be added (e.g. always scheduled canary games), removed (e.g. a central deny list) or reordered.
+// The transform runs on the scheduler loop so must be fast. The transformed list is treated exactly as if it
+// had been passed to Schedule, so games already in flight are still skipped.
+func WithScheduleTransform(transform ScheduleTransform) SchedulerOption {
+	return func(cfg *config) {
+		cfg.scheduleTransform = transform
+	}
+}
+
+// WithGasBudget limits the estimated gas spent on actions (see GasReporter) to budget per window.
+// Once the budget for the current window is used, games whose most recent progression took action are not
+// scheduled until the next window starts. Games that didn't act last time are still scheduled as they are
+// expected to be read-only, though any gas they do spend is counted against the budget.
+// A window of 0 applies the budget per scheduling cycle. The default budget of 0 disables the limit.
+func WithGasBudget(budget uint64, window time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.gasBudget = budget
+		cfg.gasBudgetWindow = window
+	}
+}
+
+// WithDependencies declares prerequisite games that must be handled before a game is progressed.
+// A game is not scheduled until each of its prerequisites has been resolved or progressed without error
+// during this session. If a prerequisite's latest progression failed, its dependents are skipped until it
+// succeeds. The reason a game was skipped is logged and included in Scheduler.ExportState.
+// By default games have no dependencies.
+func WithDependencies(deps map[common.Address][]common.Address) SchedulerOption {
+	return func(cfg *config) {
+		cfg.dependencies = cloneDependencies(deps)
+	}
+}
+
+func cloneDependencies(deps map[common.Address][]common.Address) map[common.Address][]common.Address {
+	if deps == nil {
+		return nil
+	}
+	clone := make(map[common.Address][]common.Address, len(deps))
+	for game, prereqs := range deps {
+		clone[game] = slices.Clone(prereqs)
+	}
+	return clone
+}
+
+// WithErrorLogWindow sets the window within which repeated identical errors are collapsed into a single log line.
+// Errors are identical if they occur in the same part of the scheduler and have the same message; distinct errors
+// are always logged. Once an error stops recurring, a summary with the number of suppressed occurrences is logged.
+// Defaults to one minute. A window of 0 logs every error.
+func WithErrorLogWindow(d time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.errorLogWindow = d
+	}
+}
+
+// WithResultSink sets a sink that is called with the outcome of each completed job, for example to publish
+// results to a message queue for downstream services. The sink is called sequentially from a separate goroutine
+// so it may perform I/O without delaying the processing of results. Up to 1000 results are buffered; further
+// results are dropped while the buffer is full. Errors returned by the sink are logged and recorded as a metric.
+func WithResultSink(sink ResultSink) SchedulerOption {
+	return func(cfg *config) {
+		cfg.resultSink = sink
+	}
+}
+
+// WithResultBackpressure pauses dispatching jobs once high results are waiting to be processed, processing results
+// until fewer than low are pending before dispatch resumes. This prevents workers from blocking on a full result
+// queue when result processing is slow. Values above the size of the result queue, twice maxConcurrency, are
+// never reached. A low water mark above high is treated as high. A high water mark of 0 (the default) disables it.
+func WithResultBackpressure(high, low uint) SchedulerOption {
+	return func(cfg *config) {
+		cfg.resultsHighWater = high
+		cfg.resultsLowWater = low
+	}
+}
+
+// WithScheduleSpreading spreads the progression of games across successive schedule calls rather than progressing
+// every game each time. Games are deterministically divided into the specified number of buckets and each call to
+// Schedule progresses the games in the next bucket, so every game is progressed once every buckets calls.
+// Games where the player took action in their most recent progression are progressed on every call. This smooths
+// the load on backends when there is a very large number of games. Values of 1 or less (the default) disable it.
+func WithScheduleSpreading(buckets int) SchedulerOption {
+	return func(cfg *config) {
+		cfg.scheduleBuckets = buckets
+	}
+}
+
+// WithWorkerStateListener sets a listener that is notified each time a worker starts progressing a game and when it
+// finishes. The listener is called synchronously from the worker goroutine, concurrently for different workers, so
+// it must be safe for concurrent use, return quickly and never block. Each worker's transitions alternate, starting
+// with WorkerActive. By default no listener is set.
+func WithWorkerStateListener(listener WorkerStateListener) SchedulerOption {
+	return func(cfg *config) {
+		cfg.workerStateListener = listener
+	}
+}
+
+// WithGlobalBackoff slows down the progression of all games while the backend appears degraded. When at least the
+// threshold proportion (between 0 and 1) of progressions since the previous schedule call reported an error, the
+// dispatch of the next batch of jobs is delayed, starting at initial and doubling each time up to max. The delay
+// resets once the failure rate drops below the threshold. Results continue to be processed while waiting.
+// Only failures reported by players implementing ErrorReporter are counted. An initial delay of 0 (the default)
+// disables it.
+func WithGlobalBackoff(threshold float64, initial, max time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.backoffThreshold = threshold
+		cfg.backoffInitial = initial
+		cfg.backoffMax = max
+	}
+}
+
+// WithResolvedRetention keeps the data directory and in-memory state of resolved games for d after they are first
+// seen to be resolved, for post-mortem analysis. After that the directory is removed and the state is evicted once
+// the game is no longer scheduled. By default (0) a resolved game's directory is removed as soon as it resolves.
+func WithResolvedRetention(d time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.resolvedRetention = d
+	}
+}
+
+// WithRecentResults keeps the most recent depth results of each game in memory so they can be inspected with
+// Scheduler.RecentResults, for example to spot a game oscillating between actions. This is much cheaper than
+// snapshot capture. Results are discarded an hour after the game resolves or stops being scheduled and the total
+// number kept across all games is capped. Disabled by default (0).
+func WithRecentResults(depth int) SchedulerOption {
+	return func(cfg *config) {
+		cfg.recentResults = depth
+	}
+}
+
+// WithRandomizedDispatch shuffles the jobs in each batch before they are enqueued so that, over many cycles, no
+// game is disadvantaged by its position in the batch. Higher priority jobs (see WithFailureDemotion) are still
+// enqueued first. Disabled by default so games are enqueued in the order they are received.
+func WithRandomizedDispatch(enabled bool) SchedulerOption {
+	return func(cfg *config) {
+		cfg.randomizeDispatch = enabled
+	}
+}
+
+// WithReadinessCheck calls check for each in progress game in every batch and skips progressing games that aren't
+// ready yet, for example because an on-chain condition doesn't hold. Skipped games are checked again the next time
+// they are scheduled. Games are also skipped if check returns an error.
+// check is called sequentially from the scheduling thread so should return promptly and respect ctx.
+func WithReadinessCheck(check ReadinessCheck) SchedulerOption {
+	return func(cfg *config) {
+		cfg.readinessCheck = check
+	}
+}
+
+// WithMaxTrackedGames limits the number of distinct games the scheduler holds state for to n, bounding memory use if
+// very many games are scheduled, for example during a reorg storm. Once exceeded, the state of the least recently
+// scheduled games is evicted, including resolved games kept by WithResolvedRetention. Games with a job in flight or
+// queued are never evicted. An evicted game is treated as new if it is scheduled again. Unlimited by default (0).
+func WithMaxTrackedGames(n int) SchedulerOption {
+	return func(cfg *config) {
+		cfg.maxTrackedGames = n
+	}
+}
+
+// WithResultClassifier sets the function used to classify each completed job as a success, transient failure,
+// permanent failure or no-op, which decides how WithFailureDemotion, WithDependencies and WithGlobalBackoff
+// treat the result. By default, results are transient failures if the player reports an error (see ErrorReporter)
+// and successes otherwise. classifier is called from the scheduling thread so should return promptly.
+func WithResultClassifier(classifier ResultClassifier) SchedulerOption {
+	return func(cfg *config) {
+		cfg.resultClassifier = classifier
+	}
+}
+
+// WithInvalidResultPolicy sets how results that fail validation, such as a player returning an unknown game
+// status, are handled. Invalid results are always logged and recorded, and never update the game's state.
+// Defaults to InvalidResultDrop.
+func WithInvalidResultPolicy(policy InvalidResultPolicy) SchedulerOption {
+	return func(cfg *config) {
+		cfg.invalidResultPolicy = policy
+	}
+}
+
+// WithDiskReconciliation compares the games being tracked with the game directories on disk every interval,
+// recording the number of orphaned directories, belonging to games that aren't tracked, and in progress games whose
+// directory is missing. If repair is true, orphaned directories are removed and games missing their directory have
+// their player recreated the next time they are scheduled. Requires a DiskManager that implements
+// RecoverableDiskManager. Disabled by default (0).
+func WithDiskReconciliation(interval time.Duration, repair bool) SchedulerOption {
+	return func(cfg *config) {
+		cfg.reconcileInterval = interval
+		cfg.reconcileRepair = repair
+	}
+}
+
+// WithMaxRetryAge abandons games that have been failing for longer than d, measured from the first of their
+// consecutive failures to create a job or progress the game, so a broken game isn't retried indefinitely.
+// Abandoned games are no longer progressed and are reported by Scheduler.AbandonedGames until they stop being
+// scheduled. Unlimited by default (0).
+func WithMaxRetryAge(d time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.maxRetryAge = d
+	}
+}
+
+// WithAuditSink writes an AuditRecord, as a single line of JSON, to w for each progression in which the player
+// took action (see ActionReporter and ActionCategoryReporter), to provide an audit trail of actions taken.
+// Records are written in the order results are processed, before processing continues, and w is synced after each
+// record if it implements Sync() error, as an *os.File does. A sink that buffers records and implements
+// Flush() error, such as a *bufio.Writer, is flushed by Scheduler.Flush and Close. Failed writes are logged and
+// recorded but can't prevent the action, which has already been taken.
+func WithAuditSink(w io.Writer) SchedulerOption {
+	return func(cfg *config) {
+		cfg.auditSink = w
+	}
+}
+
+// WithDropPolicy sets how the jobs to drop are chosen when a batch doesn't fit in the job queue with
+// QueueFullDrop, so the least valuable work is shed. Defaults to DropLowestPriority.
+func WithDropPolicy(policy DropPolicy) SchedulerOption {
+	return func(cfg *config) {
+		cfg.dropPolicy = policy
+	}
+}
+
+// WithEventPublisher publishes an Event to publisher at each transition in the lifecycle of a game's jobs, for
+// example to forward them to an external event bus. Events are buffered and published from a separate goroutine
+// so a slow publisher never stalls scheduling. Events are dropped, and the drop recorded, if the buffer is full.
+// See also Scheduler.Subscribe to receive the events in process.
+func WithEventPublisher(publisher EventPublisher) SchedulerOption {
+	return func(cfg *config) {
+		cfg.eventPublisher = publisher
+	}
+}
+
+// WithUrgentWorkers reserves n workers, in addition to the main pool, that only progress games scheduled with
+// Scheduler.ScheduleUrgent so urgent games are progressed promptly even when the main pool is saturated.
+// There are no urgent workers by default (0), in which case ScheduleUrgent returns ErrNoUrgentWorkers.
+func WithUrgentWorkers(n uint) SchedulerOption {
+	return func(cfg *config) {
+		cfg.urgentWorkers = n
+	}
+}
+
+// WithInFlightWarnThreshold logs a warning and records a metric, once per job, for jobs that have been in flight
+// for longer than d, giving early visibility of slow games. Unlike the timeouts set by ScheduleGames, jobs are not cancelled.
+// In-flight jobs are checked every 5 seconds. Disabled by default (0).
+func WithInFlightWarnThreshold(d time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.inFlightWarnThreshold = d
+	}
+}
+
+// WithBatchGate sets a gate that is called once per cycle, before any jobs are created, with the games in the
+// batch to decide whether the cycle should proceed based on global conditions such as gas prices or feature flags.
+// If the gate returns false the whole cycle is skipped. Errors are logged and also skip the cycle.
+func WithBatchGate(gate BatchGate) SchedulerOption {
+	return func(cfg *config) {
+		cfg.batchGate = gate
+	}
+}
+
+// WithStableOrder makes the order in which games are dispatched and their results processed within each batch
+// stable and reproducible, so audit logs (see WithAuditSink) and result sinks produced from the same input can be
+// diffed across runs. Each batch is sorted using compare, or by address if compare is nil, in place of the usual
+// priority and randomised ordering. Games are still progressed concurrently but each result is held until the
+// results of all regularly scheduled jobs dispatched before it have been processed, so one slow game delays
+// processing, and any follow up passes, of every game after it. Results of follow up, forced and urgent passes are
+// not held back.
+func WithStableOrder(compare func(a, b common.Address) int) SchedulerOption {
+	return func(cfg *config) {
+		if compare == nil {
+			compare = compareAddresses
+		}
+		cfg.stableOrder = compare
+	}
+}
+
+// WithMaxAcceptedBatchSize rejects batches of more than n games with ErrBatchTooLarge, without scheduling any of
+// them, as a safety valve against a faulty caller flooding the scheduler with a runaway batch.
+// Unlike WithScheduleSpreading, large batches are refused rather than progressed gradually. Unlimited by default (0).
+// The limit applies to each batch as it is scheduled. A batch merged into one that hasn't been accepted yet may
+// exceed it, since the already accepted batch isn't then rejected, but games listed in both are only counted once.
+func WithMaxAcceptedBatchSize(n int) SchedulerOption {
+	return func(cfg *config) {
+		cfg.maxAcceptedBatchSize = n
+	}
+}
+
+// WithLatencyPercentiles sets the percentiles, expressed as fractions between 0 and 1, reported by
+// Scheduler.LatencyPercentiles and the number of most recent jobs they are computed over, which bounds the memory
+// used. Defaults to the 0.5, 0.95 and 0.99 percentiles of the last 1000 jobs. A window of 0 disables tracking.
+func WithLatencyPercentiles(window int, percentiles ...float64) SchedulerOption {
+	return func(cfg *config) {
+		cfg.latencyWindow = window
+		cfg.latencyPercentiles = slices.Clone(percentiles)
+	}
+}
+
+// WithGameTypeConfig overrides global settings for games of the specified type so a single scheduler can be tuned
+// for a mix of game types, for example disabling the action cooldown for permissioned games. Settings are resolved
+// when each job is created: a setting for an individual game takes precedence over the game type's override,
+// which takes precedence over the global setting. May be used multiple times for different game types.
+func WithGameTypeConfig(gameType uint32, override GameTypeConfig) SchedulerOption {
+	return func(cfg *config) {
+		if cfg.gameTypes == nil {
+			cfg.gameTypes = make(map[uint32]GameTypeConfig)
+		}
+		cfg.gameTypes[gameType] = override
+	}
+}
+
+// WithPlayerInitPolicy sets how a game is handled when its player is created but fails to initialize its disk state,
+// for example because its prestate couldn't be loaded. Each failure is reported via RecordPlayerInitFailure.
+// With PlayerInitRetry, or PlayerInitQuarantine if the directory can't be quarantined, the player isn't created again
+// until a backoff has expired, doubling from initialBackoff up to maxBackoff for each consecutive failure.
+// Defaults to PlayerInitRetry with no backoff, retrying every cycle.
+func WithPlayerInitPolicy(policy PlayerInitPolicy, initialBackoff time.Duration, maxBackoff time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.playerInitPolicy = policy
+		cfg.playerInitBackoff = playerInitBackoff{initial: initialBackoff, max: maxBackoff}
+	}
+}
+
+// WithUpstreamConcurrency limits the total number of concurrent interactions with the upstream node to n, shared
+// between the checks made while scheduling, namely prestate validation and the check set by WithReadinessCheck,
+// and the progression of games by workers. This bounds the combined pressure on the node with a single limit,
+// rather than it depending on both the number of workers and a large batch being checked at the same time.
+// The number of slots in use is reported via RecordUpstreamInUse. Unlimited by default (0).
+func WithUpstreamConcurrency(n uint) SchedulerOption {
+	return func(cfg *config) {
+		cfg.upstreamConcurrency = n
+	}
+}
+
+// WithDecisionTTL sets how long the most recent scheduling decision for each game, reported by
+// Scheduler.LastDecision, is kept after it is made. Decisions are pruned once per cycle. Defaults to an hour.
+// A TTL of 0 disables recording decisions.
+func WithDecisionTTL(ttl time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.decisionTTL = ttl
+	}
+}
+
+// WithGameFilter sets the initial filter deciding which games are progressed, which can be replaced while the
+// scheduler is running with Scheduler.SetGameFilter. Games excluded by the filter are kept track of but not
+// progressed. All games are included by default.
+func WithGameFilter(filter GameFilter) SchedulerOption {
+	return func(cfg *config) {
+		cfg.gameFilter = filter
+	}
+}
+
+// WithDeadlineResolver sets a function used to look up each game's next on-chain deadline, such as its chess clock
+// expiry, after it is progressed if its player doesn't implement DeadlineReporter. The resolver is called by the
+// worker that progressed the game. Games nearest their deadline are dispatched first and each progression's
+// timeout is bounded by the time remaining until the deadline when it is dispatched, see DeadlineReporter.
+func WithDeadlineResolver(resolver DeadlineResolver) SchedulerOption {
+	return func(cfg *config) {
+		cfg.deadlineResolver = resolver
+	}
+}
+
+// WithMemoryPressure pauses dispatching jobs while the memory actually used by the process, read from the Go
+// runtime every second, is at least high bytes, resuming once it drops below low. Results continue to be
+// processed while paused. If forceGC is set, a garbage collection is forced each time dispatch is paused.
+// This is a backstop for players using more memory than expected. A low water mark above high is treated as
+// high. A high water mark of 0 (the default) disables it.
+func WithMemoryPressure(high, low uint64, forceGC bool) SchedulerOption {
+	return func(cfg *config) {
+		cfg.memoryHighWater = high
+		cfg.memoryLowWater = low
+		cfg.memoryForceGC = forceGC
+	}
+}
+
+// WithAckSink sets a sink notified exactly once for each game passed to Schedule, or listed more than once, when it
+// reaches a terminal state in the pipeline so the caller can check every game it handed off was accounted for.
+// Games progressed by the batch are acknowledged once their result is processed or the job is dropped or cancelled.
+// Games that aren't progressed, such as those excluded by the filter, already in flight or abandoned, are acknowledged
+// when the batch is scheduled. Games in batches dropped because the scheduler stopped before scheduling them are
+// acknowledged when the batch is dropped. The sink is called synchronously from the scheduler loop with internal
+// locks held so it must return quickly and must not call the Scheduler. By default no sink is set.
+func WithAckSink(sink AckSink) SchedulerOption {
+	return func(cfg *config) {
+		cfg.ackSink = sink
+	}
+}
+
+// WithPriorityDispatch dispatches the jobs waiting for a worker most urgent first rather than in the order they were
+// enqueued, so games where the challenger has claims at risk or whose chess clock is nearest to expiring aren't held
+// up behind a backlog of jobs from earlier batches, see ClaimRiskReporter and DeadlineReporter. Waiting jobs are
+// moved out of the job queue to be ordered, which allows up to twice as many jobs to wait for a worker before the
+// job queue is full. By default jobs are only ordered within each batch and dispatched in the order they were
+// enqueued.
+func WithPriorityDispatch() SchedulerOption {
+	return func(cfg *config) {
+		cfg.priorityDispatch = true
+	}
+}
+
+// WithCheckpoint periodically saves the state of the scheduler, as exported by ExportFullState, every interval and
+// once more when the scheduler stops, and restores it when the scheduler starts so a restart or crash doesn't lose
+// the state of known games, including their retry and failure history. The checkpoint is saved to the state store
+// for SchedulerStateGame with the key StateKeyCheckpoint, which the default disk store writes to the file at path.
+// The path is only used by the default store and may be empty if WithStateStore is used. Games that had a job queued or in flight when the checkpoint was
+// written are progressed as soon as the scheduler starts rather than waiting for the next batch. A restored
+// checkpoint replaces any state imported with ImportFullState, and one that can't be restored is logged and ignored. An interval of 0 only writes a checkpoint when the scheduler stops.
+// By default no checkpoint is kept.
+func WithCheckpoint(path string, interval time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.checkpoint = true
+		cfg.checkpointPath = path
+		cfg.checkpointInterval = interval
+	}
+}
+
+// WithFailureBackoff delays progressing a game again after it fails to create a job or progress, doubling from
+// initial up to max for each consecutive failure. The backoff ends once the game is progressed successfully.
+// By default failing games are retried every cycle.
+func WithFailureBackoff(initial time.Duration, max time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.failureBackoff = playerInitBackoff{initial: initial, max: max}
+	}
+}
+
+// WithCircuitBreaker quarantines a game once it fails to create a job or progress threshold times in a row, so it
+// isn't progressed again until cooldown has passed. Each quarantine is reported via RecordGameQuarantined. Once the
+// cooldown passes the game is progressed once more and quarantined again immediately if that fails. Quarantined
+// games are listed by QuarantinedGames and can be re-enabled early with ReleaseQuarantine. A threshold of 0, the
+// default, never quarantines games.
+func WithCircuitBreaker(threshold uint, cooldown time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.breakerThreshold = threshold
+		cfg.breakerCooldown = cooldown
+	}
+}
+
+// WithShadowMode switches each player to logging the transactions it would send instead of sending them as it is
+// created by the PlayerCreator, see ShadowPlayer. Games whose player doesn't support shadow mode fail to be created
+// rather than risk sending transactions. The scheduler progresses games as normal, but warns at start up that no
+// transactions will be sent and reports the mode in EffectiveConfig so operators can confirm it before trusting a
+// deployment.
+func WithShadowMode(enabled bool) SchedulerOption {
+	return func(cfg *config) {
+		cfg.shadowMode = enabled
+	}
+}
+
+// WithJobTimeout limits how long each progression may run for games without a timeout set by ScheduleGames or
+// WithGameTypeConfig, so a hung player, such as one waiting on a wedged cannon subprocess, can't occupy a worker
+// forever. Once the timeout expires the context passed to the player is cancelled, the timeout is recorded via
+// RecordGameUpdateTimedOut and the game is progressed once more straight away. There is no timeout by default (0).
+func WithJobTimeout(d time.Duration) SchedulerOption {
+	return func(cfg *config) {
+		cfg.jobTimeout = d
+	}
+}
+
+// WithResolutionHandler sets a handler called with each game of the primary factory once a progression finds it
+// has resolved, including games that had already resolved when first scheduled, for example to claim its bonds.
+// Games whose player reports they resolved against the challenger, see OutcomeReporter, are skipped. The handler is
+// called from the scheduler's loop so must not block.
+func WithResolutionHandler(handler ResolutionHandler) SchedulerOption {
+	return func(cfg *config) {
+		cfg.resolutionHandler = handler
+	}
+}
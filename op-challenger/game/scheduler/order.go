@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// compareAddresses orders games by address, the default order for WithStableOrder.
+func compareAddresses(a, b common.Address) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// resultSequencer holds back results so they are processed in the order their jobs were dispatched rather than
+// the order they complete, see WithStableOrder. Only jobs added to the sequencer are held.
+type resultSequencer struct {
+	// order holds the ids of sequenced jobs awaiting processing, in dispatch order.
+	order []uint64
+	// held holds the results of sequenced jobs that completed before the jobs dispatched ahead of them.
+	held map[uint64]job
+}
+
+func newResultSequencer() *resultSequencer {
+	return &resultSequencer{held: make(map[uint64]job)}
+}
+
+func newResultSequencerFor(cfg config) *resultSequencer {
+	if cfg.stableOrder == nil {
+		return nil
+	}
+	return newResultSequencer()
+}
+
+// add records that the job is being dispatched after all previously added jobs.
+func (s *resultSequencer) add(id uint64) {
+	s.order = append(s.order, id)
+}
+
+// offer records the result and returns the results that are now ready to be processed, in dispatch order.
+// Results of jobs that weren't added are returned immediately.
+func (s *resultSequencer) offer(j job) []job {
+	if !slices.Contains(s.order, j.id) {
+		return []job{j}
+	}
+	s.held[j.id] = j
+	return s.ready()
+}
+
+// release removes a job that will never produce a result and returns the results that are now ready to be
+// processed, in dispatch order.
+func (s *resultSequencer) release(id uint64) []job {
+	s.order = slices.DeleteFunc(s.order, func(candidate uint64) bool {
+		return candidate == id
+	})
+	delete(s.held, id)
+	return s.ready()
+}
+
+func (s *resultSequencer) ready() []job {
+	var ready []job
+	for len(s.order) > 0 {
+		j, ok := s.held[s.order[0]]
+		if !ok {
+			break
+		}
+		delete(s.held, j.id)
+		s.order = s.order[1:]
+		ready = append(ready, j)
+	}
+	return ready
+}
+
+// sequenceJobs orders the jobs of a batch with the comparator set by WithStableOrder and adds them to the
+// sequencer so their results are processed in that order. The lock must be held.
+func (c *coordinator) sequenceJobs(jobs []job) {
+	slices.SortStableFunc(jobs, func(a, b job) int {
+		return c.cfg.stableOrder(a.addr, b.addr)
+	})
+	for _, j := range jobs {
+		c.sequencer.add(j.id)
+	}
+}
+
+// processSequenced processes the result, and any held results it releases, in dispatch order.
+// The lock must be held.
+func (c *coordinator) processSequenced(j job) error {
+	var errs []error
+	for _, ready := range c.sequencer.offer(j) {
+		if err := c.applyResult(ready); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// releaseSequenced removes a job that will never produce a result from the sequencer and processes any held
+// results released by it. The lock must be held.
+func (c *coordinator) releaseSequenced(id uint64) {
+	for _, ready := range c.sequencer.release(id) {
+		if err := c.applyResult(ready); err != nil {
+			c.errLog.Log(log.LevelError, "result", "Failed to process result", err, "game", ready.addr)
+		}
+	}
+}
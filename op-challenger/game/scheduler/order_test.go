@@ -0,0 +1,150 @@
+package scheduler
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultSequencer(t *testing.T) {
+	s := newResultSequencer()
+	s.add(1)
+	s.add(2)
+	s.add(3)
+	s.add(4)
+
+	require.Empty(t, s.offer(job{id: 3}))
+	require.Empty(t, s.offer(job{id: 2}))
+	require.Equal(t, []job{{id: 5}}, s.offer(job{id: 5}), "should not hold unsequenced results")
+	require.Equal(t, []job{{id: 1}, {id: 2}, {id: 3}}, s.offer(job{id: 1}))
+	require.Equal(t, []job{{id: 4}}, s.offer(job{id: 4}))
+
+	s.add(6)
+	s.add(7)
+	require.Empty(t, s.offer(job{id: 7}))
+	require.Equal(t, []job{{id: 7}}, s.release(6))
+	require.Empty(t, s.order)
+	require.Empty(t, s.held)
+}
+
+func TestStableOrderProcessesResultsInDispatchOrder(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.stableOrder = compareAddresses
+	c.sequencer = newResultSequencer()
+	game1 := common.Address{0x01}
+	game2 := common.Address{0x02}
+	game3 := common.Address{0x03}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(game3, game1, game2), 5))
+	jobs := []job{<-workQueue, <-workQueue, <-workQueue}
+	require.Equal(t, []common.Address{game1, game2, game3}, []common.Address{jobs[0].addr, jobs[1].addr, jobs[2].addr})
+
+	require.NoError(t, c.processResult(runJob(ctx, jobs[2])))
+	require.NoError(t, c.processResult(runJob(ctx, jobs[1])))
+	require.Equal(t, []uint64{0, 0, 0}, processedBlocks(c, game1, game2, game3), "should hold results until earlier jobs complete")
+	require.False(t, c.idle.IsIdle())
+
+	require.NoError(t, c.processResult(runJob(ctx, jobs[0])))
+	require.Equal(t, []uint64{5, 5, 5}, processedBlocks(c, game1, game2, game3))
+	require.True(t, c.idle.IsIdle())
+	require.Empty(t, c.sequencer.held)
+}
+
+func TestStableOrderReleasedJobUnblocksResults(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	c.cfg.stableOrder = compareAddresses
+	c.sequencer = newResultSequencer()
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(common.Address{0x01}, common.Address{0x02}), 5))
+	first, second := <-workQueue, <-workQueue
+	require.NoError(t, c.processResult(runJob(ctx, second)))
+	require.False(t, c.idle.IsIdle())
+
+	c.abandonJobs([]job{first})
+	require.True(t, c.idle.IsIdle())
+	require.Equal(t, uint64(5), c.states[second.addr].lastProcessedBlockNum)
+}
+
+func TestStableOrderAuditIsReproducible(t *testing.T) {
+	games := []common.Address{{0x05}, {0x03}, {0x08}, {0x01}, {0x07}, {0x02}, {0x06}, {0x04}}
+	first := runStableOrderAudit(t, games, 1)
+	second := runStableOrderAudit(t, games, 2)
+	require.Equal(t, first, second)
+
+	// Each cycle is processed in address order.
+	sorted := []common.Address{{0x01}, {0x02}, {0x03}, {0x04}, {0x05}, {0x06}, {0x07}, {0x08}}
+	require.Equal(t, append(sorted, sorted...), first)
+}
+
+// runStableOrderAudit progresses games for two cycles, with players that take a random time to complete, and
+// returns the games in the order they were audited.
+func runStableOrderAudit(t *testing.T, games []common.Address, seed int64) []common.Address {
+	logger := testlog.Logger(t, log.LevelInfo)
+	rng := rand.New(rand.NewSource(seed))
+	delays := make(map[common.Address]time.Duration)
+	for _, addr := range games {
+		delays[addr] = time.Duration(rng.Intn(5)) * time.Millisecond
+	}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &delayedPlayer{
+			StubGamePlayer: &test.StubGamePlayer{StatusValue: types.GameStatusInProgress, ActionTakenValue: true},
+			delay:          delays[g.Proxy],
+		}, nil
+	}
+	var sink bytes.Buffer
+	disk := &stubDiskManager{gameDirExists: make(map[common.Address]bool)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 4, createPlayer, false,
+		WithClock(clock.NewDeterministicClock(time.Unix(1000, 0))), WithAuditSink(&sink), WithStableOrder(nil))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	for block := uint64(0); block < 2; block++ {
+		require.NoError(t, s.Schedule(asGames(games...), block))
+		require.NoError(t, s.WaitIdle(ctx))
+	}
+	require.NoError(t, s.Close())
+
+	var audited []common.Address
+	scanner := bufio.NewScanner(&sink)
+	for scanner.Scan() {
+		var record AuditRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		audited = append(audited, record.Game)
+	}
+	require.NoError(t, scanner.Err())
+	return audited
+}
+
+func processedBlocks(c *coordinator, games ...common.Address) []uint64 {
+	blocks := make([]uint64, len(games))
+	for i, addr := range games {
+		blocks[i] = c.states[addr].lastProcessedBlockNum
+	}
+	return blocks
+}
+
+// delayedPlayer takes delay to progress the game.
+type delayedPlayer struct {
+	*test.StubGamePlayer
+	delay time.Duration
+}
+
+func (p *delayedPlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	time.Sleep(p.delay)
+	return p.StubGamePlayer.ProgressGame(ctx)
+}
@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PinGame pins the game so it is scheduled every cycle at full frequency, bypassing the cadence reduction for idle
+// games set by WithActivityDecay. Pinned games are otherwise scheduled as usual: they still wait for cooldowns and
+// only have one job in flight at a time. The pin applies until UnpinGame is called, even if the game stops being
+// scheduled.
+func (s *Scheduler) PinGame(addr common.Address) {
+	c := s.coordinator
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.pinned[addr] = true
+	c.logger.Info("Pinned game", "game", addr)
+	c.tracer.Log(addr, "Pinned game")
+}
+
+// UnpinGame removes the pin set by PinGame so the game's schedule is reduced when idle again.
+func (s *Scheduler) UnpinGame(addr common.Address) {
+	c := s.coordinator
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !c.pinned[addr] {
+		return
+	}
+	delete(c.pinned, addr)
+	c.logger.Info("Unpinned game", "game", addr)
+	c.tracer.Log(addr, "Unpinned game")
+}
+
+// PinnedGames returns the games currently pinned by PinGame, ordered by address.
+func (s *Scheduler) PinnedGames() []common.Address {
+	c := s.coordinator
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	pinned := make([]common.Address, 0, len(c.pinned))
+	for addr := range c.pinned {
+		pinned = append(pinned, addr)
+	}
+	slices.SortFunc(pinned, compareAddresses)
+	return pinned
+}
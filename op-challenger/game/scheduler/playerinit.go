@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrPlayerInit is wrapped by the error creating a job for a game whose player was created but failed to initialize
+// its disk state, for example because its prestate couldn't be loaded.
+var ErrPlayerInit = errors.New("failed to initialize game player")
+
+// AbandonReasonPlayerInit is the reason recorded for games abandoned by PlayerInitAbandon.
+const AbandonReasonPlayerInit = "player init failed"
+
+// PlayerInitPolicy determines how a game is handled when its player is created but fails to initialize its disk
+// state, see WithPlayerInitPolicy. Failures to create the player and invalid prestates are not affected.
+type PlayerInitPolicy int
+
+const (
+	// PlayerInitRetry creates the player again in a later cycle, once the backoff has expired. This is the default.
+	PlayerInitRetry PlayerInitPolicy = iota
+	// PlayerInitQuarantine moves the game's directory aside, keeping it for investigation, and creates the player
+	// again with a fresh directory in the next cycle. Useful when the existing directory may be corrupt.
+	// Requires the DiskManager to implement RecoverableDiskManager, otherwise behaves like PlayerInitRetry.
+	PlayerInitQuarantine
+	// PlayerInitAbandon stops progressing the game, see AbandonedGames.
+	PlayerInitAbandon
+)
+
+func (p PlayerInitPolicy) String() string {
+	switch p {
+	case PlayerInitRetry:
+		return "retry"
+	case PlayerInitQuarantine:
+		return "quarantine"
+	case PlayerInitAbandon:
+		return "abandon"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(p))
+	}
+}
+
+type PlayerInitMetricer interface {
+	RecordPlayerInitFailure()
+}
+
+// playerInitBackoff is the delay before retrying to initialize a game's player, doubling from initial up to max for
+// each consecutive failure.
+type playerInitBackoff struct {
+	initial time.Duration
+	max     time.Duration
+}
+
+func (b playerInitBackoff) delay(failures uint) time.Duration {
+	if b.initial <= 0 || failures == 0 {
+		return 0
+	}
+	limit := max(b.max, b.initial)
+	d := b.initial
+	for i := uint(1); i < failures && d < limit; i++ {
+		d *= 2
+	}
+	return min(d, limit)
+}
+
+// playerInitFailed handles a failure of the game's newly created player to initialize according to the policy set
+// by WithPlayerInitPolicy and returns the error to report. The lock must be held.
+func (c *coordinator) playerInitFailed(addr common.Address, state *gameState, err error) error {
+	err = fmt.Errorf("%w: %w", ErrPlayerInit, err)
+	c.m.RecordPlayerInitFailure()
+	state.initFailures++
+	switch c.cfg.playerInitPolicy {
+	case PlayerInitAbandon:
+		if _, ok := c.abandoned[addr]; !ok {
+			now := c.cfg.clock.Now()
+			c.abandoned[addr] = AbandonedGame{Game: addr, Factory: c.factory, Reason: AbandonReasonPlayerInit, FirstFailure: now, Time: now}
+			c.m.RecordGameAbandoned(AbandonReasonPlayerInit)
+			c.events.Emit(addr, EventAbandoned, c.cycle, state.correlationID)
+			c.logger.Error("Abandoning game", "game", addr, "reason", AbandonReasonPlayerInit, "err", err)
+			c.tracer.Log(addr, "Abandoned game", "reason", AbandonReasonPlayerInit)
+		}
+		return err
+	case PlayerInitQuarantine:
+		if c.recoverable != nil {
+			dest, qErr := c.recoverable.QuarantineGame(addr)
+			if qErr == nil {
+				c.m.RecordGameDirQuarantined()
+				c.logger.Warn("Quarantined game directory after player failed to initialize", "game", addr, "dest", dest, "err", err)
+				c.tracer.Log(addr, "Quarantined game directory", "dest", dest)
+				return err
+			}
+			c.logger.Error("Failed to quarantine game directory", "game", addr, "err", qErr)
+			err = errors.Join(err, fmt.Errorf("failed to quarantine game directory: %w", qErr))
+		}
+	}
+	if delay := c.cfg.playerInitBackoff.delay(state.initFailures); delay > 0 {
+		state.initRetryAt = c.cfg.clock.Now().Add(delay)
+		c.tracer.Log(addr, "Backing off player initialization", "delay", delay)
+	}
+	return err
+}
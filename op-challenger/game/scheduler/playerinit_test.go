@@ -0,0 +1,160 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlayerInitQuarantineRetriesFresh(t *testing.T) {
+	disk := &recoverableDiskManager{dir: t.TempDir()}
+	c, workQueue, m := setupPlayerInitTest(t, disk, WithPlayerInitPolicy(PlayerInitQuarantine, time.Minute, time.Hour))
+	c.recoverable = disk
+	ctx := context.Background()
+	gameAddr := common.Address{0xaa}
+	dir := disk.DirForGame(gameAddr)
+	writeCorruptPrestate(t, dir)
+
+	err := c.schedule(ctx, asGames(gameAddr), 0)
+	require.ErrorIs(t, err, ErrPlayerInit)
+	require.Empty(t, workQueue)
+	require.Equal(t, 1, m.initFailures)
+	require.Equal(t, 1, m.quarantined)
+	require.Contains(t, disk.quarantined, gameAddr)
+	require.NoDirExists(t, dir)
+
+	// The player is created again with a fresh directory without waiting for the backoff
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 1))
+	require.Len(t, workQueue, 1)
+	require.Equal(t, gameAddr, (<-workQueue).addr)
+	require.Equal(t, 1, m.initFailures)
+	require.Zero(t, c.states[gameAddr].initFailures)
+}
+
+func TestPlayerInitQuarantineUnsupported(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	disk := &tempDirDiskManager{dir: t.TempDir()}
+	c, workQueue, m := setupPlayerInitTest(t, disk, WithClock(cl), WithPlayerInitPolicy(PlayerInitQuarantine, time.Minute, time.Hour))
+	ctx := context.Background()
+	gameAddr := common.Address{0xaa}
+	writeCorruptPrestate(t, disk.DirForGame(gameAddr))
+
+	require.ErrorIs(t, c.schedule(ctx, asGames(gameAddr), 0), ErrPlayerInit)
+	require.Zero(t, m.quarantined)
+	require.Equal(t, cl.Now().Add(time.Minute), c.states[gameAddr].initRetryAt)
+	require.Empty(t, workQueue)
+}
+
+func TestPlayerInitRetryBackoff(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	disk := &tempDirDiskManager{dir: t.TempDir()}
+	c, workQueue, m := setupPlayerInitTest(t, disk, WithClock(cl), WithPlayerInitPolicy(PlayerInitRetry, time.Minute, 3*time.Minute))
+	ctx := context.Background()
+	gameAddr := common.Address{0xaa}
+	prestate := writeCorruptPrestate(t, disk.DirForGame(gameAddr))
+
+	require.ErrorIs(t, c.schedule(ctx, asGames(gameAddr), 0), ErrPlayerInit)
+	require.Equal(t, 1, m.initFailures)
+	require.Equal(t, uint(1), c.states[gameAddr].retries)
+
+	// Not retried until the backoff expires
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 1))
+	require.Equal(t, 1, m.initFailures)
+	require.Equal(t, uint(1), c.states[gameAddr].retries, "waiting for the backoff should not reset retries")
+
+	// Backoff doubles up to the max
+	for _, delay := range []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute} {
+		cl.AdvanceTime(delay)
+		require.ErrorIs(t, c.schedule(ctx, asGames(gameAddr), 2), ErrPlayerInit)
+	}
+	require.Equal(t, 5, m.initFailures)
+	require.Equal(t, cl.Now().Add(3*time.Minute), c.states[gameAddr].initRetryAt)
+
+	// Succeeds once the prestate can be loaded
+	require.NoError(t, os.Remove(prestate))
+	cl.AdvanceTime(3 * time.Minute)
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 3))
+	require.Len(t, workQueue, 1)
+	require.Zero(t, c.states[gameAddr].initFailures)
+	require.Zero(t, c.states[gameAddr].retries)
+}
+
+func TestPlayerInitAbandon(t *testing.T) {
+	disk := &tempDirDiskManager{dir: t.TempDir()}
+	c, workQueue, m := setupPlayerInitTest(t, disk, WithPlayerInitPolicy(PlayerInitAbandon, 0, 0))
+	ctx := context.Background()
+	gameAddr := common.Address{0xaa}
+	writeCorruptPrestate(t, disk.DirForGame(gameAddr))
+
+	require.ErrorIs(t, c.schedule(ctx, asGames(gameAddr), 0), ErrPlayerInit)
+	require.Equal(t, AbandonReasonPlayerInit, c.abandoned[gameAddr].Reason)
+	require.Equal(t, 1, m.abandoned[AbandonReasonPlayerInit])
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 1))
+	require.Empty(t, workQueue)
+	require.Equal(t, 1, m.initFailures)
+}
+
+func TestPlayerInitInvalidPrestateNotClassified(t *testing.T) {
+	c, _, _, games, _, _ := setupCoordinatorTest(t, 10)
+	m := c.m.(*stubSchedulerMetrics)
+	games.PrestateErr = types.ErrInvalidPrestate
+
+	err := c.schedule(context.Background(), asGames(common.Address{0xaa}), 0)
+	require.ErrorIs(t, err, types.ErrInvalidPrestate)
+	require.NotErrorIs(t, err, ErrPlayerInit)
+	require.Zero(t, m.initFailures)
+}
+
+func TestPlayerInitBackoffDelay(t *testing.T) {
+	backoff := playerInitBackoff{initial: time.Second, max: 5 * time.Second}
+	require.Zero(t, backoff.delay(0))
+	require.Equal(t, time.Second, backoff.delay(1))
+	require.Equal(t, 2*time.Second, backoff.delay(2))
+	require.Equal(t, 4*time.Second, backoff.delay(3))
+	require.Equal(t, 5*time.Second, backoff.delay(4))
+	require.Equal(t, 5*time.Second, backoff.delay(100))
+
+	require.Zero(t, playerInitBackoff{}.delay(3))
+	require.Equal(t, time.Second, playerInitBackoff{initial: time.Second}.delay(3), "max below initial caps at initial")
+}
+
+// setupPlayerInitTest creates a coordinator whose players fail to load their prestate while the game directory
+// contains a corrupt prestate file.
+func setupPlayerInitTest(t *testing.T, disk DiskManager, opts ...SchedulerOption) (*coordinator, <-chan job, *stubSchedulerMetrics) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	workQueue := make(chan job, 10)
+	resultQueue := make(chan job, 10)
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	m := &stubSchedulerMetrics{}
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		player := &test.StubGamePlayer{Addr: game.Proxy, StatusValue: types.GameStatusInProgress, Dir: dir}
+		if data, err := os.ReadFile(filepath.Join(dir, "prestate")); err == nil && string(data) == "corrupt" {
+			player.PrestateErr = errors.New("failed to load prestate")
+		}
+		return player, nil
+	}
+	c := newCoordinator(logger, m, workQueue, resultQueue, createPlayer, disk, false, cfg)
+	return c, workQueue, m
+}
+
+func writeCorruptPrestate(t *testing.T, dir string) string {
+	path := filepath.Join(dir, "prestate")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(path, []byte("corrupt"), 0644))
+	return path
+}
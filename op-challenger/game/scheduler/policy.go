@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PolicyMetricer records the games and cycles held back by the scheduling policies.
+type PolicyMetricer interface {
+	ActionCooldownMetricer
+	BatchGateMetricer
+	FailureBreakerMetricer
+	GasBudgetMetricer
+	GlobalBackoffMetricer
+	ReadinessMetricer
+}
+
+// resultPolicy holds back games from being scheduled based on the results of their previous progressions, such as
+// the action cooldown and the failure backoff. Each policy keeps its own state for each game, keyed by address.
+type resultPolicy interface {
+	// recordResult updates the policy's state for the game with the result of a progression and its outcome.
+	recordResult(j job, outcome Outcome, gameType uint32, now time.Time)
+	// skipReason returns the reason the game can't be scheduled at now and when it may be scheduled again, or the
+	// empty string if it can be scheduled.
+	skipReason(addr common.Address, now time.Time) (string, time.Time)
+	// forget removes the policy's state for a game that is no longer tracked.
+	forget(addr common.Address)
+}
+
+// resultObserver is notified of each result once it has been applied to the game's state, such as to write the
+// audit log set by WithAuditSink.
+type resultObserver interface {
+	observe(j job, now time.Time)
+}
+
+// traceFunc logs a scheduling step of a game traced with TraceGame.
+type traceFunc func(addr common.Address, msg string, ctx ...any)
+
+// skipForPolicy records the game as skipped if the policy holds it back and returns true. The lock must be held.
+func (c *coordinator) skipForPolicy(addr common.Address, policy resultPolicy) bool {
+	now := c.cfg.clock.Now()
+	reason, until := policy.skipReason(addr, now)
+	if reason == "" {
+		return false
+	}
+	c.logger.Debug("Not scheduling game held back by policy", "game", addr, "reason", reason, "until", until)
+	c.tracer.Log(addr, "Not scheduling game held back by policy", "reason", reason, "remaining", until.Sub(now))
+	c.skip(addr, reason)
+	return true
+}
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionCooldownPolicy(t *testing.T) {
+	cfg := defaultConfig()
+	WithActionCooldown(time.Minute)(&cfg)
+	WithGameTypeConfig(5, GameTypeConfig{ActionCooldown: -1})(&cfg)
+	m := &stubSchedulerMetrics{}
+	policy := newActionCooldown(&cfg, m)
+	now := time.Unix(1000, 0)
+	game := common.Address{0xaa}
+
+	policy.recordResult(job{addr: game, acted: false}, OutcomeSuccess, 0, now)
+	reason, _ := policy.skipReason(game, now)
+	require.Empty(t, reason, "should not cool down when no action was taken")
+
+	policy.recordResult(job{addr: game, acted: true}, OutcomeSuccess, 0, now)
+	reason, until := policy.skipReason(game, now.Add(time.Second))
+	require.Equal(t, SkipReasonCoolingDown, reason)
+	require.Equal(t, now.Add(time.Minute), until)
+	require.Equal(t, 1, m.coolingDown)
+	reason, _ = policy.skipReason(game, now.Add(time.Minute))
+	require.Empty(t, reason, "should schedule once cooldown expires")
+
+	disabled := common.Address{0xbb}
+	policy.recordResult(job{addr: disabled, acted: true}, OutcomeSuccess, 5, now)
+	reason, _ = policy.skipReason(disabled, now)
+	require.Empty(t, reason, "should not cool down when disabled for game type")
+
+	policy.recordResult(job{addr: game, acted: true}, OutcomeSuccess, 0, now)
+	policy.forget(game)
+	reason, _ = policy.skipReason(game, now)
+	require.Empty(t, reason, "should not cool down forgotten game")
+	require.Empty(t, policy.until)
+}
+
+func TestFailurePolicy(t *testing.T) {
+	cfg := defaultConfig()
+	WithFailureBackoff(time.Minute, time.Hour)(&cfg)
+	WithCircuitBreaker(2, 10*time.Minute)(&cfg)
+	m := &stubSchedulerMetrics{}
+	policy := newFailurePolicy(&cfg, m, testlog.Logger(t, log.LevelInfo), func(common.Address, string, ...any) {})
+	now := time.Unix(1000, 0)
+	game := common.Address{0xaa}
+
+	policy.recordResult(job{addr: game}, OutcomeTransientFailure, 0, now)
+	reason, until := policy.skipReason(game, now)
+	require.Equal(t, SkipReasonFailureBackoff, reason)
+	require.Equal(t, now.Add(time.Minute), until)
+
+	policy.recordResult(job{addr: game}, OutcomeNoOp, 0, now)
+	require.EqualValues(t, 1, policy.state(game).streak, "should not change streak for no-op")
+
+	policy.recordResult(job{addr: game}, OutcomePermanentFailure, 0, now)
+	reason, until = policy.skipReason(game, now)
+	require.Equal(t, SkipReasonQuarantined, reason)
+	require.Equal(t, now.Add(10*time.Minute), until)
+	require.Equal(t, 1, m.gamesQuarantined)
+	require.Equal(t, []QuarantinedGame{{Game: game, Failures: 2, Until: until}}, policy.quarantinedGames(now))
+
+	policy.recordResult(job{addr: game}, OutcomeSuccess, 0, now)
+	require.Equal(t, failureState{}, policy.state(game))
+	require.Empty(t, policy.games, "should not keep state for games that succeeded")
+	reason, _ = policy.skipReason(game, now)
+	require.Empty(t, reason)
+}
@@ -0,0 +1,25 @@
+package scheduler
+
+// Pressure returns a value between 0 and 1 indicating how backed up the scheduler is.
+// It is the average of three components, each between 0 and 1:
+//   - the fraction of live workers currently progressing a game
+//   - how full the job and result queues are, including jobs held by WithPriorityDispatch
+//   - whether a schedule batch is waiting to be processed
+//
+// 0 means the scheduler is completely idle and 1 means all workers are busy, the job and result queues
+// are full and the next batch is already waiting, so further calls to Schedule will be merged into the waiting batch.
+// Callers that control how often games are scheduled can use it to adapt their polling interval, e.g.
+// polling at the normal rate below 0.5 and increasingly slowly as pressure approaches 1.
+// The value is computed from atomic counters and channel lengths so is cheap to call frequently.
+func (s *Scheduler) Pressure() float64 {
+	var workers float64
+	if live := s.liveWorkers.Load(); live > 0 {
+		workers = min(1, float64(s.activeWorkers.Load())/float64(live))
+	}
+	var queues float64
+	if capacity := cap(s.jobQueue) + cap(s.resultQueue) + s.dispatcher.Cap(); capacity > 0 {
+		queues = float64(len(s.jobQueue)+len(s.resultQueue)+s.dispatcher.Len()) / float64(capacity)
+	}
+	schedule := float64(len(s.scheduleQueue)) / float64(cap(s.scheduleQueue))
+	return (workers + queues + schedule) / 3
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPressure(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	// Queues have a capacity of 4 each
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false)
+
+	require.Zero(t, s.Pressure(), "should be zero when idle")
+
+	// Half of the workers are busy
+	s.liveWorkers.Store(2)
+	s.activeWorkers.Store(1)
+	require.InDelta(t, 0.5/3, s.Pressure(), 0.0001)
+
+	// Job and result queues are a quarter full
+	s.jobQueue <- job{}
+	s.resultQueue <- job{}
+	require.InDelta(t, (0.5+0.25)/3, s.Pressure(), 0.0001)
+
+	// Pending schedule batch, marking the scheduler as started without running the loop so it isn't accepted
+	s.started.Store(true)
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 0))
+	require.InDelta(t, (0.5+0.25+1)/3, s.Pressure(), 0.0001)
+
+	// Fully backed up
+	s.activeWorkers.Store(2)
+	for i := 0; i < 3; i++ {
+		s.jobQueue <- job{}
+		s.resultQueue <- job{}
+	}
+	require.Equal(t, 1.0, s.Pressure())
+}
@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Prewarm creates the data directories for games before they are first scheduled, for example ahead of a known
+// surge of new games, so that their first progression doesn't pay the cost of setting them up. Games aren't
+// progressed and workers aren't used, so prewarming doesn't delay scheduled work. Prewarmed directories are kept
+// until the game is scheduled and then cleaned up as normal once it resolves.
+// Prewarming a game again has no effect. Returns ctx.Err() without prewarming the remaining games if ctx is done.
+func (s *Scheduler) Prewarm(ctx context.Context, games []common.Address) error {
+	for _, addr := range games {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if isInvalidGame(types.GameMetadata{Proxy: addr}) {
+			continue
+		}
+		// Mark the game before creating the directory so it can't be removed as a resolved game in between.
+		s.coordinator.markPrewarmed(addr)
+		dir := s.disk.DirForGame(addr)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory for game %v: %w", addr, err)
+		}
+	}
+	return nil
+}
+
+// markPrewarmed records that the game's directory is prewarmed so it isn't removed before the game is scheduled.
+func (c *coordinator) markPrewarmed(addr common.Address) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.states[addr]; !ok {
+		c.prewarmed[addr] = true
+	}
+}
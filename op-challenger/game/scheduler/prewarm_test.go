@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrewarm(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	disk := &removingDiskManager{dir: t.TempDir()}
+	games := &createdGames{t: t, created: make(map[common.Address]*test.StubGamePlayer)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, games.CreateGame, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	scheduledGame := common.Address{0xaa}
+	prewarmedGame := common.Address{0xbb}
+	require.NoError(t, s.Prewarm(ctx, []common.Address{prewarmedGame, {}}))
+	require.DirExists(t, disk.DirForGame(prewarmedGame))
+	require.NoDirExists(t, disk.DirForGame(common.Address{}), "should not prewarm invalid games")
+	// Prewarming is idempotent
+	require.NoError(t, s.Prewarm(ctx, []common.Address{prewarmedGame}))
+	require.DirExists(t, disk.DirForGame(prewarmedGame))
+	require.Empty(t, games.created, "should not create players")
+
+	// Scheduling other games progresses them as normal and keeps the prewarmed directory
+	require.NoError(t, s.Schedule(asGames(scheduledGame), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Equal(t, 1, games.created[scheduledGame].ProgressCount)
+	require.NotContains(t, games.created, prewarmedGame)
+	require.DirExists(t, disk.DirForGame(prewarmedGame))
+
+	// Once scheduled, the game's directory is managed as normal
+	require.NoError(t, s.Schedule(asGames(scheduledGame, prewarmedGame), 1))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Equal(t, 1, games.created[prewarmedGame].ProgressCount)
+	s.coordinator.lock.Lock()
+	defer s.coordinator.lock.Unlock()
+	require.Empty(t, s.coordinator.prewarmed)
+}
+
+func TestPrewarmCancelled(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	disk := &removingDiskManager{dir: t.TempDir()}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, nil, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	game := common.Address{0xaa}
+	require.ErrorIs(t, s.Prewarm(ctx, []common.Address{game}), context.Canceled)
+	require.NoDirExists(t, disk.DirForGame(game))
+}
+
+// removingDiskManager is a DiskManager using a temporary directory that removes the directories of other games.
+type removingDiskManager struct {
+	dir string
+}
+
+func (d *removingDiskManager) DirForGame(addr common.Address) string {
+	return filepath.Join(d.dir, addr.Hex())
+}
+
+func (d *removingDiskManager) RemoveAllExcept(addrs []common.Address) error {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !slices.ContainsFunc(addrs, func(addr common.Address) bool { return addr.Hex() == entry.Name() }) {
+			if err := os.RemoveAll(filepath.Join(d.dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrDiskUnavailable = errors.New("disk unavailable")
+
+const diskProbeFile = "scheduler-probe"
+
+// probeDisk checks that the DiskManager provides a writable and readable directory.
+// It uses the directory for the zero address, which never corresponds to a real game.
+func probeDisk(disk DiskManager) error {
+	dir := disk.DirForGame(common.Address{})
+	_, statErr := os.Stat(dir)
+	created := errors.Is(statErr, os.ErrNotExist)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("%w: failed to create directory %v: %w", ErrDiskUnavailable, dir, err)
+	}
+	if created {
+		defer os.Remove(dir)
+	}
+	path := filepath.Join(dir, diskProbeFile)
+	expected := []byte("probe")
+	if err := os.WriteFile(path, expected, 0644); err != nil {
+		return fmt.Errorf("%w: failed to write probe file %v: %w", ErrDiskUnavailable, path, err)
+	}
+	defer os.Remove(path)
+	actual, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w: failed to read probe file %v: %w", ErrDiskUnavailable, path, err)
+	}
+	if !bytes.Equal(expected, actual) {
+		return fmt.Errorf("%w: probe file %v contained unexpected data", ErrDiskUnavailable, path)
+	}
+	return nil
+}
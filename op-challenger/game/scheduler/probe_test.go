@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeDisk(t *testing.T) {
+	t.Run("Writable", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, probeDisk(&tempDirDiskManager{dir: dir}))
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Empty(t, entries, "should clean up probe data")
+	})
+
+	t.Run("ExistingDirPreserved", func(t *testing.T) {
+		dir := t.TempDir()
+		disk := &tempDirDiskManager{dir: dir}
+		require.NoError(t, os.MkdirAll(disk.DirForGame(common.Address{}), 0755))
+		require.NoError(t, probeDisk(disk))
+		require.DirExists(t, disk.DirForGame(common.Address{}))
+	})
+
+	t.Run("NotADirectory", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(file, []byte("data"), 0644))
+		require.ErrorIs(t, probeDisk(&tempDirDiskManager{dir: file}), ErrDiskUnavailable)
+	})
+
+	t.Run("ReadOnly", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("file permissions are not enforced for root")
+		}
+		dir := t.TempDir()
+		require.NoError(t, os.Chmod(dir, 0500))
+		t.Cleanup(func() {
+			_ = os.Chmod(dir, 0700)
+		})
+		require.ErrorIs(t, probeDisk(&tempDirDiskManager{dir: dir}), ErrDiskUnavailable)
+	})
+}
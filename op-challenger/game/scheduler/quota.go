@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+)
+
+// QuotaDiskManager is an optional interface a DiskManager can implement to limit the space used by game data.
+// Once the games no longer needed have been removed, the least recently progressed in progress games without a job
+// in flight have their data evicted until the game data is back within the quota. An evicted game's player is
+// recreated the next time it is scheduled, so its data, such as its trace, is generated again.
+type QuotaDiskManager interface {
+	// EnforceQuota removes the directories of the candidate games, in order, until the total size of all game
+	// directories is within the quota. Returns the games whose directories were removed and the total size of the
+	// game directories remaining, in bytes. It is called without holding the scheduler's locks but delays the
+	// scheduling cycle, so implementations should avoid measuring every game directory on each call.
+	EnforceQuota(candidates []common.Address) ([]common.Address, uint64, error)
+}
+
+type DiskQuotaMetricer interface {
+	RecordDiskUsage(bytes uint64)
+	RecordDiskEvictions(n int)
+}
+
+// quotaCandidates returns the games whose data may be evicted to stay within the quota of a disk manager
+// implementing QuotaDiskManager, least recently progressed first. The lock must be held.
+func (c *coordinator) quotaCandidates() []common.Address {
+	if c.quota == nil {
+		return nil
+	}
+	candidates := []common.Address{}
+	for addr, state := range c.states {
+		if state.player != nil && !state.inflight && state.status == types.GameStatusInProgress {
+			candidates = append(candidates, addr)
+		}
+	}
+	slices.SortFunc(candidates, func(a, b common.Address) int {
+		if diff := cmp.Compare(c.states[a].lastScheduledCycle, c.states[b].lastScheduledCycle); diff != 0 {
+			return diff
+		}
+		return compareAddresses(a, b)
+	})
+	return candidates
+}
+
+// enforceDiskQuota evicts the data of the candidate games, in order, if the game data exceeds the quota. It is
+// called without the lock, as measuring and removing game data may be slow, from the thread calling schedule so no
+// job for a candidate can be started meanwhile.
+func (c *coordinator) enforceDiskQuota(candidates []common.Address) {
+	if c.quota == nil {
+		return
+	}
+	evicted, usage, err := c.quota.EnforceQuota(candidates)
+	c.lock.Lock()
+	for _, addr := range evicted {
+		// The player may have cached state derived from its data so is recreated with a fresh directory.
+		if state, ok := c.states[addr]; ok {
+			state.player = nil
+		}
+		c.logger.Warn("Evicted game data to stay within disk quota", "game", addr)
+		c.tracer.Log(addr, "Evicted game data to stay within disk quota")
+	}
+	c.lock.Unlock()
+	if len(evicted) > 0 {
+		c.m.RecordDiskEvictions(len(evicted))
+	}
+	if err != nil {
+		c.errLog.Log(log.LevelError, "quota", "Unable to enforce disk quota", err)
+		return
+	}
+	c.m.RecordDiskUsage(usage)
+}
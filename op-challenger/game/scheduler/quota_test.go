@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnforceDiskQuota(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	quota := &stubQuotaDisk{usage: 100}
+	quota.locked = func() bool {
+		if c.lock.TryLock() {
+			c.lock.Unlock()
+			return false
+		}
+		return true
+	}
+	c.quota = quota
+	m := c.m.(*stubSchedulerMetrics)
+	oldest := common.Address{0xaa}
+	newest := common.Address{0xbb}
+	inflight := common.Address{0xcc}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(oldest, newest, inflight), 0))
+	require.Equal(t, [][]common.Address{{}}, quota.candidates, "should not evict games without data")
+	var inflightJob job
+	for len(workQueue) > 0 {
+		j := <-workQueue
+		if j.addr == inflight {
+			inflightJob = j
+			continue
+		}
+		require.NoError(t, c.processResult(runJob(ctx, j)))
+	}
+	c.states[oldest].lastScheduledCycle = 0
+	evictedPlayer := games.created[oldest]
+	delete(games.created, oldest)
+	newestPlayer := games.created[newest]
+
+	// Least recently progressed games without a job in flight are evicted first and their player recreated
+	quota.evict = 1
+	require.NoError(t, c.schedule(ctx, asGames(oldest, newest, inflight), 1))
+	require.Equal(t, [][]common.Address{{}, {oldest, newest}}, quota.candidates)
+	require.Equal(t, 1, m.diskEvictions)
+	require.EqualValues(t, 100, m.diskUsage)
+	require.NotSame(t, evictedPlayer, c.states[oldest].player)
+	require.Same(t, games.created[oldest], c.states[oldest].player)
+	require.Same(t, newestPlayer, c.states[newest].player)
+	require.NoError(t, c.processResult(runJob(ctx, inflightJob)))
+	require.False(t, quota.calledLocked, "should not hold the lock while enforcing the quota")
+}
+
+type stubQuotaDisk struct {
+	candidates [][]common.Address
+	evict      int
+	usage      uint64
+	// locked reports whether the coordinator's lock is held, recorded in calledLocked by EnforceQuota.
+	locked       func() bool
+	calledLocked bool
+}
+
+func (d *stubQuotaDisk) EnforceQuota(candidates []common.Address) ([]common.Address, uint64, error) {
+	if d.locked != nil && d.locked() {
+		d.calledLocked = true
+	}
+	d.candidates = append(d.candidates, append([]common.Address{}, candidates...))
+	return candidates[:min(d.evict, len(candidates))], d.usage, nil
+}
@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type ReadinessMetricer interface {
+	RecordGameNotReady()
+}
+
+// ReadinessCheck reports whether a game is ready to be progressed, see WithReadinessCheck.
+type ReadinessCheck func(ctx context.Context, addr common.Address) (bool, error)
+
+// checkReadiness runs the readiness check set by WithReadinessCheck for each in progress game in the batch and
+// returns the games that aren't ready. Games are treated as not ready if the check fails.
+// Must be called without the lock held as the check may be slow.
+func (c *coordinator) checkReadiness(ctx context.Context, games []types.GameMetadata) map[common.Address]bool {
+	if c.cfg.readinessCheck == nil {
+		return nil
+	}
+	c.lock.Lock()
+	candidates := make([]common.Address, 0, len(games))
+	for _, game := range games {
+		if state, ok := c.states[game.Proxy]; ok && state.status != types.GameStatusInProgress {
+			// Resolved games aren't progressed so there's no need to check them.
+			continue
+		}
+		candidates = append(candidates, game.Proxy)
+	}
+	c.lock.Unlock()
+
+	notReady := make(map[common.Address]bool)
+	for _, addr := range candidates {
+		ready, err := c.checkGameReadiness(ctx, addr)
+		if err != nil {
+			c.errLog.Log(log.LevelWarn, "readiness", "Failed to check game readiness", err, "game", addr)
+		}
+		if !ready || err != nil {
+			notReady[addr] = true
+		}
+	}
+	return notReady
+}
+
+// checkGameReadiness runs the readiness check for the game within the limit set by WithUpstreamConcurrency.
+func (c *coordinator) checkGameReadiness(ctx context.Context, addr common.Address) (bool, error) {
+	release, err := c.upstream.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer release()
+	return c.cfg.readinessCheck(ctx, addr)
+}
@@ -0,0 +1,72 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleGameOnceReady(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	m := c.m.(*stubSchedulerMetrics)
+	pendingGame := common.Address{0xaa}
+	readyGame := common.Address{0xbb}
+	checks := make(map[common.Address]int)
+	c.cfg.readinessCheck = func(_ context.Context, addr common.Address) (bool, error) {
+		checks[addr]++
+		if addr != pendingGame {
+			return true, nil
+		}
+		switch checks[addr] {
+		case 1, 2:
+			return false, nil
+		case 3:
+			return true, errors.New("boom")
+		default:
+			return true, nil
+		}
+	}
+	ctx := context.Background()
+
+	// Not ready for the first two cycles and the check fails in the third
+	for i := uint64(0); i < 3; i++ {
+		require.NoError(t, c.schedule(ctx, asGames(pendingGame, readyGame), i))
+		require.Len(t, workQueue, 1)
+		j := <-workQueue
+		require.Equal(t, readyGame, j.addr)
+		require.NoError(t, c.processResult(j))
+		require.Contains(t, c.states, pendingGame, "should keep state of game that isn't ready")
+	}
+	require.Equal(t, 3, m.notReady)
+
+	require.NoError(t, c.schedule(ctx, asGames(pendingGame, readyGame), 3))
+	require.Len(t, workQueue, 2, "should schedule game once ready")
+	for len(workQueue) > 0 {
+		require.NoError(t, c.processResult(<-workQueue))
+	}
+	require.Equal(t, 3, m.notReady)
+}
+
+func TestSkipReadinessCheckForResolvedGames(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	gameAddr := common.Address{0xaa}
+	var checks int
+	c.cfg.readinessCheck = func(_ context.Context, addr common.Address) (bool, error) {
+		checks++
+		return true, nil
+	}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	j := <-workQueue
+	j.status = types.GameStatusChallengerWon
+	require.NoError(t, c.processResult(j))
+	require.Equal(t, 1, checks)
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 1))
+	require.Equal(t, 1, checks, "should not check resolved game")
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type ReconcileMetricer interface {
+	RecordDiskInconsistencies(orphaned, missing int)
+}
+
+// gameLister is implemented by DiskManagers that can list the games that have a directory, as required by
+// WithDiskReconciliation. It is a subset of RecoverableDiskManager.
+type gameLister interface {
+	ExistingGames() ([]common.Address, error)
+}
+
+// reconcileDisk periodically compares the games being tracked with the game directories on disk.
+func (s *Scheduler) reconcileDisk(ctx context.Context, lister gameLister, ticker clock.Ticker) {
+	defer s.wg.Done()
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Ch():
+			existing, err := lister.ExistingGames()
+			if err != nil {
+				s.coordinator.errLog.Log(log.LevelError, "reconcile", "Failed to list existing game directories", err)
+				continue
+			}
+			s.coordinator.reconcileDisk(existing, s.cfg.reconcileRepair)
+		}
+	}
+}
+
+// reconcileDisk compares the games being tracked with existing, the games that have a directory, and records the
+// number of orphaned directories, belonging to games that aren't tracked, and in progress games missing their
+// directory. If repair is true, orphaned directories are removed and the players of games missing their directory
+// are discarded so they are recreated the next time the game is scheduled. Games with a job pending are repaired
+// by a later pass once the job completes.
+func (c *coordinator) reconcileDisk(existing []common.Address, repair bool) (orphaned int, missing int) {
+	c.lock.Lock()
+	hasDir := make(map[common.Address]bool, len(existing))
+	for _, addr := range existing {
+		hasDir[addr] = true
+		if _, ok := c.states[addr]; !ok && !c.prewarmed[addr] {
+			c.logger.Debug("Found orphaned game directory", "game", addr)
+			orphaned++
+		}
+	}
+	for addr, state := range c.states {
+		if state.player == nil || state.status != types.GameStatusInProgress || hasDir[addr] {
+			continue
+		}
+		c.tracer.Log(addr, "Game directory missing", "repair", repair)
+		missing++
+		if repair && state.pendingJobID == 0 {
+			state.player = nil
+		}
+	}
+	c.m.RecordDiskInconsistencies(orphaned, missing)
+	var keepGames []common.Address
+	if repair && orphaned > 0 {
+		keepGames = c.gamesToKeep()
+	}
+	c.lock.Unlock()
+	if orphaned == 0 && missing == 0 {
+		return orphaned, missing
+	}
+	c.logger.Warn("Tracked games inconsistent with disk", "orphaned", orphaned, "missing", missing, "repair", repair)
+	if repair && orphaned > 0 {
+		c.removeGameFiles(keepGames)
+	}
+	return orphaned, missing
+}
@@ -0,0 +1,130 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcileDisk(t *testing.T) {
+	c, workQueue, _, games, disk, _ := setupCoordinatorTest(t, 10)
+	m := c.m.(*stubSchedulerMetrics)
+	healthy := common.Address{0xaa}
+	missingDir := common.Address{0xbb}
+	pendingMissingDir := common.Address{0xcc}
+	orphan := common.Address{0xdd}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(healthy, missingDir, pendingMissingDir), 0))
+	for i := 0; i < 3; i++ {
+		j := <-workQueue
+		if j.addr == pendingMissingDir {
+			continue
+		}
+		require.NoError(t, c.processResult(runJob(ctx, j)))
+	}
+	// Seed drift between the tracked games and disk
+	disk.gameDirExists[orphan] = true
+	disk.gameDirExists[missingDir] = false
+	disk.gameDirExists[pendingMissingDir] = false
+	existing := []common.Address{healthy, orphan}
+
+	// Without repair, inconsistencies are only reported
+	orphaned, missing := c.reconcileDisk(existing, false)
+	require.Equal(t, 1, orphaned)
+	require.Equal(t, 2, missing)
+	require.Equal(t, 1, m.orphanedDirs)
+	require.Equal(t, 2, m.missingDirs)
+	require.True(t, disk.gameDirExists[orphan])
+	require.NotNil(t, c.states[missingDir].player)
+
+	// With repair, orphaned directories are removed and players recreated
+	orphaned, missing = c.reconcileDisk(existing, true)
+	require.Equal(t, 1, orphaned)
+	require.Equal(t, 2, missing)
+	require.False(t, disk.gameDirExists[orphan], "should remove orphaned directory")
+	require.True(t, disk.gameDirExists[healthy], "should keep directory of tracked game")
+	require.Nil(t, c.states[missingDir].player, "should discard player of game missing its directory")
+	require.NotNil(t, c.states[pendingMissingDir].player, "should not discard player with a job pending")
+	require.NotNil(t, c.states[healthy].player)
+
+	original := games.created[missingDir]
+	delete(games.created, missingDir)
+	require.NoError(t, c.schedule(ctx, asGames(healthy, missingDir), 1))
+	require.NotSame(t, original, games.created[missingDir], "should create new player")
+	require.True(t, disk.gameDirExists[missingDir])
+	require.Len(t, workQueue, 2)
+}
+
+func TestReconcileDiskConsistent(t *testing.T) {
+	c, workQueue, _, _, _, logs := setupCoordinatorTest(t, 10)
+	m := c.m.(*stubSchedulerMetrics)
+	gameAddr := common.Address{0xaa}
+	prewarmed := common.Address{0xbb}
+	ctx := context.Background()
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	c.prewarmed[prewarmed] = true
+	m.orphanedDirs = -1
+	m.missingDirs = -1
+
+	orphaned, missing := c.reconcileDisk([]common.Address{gameAddr, prewarmed}, true)
+	require.Zero(t, orphaned)
+	require.Zero(t, missing)
+	require.Zero(t, m.orphanedDirs)
+	require.Zero(t, m.missingDirs)
+	require.Nil(t, logs.FindLog(testlog.NewMessageFilter("Tracked games inconsistent with disk")))
+}
+
+func TestSchedulerReconcilesDiskPeriodically(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	orphan := common.Address{0xdd}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &blockingPlayer{}, nil
+	}
+	m := &reconcileMetrics{}
+	disk := &listingDiskManager{
+		trackingDiskManager: trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)},
+		existing:            []common.Address{orphan},
+	}
+	s := NewScheduler(logger, m, disk, 1, createPlayer, false, WithClock(cl), WithDiskReconciliation(time.Minute, true))
+	s.Start(context.Background())
+	defer s.Close()
+	require.Equal(t, time.Minute, s.EffectiveConfig().DiskReconcileInterval)
+	require.True(t, s.EffectiveConfig().DiskReconcileRepair)
+
+	cl.AdvanceTime(time.Minute)
+	require.Eventually(t, func() bool {
+		return m.orphaned.Load() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Empty(t, <-disk.removeExceptCalls, "should remove orphaned directory")
+}
+
+// listingDiskManager is a trackingDiskManager that reports existing as the games with a directory.
+type listingDiskManager struct {
+	trackingDiskManager
+	existing []common.Address
+}
+
+func (d *listingDiskManager) ExistingGames() ([]common.Address, error) {
+	return d.existing, nil
+}
+
+type reconcileMetrics struct {
+	metrics.NoopMetricsImpl
+	orphaned atomic.Int32
+}
+
+func (m *reconcileMetrics) RecordDiskInconsistencies(orphaned, _ int) {
+	m.orphaned.Store(int32(orphaned))
+}
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RecoverableDiskManager is an optional interface a DiskManager can implement to allow the scheduler to check
+// game directories left by a previous run when it starts, so partially written data doesn't cause failures later.
+type RecoverableDiskManager interface {
+	// ExistingGames returns the games that currently have a directory.
+	ExistingGames() ([]common.Address, error)
+	// QuarantineGame moves the game's directory aside, keeping it for investigation, and returns its new location.
+	QuarantineGame(addr common.Address) (string, error)
+}
+
+type RecoveryMetricer interface {
+	RecordGameDirQuarantined()
+}
+
+// recoverDisk checks the existing game directories if the DiskManager supports it.
+// Temporary files left by interrupted state writes are removed. Directories that can't be read or that contain
+// a snapshot that can't be decoded are quarantined. Failures are logged but don't prevent the scheduler starting.
+func (s *Scheduler) recoverDisk() {
+	disk, ok := s.baseDisk.(RecoverableDiskManager)
+	if !ok {
+		return
+	}
+	games, err := disk.ExistingGames()
+	if err != nil {
+		s.logger.Error("Failed to list existing game directories", "err", err)
+		return
+	}
+	var repaired, quarantined int
+	for _, addr := range games {
+		dir := s.baseDisk.DirForGame(addr)
+		removed, err := checkGameDir(dir)
+		if err != nil {
+			dest, qErr := disk.QuarantineGame(addr)
+			if qErr != nil {
+				s.logger.Error("Failed to quarantine corrupt game directory", "game", addr, "dir", dir, "err", errors.Join(err, qErr))
+				continue
+			}
+			s.logger.Warn("Quarantined corrupt game directory", "game", addr, "dest", dest, "err", err)
+			s.m.RecordGameDirQuarantined()
+			quarantined++
+		} else if removed > 0 {
+			repaired++
+		}
+	}
+	s.logger.Info("Recovered existing game directories", "games", len(games), "repaired", repaired, "quarantined", quarantined)
+}
+
+// checkGameDir removes temporary files left by interrupted state writes from the game directory, returning the
+// number removed. Returns an error if the directory is corrupt.
+func checkGameDir(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read directory: %w", err)
+	}
+	var removed int
+	for _, entry := range entries {
+		name := entry.Name()
+		path := filepath.Join(dir, name)
+		switch {
+		case strings.HasPrefix(name, stateFilePrefix) && strings.HasSuffix(name, ".tmp"):
+			// The write was never committed by renaming so the previous value, if any, is intact.
+			if err := os.Remove(path); err != nil {
+				return removed, fmt.Errorf("failed to remove incomplete state write: %w", err)
+			}
+			removed++
+		case name == snapshotFileName:
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return removed, fmt.Errorf("failed to read snapshot: %w", err)
+			}
+			var snapshot GameSnapshot
+			if err := json.Unmarshal(data, &snapshot); err != nil {
+				return removed, fmt.Errorf("failed to decode snapshot: %w", err)
+			}
+		}
+	}
+	return removed, nil
+}
@@ -0,0 +1,121 @@
+package scheduler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverDiskOnStart(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	disk := &recoverableDiskManager{dir: t.TempDir()}
+	validGame := common.Address{0xaa}
+	partialGame := common.Address{0xbb}
+	corruptGame := common.Address{0xcc}
+
+	validDir := disk.DirForGame(validGame)
+	require.NoError(t, os.MkdirAll(validDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(validDir, snapshotFileName), []byte(`{"version":1}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(validDir, "cannon-data"), []byte("data"), 0644))
+
+	// Interrupted state write leaves a temporary file alongside the previous value
+	partialDir := disk.DirForGame(partialGame)
+	require.NoError(t, os.MkdirAll(partialDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(partialDir, stateFilePrefix+"key"), []byte("old"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(partialDir, stateFilePrefix+"key.tmp"), []byte("ne"), 0644))
+
+	corruptDir := disk.DirForGame(corruptGame)
+	require.NoError(t, os.MkdirAll(corruptDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(corruptDir, snapshotFileName), []byte(`{"version":`), 0644))
+
+	m := &recoveryMetrics{}
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{}, nil
+	}
+	s := NewScheduler(logger, m, disk, 1, createPlayer, false)
+	s.Start(context.Background())
+	defer s.Close()
+
+	require.FileExists(t, filepath.Join(validDir, snapshotFileName))
+	require.FileExists(t, filepath.Join(validDir, "cannon-data"))
+	require.FileExists(t, filepath.Join(partialDir, stateFilePrefix+"key"))
+	require.NoFileExists(t, filepath.Join(partialDir, stateFilePrefix+"key.tmp"))
+	require.NoDirExists(t, corruptDir)
+	require.FileExists(t, filepath.Join(disk.quarantined[corruptGame], snapshotFileName), "should keep corrupt data")
+	require.Len(t, disk.quarantined, 1)
+	require.Equal(t, 1, m.quarantined)
+
+	summary := logs.FindLog(testlog.NewMessageFilter("Recovered existing game directories"))
+	require.NotNil(t, summary)
+	require.Equal(t, int64(3), summary.AttrValue("games"))
+	require.Equal(t, int64(1), summary.AttrValue("repaired"))
+	require.Equal(t, int64(1), summary.AttrValue("quarantined"))
+}
+
+func TestRecoverDiskNotSupported(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, nil, false)
+	s.Start(context.Background())
+	defer s.Close()
+	require.Nil(t, logs.FindLog(testlog.NewMessageFilter("Recovered existing game directories")))
+}
+
+// recoverableDiskManager is a RecoverableDiskManager using a temporary directory.
+type recoverableDiskManager struct {
+	dir         string
+	quarantined map[common.Address]string
+}
+
+func (d *recoverableDiskManager) DirForGame(addr common.Address) string {
+	return filepath.Join(d.dir, "games", addr.Hex())
+}
+
+func (d *recoverableDiskManager) RemoveAllExcept(_ []common.Address) error {
+	return nil
+}
+
+func (d *recoverableDiskManager) ExistingGames() ([]common.Address, error) {
+	entries, err := os.ReadDir(filepath.Join(d.dir, "games"))
+	if err != nil {
+		return nil, err
+	}
+	var games []common.Address
+	for _, entry := range entries {
+		games = append(games, common.HexToAddress(entry.Name()))
+	}
+	return games, nil
+}
+
+func (d *recoverableDiskManager) QuarantineGame(addr common.Address) (string, error) {
+	dest := filepath.Join(d.dir, "quarantine", addr.Hex())
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return "", err
+	}
+	if err := os.Rename(d.DirForGame(addr), dest); err != nil {
+		return "", err
+	}
+	if d.quarantined == nil {
+		d.quarantined = make(map[common.Address]string)
+	}
+	d.quarantined[addr] = dest
+	return dest, nil
+}
+
+type recoveryMetrics struct {
+	metrics.NoopMetricsImpl
+	quarantined int
+}
+
+func (m *recoveryMetrics) RecordGameDirQuarantined() {
+	m.quarantined++
+}
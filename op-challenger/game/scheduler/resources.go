@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+// ResourceUser is an optional interface a GamePlayer can implement to declare named shared resources
+// (e.g. a local VM executable or prestate file) that must not be used concurrently by multiple games.
+// Workers acquire an exclusive lock on each declared resource before progressing the game and release
+// them afterwards, so jobs that need a contended resource wait for it to become available.
+type ResourceUser interface {
+	RequiredResources() []string
+}
+
+type ResourceMetricer interface {
+	RecordResourceWaitTime(resource string, t float64)
+}
+
+// resourceLocks provides exclusive, context aware locks for named resources.
+// Locks are created on first use. Safe for concurrent use.
+type resourceLocks struct {
+	m     ResourceMetricer
+	clock clock.Clock
+	lock  sync.Mutex
+	locks map[string]chan struct{}
+}
+
+func newResourceLocks(m ResourceMetricer, cl clock.Clock) *resourceLocks {
+	return &resourceLocks{
+		m:     m,
+		clock: cl,
+		locks: make(map[string]chan struct{}),
+	}
+}
+
+func (r *resourceLocks) get(resource string) chan struct{} {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	l, ok := r.locks[resource]
+	if !ok {
+		l = make(chan struct{}, 1)
+		r.locks[resource] = l
+	}
+	return l
+}
+
+// Acquire blocks until all the specified resources are locked or ctx is done.
+// Resources are always acquired in sorted order to avoid deadlocks between jobs sharing multiple resources.
+// Returns a function to release the resources. If ctx is done, any already acquired resources are released.
+func (r *resourceLocks) Acquire(ctx context.Context, resources []string) (func(), error) {
+	sorted := slices.Clone(resources)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+	var held []chan struct{}
+	release := func() {
+		for _, l := range held {
+			<-l
+		}
+	}
+	for _, resource := range sorted {
+		l := r.get(resource)
+		start := r.clock.Now()
+		select {
+		case l <- struct{}{}:
+			held = append(held, l)
+			r.m.RecordResourceWaitTime(resource, r.clock.Since(start).Seconds())
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
@@ -0,0 +1,136 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceLocksWaitForRelease(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	m := &resourceMetrics{}
+	locks := newResourceLocks(m, cl)
+	ctx := context.Background()
+
+	release, err := locks.Acquire(ctx, []string{"vm"})
+	require.NoError(t, err)
+
+	acquired := make(chan func(), 1)
+	go func() {
+		release, err := locks.Acquire(ctx, []string{"vm", "vm"})
+		require.NoError(t, err)
+		acquired <- release
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("acquired resource while it was held")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cl.AdvanceTime(3 * time.Second)
+	release()
+	select {
+	case release := <-acquired:
+		release()
+	case <-time.After(10 * time.Second):
+		t.Fatal("resource not acquired after release")
+	}
+	require.Equal(t, map[string][]float64{"vm": {0, 3}}, m.waits())
+}
+
+func TestResourceLocksReleaseHeldResourcesWhenContextDone(t *testing.T) {
+	locks := newResourceLocks(metrics.NoopMetrics, clock.SystemClock)
+	releaseB, err := locks.Acquire(context.Background(), []string{"b"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = locks.Acquire(ctx, []string{"b", "a"})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// "a" was acquired before blocking on "b" and must have been released.
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	releaseA, err := locks.Acquire(ctx, []string{"a"})
+	require.NoError(t, err)
+	releaseA()
+	releaseB()
+}
+
+func TestSchedulerSerializesSharedResources(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	calls := newConcurrencyTracker()
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &resourcePlayer{resources: []string{"vm"}, calls: calls}, nil
+	}
+	m := &executorMetrics{}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, m, disk, 2, createPlayer, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}, common.Address{0xbb}), 0))
+	// Both workers have taken a job but only one can hold the resource
+	require.Eventually(t, func() bool {
+		return m.active.Load() == 2
+	}, 10*time.Second, time.Millisecond, "both workers should be busy")
+	calls.releaseAt(t, 1)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.EqualValues(t, 1, calls.maxActive.Load(), "games sharing a resource progressed concurrently")
+}
+
+// resourcePlayer is a GamePlayer that declares the resources it requires and records how many
+// players are progressing games concurrently.
+type resourcePlayer struct {
+	resources []string
+	calls     *concurrencyTracker
+}
+
+func (r *resourcePlayer) RequiredResources() []string {
+	return r.resources
+}
+
+func (r *resourcePlayer) ValidatePrestate(_ context.Context) error {
+	return nil
+}
+
+func (r *resourcePlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	r.calls.call(ctx)
+	return types.GameStatusInProgress
+}
+
+func (r *resourcePlayer) Status() types.GameStatus {
+	return types.GameStatusInProgress
+}
+
+type resourceMetrics struct {
+	metrics.NoopMetricsImpl
+	lock    sync.Mutex
+	waitFor map[string][]float64
+}
+
+func (m *resourceMetrics) RecordResourceWaitTime(resource string, t float64) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.waitFor == nil {
+		m.waitFor = make(map[string][]float64)
+	}
+	m.waitFor[resource] = append(m.waitFor[resource], t)
+}
+
+func (m *resourceMetrics) waits() map[string][]float64 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.waitFor
+}
@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+)
+
+type RetentionMetricer interface {
+	RecordResolvedGamesRetained(n int)
+}
+
+// recordResolution records the time the game was first seen to be resolved.
+func (c *coordinator) recordResolution(state *gameState) {
+	if state.status != types.GameStatusInProgress && state.resolvedAt.IsZero() {
+		state.resolvedAt = c.cfg.clock.Now()
+	}
+}
+
+// retained returns true if the game is resolved and still within the retention period set by WithResolvedRetention.
+func (c *coordinator) retained(state *gameState, now time.Time) bool {
+	return c.cfg.resolvedRetention > 0 && !state.resolvedAt.IsZero() && now.Before(state.resolvedAt.Add(c.cfg.resolvedRetention))
+}
+
+// retentionExpired returns true if the game is resolved and the retention period set by WithResolvedRetention has
+// passed, so its state can be evicted once it is no longer scheduled.
+func (c *coordinator) retentionExpired(state *gameState, now time.Time) bool {
+	return c.cfg.resolvedRetention > 0 && !state.resolvedAt.IsZero() && !c.retained(state, now)
+}
@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetainResolvedGames(t *testing.T) {
+	c, workQueue, _, _, disk, _ := setupCoordinatorTest(t, 10)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	c.cfg.clock = cl
+	c.cfg.resolvedRetention = time.Hour
+	m := c.m.(*stubSchedulerMetrics)
+	resolvedAddr := common.Address{0xaa}
+	otherAddr := common.Address{0xbb}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(resolvedAddr, otherAddr), 0))
+	require.Len(t, workQueue, 2)
+	for i := 0; i < 2; i++ {
+		j := <-workQueue
+		if j.addr == resolvedAddr {
+			j.status = types.GameStatusDefenderWon
+		}
+		require.NoError(t, c.processResult(j))
+	}
+
+	// Still retained after the game stops being scheduled, until the retention period expires
+	cl.AdvanceTime(time.Hour - time.Second)
+	require.NoError(t, c.schedule(ctx, asGames(otherAddr), 1))
+	require.Contains(t, c.states, resolvedAddr, "resolved game state should be retained")
+	require.True(t, disk.gameDirExists[resolvedAddr], "resolved game data should be retained")
+	require.Equal(t, 1, m.retained)
+	j := <-workQueue
+	require.NoError(t, c.processResult(j))
+
+	cl.AdvanceTime(time.Second)
+	require.NoError(t, c.schedule(ctx, asGames(otherAddr), 2))
+	require.NotContains(t, c.states, resolvedAddr, "resolved game state should be pruned")
+	require.False(t, disk.gameDirExists[resolvedAddr], "resolved game data should be pruned")
+	require.True(t, disk.gameDirExists[otherAddr], "in progress game data should be preserved")
+	require.Zero(t, m.retained)
+}
+
+func TestDeleteResolvedGamesWithoutRetention(t *testing.T) {
+	c, workQueue, _, _, disk, _ := setupCoordinatorTest(t, 10)
+	gameAddr := common.Address{0xaa}
+
+	require.NoError(t, c.schedule(context.Background(), asGames(gameAddr), 0))
+	j := <-workQueue
+	j.status = types.GameStatusChallengerWon
+	require.NoError(t, c.processResult(j))
+	require.NoError(t, c.schedule(context.Background(), asGames(gameAddr), 1))
+	require.False(t, disk.gameDirExists[gameAddr], "resolved game data should be deleted by the next cycle")
+	require.Zero(t, c.m.(*stubSchedulerMetrics).retained)
+}
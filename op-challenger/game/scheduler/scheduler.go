@@ -2,44 +2,170 @@ package scheduler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
-var ErrBusy = errors.New("busy scheduling previous update")
+var (
+	ErrJobLimitReached = errors.New("job limit reached")
+	ErrStopped         = errors.New("scheduler stopped")
+	ErrNotStarted      = errors.New("scheduler not started")
+	ErrBatchTooLarge   = errors.New("batch too large")
+)
 
+// SchedulerMetricer records the metrics of the scheduler, its coordinators and workers.
 type SchedulerMetricer interface {
-	RecordActedL1Block(n uint64)
-	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
-	RecordGameUpdateScheduled()
-	RecordGameUpdateCompleted()
+	CoordinatorMetricer
+
 	IncActiveExecutors()
 	DecActiveExecutors()
 	IncIdleExecutors()
 	DecIdleExecutors()
+	RecordDispatchDelay(d time.Duration)
+	RecordBatchSize(n int)
+	RecordBatchRejected()
+	RecordBatchCoalesced()
+
+	AuditMetricer
+	DiskMetricer
+	EventMetricer
+	FilterMetricer
+	ForceScheduleMetricer
+	InFlightMetricer
+	MemoryPressureMetricer
+	ResourceMetricer
+	ResultLagMetricer
+	ResultSinkMetricer
+	SourceMetricer
+	UpstreamMetricer
+	WorkerPoolMetricer
 }
 
 type blockGames struct {
 	blockNumber uint64
 	games       []types.GameMetadata
+	// timeouts holds the timeout for each game in the batch that has one, see ScheduleGames.
+	timeouts map[common.Address]time.Duration
+	// correlationID tags the jobs created for the batch, see ScheduleCorrelated. Generated if empty.
+	correlationID string
+	// gameCorrelationIDs holds the correlation id of each game merged from a batch with a different correlation id,
+	// which is used instead of correlationID for that game, see coalesceBatches.
+	gameCorrelationIDs map[common.Address]string
+	// factory is the factory of the source the games are from, see ScheduleSource, or zero for the primary factory.
+	factory common.Address
 }
 
 type Scheduler struct {
 	logger         log.Logger
+	cfg            config
 	coordinator    *coordinator
 	m              SchedulerMetricer
+	disk           DiskManager
+	baseDisk       DiskManager
 	maxConcurrency uint
+	createPlayer   PlayerCreator
 	scheduleQueue  chan blockGames
-	jobQueue       chan job
-	resultQueue    chan job
-	wg             sync.WaitGroup
-	cancel         func()
+	forceQueue     chan forceRequest
+	groupQueue     chan groupRequest
+	urgentRequests chan urgentRequest
+	filterRequests chan filterRequest
+	ignoreRequests chan ignoreRequest
+	listRequests   chan listRequest
+	// concurrencyRequests asks the loop to resize the worker pool, see SetConcurrency.
+	concurrencyRequests chan concurrencyRequest
+	jobQueue            chan job
+	// urgentQueue holds jobs for the workers set by WithUrgentWorkers, or is nil if there are none.
+	urgentQueue chan job
+	resultQueue chan job
+	// dispatcher hands waiting jobs to workers most urgent first, or is nil if jobs are taken from jobQueue in
+	// order, see WithPriorityDispatch.
+	dispatcher *jobDispatcher
+	wg         sync.WaitGroup
+	cancel     func()
+	// started is set by Start. Work submitted before then is rejected with ErrNotStarted.
+	started atomic.Bool
+
+	// workersLock serialises starting workers with DrainTo and SetConcurrency so ramp up can't exceed the target.
+	// It also guards maxConcurrency once the scheduler has started.
+	workersLock sync.Mutex
+	// drainedTo is the number of workers set by DrainTo, or 0 if not drained. Guarded by workersLock.
+	drainedTo uint
+	// retire is received from by a worker between jobs to stop it, see DrainTo.
+	retire chan struct{}
+	// liveWorkers is the number of worker goroutines currently running.
+	liveWorkers atomic.Int32
+	// activeWorkers is the number of workers currently progressing a game.
+	activeWorkers atomic.Int32
+	// nextWorkerID is the id to assign to the next worker started.
+	nextWorkerID atomic.Int32
+	inFlight     *inFlightTracker
+	resources    *resourceLocks
+
+	// actionsPaused is set by PauseActions to progress games without taking actions.
+	actionsPaused atomic.Bool
+
+	// stopped is closed when the loop exits, after which no further batches are accepted.
+	stopped chan struct{}
+	// sendersLock and senders track callers sending to scheduleQueue so batches queued as the loop exits are
+	// logged rather than lost.
+	sendersLock sync.Mutex
+	senders     sync.WaitGroup
+
+	// done is closed once the job limit has been reached and all outstanding work is complete.
+	done     chan struct{}
+	doneOnce sync.Once
+
+	// sources holds the coordinator for each source registered with RegisterSource, keyed by factory address.
+	// It is only modified before Start.
+	sources map[common.Address]*coordinator
+	// sourcesLock guards pendingSources, the batch waiting to be scheduled for each source, and sourcesReady
+	// signals the loop that batches are pending, see ScheduleSource.
+	sourcesLock    sync.Mutex
+	pendingSources map[common.Address]blockGames
+	sourcesReady   chan struct{}
+
+	// loopPriority is a test-only hook, see loopPriorityFunc. Always nil in production.
+	loopPriority loopPriorityFunc
 }
 
-func NewScheduler(logger log.Logger, m SchedulerMetricer, disk DiskManager, maxConcurrency uint, createPlayer PlayerCreator, allowInvalidPrestate bool) *Scheduler {
+// loopEvent identifies an input channel serviced by the scheduler loop.
+type loopEvent int
+
+const (
+	loopEventSchedule loopEvent = iota
+	loopEventResult
+)
+
+// loopPriorityFunc is a test-only hook allowing tests to deterministically control the order in which the
+// loop services its inputs. When set, it is called before each iteration of the loop and the returned event
+// is serviced first if it is ready. Otherwise, the loop falls back to the pseudo-random choice made by select.
+// It is never set in production so the only cost is a nil check per iteration.
+type loopPriorityFunc func() loopEvent
+
+func NewScheduler(logger log.Logger, m SchedulerMetricer, disk DiskManager, maxConcurrency uint, createPlayer PlayerCreator, allowInvalidPrestate bool, opts ...SchedulerOption) *Scheduler {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	baseDisk := disk
+	disk = newInstrumentedDisk(disk, m, cfg.clock)
+	if cfg.stateStore == nil {
+		cfg.stateStore = NewDiskStateStore(disk, cfg.checkpointPath)
+	} else {
+		cfg.customStateStore = true
+	}
+	cfg.stateStore = newInstrumentedStateStore(cfg.stateStore, m, cfg.clock)
+
 	// Size job and results queues to be fairly small so backpressure is applied early
 	// but with enough capacity to keep the workers busy
 	jobQueue := make(chan job, maxConcurrency*2)
@@ -49,70 +175,508 @@ func NewScheduler(logger log.Logger, m SchedulerMetricer, disk DiskManager, maxC
 	// allowing them to potentially skip update cycles.
 	scheduleQueue := make(chan blockGames, 1)
 
+	coordinator := newCoordinator(logger, m, jobQueue, resultQueue, createPlayer, disk, allowInvalidPrestate, cfg)
+	if cfg.resultSink != nil {
+		coordinator.results = newResultPublisher(logger, m, coordinator.errLog, cfg.resultSink)
+	}
+	coordinator.onResolved = cfg.resolutionHandler
+	if cfg.auditSink != nil {
+		coordinator.audit = newAuditLog(m, coordinator.errLog, cfg.auditSink)
+	}
+	coordinator.events = newEventQueue(logger, m, cfg.clock, cfg.eventPublisher)
+	coordinator.upstream = newUpstreamLimiter(m, cfg.upstreamConcurrency)
+	if recoverable, ok := baseDisk.(RecoverableDiskManager); ok {
+		coordinator.recoverable = recoverable
+	}
+	if quota, ok := baseDisk.(QuotaDiskManager); ok {
+		coordinator.quota = quota
+	}
+	var urgentQueue chan job
+	if cfg.urgentWorkers > 0 {
+		urgentQueue = make(chan job, cfg.urgentWorkers)
+		coordinator.urgentQueue = urgentQueue
+	}
+	var dispatcher *jobDispatcher
+	if cfg.priorityDispatch {
+		dispatcher = newJobDispatcher(jobQueue, cap(jobQueue))
+	}
+
 	return &Scheduler{
-		logger:         logger,
-		m:              m,
-		coordinator:    newCoordinator(logger, m, jobQueue, resultQueue, createPlayer, disk, allowInvalidPrestate),
-		maxConcurrency: maxConcurrency,
-		scheduleQueue:  scheduleQueue,
-		jobQueue:       jobQueue,
-		resultQueue:    resultQueue,
+		logger:              logger,
+		cfg:                 cfg,
+		m:                   m,
+		disk:                disk,
+		baseDisk:            baseDisk,
+		coordinator:         coordinator,
+		maxConcurrency:      maxConcurrency,
+		createPlayer:        createPlayer,
+		scheduleQueue:       scheduleQueue,
+		forceQueue:          make(chan forceRequest),
+		groupQueue:          make(chan groupRequest),
+		urgentRequests:      make(chan urgentRequest),
+		filterRequests:      make(chan filterRequest),
+		ignoreRequests:      make(chan ignoreRequest),
+		listRequests:        make(chan listRequest),
+		concurrencyRequests: make(chan concurrencyRequest),
+		retire:              make(chan struct{}),
+		pendingSources:      make(map[common.Address]blockGames),
+		sourcesReady:        make(chan struct{}, 1),
+		stopped:             make(chan struct{}),
+		jobQueue:            jobQueue,
+		urgentQueue:         urgentQueue,
+		resultQueue:         resultQueue,
+		dispatcher:          dispatcher,
+		inFlight:            newInFlightTracker(cfg.clock, maxTrackedInFlight),
+		resources:           newResourceLocks(m, cfg.clock),
+		done:                make(chan struct{}),
 	}
 }
 
 func (s *Scheduler) ThreadActive() {
+	s.activeWorkers.Add(1)
 	s.m.IncActiveExecutors()
 	s.m.DecIdleExecutors()
 }
 
 func (s *Scheduler) ThreadIdle() {
+	s.activeWorkers.Add(-1)
 	s.m.IncIdleExecutors()
 	s.m.DecActiveExecutors()
 }
 
+// StartChecked verifies the DiskManager is writable before starting the scheduler.
+// Returns an error wrapping ErrDiskUnavailable, without starting, if it is not.
+func (s *Scheduler) StartChecked(ctx context.Context) error {
+	if err := probeDisk(s.disk); err != nil {
+		return err
+	}
+	s.Start(ctx)
+	return nil
+}
+
+// jobStarted is called by workers when they start progressing a job.
+func (s *Scheduler) jobStarted(workerID int, j job) {
+	s.m.RecordDispatchDelay(s.cfg.clock.Since(j.enqueuedAt))
+	s.inFlight.Start(workerID, j.addr)
+	s.ThreadActive()
+	s.coordinatorFor(j.factory).events.Emit(j.addr, EventStarted, j.cycle, j.correlationID)
+	if s.cfg.workerStateListener != nil {
+		s.cfg.workerStateListener(workerID, WorkerActive)
+	}
+}
+
+// jobFinished is called by workers when they have finished progressing a job and returned the result.
+func (s *Scheduler) jobFinished(workerID int, j job) {
+	s.coordinatorFor(j.factory).resultLag.arrived.Add(1)
+	s.inFlight.Finish(workerID)
+	s.ThreadIdle()
+	if s.cfg.workerStateListener != nil {
+		s.cfg.workerStateListener(workerID, WorkerIdle)
+	}
+}
+
 func (s *Scheduler) Start(ctx context.Context) {
 	ctx, cancel := context.WithCancel(ctx)
 	s.cancel = cancel
+	s.started.Store(true)
+	if s.cfg.shadowMode {
+		s.logger.Warn("Shadow mode enabled, games are progressed but no transactions will be sent")
+	}
+	s.recoverDisk()
+	s.restoreCheckpoint(ctx)
 
-	for i := uint(0); i < s.maxConcurrency; i++ {
-		s.m.IncIdleExecutors()
+	s.m.RecordWorkerPoolSize(s.maxConcurrency)
+	initialWorkers := s.maxConcurrency
+	if s.cfg.rampUp > 0 && s.maxConcurrency > 1 {
+		initialWorkers = 1
+	}
+	if dispatcher := s.dispatcher; dispatcher != nil {
 		s.wg.Add(1)
-		go progressGames(ctx, s.jobQueue, s.resultQueue, &s.wg, s.ThreadActive, s.ThreadIdle)
+		go func() {
+			defer s.wg.Done()
+			dispatcher.run(ctx)
+		}()
+	}
+	for i := uint(0); i < initialWorkers; i++ {
+		s.startWorker(ctx)
+	}
+	s.startUrgentWorkers(ctx)
+	// Tickers are created before starting goroutines so they are registered with the clock when Start returns.
+	if remaining := s.maxConcurrency - initialWorkers; remaining > 0 {
+		ticker := s.cfg.clock.NewTicker(max(s.cfg.rampUp/time.Duration(remaining), time.Millisecond))
+		s.wg.Add(1)
+		go s.rampUp(ctx, ticker, remaining)
+	}
+
+	s.wg.Add(1)
+	go s.reportOldestInFlight(ctx, s.cfg.clock.NewTicker(oldestInFlightInterval))
+
+	s.wg.Add(1)
+	go s.reportResultLag(ctx, s.cfg.clock.NewTicker(resultLagInterval))
+
+	if s.cfg.checkpoint {
+		var ticker clock.Ticker
+		if s.cfg.checkpointInterval > 0 {
+			ticker = s.cfg.clock.NewTicker(s.cfg.checkpointInterval)
+		}
+		s.wg.Add(1)
+		go s.checkpoint(ctx, ticker)
+	}
+
+	if s.coordinator.memory != nil {
+		s.wg.Add(1)
+		go s.monitorMemory(ctx, s.cfg.clock.NewTicker(memoryCheckInterval))
+	}
+
+	if results := s.coordinator.results; results != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			results.run(ctx)
+		}()
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.coordinator.events.run(ctx)
+	}()
+
+	if s.cfg.errorLogWindow > 0 {
+		s.wg.Add(1)
+		go s.flushErrorLog(ctx, s.cfg.clock.NewTicker(s.cfg.errorLogWindow))
+	}
+
+	if s.cfg.reconcileInterval > 0 {
+		if lister, ok := s.baseDisk.(gameLister); ok {
+			s.wg.Add(1)
+			go s.reconcileDisk(ctx, lister, s.cfg.clock.NewTicker(s.cfg.reconcileInterval))
+		} else {
+			s.logger.Warn("Disk reconciliation not supported by disk manager")
+		}
 	}
 
 	s.wg.Add(1)
 	go s.loop(ctx)
 }
 
+// flushErrorLog periodically logs summaries of throttled errors that have stopped recurring.
+func (s *Scheduler) flushErrorLog(ctx context.Context, ticker clock.Ticker) {
+	defer s.wg.Done()
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			s.coordinator.errLog.Flush()
+			return
+		case <-ticker.Ch():
+			s.coordinator.errLog.Flush()
+		}
+	}
+}
+
+// reportOldestInFlight periodically records the age of the longest running in-flight job.
+func (s *Scheduler) reportOldestInFlight(ctx context.Context, ticker clock.Ticker) {
+	defer s.wg.Done()
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Ch():
+			_, age, _ := s.OldestInFlight()
+			s.m.RecordOldestInFlightAge(age)
+			s.warnLongRunningJobs()
+		}
+	}
+}
+
+func (s *Scheduler) startWorker(ctx context.Context) {
+	s.m.IncIdleExecutors()
+	s.liveWorkers.Add(1)
+	id := int(s.nextWorkerID.Add(1))
+	scratchDir, err := s.createScratchDir(id)
+	if err != nil {
+		s.logger.Error("Failed to create worker scratch directory", "worker", id, "err", err)
+	}
+	var in <-chan job = s.jobQueue
+	if s.dispatcher != nil {
+		in = s.dispatcher.out
+	}
+	s.wg.Add(1)
+	w := &worker{
+		id:           id,
+		logger:       s.logger,
+		clock:        s.cfg.clock,
+		in:           in,
+		out:          s.resultQueue,
+		threadActive: s.jobStarted,
+		threadIdle:   s.jobFinished,
+		tracer:       s.coordinator.tracer,
+		resources:    s.resources,
+		upstream:     s.coordinator.upstream,
+		canceller:    s.coordinator.canceller,
+		deadlines:    s.cfg.deadlineResolver,
+		retire:       s.retire,
+		scratchDir:   scratchDir,
+
+		actionsPaused: &s.actionsPaused,
+	}
+	go func() {
+		defer s.liveWorkers.Add(-1)
+		w.progressGames(ctx, &s.wg)
+		if ctx.Err() == nil {
+			// The worker was retired by DrainTo rather than the scheduler stopping.
+			s.m.DecIdleExecutors()
+		}
+	}()
+}
+
+// rampUp starts the remaining workers one at a time, evenly spaced over the configured ramp up duration.
+func (s *Scheduler) rampUp(ctx context.Context, ticker clock.Ticker, remaining uint) {
+	defer s.wg.Done()
+	defer ticker.Stop()
+	for remaining > 0 {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Ch():
+			s.workersLock.Lock()
+			if !s.canStartWorker() {
+				s.workersLock.Unlock()
+				return
+			}
+			s.startWorker(ctx)
+			s.workersLock.Unlock()
+			remaining--
+		}
+	}
+}
+
+// Close stops the scheduler, waiting for workers to exit, then flushes any buffered durable state, see Flush.
 func (s *Scheduler) Close() error {
 	s.cancel()
 	s.wg.Wait()
-	return nil
+	return s.Flush(context.Background())
 }
 
+// Schedule queues a batch of games to be progressed. If the previous batch hasn't been accepted yet, the games are
+// merged into it rather than waiting, so the scheduler catches up in a single cycle, see RecordBatchCoalesced.
+// Returns ErrNotStarted, without queuing the batch, if Start hasn't been called so that a batch isn't left waiting
+// on a scheduler that may never start.
 func (s *Scheduler) Schedule(games []types.GameMetadata, blockNumber uint64) error {
-	select {
-	case s.scheduleQueue <- blockGames{blockNumber: blockNumber, games: games}:
+	return s.enqueueBatch(blockGames{blockNumber: blockNumber, games: games})
+}
+
+// enqueueBatch queues the batch to be scheduled, merging it into the previous batch if that hasn't been accepted yet.
+func (s *Scheduler) enqueueBatch(batch blockGames) error {
+	if !s.started.Load() {
+		return ErrNotStarted
+	}
+	if s.coordinator.jobLimitReached.Load() {
+		return ErrJobLimitReached
+	}
+	if err := s.checkBatchSize(len(batch.games)); err != nil {
+		return err
+	}
+	if !s.beginSend() {
+		return ErrStopped
+	}
+	defer s.endSend()
+	// Count the batch as outstanding work before it is queued so WaitIdle can't miss it.
+	s.coordinator.idle.Add(1)
+	size := len(batch.games)
+	for {
+		// The queue holds a single batch so either it has space for this batch or there is a pending batch to merge
+		// with. The queue may be emptied by the loop, or refilled by another caller, before the pending batch is taken
+		// so keep going until the batch is queued, merging with whichever batch is waiting.
+		select {
+		case s.scheduleQueue <- batch:
+			s.m.RecordBatchSize(size)
+			return nil
+		case pending := <-s.scheduleQueue:
+			batch = coalesceBatches(pending, batch)
+			// The two batches are now a single piece of outstanding work.
+			s.coordinator.idle.Done()
+			s.m.RecordBatchCoalesced()
+			s.logger.Debug("Merged batch into pending batch", "block", batch.blockNumber, "count", len(batch.games))
+		}
+	}
+}
+
+// checkBatchSize returns ErrBatchTooLarge if the batch exceeds the limit set by WithMaxAcceptedBatchSize. Batches are
+// checked before they are merged into a pending batch, see WithMaxAcceptedBatchSize.
+func (s *Scheduler) checkBatchSize(size int) error {
+	if s.cfg.maxAcceptedBatchSize <= 0 || size <= s.cfg.maxAcceptedBatchSize {
 		return nil
-	default:
-		return ErrBusy
 	}
+	s.m.RecordBatchRejected()
+	s.logger.Warn("Rejecting batch larger than limit", "size", size, "limit", s.cfg.maxAcceptedBatchSize)
+	return fmt.Errorf("%w: %v games exceeds limit of %v", ErrBatchTooLarge, size, s.cfg.maxAcceptedBatchSize)
+}
+
+// TraceGame enables or disables detailed logging of every scheduling step for a single game, without
+// changing the verbosity for other games. Trace logs are emitted at info level and tagged with trace=true.
+// Returns ErrTooManyTracedGames if the maximum number of games are already being traced.
+func (s *Scheduler) TraceGame(addr common.Address, enable bool) error {
+	return s.coordinator.tracer.SetEnabled(addr, enable)
+}
+
+// ReplayFromSnapshot creates a new player from a snapshot captured with WithSnapshotCapture and progresses
+// it once, returning the result. The player uses a temporary directory and live game state is unaffected.
+// No transactions are sent: the player is switched to shadow mode if it implements ShadowPlayer and is otherwise
+// progressed with actions suppressed.
+func (s *Scheduler) ReplayFromSnapshot(ctx context.Context, snapshotPath string) (ResultSummary, error) {
+	data, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		return ResultSummary{}, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	return replaySnapshot(ctx, s.createPlayer, data)
+}
+
+// ReplayGame replays the last snapshot captured for the game with WithSnapshotCapture, loaded from the state store,
+// like ReplayFromSnapshot. The game may belong to the primary factory or any registered source. Returns
+// ErrStateNotFound if no snapshot has been captured for the game.
+func (s *Scheduler) ReplayGame(ctx context.Context, game common.Address) (ResultSummary, error) {
+	for _, c := range s.coordinators() {
+		data, err := c.store.Load(ctx, game, StateKeySnapshot)
+		if errors.Is(err, ErrStateNotFound) {
+			continue
+		} else if err != nil {
+			return ResultSummary{}, fmt.Errorf("failed to load snapshot: %w", err)
+		}
+		return replaySnapshot(ctx, c.createPlayer, data)
+	}
+	return ResultSummary{}, ErrStateNotFound
+}
+
+// InFlight returns the jobs currently being progressed by workers, ordered from the longest running.
+func (s *Scheduler) InFlight() []InFlightJob {
+	return s.inFlight.Jobs()
+}
+
+// WorkerAssignments returns the game each busy worker is currently progressing, keyed by worker id, to help
+// identify hung players. Worker ids are stable for the lifetime of each worker goroutine. Idle workers are not included.
+func (s *Scheduler) WorkerAssignments() map[int]WorkerAssignment {
+	return s.inFlight.Assignments()
+}
+
+// ExportState returns a JSON encoded, point-in-time snapshot of the state of all games known to the scheduler
+// for consumption by external tooling. The schema is described by ExportedState and versioned by StateExportVersion.
+func (s *Scheduler) ExportState() ([]byte, error) {
+	return json.Marshal(s.coordinator.exportState(s.inFlight))
+}
+
+// Done returns a channel that is closed once the limit set by WithMaxTotalJobs has been reached and all
+// outstanding jobs have completed, signalling that the caller should Close the scheduler and exit.
+// The channel is never closed if no limit is set.
+func (s *Scheduler) Done() <-chan struct{} {
+	return s.done
+}
+
+// OldestInFlight returns the game and age of the longest running job currently being progressed by a worker.
+// Returns false if no jobs are in flight.
+func (s *Scheduler) OldestInFlight() (common.Address, time.Duration, bool) {
+	oldest, ok := s.inFlight.Oldest()
+	return oldest.Game, oldest.Elapsed, ok
+}
+
+// WaitIdle blocks until there are no pending schedule batches, queued or in-flight jobs and no
+// results waiting to be processed. Returns the context error if ctx is done before that happens.
+func (s *Scheduler) WaitIdle(ctx context.Context) error {
+	return s.coordinator.idle.Wait(ctx)
 }
 
 func (s *Scheduler) loop(ctx context.Context) {
 	defer s.wg.Done()
+	defer s.dropUnprocessedBatches()
+	for _, c := range s.coordinators() {
+		c.resumePending(ctx)
+	}
 	for {
+		if s.loopPriority != nil && ctx.Err() == nil && s.servicePreferred(ctx, s.loopPriority()) {
+			s.checkDone()
+			continue
+		}
 		select {
 		case <-ctx.Done():
 			return
 		case blockGames := <-s.scheduleQueue:
-			if err := s.coordinator.schedule(ctx, blockGames.games, blockGames.blockNumber); err != nil {
-				s.logger.Error("Failed to schedule game updates", "err", err)
-			}
+			s.handleSchedule(ctx, blockGames)
 		case j := <-s.resultQueue:
-			if err := s.coordinator.processResult(j); err != nil {
-				s.logger.Error("Error while processing game result", "game", j.addr, "err", err)
-			}
+			s.handleResult(j)
+		case req := <-s.forceQueue:
+			s.handleForce(ctx, req)
+		case req := <-s.groupQueue:
+			s.handleGroup(ctx, req)
+		case req := <-s.urgentRequests:
+			s.handleUrgent(ctx, req)
+		case req := <-s.filterRequests:
+			s.handleFilter(ctx, req)
+		case req := <-s.ignoreRequests:
+			s.handleIgnore(req)
+		case req := <-s.listRequests:
+			s.handleList(req)
+		case req := <-s.concurrencyRequests:
+			s.handleConcurrency(ctx, req)
+		case <-s.sourcesReady:
+			s.handleSources(ctx)
 		}
+		s.checkDone()
+	}
+}
+
+// checkDone closes the done channel if the job limit has been reached and there is no outstanding work.
+func (s *Scheduler) checkDone() {
+	if s.coordinator.jobLimitReached.Load() && s.coordinator.idle.IsIdle() {
+		s.doneOnce.Do(func() {
+			s.logger.Info("Job limit reached and all jobs complete")
+			close(s.done)
+		})
+	}
+}
+
+// servicePreferred services the specified event if it is ready, returning true if it was serviced.
+func (s *Scheduler) servicePreferred(ctx context.Context, event loopEvent) bool {
+	switch event {
+	case loopEventSchedule:
+		select {
+		case blockGames := <-s.scheduleQueue:
+			s.handleSchedule(ctx, blockGames)
+			return true
+		default:
+		}
+	case loopEventResult:
+		select {
+		case j := <-s.resultQueue:
+			s.handleResult(j)
+			return true
+		default:
+		}
+	}
+	return false
+}
+
+func (s *Scheduler) handleSchedule(ctx context.Context, blockGames blockGames) {
+	if ctx.Err() != nil {
+		// The loop may select the batch rather than exiting when both are ready.
+		s.dropBatch(blockGames)
+		return
+	}
+	c := s.coordinatorFor(blockGames.factory)
+	c.setTimeouts(blockGames.timeouts)
+	correlationID := c.setCorrelationID(blockGames.correlationID, blockGames.gameCorrelationIDs)
+	c.logger.Debug("Scheduling batch", "block", blockGames.blockNumber, "games", len(blockGames.games), "correlation", correlationID)
+	if err := c.schedule(ctx, blockGames.games, blockGames.blockNumber); err != nil {
+		c.errLog.LogAll(log.LevelError, "schedule", "Failed to schedule game updates", err, "correlation", correlationID)
+	}
+	c.idle.Done()
+}
+
+func (s *Scheduler) handleResult(j job) {
+	c := s.coordinatorFor(j.factory)
+	if err := c.processResult(j); err != nil {
+		c.errLog.Log(log.LevelError, "result", "Error while processing game result", err, "game", j.addr, "correlation", j.correlationID)
 	}
+	s.enqueueSourcesDeferred(c)
 }
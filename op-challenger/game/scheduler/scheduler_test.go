@@ -2,11 +2,16 @@ package scheduler
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -31,32 +36,175 @@ func TestSchedulerProcessesGames(t *testing.T) {
 
 	require.NoError(t, s.Schedule(games, 0))
 
-	// All jobs should be executed and completed, the last step being to clean up disk resources
-	for i := 0; i < len(games); i++ {
-		kept := <-removeExceptCalls
-		require.Len(t, kept, len(games), "should keep all games")
-		for _, game := range games {
-			require.Containsf(t, kept, game.Proxy, "should keep game %v", game.Proxy)
-		}
+	// The cycle first cleans up disk resources of games that are no longer required
+	kept := <-removeExceptCalls
+	require.Len(t, kept, len(games), "should keep all games")
+	for _, game := range games {
+		require.Containsf(t, kept, game.Proxy, "should keep game %v", game.Proxy)
 	}
+	require.NoError(t, s.WaitIdle(ctx))
 	require.NoError(t, s.Close())
 }
 
-func TestReturnBusyWhenScheduleQueueFull(t *testing.T) {
+func TestRejectWorkBeforeStart(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{StatusValue: types.GameStatusInProgress}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	game := common.Address{0xaa}
+
+	require.ErrorIs(t, s.Schedule(asGames(game), 0), ErrNotStarted)
+	require.ErrorIs(t, s.ScheduleGames([]ScheduledGame{{GameMetadata: types.GameMetadata{Proxy: game}}}, 0), ErrNotStarted)
+	require.ErrorIs(t, s.ForceSchedule(ctx, game), ErrNotStarted)
+	_, err := s.ScheduleGroupAndWait(ctx, []common.Address{game})
+	require.ErrorIs(t, err, ErrNotStarted)
+	require.True(t, s.coordinator.idle.IsIdle(), "should not count rejected batches as outstanding work")
+
+	// Rejected batches aren't queued so the first batch after starting is accepted and progressed
+	s.Start(ctx)
+	defer s.Close()
+	require.NoError(t, s.Schedule(asGames(game), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Len(t, disk.removeExceptCalls, 1)
+}
+
+func TestRampUpWorkers(t *testing.T) {
 	logger := testlog.Logger(t, log.LevelInfo)
 	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
 		return &test.StubGamePlayer{}, nil
 	}
-	removeExceptCalls := make(chan []common.Address)
-	disk := &trackingDiskManager{removeExceptCalls: removeExceptCalls}
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	m := &executorMetrics{}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address)}
+	s := NewScheduler(logger, m, disk, 4, createPlayer, false, WithClock(cl), WithRampUp(3*time.Second))
+	s.Start(context.Background())
+	defer s.Close()
+
+	require.EqualValues(t, 1, m.idle.Load(), "should start with a single worker")
+	require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second), "should start ramp up ticker")
+	for i := int32(2); i <= 4; i++ {
+		cl.AdvanceTime(time.Second)
+		require.Eventuallyf(t, func() bool {
+			return m.idle.Load() == i
+		}, 10*time.Second, 10*time.Millisecond, "should have %v workers", i)
+	}
+
+	// Ramp up is complete so no further workers should be added
+	cl.AdvanceTime(time.Second)
+	require.Never(t, func() bool {
+		return m.idle.Load() > 4
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestStartAllWorkersWithoutRampUp(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{}, nil
+	}
+	m := &executorMetrics{}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address)}
+	s := NewScheduler(logger, m, disk, 4, createPlayer, false)
+	s.Start(context.Background())
+	defer s.Close()
+	require.EqualValues(t, 4, m.idle.Load())
+}
+
+func TestWaitIdle(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	players := make(map[common.Address]*test.StubGamePlayer)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		player := &test.StubGamePlayer{}
+		players[g.Proxy] = player
+		return player, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false)
+
+	// Idle before any work is scheduled
+	require.NoError(t, s.WaitIdle(ctx))
+
+	s.Start(ctx)
+	defer s.Close()
+
+	games := asGames(common.Address{0xaa}, common.Address{0xbb}, common.Address{0xcc})
+	require.NoError(t, s.Schedule(games, 0))
+	require.NoError(t, s.WaitIdle(ctx))
+
+	require.Len(t, players, len(games))
+	for addr, player := range players {
+		require.Equalf(t, 1, player.ProgressCount, "should have progressed game %v", addr)
+	}
+	require.Len(t, disk.removeExceptCalls, 1, "should clean up disk resources once per cycle")
+}
+
+func TestWaitIdleReturnsWhenContextDone(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
 	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false)
 
-	// Scheduler not started - first call fills the queue
+	// Loop not running so the batch remains pending
+	s.started.Store(true)
 	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 0))
 
-	// Second call should return busy
-	err := s.Schedule(asGames(common.Address{0xaa}), 0)
-	require.ErrorIs(t, err, ErrBusy)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, s.WaitIdle(ctx), context.DeadlineExceeded)
+}
+
+func TestStartCheckedFailsWhenDiskUnavailable(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{}, nil
+	}
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, []byte("data"), 0644))
+	m := &executorMetrics{}
+	s := NewScheduler(logger, m, &tempDirDiskManager{dir: file}, 2, createPlayer, false)
+	require.ErrorIs(t, s.StartChecked(context.Background()), ErrDiskUnavailable)
+	require.Zero(t, m.idle.Load(), "should not have started any workers")
+}
+
+func TestStartChecked(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{}, nil
+	}
+	m := &executorMetrics{}
+	s := NewScheduler(logger, m, &tempDirDiskManager{dir: t.TempDir()}, 2, createPlayer, false)
+	require.NoError(t, s.StartChecked(context.Background()))
+	defer s.Close()
+	require.EqualValues(t, 2, m.idle.Load())
+}
+
+type executorMetrics struct {
+	metrics.NoopMetricsImpl
+	idle   atomic.Int32
+	active atomic.Int32
+}
+
+func (m *executorMetrics) IncIdleExecutors() {
+	m.idle.Add(1)
+}
+
+func (m *executorMetrics) DecIdleExecutors() {
+	m.idle.Add(-1)
+}
+
+func (m *executorMetrics) IncActiveExecutors() {
+	m.active.Add(1)
+}
+
+func (m *executorMetrics) DecActiveExecutors() {
+	m.active.Add(-1)
 }
 
 type trackingDiskManager struct {
@@ -71,3 +219,224 @@ func (t *trackingDiskManager) RemoveAllExcept(addrs []common.Address) error {
 	t.removeExceptCalls <- addrs
 	return nil
 }
+
+func TestLoopPriority(t *testing.T) {
+	for name, event := range map[string]loopEvent{"ScheduleFirst": loopEventSchedule, "ResultFirst": loopEventResult} {
+		event := event
+		t.Run(name, func(t *testing.T) {
+			logger := testlog.Logger(t, log.LevelInfo)
+			disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+			games := &createdGames{t: t, created: make(map[common.Address]*test.StubGamePlayer)}
+			s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, games.CreateGame, false)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			gameAddr := common.Address{0xaa}
+
+			// Make both a result and a new batch including the same game ready before the loop starts.
+			require.NoError(t, s.coordinator.schedule(ctx, asGames(gameAddr), 0))
+			s.resultQueue <- runJob(ctx, <-s.jobQueue)
+			s.coordinator.idle.Add(1)
+			s.scheduleQueue <- blockGames{blockNumber: 1, games: asGames(gameAddr)}
+
+			iterations := make(chan struct{}, 3)
+			s.loopPriority = func() loopEvent {
+				iterations <- struct{}{}
+				return event
+			}
+			s.wg.Add(1)
+			go s.loop(ctx)
+			// The third iteration only starts once both inputs have been serviced.
+			for i := 0; i < 3; i++ {
+				readWithTimeout(t, iterations)
+			}
+			cancel()
+			s.wg.Wait()
+
+			if event == loopEventResult {
+				require.Len(t, s.jobQueue, 1, "should reschedule game after processing its result")
+			} else {
+				require.Empty(t, s.jobQueue, "should not reschedule game still in flight")
+			}
+		})
+	}
+}
+
+func TestStopAfterMaxTotalJobs(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	games := &createdGames{t: t, created: make(map[common.Address]*test.StubGamePlayer)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, games.CreateGame, false, WithMaxTotalJobs(3))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}, common.Address{0xbb}, common.Address{0xcc}), 0))
+	select {
+	case <-s.Done():
+	case <-ctx.Done():
+		t.Fatal("scheduler did not signal completion")
+	}
+	require.ErrorIs(t, s.Schedule(asGames(common.Address{0xaa}), 1), ErrJobLimitReached)
+	for _, player := range games.created {
+		require.Equal(t, 1, player.ProgressCount)
+	}
+}
+
+func TestDoneNotClosedWithoutJobLimit(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	games := &createdGames{t: t, created: make(map[common.Address]*test.StubGamePlayer)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, games.CreateGame, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	select {
+	case <-s.Done():
+		t.Fatal("should not signal completion without a job limit")
+	default:
+	}
+}
+
+func TestLoopNotStalledByFullJobQueue(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	release := make(chan struct{})
+	var created atomic.Int32
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		created.Add(1)
+		return &blockingPlayer{release: release}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	// Single worker so the job queue only has space for two jobs
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	var games []common.Address
+	for i := 0; i < 10; i++ {
+		games = append(games, common.Address{byte(i + 1)})
+	}
+	require.NoError(t, s.Schedule(asGames(games...), 0))
+	// The loop keeps accepting batches while the job queue is full
+	for i := 1; i <= 3; i++ {
+		require.Eventually(t, func() bool {
+			return len(s.scheduleQueue) == 0
+		}, 10*time.Second, 10*time.Millisecond)
+		require.NoError(t, s.Schedule(asGames(games...), uint64(i)))
+	}
+
+	close(release)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.EqualValues(t, len(games), created.Load())
+}
+
+func TestRecordDispatchDelay(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	release := make(chan struct{})
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &blockingPlayer{release: release}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	m := &dispatchDelayMetrics{delays: make(chan time.Duration, 10)}
+	// A single worker so each job waits for the previous one to complete
+	s := NewScheduler(logger, m, disk, 1, createPlayer, false, WithClock(cl))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	games := asGames(common.Address{0xaa}, common.Address{0xbb}, common.Address{0xcc})
+	require.NoError(t, s.Schedule(games[:1], 0))
+	require.Equal(t, time.Duration(0), readWithTimeout(t, m.delays))
+	// The remaining games wait in the job queue while the worker is busy
+	require.NoError(t, s.Schedule(games, 1))
+	require.Eventually(t, func() bool {
+		return len(s.jobQueue) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+	for i := 1; i <= 2; i++ {
+		cl.AdvanceTime(time.Second)
+		release <- struct{}{}
+		require.Equal(t, time.Duration(i)*time.Second, readWithTimeout(t, m.delays))
+	}
+	release <- struct{}{}
+	require.NoError(t, s.WaitIdle(ctx))
+}
+
+type dispatchDelayMetrics struct {
+	metrics.NoopMetricsImpl
+	delays chan time.Duration
+}
+
+func (m *dispatchDelayMetrics) RecordDispatchDelay(d time.Duration) {
+	m.delays <- d
+}
+
+func TestRecordBatchSize(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	m := &batchSizeMetrics{}
+	s := NewScheduler(logger, m, disk, 2, createPlayer, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Loop not running so the second batch is merged into the first
+	s.started.Store(true)
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}, common.Address{0xbb}, common.Address{0xcc}), 0))
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 0))
+	require.Equal(t, []int{3, 1}, m.sizes)
+
+	s.Start(ctx)
+	defer s.Close()
+	require.NoError(t, s.WaitIdle(ctx))
+	require.NoError(t, s.Schedule(nil, 1))
+	require.Equal(t, []int{3, 1, 0}, m.sizes)
+}
+
+type batchSizeMetrics struct {
+	metrics.NoopMetricsImpl
+	sizes    []int
+	rejected int
+}
+
+func (m *batchSizeMetrics) RecordBatchSize(n int) {
+	m.sizes = append(m.sizes, n)
+}
+
+func (m *batchSizeMetrics) RecordBatchRejected() {
+	m.rejected++
+}
+
+func TestMaxAcceptedBatchSize(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{StatusValue: types.GameStatusInProgress}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	m := &batchSizeMetrics{}
+	s := NewScheduler(logger, m, disk, 2, createPlayer, false, WithMaxAcceptedBatchSize(2))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	err := s.Schedule(asGames(common.Address{0xaa}, common.Address{0xbb}, common.Address{0xcc}), 0)
+	require.ErrorIs(t, err, ErrBatchTooLarge)
+	require.Equal(t, 1, m.rejected)
+	require.Empty(t, m.sizes, "should not accept the rejected batch")
+	require.NoError(t, s.WaitIdle(ctx))
+
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}, common.Address{0xbb}), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Equal(t, []int{2}, m.sizes)
+	require.Equal(t, 1, m.rejected)
+}
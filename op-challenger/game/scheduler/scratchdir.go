@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// WorkerDirManager is an optional interface a DiskManager can implement to give each worker its own scratch
+// directory, so temporary files written by players progressing games concurrently can't collide.
+type WorkerDirManager interface {
+	// DirForWorker returns the scratch directory for the worker with the specified id.
+	DirForWorker(workerID int) string
+}
+
+type scratchDirKey struct{}
+
+func withScratchDir(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, scratchDirKey{}, dir)
+}
+
+// ScratchDir returns the scratch directory of the worker progressing the game, for the player to use for
+// temporary files. The directory is only used by one job at a time and is reused for later jobs on the same worker,
+// so players should remove files they no longer need. It is removed when the worker stops.
+// Returns false if the DiskManager doesn't implement WorkerDirManager.
+func ScratchDir(ctx context.Context) (string, bool) {
+	dir, ok := ctx.Value(scratchDirKey{}).(string)
+	return dir, ok
+}
+
+// createScratchDir creates an empty scratch directory for the worker if the DiskManager supports it.
+// Any content left by a previous worker with the same id is removed.
+// Returns an empty string if the DiskManager doesn't support scratch directories.
+func (s *Scheduler) createScratchDir(workerID int) (string, error) {
+	disk, ok := s.baseDisk.(WorkerDirManager)
+	if !ok {
+		return "", nil
+	}
+	dir := disk.DirForWorker(workerID)
+	if err := os.RemoveAll(dir); err != nil {
+		return "", fmt.Errorf("failed to clear scratch directory: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create scratch directory: %w", err)
+	}
+	return dir, nil
+}
+
+// removeScratchDir removes the worker's scratch directory, if it has one, when the worker stops.
+func (w *worker) removeScratchDir() {
+	if w.scratchDir == "" {
+		return
+	}
+	if err := os.RemoveAll(w.scratchDir); err != nil {
+		w.logger.Error("Failed to remove worker scratch directory", "worker", w.id, "dir", w.scratchDir, "err", err)
+	}
+}
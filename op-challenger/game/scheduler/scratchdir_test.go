@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerScratchDirs(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	release := make(chan struct{})
+	var dirsLock sync.Mutex
+	dirs := make(map[common.Address]string)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &scratchDirPlayer{blockingPlayer: blockingPlayer{release: release}, record: func(dir string) {
+			dirsLock.Lock()
+			defer dirsLock.Unlock()
+			dirs[g.Proxy] = dir
+		}}, nil
+	}
+	disk := &scratchDirDiskManager{tempDirDiskManager: tempDirDiskManager{dir: t.TempDir()}}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	require.NoError(t, s.Schedule(asGames(game1, game2), 0))
+	require.Eventually(t, func() bool {
+		dirsLock.Lock()
+		defer dirsLock.Unlock()
+		return len(dirs) == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	dirsLock.Lock()
+	dir1, dir2 := dirs[game1], dirs[game2]
+	dirsLock.Unlock()
+	require.NotEmpty(t, dir1)
+	require.NotEmpty(t, dir2)
+	require.NotEqual(t, dir1, dir2, "concurrent jobs should have distinct scratch dirs")
+	require.DirExists(t, dir1)
+	require.DirExists(t, dir2)
+
+	close(release)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.NoError(t, s.Close())
+	require.NoDirExists(t, dir1)
+	require.NoDirExists(t, dir2)
+}
+
+func TestScratchDirContext(t *testing.T) {
+	ctx := context.Background()
+	_, ok := ScratchDir(ctx)
+	require.False(t, ok)
+	dir, ok := ScratchDir(withScratchDir(ctx, "/tmp/scratch"))
+	require.True(t, ok)
+	require.Equal(t, "/tmp/scratch", dir)
+}
+
+// scratchDirDiskManager is a tempDirDiskManager that also provides worker scratch directories.
+type scratchDirDiskManager struct {
+	tempDirDiskManager
+}
+
+func (d *scratchDirDiskManager) DirForWorker(workerID int) string {
+	return filepath.Join(d.dir, "scratch", strconv.Itoa(workerID))
+}
+
+// scratchDirPlayer records the scratch dir from the context it is progressed with.
+type scratchDirPlayer struct {
+	blockingPlayer
+	record func(dir string)
+}
+
+func (p *scratchDirPlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	dir, _ := ScratchDir(ctx)
+	p.record(dir)
+	return p.blockingPlayer.ProgressGame(ctx)
+}
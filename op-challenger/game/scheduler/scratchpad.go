@@ -0,0 +1,44 @@
+package scheduler
+
+const (
+	maxScratchpadEntries = 32
+	maxScratchpadBytes   = 4096
+)
+
+// Scratchpad is a small in-memory key/value map the coordinator keeps for each game so players can remember
+// cheap hints (e.g. the last known claim depth) between progressions without recomputing them or going to disk.
+// The scratchpad is not durable: it is lost when the challenger restarts and discarded once the game resolves,
+// so it must never be used in place of the game's disk state.
+// It is limited to maxScratchpadEntries entries and maxScratchpadBytes bytes of keys and values.
+type Scratchpad map[string]string
+
+// ScratchpadUser is an optional interface a GamePlayer can implement to use its game's Scratchpad.
+type ScratchpadUser interface {
+	// LoadScratchpad is called with a copy of the game's scratchpad before each ProgressGame call.
+	LoadScratchpad(pad Scratchpad)
+	// SaveScratchpad is called after each ProgressGame call and returns the updated scratchpad to keep.
+	// Updates exceeding the size limits are discarded, keeping the previous scratchpad.
+	SaveScratchpad() Scratchpad
+}
+
+func (s Scratchpad) clone() Scratchpad {
+	if s == nil {
+		return nil
+	}
+	clone := make(Scratchpad, len(s))
+	for k, v := range s {
+		clone[k] = v
+	}
+	return clone
+}
+
+func (s Scratchpad) withinLimits() bool {
+	if len(s) > maxScratchpadEntries {
+		return false
+	}
+	size := 0
+	for k, v := range s {
+		size += len(k) + len(v)
+	}
+	return size <= maxScratchpadBytes
+}
@@ -0,0 +1,65 @@
+package scheduler
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScratchpadSurvivesAcrossCycles(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	player := &scratchpadPlayer{StubGamePlayer: test.StubGamePlayer{StatusValue: types.GameStatusInProgress}}
+	c.createPlayer = func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return player, nil
+	}
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, c.schedule(ctx, asGames(gameAddr), uint64(i)))
+		require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	}
+	require.Equal(t, []Scratchpad{nil, {"depth": "1"}, {"depth": "2"}}, player.loaded)
+	require.Equal(t, Scratchpad{"depth": "3"}, c.states[gameAddr].scratchpad)
+
+	// Updates exceeding the limits are discarded
+	player.extra = strings.Repeat("a", maxScratchpadBytes)
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 3))
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.Equal(t, Scratchpad{"depth": "3"}, c.states[gameAddr].scratchpad)
+
+	// Scratchpad is evicted once the game resolves
+	player.extra = ""
+	player.StatusValue = types.GameStatusDefenderWon
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 4))
+	require.NoError(t, c.processResult(runJob(ctx, <-workQueue)))
+	require.Nil(t, c.states[gameAddr].scratchpad)
+}
+
+// scratchpadPlayer counts its progressions in its scratchpad.
+type scratchpadPlayer struct {
+	test.StubGamePlayer
+	loaded []Scratchpad
+	pad    Scratchpad
+	extra  string
+}
+
+func (p *scratchpadPlayer) LoadScratchpad(pad Scratchpad) {
+	p.loaded = append(p.loaded, pad.clone())
+	p.pad = pad
+}
+
+func (p *scratchpadPlayer) SaveScratchpad() Scratchpad {
+	depth, _ := strconv.Atoi(p.pad["depth"])
+	updated := Scratchpad{"depth": strconv.Itoa(depth + 1)}
+	if p.extra != "" {
+		updated["extra"] = p.extra
+	}
+	return updated
+}
@@ -0,0 +1,24 @@
+package scheduler
+
+import "errors"
+
+var ErrShadowModeUnsupported = errors.New("game player does not support shadow mode")
+
+// ShadowPlayer is an optional interface a GamePlayer can implement to be progressed in shadow mode, see
+// WithShadowMode. Players that don't implement it can't be created while shadow mode is enabled since they may
+// send transactions.
+type ShadowPlayer interface {
+	// EnableShadowMode switches the player to logging the transactions it would send instead of sending them. It is
+	// called once, before the player is first progressed.
+	EnableShadowMode() error
+}
+
+// enableShadowMode switches the player to shadow mode, returning ErrShadowModeUnsupported if it doesn't implement
+// ShadowPlayer.
+func enableShadowMode(player GamePlayer) error {
+	shadow, ok := player.(ShadowPlayer)
+	if !ok {
+		return ErrShadowModeUnsupported
+	}
+	return shadow.EnableShadowMode()
+}
@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShadowModeEnabledOnCreatedPlayers(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+	}{
+		{name: "Enabled", enabled: true},
+		{name: "Disabled", enabled: false},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			logger := testlog.Logger(t, log.LevelInfo)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			games := &createdGames{t: t, created: make(map[common.Address]*test.StubGamePlayer)}
+			disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+			s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, games.CreateGame, false, WithShadowMode(tc.enabled))
+			s.Start(ctx)
+			defer s.Close()
+
+			require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 1))
+			require.NoError(t, s.WaitIdle(ctx))
+			player := games.created[common.Address{0xaa}]
+			require.Equal(t, 1, player.ProgressCount)
+			require.Equal(t, tc.enabled, player.ShadowMode)
+		})
+	}
+}
+
+func TestShadowModeRequiresShadowPlayer(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	player := &liveOnlyPlayer{}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return player, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, WithShadowMode(true))
+	s.Start(ctx)
+	defer s.Close()
+
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 1))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Zero(t, player.progressCount, "should not progress player that may send transactions")
+}
+
+// liveOnlyPlayer is a player that doesn't support shadow mode.
+type liveOnlyPlayer struct {
+	progressCount int
+	suppressed    bool
+}
+
+func (p *liveOnlyPlayer) ValidatePrestate(_ context.Context) error {
+	return nil
+}
+
+func (p *liveOnlyPlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	p.progressCount++
+	p.suppressed = types.ActionsSuppressed(ctx)
+	return types.GameStatusInProgress
+}
+
+func (p *liveOnlyPlayer) Status() types.GameStatus {
+	return types.GameStatusInProgress
+}
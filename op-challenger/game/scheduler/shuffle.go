@@ -0,0 +1,22 @@
+package scheduler
+
+import "math/rand"
+
+// shuffleJobs randomises the order of jobs for games of equal priority if WithRandomizedDispatch is enabled, so
+// that games early in each batch aren't consistently progressed first and games late in each batch aren't
+// consistently starved when workers are saturated.
+func (c *coordinator) shuffleJobs(jobs []job) {
+	if c.dispatchRand == nil {
+		return
+	}
+	c.dispatchRand.Shuffle(len(jobs), func(i, j int) {
+		jobs[i], jobs[j] = jobs[j], jobs[i]
+	})
+}
+
+func newDispatchRand(cfg config) *rand.Rand {
+	if !cfg.randomizeDispatch {
+		return nil
+	}
+	return rand.New(rand.NewSource(cfg.clock.Now().UnixNano()))
+}
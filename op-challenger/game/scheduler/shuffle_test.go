@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRandomizedDispatchIsFair(t *testing.T) {
+	const cycles = 500
+	count := func(randomize bool) (head, tail int) {
+		// Room for only two of the ten games each cycle, the rest are dropped
+		c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 2)
+		c.cfg.queueFullStrategy = QueueFullDrop
+		if randomize {
+			c.dispatchRand = rand.New(rand.NewSource(1))
+		}
+		var addrs []common.Address
+		for i := 0; i < 10; i++ {
+			addrs = append(addrs, common.Address{byte(i + 1)})
+		}
+		headGame, tailGame := addrs[0], addrs[len(addrs)-1]
+		ctx := context.Background()
+		for i := uint64(0); i < cycles; i++ {
+			require.NoError(t, c.schedule(ctx, asGames(addrs...), i))
+			for len(workQueue) > 0 {
+				j := <-workQueue
+				switch j.addr {
+				case headGame:
+					head++
+				case tailGame:
+					tail++
+				}
+				require.NoError(t, c.processResult(j))
+			}
+		}
+		return head, tail
+	}
+
+	head, tail := count(false)
+	require.Equal(t, cycles, head, "head game should always be dispatched without randomisation")
+	require.Zero(t, tail, "tail game should be starved without randomisation")
+
+	head, tail = count(true)
+	// Each game has a 1 in 5 chance of being dispatched each cycle
+	expected := cycles / 5
+	require.InDelta(t, expected, head, float64(expected)/2)
+	require.InDelta(t, expected, tail, float64(expected)/2)
+}
+
+func TestRandomizedDispatchRespectsPriority(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	c.dispatchRand = rand.New(rand.NewSource(1))
+	c.cfg.failureDemotion = failureDemotion{threshold: 1, amount: 1}
+	var addrs []common.Address
+	for i := 0; i < 5; i++ {
+		addrs = append(addrs, common.Address{byte(i + 1)})
+	}
+	demoted := addrs[0]
+	ctx := context.Background()
+	require.NoError(t, c.schedule(ctx, asGames(addrs...), 0))
+	for len(workQueue) > 0 {
+		require.NoError(t, c.processResult(<-workQueue))
+	}
+	c.states[demoted].progressFailures = 1
+
+	for i := uint64(1); i < 20; i++ {
+		require.NoError(t, c.schedule(ctx, asGames(addrs...), i))
+		var order []common.Address
+		for len(workQueue) > 0 {
+			j := <-workQueue
+			order = append(order, j.addr)
+			if j.addr == demoted {
+				j.err = errors.New("boom")
+			}
+			require.NoError(t, c.processResult(j))
+		}
+		require.Len(t, order, len(addrs))
+		require.Equal(t, demoted, order[len(order)-1], "demoted game should be dispatched last")
+	}
+}
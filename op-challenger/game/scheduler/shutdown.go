@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// beginSend registers a caller about to send a batch to the scheduleQueue so that the loop waits for it to
+// complete before dropping unprocessed batches. Returns false, without registering, if the loop has stopped.
+// endSend must be called once the send completes or is abandoned.
+func (s *Scheduler) beginSend() bool {
+	s.sendersLock.Lock()
+	defer s.sendersLock.Unlock()
+	select {
+	case <-s.stopped:
+		return false
+	default:
+	}
+	s.senders.Add(1)
+	return true
+}
+
+func (s *Scheduler) endSend() {
+	s.senders.Done()
+}
+
+// dropUnprocessedBatches is called when the loop exits. It unblocks any callers waiting to send a batch, then
+// logs, acknowledges and discards any batches that were queued but not processed so they aren't silently lost.
+func (s *Scheduler) dropUnprocessedBatches() {
+	s.sendersLock.Lock()
+	close(s.stopped)
+	s.sendersLock.Unlock()
+	s.senders.Wait()
+	for _, batch := range s.takePendingSources() {
+		s.dropBatch(batch)
+	}
+	for {
+		select {
+		case batch := <-s.scheduleQueue:
+			s.dropBatch(batch)
+		default:
+			return
+		}
+	}
+}
+
+// dropBatch logs and discards a batch that can't be processed because the scheduler is stopping. Each game of the
+// batch is acknowledged, like those of a batch rejected before its cycle started, so the sink set by WithAckSink
+// accounts for every game it was handed.
+func (s *Scheduler) dropBatch(batch blockGames) {
+	games := make([]common.Address, 0, len(batch.games))
+	for _, game := range batch.games {
+		games = append(games, game.Proxy)
+	}
+	s.logger.Warn("Scheduler stopped, dropping unprocessed batch", "block", batch.blockNumber, "count", len(games), "games", games)
+	c := s.coordinatorFor(batch.factory)
+	c.ackSkippedBatch(batch.games, c.cycle+1)
+	s.coordinator.idle.Done()
+}
@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogBatchQueuedWhenStopped(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		// Never released so the loop stays blocked enqueuing the first batch
+		return &blockingPlayer{}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, WithQueueFullStrategy(QueueFullBlock))
+	s.Start(context.Background())
+
+	// One job in progress and two in the job queue, so the loop blocks enqueuing the fourth
+	var games []common.Address
+	for i := 0; i < 4; i++ {
+		games = append(games, common.Address{byte(i + 1)})
+	}
+	require.NoError(t, s.Schedule(asGames(games...), 0))
+	require.Eventually(t, func() bool {
+		return len(s.scheduleQueue) == 0 && len(s.jobQueue) == cap(s.jobQueue)
+	}, 10*time.Second, 10*time.Millisecond)
+	queued := []common.Address{{0xaa}, {0xbb}}
+	require.NoError(t, s.Schedule(asGames(queued...), 1))
+
+	require.NoError(t, s.Close())
+	droppedLog := logs.FindLog(testlog.NewMessageFilter("Scheduler stopped, dropping unprocessed batch"))
+	require.NotNil(t, droppedLog, "should log the dropped batch")
+	require.EqualValues(t, 1, droppedLog.AttrValue("block"))
+	require.Equal(t, queued, droppedLog.AttrValue("games"))
+	require.Empty(t, s.scheduleQueue)
+
+	require.ErrorIs(t, s.Schedule(asGames(queued...), 2), ErrStopped, "should not accept batches once stopped")
+	require.ErrorIs(t, s.ForceSchedule(context.Background(), games[0]), ErrStopped)
+}
+
+func TestAckBatchDroppedWhenStopped(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &blockingPlayer{}, nil
+	}
+	var lock sync.Mutex
+	var acks []ack
+	sink := func(addr common.Address, cycle uint64) {
+		lock.Lock()
+		defer lock.Unlock()
+		acks = append(acks, ack{addr, cycle})
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, WithQueueFullStrategy(QueueFullBlock), WithAckSink(sink))
+	s.Start(context.Background())
+
+	var games []common.Address
+	for i := 0; i < 4; i++ {
+		games = append(games, common.Address{byte(i + 1)})
+	}
+	require.NoError(t, s.Schedule(asGames(games...), 0))
+	require.Eventually(t, func() bool {
+		return len(s.scheduleQueue) == 0 && len(s.jobQueue) == cap(s.jobQueue)
+	}, 10*time.Second, 10*time.Millisecond)
+	queued := []common.Address{{0xaa}, {0xbb}}
+	require.NoError(t, s.Schedule(asGames(queued...), 1))
+
+	require.NoError(t, s.Close())
+	lock.Lock()
+	defer lock.Unlock()
+	for _, addr := range queued {
+		require.True(t, slices.ContainsFunc(acks, func(a ack) bool { return a.game == addr }), "should ack dropped game %v", addr)
+	}
+}
+
+func TestUnblockScheduleFromFileWhenStopped(t *testing.T) {
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &blockingPlayer{}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, WithQueueFullStrategy(QueueFullBlock))
+	s.Start(context.Background())
+
+	var games []common.Address
+	for i := 0; i < 4; i++ {
+		games = append(games, common.Address{byte(i + 1)})
+	}
+	require.NoError(t, s.Schedule(asGames(games...), 0))
+	require.Eventually(t, func() bool {
+		return len(s.scheduleQueue) == 0 && len(s.jobQueue) == cap(s.jobQueue)
+	}, 10*time.Second, 10*time.Millisecond)
+	// Fill the schedule queue so the next send blocks
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 1))
+
+	path := filepath.Join(t.TempDir(), "games.txt")
+	require.NoError(t, os.WriteFile(path, []byte(common.Address{0xbb}.Hex()+"\n"), 0644))
+	result := make(chan error, 1)
+	go func() {
+		_, err := s.ScheduleFromFile(context.Background(), path, 0, 2, InvalidLineFail)
+		result <- err
+	}()
+	require.Never(t, func() bool {
+		return len(result) > 0
+	}, 100*time.Millisecond, 10*time.Millisecond, "should block while the schedule queue is full")
+
+	require.NoError(t, s.Close())
+	select {
+	case err := <-result:
+		if err == nil {
+			// The batch was accepted as the loop exited so must have been logged as dropped instead.
+			droppedLog := logs.FindLog(testlog.NewMessageFilter("Scheduler stopped, dropping unprocessed batch"), testlog.NewAttributesFilter("block", "2"))
+			require.NotNil(t, droppedLog, "should either reject or log the batch")
+		} else {
+			require.ErrorIs(t, err, ErrStopped)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("ScheduleFromFile not unblocked")
+	}
+}
@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// resultSinkBufferSize is the number of results buffered for the sink before further results are dropped.
+const resultSinkBufferSize = 1000
+
+// ResultSink is called with the outcome of each completed job, see WithResultSink.
+type ResultSink func(ctx context.Context, result ResultSummary) error
+
+type ResultSinkMetricer interface {
+	RecordResultSinkError()
+	RecordResultSinkDropped()
+}
+
+// resultPublisher forwards results to a ResultSink from its own goroutine so that a slow or failing sink
+// doesn't delay processing of results. Results are buffered and dropped if the buffer is full.
+type resultPublisher struct {
+	logger log.Logger
+	m      ResultSinkMetricer
+	errLog *errorThrottle
+	sink   ResultSink
+	queue  chan ResultSummary
+}
+
+func newResultPublisher(logger log.Logger, m ResultSinkMetricer, errLog *errorThrottle, sink ResultSink) *resultPublisher {
+	return &resultPublisher{
+		logger: logger,
+		m:      m,
+		errLog: errLog,
+		sink:   sink,
+		queue:  make(chan ResultSummary, resultSinkBufferSize),
+	}
+}
+
+// Publish queues result to be sent to the sink without blocking.
+func (p *resultPublisher) Publish(result ResultSummary) {
+	select {
+	case p.queue <- result:
+	default:
+		p.m.RecordResultSinkDropped()
+		p.logger.Warn("Result sink buffer full, dropping result", "game", result.Game, "block", result.Block)
+	}
+}
+
+// run sends queued results to the sink until ctx is done.
+func (p *resultPublisher) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case result := <-p.queue:
+			if err := p.sink(ctx, result); err != nil {
+				p.m.RecordResultSinkError()
+				p.errLog.Log(log.LevelWarn, "sink", "Failed to publish result", err, "game", result.Game)
+			}
+		}
+	}
+}
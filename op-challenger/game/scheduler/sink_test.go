@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultSink(t *testing.T) {
+	t.Run("ResultsReachSink", func(t *testing.T) {
+		results := make(chan ResultSummary, 10)
+		sink := func(ctx context.Context, result ResultSummary) error {
+			results <- result
+			return nil
+		}
+		s := newSinkTestScheduler(t, sink)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		s.Start(ctx)
+		defer s.Close()
+
+		game1 := common.Address{0xaa}
+		game2 := common.Address{0xbb}
+		require.NoError(t, s.ScheduleCorrelated(asGames(game1, game2), 5, "batch"))
+		received := []ResultSummary{readWithTimeout(t, results), readWithTimeout(t, results)}
+		require.ElementsMatch(t, []ResultSummary{
+			{Game: game1, Block: 5, Status: types.GameStatusInProgress, CorrelationID: "batch"},
+			{Game: game2, Block: 5, Status: types.GameStatusInProgress, CorrelationID: "batch"},
+		}, received)
+	})
+
+	t.Run("SlowSinkDoesNotStallLoop", func(t *testing.T) {
+		release := make(chan struct{})
+		sink := func(ctx context.Context, result ResultSummary) error {
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+			return nil
+		}
+		s := newSinkTestScheduler(t, sink)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		s.Start(ctx)
+		defer s.Close()
+		defer close(release)
+
+		game1 := common.Address{0xaa}
+		for i := uint64(0); i < 5; i++ {
+			require.NoError(t, s.Schedule(asGames(game1), i))
+			require.NoError(t, s.WaitIdle(ctx))
+		}
+	})
+}
+
+func TestResultPublisher(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	m := &sinkMetrics{}
+	errLog := newErrorThrottle(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), 0)
+	published := make(chan ResultSummary, 10)
+	sinkErr := errors.New("boom")
+	sink := func(ctx context.Context, result ResultSummary) error {
+		published <- result
+		if result.Acted {
+			return sinkErr
+		}
+		return nil
+	}
+	p := newResultPublisher(logger, m, errLog, sink)
+	p.queue = make(chan ResultSummary, 2)
+
+	// Results beyond the buffer size are dropped
+	p.Publish(ResultSummary{Block: 1})
+	p.Publish(ResultSummary{Block: 2, Acted: true})
+	p.Publish(ResultSummary{Block: 3})
+	require.Equal(t, 1, m.dropped)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.run(ctx)
+	}()
+	require.Equal(t, uint64(1), readWithTimeout(t, published).Block)
+	require.Equal(t, uint64(2), readWithTimeout(t, published).Block)
+	cancel()
+	<-done
+	require.Equal(t, 1, m.errors)
+}
+
+func newSinkTestScheduler(t *testing.T, sink ResultSink) *Scheduler {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{StatusValue: types.GameStatusInProgress}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	return NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer, false, WithResultSink(sink))
+}
+
+type sinkMetrics struct {
+	metrics.NoopMetricsImpl
+	errors  int
+	dropped int
+}
+
+func (m *sinkMetrics) RecordResultSinkError() {
+	m.errors++
+}
+
+func (m *sinkMetrics) RecordResultSinkDropped() {
+	m.dropped++
+}
@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+)
+
+var ErrUnsupportedSnapshot = errors.New("unsupported snapshot version")
+
+const (
+	snapshotVersion = 1
+	// snapshotFileName is the name of the file the default disk StateStore writes each game's snapshot to.
+	snapshotFileName = stateFilePrefix + StateKeySnapshot
+)
+
+// Snapshotter is an optional interface a GamePlayer can implement to include its local state in
+// captured game snapshots, and to restore that state when a snapshot is replayed.
+type Snapshotter interface {
+	CaptureSnapshot() ([]byte, error)
+	RestoreSnapshot(data []byte) error
+}
+
+// GameSnapshot records the inputs used to progress a game so the progression can be replayed offline.
+type GameSnapshot struct {
+	Version    uint               `json:"version"`
+	Game       types.GameMetadata `json:"game"`
+	Block      uint64             `json:"block"`
+	Status     types.GameStatus   `json:"status"`
+	CapturedAt time.Time          `json:"capturedAt"`
+	// PlayerState is the opaque state provided by players that implement Snapshotter. Fault game players capture the
+	// claims and L1 time they last acted on so the replay doesn't depend on the current state of the game.
+	PlayerState []byte `json:"playerState,omitempty"`
+}
+
+// pendingSnapshot is a job whose snapshot is captured by writeSnapshots before it is enqueued.
+type pendingSnapshot struct {
+	game types.GameMetadata
+	job  job
+}
+
+// writeSnapshots captures the snapshots of the jobs created since it was last called, see WithSnapshotCapture. The
+// lock must not be held, so that inspecting the game states isn't delayed by writing the snapshots.
+func (c *coordinator) writeSnapshots(ctx context.Context) {
+	c.lock.Lock()
+	snapshots := c.snapshots
+	c.snapshots = nil
+	c.lock.Unlock()
+	now := c.cfg.clock.Now()
+	for _, s := range snapshots {
+		if err := captureSnapshot(ctx, c.store, s.game, s.job, now); err != nil {
+			c.logger.Error("Failed to capture game snapshot", "game", s.game.Proxy, "err", err)
+		}
+	}
+}
+
+// captureSnapshot saves a snapshot of the game's inputs to the store, replacing any previous snapshot.
+func captureSnapshot(ctx context.Context, store StateStore, game types.GameMetadata, j job, capturedAt time.Time) error {
+	snapshot := GameSnapshot{
+		Version:    snapshotVersion,
+		Game:       game,
+		Block:      j.block,
+		Status:     j.status,
+		CapturedAt: capturedAt,
+	}
+	if snapshotter, ok := j.player.(Snapshotter); ok {
+		state, err := snapshotter.CaptureSnapshot()
+		if err != nil {
+			return fmt.Errorf("failed to capture player state: %w", err)
+		}
+		snapshot.PlayerState = state
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := store.Save(ctx, game.Proxy, StateKeySnapshot, data); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	return nil
+}
+
+// replaySnapshot creates a new player from the encoded snapshot in a temporary directory and progresses it once.
+// No live scheduler state is read or modified and no transactions are sent. Players that implement ShadowPlayer
+// simulate the actions they would take, other players are progressed with actions suppressed.
+func replaySnapshot(ctx context.Context, createPlayer PlayerCreator, data []byte) (ResultSummary, error) {
+	var snapshot GameSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return ResultSummary{}, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	if snapshot.Version != snapshotVersion {
+		return ResultSummary{}, fmt.Errorf("%w: %v", ErrUnsupportedSnapshot, snapshot.Version)
+	}
+	dir, err := os.MkdirTemp("", "replay-"+snapshot.Game.Proxy.Hex())
+	if err != nil {
+		return ResultSummary{}, fmt.Errorf("failed to create replay directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	player, err := createPlayer(snapshot.Game, dir)
+	if err != nil {
+		return ResultSummary{}, fmt.Errorf("failed to create game player: %w", err)
+	}
+	if snapshot.PlayerState != nil {
+		snapshotter, ok := player.(Snapshotter)
+		if !ok {
+			return ResultSummary{}, errors.New("snapshot includes player state but player does not support restoring it")
+		}
+		if err := snapshotter.RestoreSnapshot(snapshot.PlayerState); err != nil {
+			return ResultSummary{}, fmt.Errorf("failed to restore player state: %w", err)
+		}
+	}
+	if err := enableShadowMode(player); err != nil {
+		ctx = types.WithActionsSuppressed(ctx)
+	}
+	j := runJob(ctx, *newJob(snapshot.Block, snapshot.Game.Proxy, player, snapshot.Status))
+	return j.summary(), nil
+}
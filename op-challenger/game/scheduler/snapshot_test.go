@@ -0,0 +1,174 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureAndReplaySnapshot(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LevelInfo)
+	disk := &tempDirDiskManager{dir: t.TempDir()}
+	gameAddr := common.Address{0xaa}
+	var created []*snapshotPlayer
+	var s *Scheduler
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		player := &snapshotPlayer{
+			StubGamePlayer: test.StubGamePlayer{Addr: game.Proxy, Dir: dir, ActionTakenValue: true},
+			state:          []byte("local state"),
+			lock:           &s.coordinator.lock,
+		}
+		created = append(created, player)
+		return player, nil
+	}
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0).UTC())
+	s = NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, WithSnapshotCapture(true), WithClock(cl))
+	workQueue := make(chan job, 1)
+	s.coordinator.jobQueue = workQueue
+
+	game := types.GameMetadata{GameType: 3, Timestamp: 50, Proxy: gameAddr}
+	require.NoError(t, s.coordinator.schedule(ctx, []types.GameMetadata{game}, 42))
+	require.Len(t, workQueue, 1)
+	require.False(t, created[0].capturedLocked, "should not capture snapshot while holding the lock")
+
+	snapshotPath := filepath.Join(disk.DirForGame(gameAddr), snapshotFileName)
+	data, err := os.ReadFile(snapshotPath)
+	require.NoError(t, err)
+	var snapshot GameSnapshot
+	require.NoError(t, json.Unmarshal(data, &snapshot))
+	require.Equal(t, GameSnapshot{
+		Version:     snapshotVersion,
+		Game:        game,
+		Block:       42,
+		Status:      types.GameStatusInProgress,
+		CapturedAt:  cl.Now(),
+		PlayerState: []byte("local state"),
+	}, snapshot)
+
+	result, err := s.ReplayFromSnapshot(ctx, snapshotPath)
+	require.NoError(t, err)
+	require.Equal(t, ResultSummary{
+		Game:   gameAddr,
+		Block:  42,
+		Status: types.GameStatusInProgress,
+		Acted:  true,
+	}, result)
+
+	require.Len(t, created, 2, "should create a new player for the replay")
+	live, replayed := created[0], created[1]
+	require.Equal(t, []byte("local state"), replayed.restored)
+	require.Equal(t, 1, replayed.ProgressCount)
+	require.NotEqual(t, live.Dir, replayed.Dir, "should not use live game directory")
+	require.NoDirExists(t, replayed.Dir, "should clean up replay directory")
+	require.Zero(t, live.ProgressCount, "should not progress live player")
+	require.True(t, s.coordinator.states[gameAddr].inflight, "should not modify live state")
+	require.True(t, replayed.ShadowMode, "should only simulate actions when replaying")
+	require.False(t, live.ShadowMode, "should not switch live player to shadow mode")
+}
+
+func TestReplayGameFromStateStore(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LevelInfo)
+	disk := &tempDirDiskManager{dir: t.TempDir()}
+	store := newMemStateStore()
+	gameAddr := common.Address{0xaa}
+	var created []*snapshotPlayer
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		player := &snapshotPlayer{
+			StubGamePlayer: test.StubGamePlayer{Addr: game.Proxy, Dir: dir},
+			state:          []byte("local state"),
+		}
+		created = append(created, player)
+		return player, nil
+	}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, WithSnapshotCapture(true), WithStateStore(store))
+	workQueue := make(chan job, 1)
+	s.coordinator.jobQueue = workQueue
+
+	require.NoError(t, s.coordinator.schedule(ctx, asGames(gameAddr), 42))
+	require.Len(t, workQueue, 1)
+	require.NoFileExists(t, filepath.Join(disk.DirForGame(gameAddr), snapshotFileName), "should not bypass state store")
+	data, err := store.Load(ctx, gameAddr, StateKeySnapshot)
+	require.NoError(t, err)
+	require.NotEmpty(t, data)
+
+	result, err := s.ReplayGame(ctx, gameAddr)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), result.Block)
+	require.Len(t, created, 2)
+	require.Equal(t, []byte("local state"), created[1].restored)
+	require.Equal(t, 1, created[1].ProgressCount)
+
+	_, err = s.ReplayGame(ctx, common.Address{0xbb})
+	require.ErrorIs(t, err, ErrStateNotFound)
+}
+
+func TestReplaySuppressesActionsWithoutShadowMode(t *testing.T) {
+	data, err := json.Marshal(GameSnapshot{Version: snapshotVersion, Game: types.GameMetadata{Proxy: common.Address{0xaa}}})
+	require.NoError(t, err)
+	player := &liveOnlyPlayer{}
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		return player, nil
+	}
+	_, err = replaySnapshot(context.Background(), createPlayer, data)
+	require.NoError(t, err)
+	require.Equal(t, 1, player.progressCount)
+	require.True(t, player.suppressed, "should not act when replaying a player that can't simulate actions")
+}
+
+func TestDoNotCaptureSnapshotByDefault(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	dir := t.TempDir()
+	c.disk = &tempDirDiskManager{dir: dir}
+	require.NoError(t, c.schedule(context.Background(), asGames(common.Address{0xaa}), 0))
+	require.Len(t, workQueue, 1)
+	require.NoFileExists(t, filepath.Join(c.disk.DirForGame(common.Address{0xaa}), snapshotFileName))
+}
+
+func TestReplayRejectsUnsupportedVersion(t *testing.T) {
+	data, err := json.Marshal(GameSnapshot{Version: snapshotVersion + 1})
+	require.NoError(t, err)
+	createPlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		t.Fatal("should not create player")
+		return nil, nil
+	}
+	_, err = replaySnapshot(context.Background(), createPlayer, data)
+	require.ErrorIs(t, err, ErrUnsupportedSnapshot)
+}
+
+type snapshotPlayer struct {
+	test.StubGamePlayer
+	state    []byte
+	restored []byte
+	// lock is the coordinator's lock, recorded in capturedLocked if held when the snapshot is captured.
+	lock           *sync.Mutex
+	capturedLocked bool
+}
+
+func (p *snapshotPlayer) CaptureSnapshot() ([]byte, error) {
+	if p.lock != nil && !p.lock.TryLock() {
+		p.capturedLocked = true
+	} else if p.lock != nil {
+		p.lock.Unlock()
+	}
+	return p.state, nil
+}
+
+func (p *snapshotPlayer) RestoreSnapshot(data []byte) error {
+	p.restored = data
+	return nil
+}
@@ -0,0 +1,254 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+)
+
+var (
+	ErrUnknownSource         = errors.New("unknown game source")
+	ErrInvalidSource         = errors.New("invalid game source")
+	ErrSourceDiskUnsupported = errors.New("disk manager does not support game sources")
+)
+
+// GameSource is an additional DisputeGameFactory, typically for another chain, whose games are progressed by the
+// scheduler alongside those of the primary factory, see RegisterSource.
+type GameSource struct {
+	// Factory is the address of the source's DisputeGameFactory. Games are identified by their factory and game
+	// address so games with the same address from different sources are progressed independently.
+	Factory common.Address
+	// Label identifies the source in logs and metrics, for example the name of its chain.
+	Label string
+	// CreatePlayer creates the players for the source's games, which use the source's own RPC endpoints.
+	CreatePlayer PlayerCreator
+}
+
+// SourceDiskManager is an optional interface a DiskManager can implement to support RegisterSource. The returned
+// DiskManager stores the data of the source's games separately from those of the primary factory and other
+// sources, so cleaning up the games of one source never removes those of another.
+type SourceDiskManager interface {
+	ForSource(factory common.Address) DiskManager
+}
+
+type SourceMetricer interface {
+	RecordSourceGamesStatus(source string, inProgress, defenderWon, challengerWon int)
+	RecordSourceGameUpdateCompleted(source string)
+	RecordSourceActedL1Block(source string, n uint64)
+	RecordSourceTrackedGames(source string, n int)
+	RecordSourceResolvedGamesRetained(source string, n int)
+	RecordSourceQuarantinedGames(source string, n int)
+	RecordSourceDiskUsage(source string, bytes uint64)
+	RecordSourceDiskInconsistencies(source string, orphaned, missing int)
+}
+
+// sourceMetrics reports the metrics of a source registered with RegisterSource. Counters are added to the totals
+// for all sources, while gauges that would overwrite those reported for the primary factory are reported with the
+// source's label instead.
+type sourceMetrics struct {
+	SchedulerMetricer
+	label string
+}
+
+func (m sourceMetrics) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {
+	m.RecordSourceGamesStatus(m.label, inProgress, defenderWon, challengerWon)
+}
+
+func (m sourceMetrics) RecordGameUpdateCompleted() {
+	m.SchedulerMetricer.RecordGameUpdateCompleted()
+	m.RecordSourceGameUpdateCompleted(m.label)
+}
+
+func (m sourceMetrics) RecordActedL1Block(n uint64) {
+	m.RecordSourceActedL1Block(m.label, n)
+}
+
+func (m sourceMetrics) RecordTrackedGames(n int) {
+	m.RecordSourceTrackedGames(m.label, n)
+}
+
+func (m sourceMetrics) RecordResolvedGamesRetained(n int) {
+	m.RecordSourceResolvedGamesRetained(m.label, n)
+}
+
+func (m sourceMetrics) RecordQuarantinedGames(n int) {
+	m.RecordSourceQuarantinedGames(m.label, n)
+}
+
+func (m sourceMetrics) RecordDiskUsage(bytes uint64) {
+	m.RecordSourceDiskUsage(m.label, bytes)
+}
+
+func (m sourceMetrics) RecordDiskInconsistencies(orphaned, missing int) {
+	m.RecordSourceDiskInconsistencies(m.label, orphaned, missing)
+}
+
+// RecordResultBackpressure is only reported by the primary factory. Every source shares its result queue so would
+// only report the same state again.
+func (sourceMetrics) RecordResultBackpressure(bool) {}
+
+// RegisterSource adds a source of games to be progressed by the scheduler alongside those of the primary factory,
+// so one challenger can serve several chains. The games of each source are scheduled with ScheduleSource and
+// tracked separately, with their own settings from the scheduler's options, but share its workers, job queue and
+// disk manager, which must implement SourceDiskManager. Sources must be registered before Start, and before
+// ImportFullState for the state of their games to be imported. ListGames, ForceSchedule, IgnoreGame, UnignoreGame,
+// AbandonedGames, ExportFullState and the checkpoint set by WithCheckpoint also cover the games of sources.
+func (s *Scheduler) RegisterSource(source GameSource) error {
+	if s.started.Load() {
+		return ErrAlreadyStarted
+	}
+	if source.Factory == (common.Address{}) || source.CreatePlayer == nil {
+		return fmt.Errorf("%w: factory and player creator are required", ErrInvalidSource)
+	}
+	if _, ok := s.sources[source.Factory]; ok {
+		return fmt.Errorf("%w: factory %v already registered", ErrInvalidSource, source.Factory)
+	}
+	sourceDisk, ok := s.baseDisk.(SourceDiskManager)
+	if !ok {
+		return ErrSourceDiskUnsupported
+	}
+	label := source.Label
+	if label == "" {
+		label = source.Factory.Hex()
+	}
+	base := sourceDisk.ForSource(source.Factory)
+	m := sourceMetrics{SchedulerMetricer: s.m, label: label}
+	c := newCoordinator(s.logger.New("source", label), m, s.jobQueue, s.resultQueue, source.CreatePlayer, newInstrumentedDisk(base, m, s.cfg.clock), s.coordinator.allowInvalidPrestate, s.cfg)
+	c.factory = source.Factory
+	if s.cfg.customStateStore {
+		c.store = sourceStateStore{StateStore: s.cfg.stateStore, factory: source.Factory}
+	} else {
+		c.store = newInstrumentedStateStore(NewDiskStateStore(c.disk, ""), m, s.cfg.clock)
+	}
+	// Share the state that spans the pipeline with the primary factory so jobs from every source are accounted for
+	// together, e.g. by WaitIdle, and can be told apart by workers.
+	c.idle = s.coordinator.idle
+	c.lastJobID = s.coordinator.lastJobID
+	c.canceller = s.coordinator.canceller
+	c.tracer = s.coordinator.tracer
+	c.upstream = s.coordinator.upstream
+	c.urgentQueue = s.coordinator.urgentQueue
+	c.results = s.coordinator.results
+	c.audit = s.coordinator.audit
+	c.events = s.coordinator.events
+	if recoverable, ok := base.(RecoverableDiskManager); ok {
+		c.recoverable = recoverable
+	}
+	if quota, ok := base.(QuotaDiskManager); ok {
+		c.quota = quota
+	}
+	if s.sources == nil {
+		s.sources = make(map[common.Address]*coordinator)
+	}
+	s.sources[source.Factory] = c
+	s.logger.Info("Registered game source", "factory", source.Factory, "label", label)
+	return nil
+}
+
+// ScheduleSource queues a batch of games from a source registered with RegisterSource to be progressed, like
+// Schedule. If the source's previous batch hasn't been accepted yet, the games are merged into it. Returns
+// ErrUnknownSource if the source isn't registered.
+func (s *Scheduler) ScheduleSource(factory common.Address, games []types.GameMetadata, blockNumber uint64) error {
+	c, ok := s.sources[factory]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrUnknownSource, factory)
+	}
+	if !s.started.Load() {
+		return ErrNotStarted
+	}
+	if c.jobLimitReached.Load() {
+		return ErrJobLimitReached
+	}
+	if err := s.checkBatchSize(len(games)); err != nil {
+		return err
+	}
+	if !s.beginSend() {
+		return ErrStopped
+	}
+	defer s.endSend()
+	batch := blockGames{blockNumber: blockNumber, games: games, factory: factory}
+	s.sourcesLock.Lock()
+	if pending, ok := s.pendingSources[factory]; ok {
+		s.pendingSources[factory] = coalesceBatches(pending, batch)
+		s.m.RecordBatchCoalesced()
+	} else {
+		s.coordinator.idle.Add(1)
+		s.pendingSources[factory] = batch
+	}
+	s.sourcesLock.Unlock()
+	s.m.RecordBatchSize(len(games))
+	select {
+	case s.sourcesReady <- struct{}{}:
+	default:
+		// The loop is already due to schedule the pending batches.
+	}
+	return nil
+}
+
+// takePendingSources returns the pending batch of each source, ordered by factory address, and clears them.
+func (s *Scheduler) takePendingSources() []blockGames {
+	s.sourcesLock.Lock()
+	defer s.sourcesLock.Unlock()
+	batches := make([]blockGames, 0, len(s.pendingSources))
+	for factory, batch := range s.pendingSources {
+		batches = append(batches, batch)
+		delete(s.pendingSources, factory)
+	}
+	slices.SortFunc(batches, func(a, b blockGames) int {
+		return compareAddresses(a.factory, b.factory)
+	})
+	return batches
+}
+
+func (s *Scheduler) handleSources(ctx context.Context) {
+	for _, batch := range s.takePendingSources() {
+		s.handleSchedule(ctx, batch)
+	}
+}
+
+// enqueueSourcesDeferred enqueues the jobs deferred by the coordinators other than processed, which already
+// enqueued its own when processing the result. The job queue is shared, so space freed by a job of one source may
+// be needed by the deferred jobs of another that has no jobs of its own in flight to trigger enqueuing them.
+func (s *Scheduler) enqueueSourcesDeferred(processed *coordinator) {
+	if len(s.sources) == 0 {
+		return
+	}
+	for _, c := range s.coordinators() {
+		if c == processed {
+			continue
+		}
+		c.lock.Lock()
+		c.enqueueDeferred()
+		c.lock.Unlock()
+	}
+}
+
+// coordinators returns the coordinator of the primary factory followed by those of the sources, ordered by factory
+// address.
+func (s *Scheduler) coordinators() []*coordinator {
+	coordinators := make([]*coordinator, 0, len(s.sources)+1)
+	for _, c := range s.sources {
+		coordinators = append(coordinators, c)
+	}
+	slices.SortFunc(coordinators, func(a, b *coordinator) int {
+		return compareAddresses(a.factory, b.factory)
+	})
+	return append([]*coordinator{s.coordinator}, coordinators...)
+}
+
+// coordinatorFor returns the coordinator tracking the games of the factory, which is the zero address for the
+// primary factory.
+func (s *Scheduler) coordinatorFor(factory common.Address) *coordinator {
+	if c, ok := s.sources[factory]; ok {
+		return c
+	}
+	if factory != (common.Address{}) {
+		s.logger.Warn("Job for unknown game source, using primary factory", "factory", factory)
+	}
+	return s.coordinator
+}
@@ -0,0 +1,242 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleGamesFromMultipleSources(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	factory := common.Address{0xfa}
+	disk := &sourceDiskManager{
+		trackingDiskManager: &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)},
+		sources:             map[common.Address]*trackingDiskManager{factory: {removeExceptCalls: make(chan []common.Address, 10)}},
+	}
+	primaryGames := &createdGames{t: t, created: make(map[common.Address]*test.StubGamePlayer)}
+	sourceGames := &createdGames{t: t, created: make(map[common.Address]*test.StubGamePlayer)}
+	m := &sourceGameMetrics{}
+	s := NewScheduler(logger, m, disk, 2, primaryGames.CreateGame, false)
+	require.ErrorIs(t, s.ScheduleSource(factory, nil, 0), ErrUnknownSource)
+	require.ErrorIs(t, s.RegisterSource(GameSource{Factory: factory}), ErrInvalidSource)
+	require.NoError(t, s.RegisterSource(GameSource{Factory: factory, Label: "other", CreatePlayer: sourceGames.CreateGame}))
+	require.ErrorIs(t, s.RegisterSource(GameSource{Factory: factory, CreatePlayer: sourceGames.CreateGame}), ErrInvalidSource)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+	require.ErrorIs(t, s.RegisterSource(GameSource{Factory: common.Address{0xfb}, CreatePlayer: sourceGames.CreateGame}), ErrAlreadyStarted)
+
+	// The same game address from each source is progressed independently
+	shared := common.Address{0xaa}
+	other := common.Address{0xbb}
+	require.NoError(t, s.Schedule(asGames(shared), 1))
+	require.NoError(t, s.ScheduleSource(factory, asGames(shared, other), 1))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Len(t, primaryGames.created, 1)
+	require.Equal(t, 1, primaryGames.created[shared].ProgressCount)
+	require.Len(t, sourceGames.created, 2)
+	require.Equal(t, 1, sourceGames.created[shared].ProgressCount)
+	require.Equal(t, 1, sourceGames.created[other].ProgressCount)
+
+	require.Equal(t, []common.Address{shared}, readWithTimeout(t, disk.removeExceptCalls))
+	require.ElementsMatch(t, []common.Address{shared, other}, readWithTimeout(t, disk.sources[factory].removeExceptCalls))
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	require.Equal(t, map[string]int{"other": 2}, m.inProgress)
+	require.Equal(t, map[string]int{"other": 2}, m.completed)
+	require.Equal(t, map[string]int{"other": 2}, m.tracked, "should report source's tracked games with its label")
+	require.Equal(t, 1, m.primaryTracked, "should not overwrite tracked games of primary factory")
+}
+
+func TestOperatorControlsApplyToSources(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	factory := common.Address{0xfa}
+	disk := &sourceDiskManager{
+		trackingDiskManager: &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)},
+		sources:             map[common.Address]*trackingDiskManager{factory: {removeExceptCalls: make(chan []common.Address, 10)}},
+	}
+	primaryGames := &createdGames{t: t, created: make(map[common.Address]*test.StubGamePlayer)}
+	sourceGames := &createdGames{t: t, created: make(map[common.Address]*test.StubGamePlayer)}
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, primaryGames.CreateGame, false, WithClock(cl))
+	require.NoError(t, s.RegisterSource(GameSource{Factory: factory, CreatePlayer: sourceGames.CreateGame}))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	shared := common.Address{0xaa}
+	other := common.Address{0xbb}
+	unseen := common.Address{0xcc}
+	require.NoError(t, s.Schedule(asGames(shared), 1))
+	require.NoError(t, s.ScheduleSource(factory, asGames(shared, other), 1))
+	require.NoError(t, s.WaitIdle(ctx))
+
+	games, err := s.ListGames(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []TrackedGame{
+		{Game: shared, Status: types.GameStatusInProgress, LastUpdated: cl.Now()},
+		{Game: shared, Factory: factory, Status: types.GameStatusInProgress, LastUpdated: cl.Now()},
+		{Game: other, Factory: factory, Status: types.GameStatusInProgress, LastUpdated: cl.Now()},
+	}, games)
+
+	// Games only tracked by a source can be forced
+	require.NoError(t, s.ForceSchedule(ctx, other))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Equal(t, 2, sourceGames.created[other].ProgressCount)
+	require.ErrorIs(t, s.ForceSchedule(ctx, unseen), ErrGameNotScheduled)
+
+	// Ignoring a game applies to every source
+	require.NoError(t, s.IgnoreGame(ctx, shared))
+	require.NoError(t, s.IgnoreGame(ctx, unseen))
+	require.NoError(t, s.Schedule(asGames(shared), 2))
+	require.NoError(t, s.ScheduleSource(factory, asGames(shared, other), 2))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Equal(t, 1, primaryGames.created[shared].ProgressCount)
+	require.Equal(t, 1, sourceGames.created[shared].ProgressCount)
+	require.Equal(t, 3, sourceGames.created[other].ProgressCount)
+
+	games, err = s.ListGames(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []TrackedGame{
+		{Game: shared, Status: types.GameStatusInProgress, LastUpdated: cl.Now(), Ignored: true},
+		{Game: unseen, Ignored: true},
+		{Game: shared, Factory: factory, Status: types.GameStatusInProgress, LastUpdated: cl.Now(), Ignored: true},
+		{Game: other, Factory: factory, Status: types.GameStatusInProgress, LastUpdated: cl.Now()},
+	}, games)
+
+	require.NoError(t, s.UnignoreGame(ctx, shared))
+	require.NoError(t, s.ScheduleSource(factory, asGames(shared, other), 3))
+	require.NoError(t, s.WaitIdle(ctx))
+	require.Equal(t, 2, sourceGames.created[shared].ProgressCount)
+}
+
+func TestSourceGamesIncludedInFullState(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	factory := common.Address{0xfa}
+	newDisk := func() *sourceDiskManager {
+		return &sourceDiskManager{
+			trackingDiskManager: &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)},
+			sources:             map[common.Address]*trackingDiskManager{factory: {removeExceptCalls: make(chan []common.Address, 10)}},
+		}
+	}
+	ok := common.Address{0xaa}
+	failing := common.Address{0xbb}
+	createSourcePlayer := func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		player := &test.StubGamePlayer{Addr: game.Proxy, StatusValue: types.GameStatusInProgress}
+		if game.Proxy == failing {
+			player.PrestateErr = errors.New("invalid prestate")
+		}
+		return player, nil
+	}
+	primaryGames := &createdGames{t: t, created: make(map[common.Address]*test.StubGamePlayer)}
+	newScheduler := func() *Scheduler {
+		s := NewScheduler(logger, metrics.NoopMetrics, newDisk(), 1, primaryGames.CreateGame, false, WithPlayerInitPolicy(PlayerInitAbandon, 0, 0))
+		require.NoError(t, s.RegisterSource(GameSource{Factory: factory, CreatePlayer: createSourcePlayer}))
+		return s
+	}
+	s := newScheduler()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	require.NoError(t, s.ScheduleSource(factory, asGames(ok, failing), 1))
+	require.NoError(t, s.WaitIdle(ctx))
+
+	abandoned := s.AbandonedGames()
+	require.Len(t, abandoned, 1, "should include abandoned games of sources")
+	require.Equal(t, failing, abandoned[0].Game)
+	require.Equal(t, factory, abandoned[0].Factory)
+
+	data, err := s.ExportFullState()
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+	var state fullState
+	require.NoError(t, json.Unmarshal(data, &state))
+	require.Empty(t, state.Games)
+	require.Len(t, state.Sources, 1)
+	require.Equal(t, factory, state.Sources[0].Factory)
+	require.Len(t, state.Sources[0].State.Games, 2, "should export games of sources")
+
+	fresh := newScheduler()
+	require.NoError(t, fresh.ImportFullState(data))
+	restored := fresh.AbandonedGames()
+	require.Len(t, restored, 1)
+	require.Equal(t, failing, restored[0].Game)
+	require.Equal(t, factory, restored[0].Factory)
+	require.Contains(t, fresh.sources[factory].states, ok, "should import games of sources")
+	require.Empty(t, fresh.coordinator.states)
+}
+
+func TestRegisterSourceRequiresSourceDisk(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	games := &createdGames{t: t, created: make(map[common.Address]*test.StubGamePlayer)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, games.CreateGame, false)
+	err := s.RegisterSource(GameSource{Factory: common.Address{0xfa}, CreatePlayer: games.CreateGame})
+	require.ErrorIs(t, err, ErrSourceDiskUnsupported)
+}
+
+type sourceDiskManager struct {
+	*trackingDiskManager
+	sources map[common.Address]*trackingDiskManager
+}
+
+func (d *sourceDiskManager) ForSource(factory common.Address) DiskManager {
+	return d.sources[factory]
+}
+
+type sourceGameMetrics struct {
+	metrics.NoopMetricsImpl
+	lock       sync.Mutex
+	inProgress map[string]int
+	completed  map[string]int
+	tracked    map[string]int
+
+	primaryTracked int
+}
+
+func (m *sourceGameMetrics) RecordSourceGamesStatus(source string, inProgress, _, _ int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.inProgress == nil {
+		m.inProgress = make(map[string]int)
+	}
+	m.inProgress[source] = inProgress
+}
+
+func (m *sourceGameMetrics) RecordSourceGameUpdateCompleted(source string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.completed == nil {
+		m.completed = make(map[string]int)
+	}
+	m.completed[source]++
+}
+
+func (m *sourceGameMetrics) RecordSourceTrackedGames(source string, n int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.tracked == nil {
+		m.tracked = make(map[string]int)
+	}
+	m.tracked[source] = n
+}
+
+func (m *sourceGameMetrics) RecordTrackedGames(n int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.primaryTracked = n
+}
@@ -0,0 +1,28 @@
+package scheduler
+
+import (
+	"hash/fnv"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// scheduleBucket returns the bucket the game belongs to when spreading scheduling over the given number of buckets.
+// Addresses are hashed so games are evenly distributed regardless of how their addresses were derived.
+func scheduleBucket(addr common.Address, buckets int) int {
+	h := fnv.New64a()
+	_, _ = h.Write(addr[:])
+	return int(h.Sum64() % uint64(buckets))
+}
+
+// inCurrentShard returns true if the game should be progressed in the current cycle, see WithScheduleSpreading.
+// The lock must be held.
+func (c *coordinator) inCurrentShard(addr common.Address) bool {
+	buckets := c.cfg.scheduleBuckets
+	if buckets <= 1 {
+		return true
+	}
+	if state, ok := c.states[addr]; ok && state.lastActed {
+		return true
+	}
+	return scheduleBucket(addr, buckets) == int(c.cycle%uint64(buckets))
+}
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleSpreading(t *testing.T) {
+	const buckets = 4
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 100)
+	c.cfg.scheduleBuckets = buckets
+	var addrs []common.Address
+	for i := 0; i < 40; i++ {
+		addrs = append(addrs, common.Address{byte(i + 1)})
+	}
+	games := asGames(addrs...)
+	ctx := context.Background()
+
+	scheduled := make(map[common.Address]int)
+	for i := 0; i < buckets; i++ {
+		require.NoError(t, c.schedule(ctx, games, uint64(i)))
+		require.Less(t, len(workQueue), len(games), "should only schedule a subset of games")
+		for len(workQueue) > 0 {
+			j := <-workQueue
+			scheduled[j.addr]++
+			require.NoError(t, c.processResult(j))
+		}
+	}
+	require.Len(t, scheduled, len(games), "should schedule every game within buckets cycles")
+	for addr, count := range scheduled {
+		require.Equalf(t, 1, count, "game %v scheduled more than once", addr)
+	}
+}
+
+func TestScheduleSpreadingActedGamesBypassShards(t *testing.T) {
+	const buckets = 4
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 100)
+	c.cfg.scheduleBuckets = buckets
+	// Find two games in different buckets
+	actedGame := common.Address{0x01}
+	otherGame := common.Address{0x02}
+	for scheduleBucket(otherGame, buckets) == scheduleBucket(actedGame, buckets) {
+		otherGame[0]++
+	}
+	games := asGames(actedGame, otherGame)
+	ctx := context.Background()
+
+	var actedCount, otherCount int
+	for i := 0; i < 2*buckets; i++ {
+		require.NoError(t, c.schedule(ctx, games, uint64(i)))
+		for len(workQueue) > 0 {
+			j := <-workQueue
+			j.status = types.GameStatusInProgress
+			if j.addr == actedGame {
+				actedCount++
+				j.acted = true
+			} else {
+				otherCount++
+			}
+			require.NoError(t, c.processResult(j))
+		}
+	}
+	// Once it has acted, the game is progressed every cycle
+	require.Greater(t, actedCount, buckets)
+	require.Equal(t, 2, otherCount)
+}
+
+func TestScheduleSpreadingDisabled(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 100)
+	c.cfg.scheduleBuckets = 1
+	require.NoError(t, c.schedule(context.Background(), asGames(common.Address{0x01}, common.Address{0x02}, common.Address{0x03}), 0))
+	require.Len(t, workQueue, 3)
+}
@@ -0,0 +1,141 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrStateNotFound   = errors.New("state not found")
+	ErrInvalidStateKey = errors.New("invalid state key")
+)
+
+const stateFilePrefix = "scheduler-state-"
+
+// SchedulerStateGame is the game that state belonging to the scheduler as a whole, rather than to any one game, is
+// stored for.
+var SchedulerStateGame = common.Address{}
+
+// The keys of the state persisted by the scheduler.
+const (
+	// StateKeyCheckpoint is the key of the checkpoint written by WithCheckpoint, stored for SchedulerStateGame. The
+	// checkpoint includes the pending games and the retry and failure history of every game.
+	StateKeyCheckpoint = "checkpoint"
+	// StateKeySnapshot is the key of each game's snapshot captured by WithSnapshotCapture.
+	StateKeySnapshot = "snapshot"
+)
+
+// StateStore persists durable scheduler state so that it survives restarts.
+// Each entry is identified by the game it belongs to and a key naming the type of state,
+// allowing different scheduler features to store independent records for the same game.
+// Implementations must be safe for concurrent use.
+type StateStore interface {
+	// Save stores data for the game and key, replacing any existing entry.
+	Save(ctx context.Context, game common.Address, key string, data []byte) error
+	// Load returns the data stored for the game and key or ErrStateNotFound if there is none.
+	Load(ctx context.Context, game common.Address, key string) ([]byte, error)
+	// Delete removes the entry for the game and key. Deleting an entry that doesn't exist is not an error.
+	Delete(ctx context.Context, game common.Address, key string) error
+}
+
+// diskStateStore is the default StateStore which stores state as files in each game's DiskManager directory.
+// State for a game is therefore removed along with the rest of the game's data once it is resolved.
+// The checkpoint is stored in the file at checkpointPath.
+type diskStateStore struct {
+	disk           DiskManager
+	checkpointPath string
+}
+
+var _ StateStore = (*diskStateStore)(nil)
+
+// NewDiskStateStore creates a StateStore storing the state of each game in its DiskManager directory and the
+// checkpoint in the file at checkpointPath, which may be empty if no checkpoint is kept.
+func NewDiskStateStore(disk DiskManager, checkpointPath string) StateStore {
+	return &diskStateStore{disk: disk, checkpointPath: checkpointPath}
+}
+
+func (d *diskStateStore) path(game common.Address, key string) (string, error) {
+	if key == "" || strings.ContainsAny(key, `/\`) || key == "." || key == ".." {
+		return "", fmt.Errorf("%w: %q", ErrInvalidStateKey, key)
+	}
+	if game == SchedulerStateGame {
+		if key != StateKeyCheckpoint || d.checkpointPath == "" {
+			return "", fmt.Errorf("%w: no file for scheduler state %q", ErrInvalidStateKey, key)
+		}
+		return d.checkpointPath, nil
+	}
+	return filepath.Join(d.disk.DirForGame(game), stateFilePrefix+key), nil
+}
+
+// sourceStateStore stores the state of the games of a source registered with RegisterSource in the store set by
+// WithStateStore, with keys prefixed by the source's factory so they are distinct from the games of other factories.
+type sourceStateStore struct {
+	StateStore
+	factory common.Address
+}
+
+func (s sourceStateStore) key(key string) string {
+	return s.factory.Hex() + "-" + key
+}
+
+func (s sourceStateStore) Save(ctx context.Context, game common.Address, key string, data []byte) error {
+	return s.StateStore.Save(ctx, game, s.key(key), data)
+}
+
+func (s sourceStateStore) Load(ctx context.Context, game common.Address, key string) ([]byte, error) {
+	return s.StateStore.Load(ctx, game, s.key(key))
+}
+
+func (s sourceStateStore) Delete(ctx context.Context, game common.Address, key string) error {
+	return s.StateStore.Delete(ctx, game, s.key(key))
+}
+
+func (d *diskStateStore) Save(_ context.Context, game common.Address, key string, data []byte) error {
+	path, err := d.path(game, key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create game directory: %w", err)
+	}
+	// Write to a temporary file and rename so a crash never leaves a partially written entry.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace state: %w", err)
+	}
+	return nil
+}
+
+func (d *diskStateStore) Load(_ context.Context, game common.Address, key string) ([]byte, error) {
+	path, err := d.path(game, key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrStateNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read state: %w", err)
+	}
+	return data, nil
+}
+
+func (d *diskStateStore) Delete(_ context.Context, game common.Address, key string) error {
+	path, err := d.path(game, key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package scheduler
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskStateStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := NewDiskStateStore(&tempDirDiskManager{dir: dir}, "")
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+
+	t.Run("LoadMissing", func(t *testing.T) {
+		_, err := store.Load(ctx, game1, "missing")
+		require.ErrorIs(t, err, ErrStateNotFound)
+	})
+
+	t.Run("SaveAndLoad", func(t *testing.T) {
+		require.NoError(t, store.Save(ctx, game1, "checkpoint", []byte("one")))
+		require.NoError(t, store.Save(ctx, game2, "checkpoint", []byte("two")))
+		data, err := store.Load(ctx, game1, "checkpoint")
+		require.NoError(t, err)
+		require.Equal(t, []byte("one"), data)
+		data, err = store.Load(ctx, game2, "checkpoint")
+		require.NoError(t, err)
+		require.Equal(t, []byte("two"), data)
+	})
+
+	t.Run("Replace", func(t *testing.T) {
+		require.NoError(t, store.Save(ctx, game1, "replace", []byte("first")))
+		require.NoError(t, store.Save(ctx, game1, "replace", []byte("second")))
+		data, err := store.Load(ctx, game1, "replace")
+		require.NoError(t, err)
+		require.Equal(t, []byte("second"), data)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, store.Save(ctx, game1, "delete", []byte("data")))
+		require.NoError(t, store.Delete(ctx, game1, "delete"))
+		_, err := store.Load(ctx, game1, "delete")
+		require.ErrorIs(t, err, ErrStateNotFound)
+		require.NoError(t, store.Delete(ctx, game1, "delete"), "should not error when deleting missing state")
+	})
+
+	t.Run("InvalidKey", func(t *testing.T) {
+		for _, key := range []string{"", ".", "..", "a/b", `a\b`} {
+			require.ErrorIs(t, store.Save(ctx, game1, key, []byte("data")), ErrInvalidStateKey)
+			_, err := store.Load(ctx, game1, key)
+			require.ErrorIs(t, err, ErrInvalidStateKey)
+			require.ErrorIs(t, store.Delete(ctx, game1, key), ErrInvalidStateKey)
+		}
+	})
+
+	t.Run("Checkpoint", func(t *testing.T) {
+		require.ErrorIs(t, store.Save(ctx, SchedulerStateGame, StateKeyCheckpoint, []byte("data")), ErrInvalidStateKey, "should reject checkpoint without a path")
+
+		path := filepath.Join(dir, "checkpoint", "state.json")
+		store := NewDiskStateStore(&tempDirDiskManager{dir: dir}, path)
+		require.NoError(t, store.Save(ctx, SchedulerStateGame, StateKeyCheckpoint, []byte("checkpoint")))
+		require.FileExists(t, path)
+		data, err := store.Load(ctx, SchedulerStateGame, StateKeyCheckpoint)
+		require.NoError(t, err)
+		require.Equal(t, []byte("checkpoint"), data)
+		require.ErrorIs(t, store.Save(ctx, SchedulerStateGame, "other", []byte("data")), ErrInvalidStateKey)
+	})
+}
+
+// memStateStore is a StateStore that keeps state in memory.
+type memStateStore struct {
+	lock  sync.Mutex
+	state map[string][]byte
+}
+
+func newMemStateStore() *memStateStore {
+	return &memStateStore{state: make(map[string][]byte)}
+}
+
+func (m *memStateStore) Save(_ context.Context, game common.Address, key string, data []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.state[game.Hex()+"/"+key] = slices.Clone(data)
+	return nil
+}
+
+func (m *memStateStore) Load(_ context.Context, game common.Address, key string) ([]byte, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	data, ok := m.state[game.Hex()+"/"+key]
+	if !ok {
+		return nil, ErrStateNotFound
+	}
+	return slices.Clone(data), nil
+}
+
+func (m *memStateStore) Delete(_ context.Context, game common.Address, key string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.state, game.Hex()+"/"+key)
+	return nil
+}
+
+// tempDirDiskManager is a DiskManager that stores game data in real directories under dir.
+type tempDirDiskManager struct {
+	dir string
+}
+
+func (d *tempDirDiskManager) DirForGame(addr common.Address) string {
+	return filepath.Join(d.dir, addr.Hex())
+}
+
+func (d *tempDirDiskManager) RemoveAllExcept(_ []common.Address) error {
+	return nil
+}
@@ -13,6 +13,18 @@ type StubGamePlayer struct {
 	StatusValue   types.GameStatus
 	Dir           string
 	PrestateErr   error
+
+	// ActionTakenValue is reported as whether the last progression took action
+	ActionTakenValue bool
+
+	// FollowUps is the number of remaining follow up passes the player will request
+	FollowUps int
+
+	// ProgressErr is reported as the error from the last progression
+	ProgressErr error
+
+	// ShadowMode is set when the scheduler switches the player to shadow mode
+	ShadowMode bool
 }
 
 func (g *StubGamePlayer) ValidatePrestate(_ context.Context) error {
@@ -27,3 +39,24 @@ func (g *StubGamePlayer) ProgressGame(_ context.Context) types.GameStatus {
 func (g *StubGamePlayer) Status() types.GameStatus {
 	return g.StatusValue
 }
+
+func (g *StubGamePlayer) FollowUpRequested() bool {
+	if g.FollowUps <= 0 {
+		return false
+	}
+	g.FollowUps--
+	return true
+}
+
+func (g *StubGamePlayer) ActionTaken() bool {
+	return g.ActionTakenValue
+}
+
+func (g *StubGamePlayer) ProgressError() error {
+	return g.ProgressErr
+}
+
+func (g *StubGamePlayer) EnableShadowMode() error {
+	g.ShadowMode = true
+	return nil
+}
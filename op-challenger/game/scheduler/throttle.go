@@ -0,0 +1,101 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/exp/slog"
+)
+
+const defaultErrorLogWindow = time.Minute
+
+type throttleKey struct {
+	category string
+	msg      string
+}
+
+type throttleEntry struct {
+	level       slog.Level
+	msg         string
+	windowStart time.Time
+	suppressed  int
+}
+
+// errorThrottle collapses repeated identical errors into a single log line so that an outage affecting many
+// games doesn't flood the logs. Errors are identical if they have the same category and error message.
+// The first occurrence is logged immediately and later occurrences within the window are counted.
+// Once the window has passed, the next occurrence is logged with the number of occurrences suppressed, or if the
+// error has stopped recurring, Flush logs a summary of the suppressed occurrences.
+// A window of 0 disables throttling. All methods are safe for concurrent use.
+type errorThrottle struct {
+	logger log.Logger
+	clock  clock.Clock
+	window time.Duration
+
+	lock    sync.Mutex
+	entries map[throttleKey]*throttleEntry
+}
+
+func newErrorThrottle(logger log.Logger, cl clock.Clock, window time.Duration) *errorThrottle {
+	return &errorThrottle{
+		logger:  logger,
+		clock:   cl,
+		window:  window,
+		entries: make(map[throttleKey]*throttleEntry),
+	}
+}
+
+// Log logs msg at lvl with err and the supplied context unless an identical error has already been logged
+// within the current window.
+func (t *errorThrottle) Log(lvl slog.Level, category string, msg string, err error, ctx ...any) {
+	ctx = append(ctx, "err", err)
+	if t.window <= 0 {
+		t.logger.Log(lvl, msg, ctx...)
+		return
+	}
+	key := throttleKey{category: category, msg: err.Error()}
+	now := t.clock.Now()
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	entry, ok := t.entries[key]
+	if ok && now.Before(entry.windowStart.Add(t.window)) {
+		entry.suppressed++
+		return
+	}
+	if ok && entry.suppressed > 0 {
+		ctx = append(ctx, "suppressed", entry.suppressed)
+	}
+	t.logger.Log(lvl, msg, ctx...)
+	t.entries[key] = &throttleEntry{level: lvl, msg: msg, windowStart: now}
+}
+
+// LogAll logs each error joined in err separately, so that unrelated errors reported together are throttled
+// independently.
+func (t *errorThrottle) LogAll(lvl slog.Level, category string, msg string, err error, ctx ...any) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			t.LogAll(lvl, category, msg, e, ctx...)
+		}
+		return
+	}
+	t.Log(lvl, category, msg, err, ctx...)
+}
+
+// Flush forgets errors whose window has passed without them recurring, logging a summary for any that had
+// occurrences suppressed.
+func (t *errorThrottle) Flush() {
+	now := t.clock.Now()
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for key, entry := range t.entries {
+		if now.Before(entry.windowStart.Add(t.window)) {
+			continue
+		}
+		if entry.suppressed > 0 {
+			t.logger.Log(entry.level, entry.msg, "err", key.msg, "suppressed", entry.suppressed, "stopped", true)
+		}
+		delete(t.entries, key)
+	}
+}
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorThrottle(t *testing.T) {
+	msgFilter := testlog.NewMessageFilter("Failed")
+
+	t.Run("CollapseIdenticalErrors", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		throttle := newErrorThrottle(logger, cl, time.Minute)
+		for i := 0; i < 10; i++ {
+			throttle.Log(log.LevelError, "schedule", "Failed", errors.New("boom"))
+			cl.AdvanceTime(time.Second)
+		}
+		require.Len(t, logs.FindLogs(msgFilter), 1)
+
+		// Summary is only logged once the window has passed
+		throttle.Flush()
+		require.Len(t, logs.FindLogs(msgFilter), 1)
+
+		cl.AdvanceTime(time.Minute)
+		throttle.Flush()
+		records := logs.FindLogs(msgFilter)
+		require.Len(t, records, 2)
+		require.Equal(t, int64(9), records[1].AttrValue("suppressed"))
+		require.Equal(t, "boom", records[1].AttrValue("err"))
+
+		// Nothing further to flush and the next occurrence is logged immediately
+		throttle.Flush()
+		require.Len(t, logs.FindLogs(msgFilter), 2)
+		throttle.Log(log.LevelError, "schedule", "Failed", errors.New("boom"))
+		require.Len(t, logs.FindLogs(msgFilter), 3)
+	})
+
+	t.Run("LogCountWhenWindowExpires", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		throttle := newErrorThrottle(logger, cl, time.Minute)
+		for i := 0; i < 4; i++ {
+			throttle.Log(log.LevelError, "schedule", "Failed", errors.New("boom"))
+		}
+		cl.AdvanceTime(time.Minute)
+		throttle.Log(log.LevelError, "schedule", "Failed", errors.New("boom"))
+		records := logs.FindLogs(msgFilter)
+		require.Len(t, records, 2)
+		require.Nil(t, records[0].AttrValue("suppressed"))
+		require.Equal(t, int64(3), records[1].AttrValue("suppressed"))
+	})
+
+	t.Run("DistinctErrorsNotSuppressed", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		throttle := newErrorThrottle(logger, cl, time.Minute)
+		throttle.Log(log.LevelError, "schedule", "Failed", errors.New("boom"))
+		throttle.Log(log.LevelError, "schedule", "Failed", errors.New("bang"))
+		throttle.Log(log.LevelError, "result", "Failed", errors.New("boom"))
+		require.Len(t, logs.FindLogs(msgFilter), 3)
+	})
+
+	t.Run("JoinedErrorsThrottledSeparately", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		throttle := newErrorThrottle(logger, cl, time.Minute)
+		throttle.LogAll(log.LevelError, "schedule", "Failed", errors.Join(errors.New("boom"), errors.New("bang")))
+		throttle.LogAll(log.LevelError, "schedule", "Failed", errors.Join(errors.New("boom"), errors.New("crash")))
+		records := logs.FindLogs(msgFilter)
+		require.Len(t, records, 3)
+		require.Equal(t, "crash", records[2].AttrValue("err").(error).Error())
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		throttle := newErrorThrottle(logger, cl, 0)
+		for i := 0; i < 5; i++ {
+			throttle.Log(log.LevelError, "schedule", "Failed", errors.New("boom"))
+		}
+		require.Len(t, logs.FindLogs(msgFilter), 5)
+	})
+}
@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrInvalidTimeout = errors.New("invalid timeout")
+
+type JobTimeoutMetricer interface {
+	RecordGameUpdateTimedOut()
+	RecordJobDuration(d time.Duration)
+}
+
+// ScheduledGame is a game to schedule with ScheduleGames along with settings that apply only to that game.
+type ScheduledGame struct {
+	types.GameMetadata
+	// Timeout limits how long each progression of the game may run before its context is cancelled.
+	// Zero means the progression isn't limited.
+	Timeout time.Duration
+}
+
+// ScheduleGames schedules a batch of games like Schedule, applying the settings specified for each game.
+// Settings apply until the next batch is scheduled. Returns ErrInvalidTimeout, without scheduling any games, if
+// any timeout is negative.
+func (s *Scheduler) ScheduleGames(games []ScheduledGame, blockNumber uint64) error {
+	batch := blockGames{blockNumber: blockNumber, games: make([]types.GameMetadata, 0, len(games))}
+	for _, game := range games {
+		if game.Timeout < 0 {
+			return fmt.Errorf("%w for game %v: %v", ErrInvalidTimeout, game.Proxy, game.Timeout)
+		}
+		if game.Timeout > 0 {
+			if batch.timeouts == nil {
+				batch.timeouts = make(map[common.Address]time.Duration)
+			}
+			batch.timeouts[game.Proxy] = game.Timeout
+		}
+		batch.games = append(batch.games, game.GameMetadata)
+	}
+	return s.enqueueBatch(batch)
+}
+
+// setTimeouts replaces the per-game timeouts with those of the batch about to be scheduled.
+func (c *coordinator) setTimeouts(timeouts map[common.Address]time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.timeouts = timeouts
+}
+
+// recordDuration records how long the worker took to progress the job, and whether it timed out.
+// The lock must be held.
+func (c *coordinator) recordDuration(j job) {
+	if j.startedAt.IsZero() {
+		return
+	}
+	c.m.RecordJobDuration(j.duration)
+	if j.timedOut && !j.cancelled {
+		c.m.RecordGameUpdateTimedOut()
+		c.logger.Warn("Game update timed out", "game", j.addr, "timeout", j.timeout, "duration", j.duration)
+		c.tracer.Log(j.addr, "Game update timed out", "timeout", j.timeout, "duration", j.duration)
+	}
+}
+
+// retryTimedOut progresses the game again straight away if its job timed out, unless the job was itself a retry
+// after a timeout, so a progression interrupted by a transient hang isn't delayed until the next cycle while a
+// game that hangs every time doesn't monopolise a worker. The lock must be held.
+func (c *coordinator) retryTimedOut(j job, state *gameState) {
+	if !j.timedOut || j.cancelled || j.retriedTimeout || state.inflight || state.filtered || c.ignored[j.addr] || state.status != types.GameStatusInProgress || c.jobLimitReached.Load() {
+		return
+	}
+	if _, abandoned := c.abandoned[j.addr]; abandoned {
+		return
+	}
+	retry := c.newForcedJob(j.addr, state)
+	retry.retriedTimeout = true
+	c.logger.Info("Retrying game after update timed out", "game", j.addr)
+	c.deferred = append(c.deferred, *retry)
+	c.enqueueDeferred()
+}
@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleGamesWithTimeouts(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	var lock sync.Mutex
+	progressions := make(map[common.Address]deadlineProgression)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &deadlinePlayer{
+			StubGamePlayer: test.StubGamePlayer{StatusValue: types.GameStatusInProgress},
+			record: func(p deadlineProgression) {
+				lock.Lock()
+				defer lock.Unlock()
+				progressions[g.Proxy] = p
+			},
+		}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 3, createPlayer, false)
+	// Start without a deadline so only the game timeouts apply
+	s.Start(context.Background())
+	defer s.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	shortGame := common.Address{0xaa}
+	longGame := common.Address{0xbb}
+	unlimitedGame := common.Address{0xcc}
+	games := []ScheduledGame{
+		{GameMetadata: types.GameMetadata{Proxy: shortGame}, Timeout: 50 * time.Millisecond},
+		{GameMetadata: types.GameMetadata{Proxy: longGame}, Timeout: 250 * time.Millisecond},
+		{GameMetadata: types.GameMetadata{Proxy: unlimitedGame}},
+	}
+	require.NoError(t, s.ScheduleGames(games, 0))
+	require.NoError(t, s.WaitIdle(ctx))
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Len(t, progressions, 3)
+	for _, game := range games[:2] {
+		p := progressions[game.Proxy]
+		require.True(t, p.hasDeadline, "game %v should have a deadline", game.Proxy)
+		require.ErrorIs(t, p.err, context.DeadlineExceeded, "game %v should be cancelled at its deadline", game.Proxy)
+		require.LessOrEqual(t, p.deadline.Sub(p.started), game.Timeout)
+		require.Greater(t, p.deadline.Sub(p.started), game.Timeout-50*time.Millisecond)
+		require.GreaterOrEqual(t, p.cancelled.Sub(p.started), game.Timeout)
+	}
+	require.Less(t, progressions[shortGame].cancelled, progressions[longGame].cancelled)
+	require.False(t, progressions[unlimitedGame].hasDeadline, "game without a timeout should not have a deadline")
+	require.NoError(t, progressions[unlimitedGame].err)
+}
+
+func TestScheduleGamesRejectsNegativeTimeout(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false)
+
+	err := s.ScheduleGames([]ScheduledGame{
+		{GameMetadata: types.GameMetadata{Proxy: common.Address{0xaa}}, Timeout: time.Second},
+		{GameMetadata: types.GameMetadata{Proxy: common.Address{0xbb}}, Timeout: -time.Second},
+	}, 0)
+	require.ErrorIs(t, err, ErrInvalidTimeout)
+	require.Empty(t, s.scheduleQueue, "should not schedule any games")
+}
+
+type deadlineProgression struct {
+	started     time.Time
+	deadline    time.Time
+	hasDeadline bool
+	cancelled   time.Time
+	err         error
+}
+
+// deadlinePlayer waits for the context to be cancelled if it has a deadline and records when that happened.
+type deadlinePlayer struct {
+	test.StubGamePlayer
+	record func(p deadlineProgression)
+}
+
+func (p *deadlinePlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	progression := deadlineProgression{started: time.Now()}
+	progression.deadline, progression.hasDeadline = ctx.Deadline()
+	if progression.hasDeadline {
+		<-ctx.Done()
+		progression.cancelled = time.Now()
+	}
+	progression.err = ctx.Err()
+	p.record(progression)
+	return p.StubGamePlayer.ProgressGame(ctx)
+}
+
+func TestJobTimeout(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	var progressions atomic.Int32
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &hangingPlayer{StubGamePlayer: test.StubGamePlayer{StatusValue: types.GameStatusInProgress}, progressions: &progressions}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	m := &jobTimeoutMetrics{}
+	s := NewScheduler(logger, m, disk, 1, createPlayer, false, WithJobTimeout(20*time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	require.NoError(t, s.Schedule(asGames(common.Address{0xaa}), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	// The game is retried once straight away after timing out
+	require.Equal(t, int32(2), progressions.Load())
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	require.Equal(t, 2, m.timedOut)
+	require.Len(t, m.durations, 2)
+	for _, d := range m.durations {
+		require.GreaterOrEqual(t, d, 20*time.Millisecond)
+	}
+	require.Equal(t, 20*time.Millisecond, s.EffectiveConfig().JobTimeout)
+}
+
+func TestRecordJobDuration(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	m := c.m.(*stubSchedulerMetrics)
+	addr := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(addr), 0))
+	j := runJob(ctx, <-workQueue)
+	j.startedAt = time.Unix(1000, 0)
+	j.duration = 5 * time.Second
+	require.NoError(t, c.processResult(j))
+	require.Equal(t, []time.Duration{5 * time.Second}, m.jobDurations)
+	require.Zero(t, m.timedOut)
+
+	// Jobs cancelled because their game was excluded aren't reported as timed out or retried
+	require.NoError(t, c.schedule(ctx, asGames(addr), 1))
+	j = runJob(ctx, <-workQueue)
+	j.startedAt = time.Unix(1000, 0)
+	j.timedOut = true
+	j.cancelled = true
+	require.NoError(t, c.processResult(j))
+	require.Zero(t, m.timedOut)
+	require.Empty(t, workQueue)
+	require.True(t, c.idle.IsIdle())
+}
+
+type hangingPlayer struct {
+	test.StubGamePlayer
+	progressions *atomic.Int32
+}
+
+func (p *hangingPlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	p.progressions.Add(1)
+	<-ctx.Done()
+	return p.StatusValue
+}
+
+type jobTimeoutMetrics struct {
+	metrics.NoopMetricsImpl
+	lock      sync.Mutex
+	timedOut  int
+	durations []time.Duration
+}
+
+func (m *jobTimeoutMetrics) RecordGameUpdateTimedOut() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.timedOut++
+}
+
+func (m *jobTimeoutMetrics) RecordJobDuration(d time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.durations = append(m.durations, d)
+}
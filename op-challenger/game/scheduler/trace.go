@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrTooManyTracedGames = errors.New("too many traced games")
+
+// defaultMaxTracedGames limits how many games can be traced at once to avoid flooding the logs.
+const defaultMaxTracedGames = 10
+
+// gameTracer emits detailed logs for each step of the scheduling pipeline, but only for explicitly
+// traced games. Trace logs are emitted at info level so they are visible without lowering the log
+// level for every game. Safe for concurrent use.
+type gameTracer struct {
+	logger log.Logger
+	max    int
+	lock   sync.RWMutex
+	games  map[common.Address]struct{}
+}
+
+func newGameTracer(logger log.Logger, max int) *gameTracer {
+	return &gameTracer{
+		logger: logger,
+		max:    max,
+		games:  make(map[common.Address]struct{}),
+	}
+}
+
+// SetEnabled enables or disables tracing for the specified game.
+// Returns ErrTooManyTracedGames if enabling tracing would exceed the maximum number of traced games.
+func (t *gameTracer) SetEnabled(addr common.Address, enable bool) error {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if !enable {
+		delete(t.games, addr)
+		return nil
+	}
+	if _, ok := t.games[addr]; ok {
+		return nil
+	}
+	if len(t.games) >= t.max {
+		return fmt.Errorf("%w: limit is %v", ErrTooManyTracedGames, t.max)
+	}
+	t.games[addr] = struct{}{}
+	return nil
+}
+
+func (t *gameTracer) Enabled(addr common.Address) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	_, ok := t.games[addr]
+	return ok
+}
+
+// Games returns the games with tracing enabled, ordered by address.
+func (t *gameTracer) Games() []common.Address {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	games := make([]common.Address, 0, len(t.games))
+	for addr := range t.games {
+		games = append(games, addr)
+	}
+	slices.SortFunc(games, func(a, b common.Address) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	return games
+}
+
+// Log emits a trace log for the game if tracing is enabled for it.
+func (t *gameTracer) Log(addr common.Address, msg string, ctx ...any) {
+	if !t.Enabled(addr) {
+		return
+	}
+	t.logger.Info("Trace: "+msg, append([]any{"game", addr, "trace", true}, ctx...)...)
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameTracerLimit(t *testing.T) {
+	tracer := newGameTracer(testlog.Logger(t, log.LevelInfo), 2)
+	game1 := common.Address{0xaa}
+	game2 := common.Address{0xbb}
+	game3 := common.Address{0xcc}
+
+	require.NoError(t, tracer.SetEnabled(game1, true))
+	require.NoError(t, tracer.SetEnabled(game2, true))
+	require.NoError(t, tracer.SetEnabled(game2, true), "should allow re-enabling traced game")
+	require.ErrorIs(t, tracer.SetEnabled(game3, true), ErrTooManyTracedGames)
+	require.False(t, tracer.Enabled(game3))
+
+	require.NoError(t, tracer.SetEnabled(game1, false))
+	require.False(t, tracer.Enabled(game1))
+	require.NoError(t, tracer.SetEnabled(game3, true), "should allow tracing once under limit")
+	require.True(t, tracer.Enabled(game2))
+	require.True(t, tracer.Enabled(game3))
+}
+
+func TestTraceOnlyEnabledGames(t *testing.T) {
+	c, workQueue, _, _, _, logs := setupCoordinatorTest(t, 10)
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	ctx := context.Background()
+	require.NoError(t, c.tracer.SetEnabled(gameAddr1, true))
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2), 0))
+	require.NoError(t, c.processResult(<-workQueue))
+	require.NoError(t, c.processResult(<-workQueue))
+
+	traceFilter := testlog.NewAttributesFilter("trace", "true")
+	game1Logs := logs.FindLogs(traceFilter, testlog.NewAttributesFilter("game", gameAddr1.String()))
+	var messages []string
+	for _, l := range game1Logs {
+		require.Equal(t, log.LevelInfo, l.Level)
+		messages = append(messages, l.Message)
+	}
+	require.Contains(t, messages, "Trace: Creating job")
+	require.Contains(t, messages, "Trace: Enqueued job")
+	require.Contains(t, messages, "Trace: Processing result")
+
+	require.Empty(t, logs.FindLogs(traceFilter, testlog.NewAttributesFilter("game", gameAddr2.String())), "should not trace game 2")
+}
@@ -0,0 +1,44 @@
+package scheduler
+
+import (
+	"bytes"
+	"cmp"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type TrackedGamesMetricer interface {
+	RecordTrackedGames(n int)
+}
+
+// limitTrackedGames evicts the states of the least recently scheduled games until no more than the limit set by
+// WithMaxTrackedGames are tracked, then records the number of tracked games. Games with a job in flight or queued
+// are never evicted so the limit may be exceeded until their results are processed. The lock must be held.
+func (c *coordinator) limitTrackedGames() {
+	defer func() {
+		c.m.RecordTrackedGames(len(c.states))
+	}()
+	limit := c.cfg.maxTrackedGames
+	if limit == 0 || len(c.states) <= limit {
+		return
+	}
+	candidates := make([]common.Address, 0, len(c.states))
+	for addr, state := range c.states {
+		if state.pendingJobID == 0 {
+			candidates = append(candidates, addr)
+		}
+	}
+	slices.SortFunc(candidates, func(a, b common.Address) int {
+		if r := cmp.Compare(c.states[a].lastSeenCycle, c.states[b].lastSeenCycle); r != 0 {
+			return r
+		}
+		return bytes.Compare(a[:], b[:])
+	})
+	evict := min(len(c.states)-limit, len(candidates))
+	for _, addr := range candidates[:evict] {
+		c.tracer.Log(addr, "Evicting game state to limit tracked games", "cycle", c.cycle)
+		c.forgetGame(addr)
+	}
+	c.logger.Warn("Too many tracked games, evicted least recently scheduled", "evicted", evict, "tracked", len(c.states), "limit", limit)
+}
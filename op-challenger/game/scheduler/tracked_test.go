@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitTrackedGames(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 100)
+	c.cfg.maxTrackedGames = 5
+	m := c.m.(*stubSchedulerMetrics)
+	ctx := context.Background()
+	active := []common.Address{{0xaa}, {0xbb}, {0xcc}}
+	// Games other than the active ones are resolved so their state isn't removed when they stop being scheduled.
+	c.createPlayer = func(game types.GameMetadata, dir string) (GamePlayer, error) {
+		status := types.GameStatusDefenderWon
+		if slices.Contains(active, game.Proxy) {
+			status = types.GameStatusInProgress
+		}
+		return &test.StubGamePlayer{Addr: game.Proxy, StatusValue: status, Dir: dir}, nil
+	}
+
+	require.NoError(t, c.schedule(ctx, asGames(active...), 0))
+	require.Len(t, workQueue, len(active))
+
+	// Flood with new games while the active games have jobs in flight
+	for i := 0; i < 20; i++ {
+		games := asGames(active...)
+		for j := 0; j < 10; j++ {
+			games = append(games, types.GameMetadata{Proxy: common.Address{0x01, byte(i), byte(j)}})
+		}
+		require.NoError(t, c.schedule(ctx, games, uint64(i+1)))
+		require.LessOrEqual(t, len(c.states), 5)
+		require.Equal(t, len(c.states), m.tracked)
+		for _, addr := range active {
+			require.Contains(t, c.states, addr, "should not evict in flight game")
+		}
+	}
+
+	// Active games continue to be progressed
+	for i := 0; i < len(active); i++ {
+		require.NoError(t, c.processResult(<-workQueue))
+	}
+	require.NoError(t, c.schedule(ctx, asGames(active...), 21))
+	require.Len(t, workQueue, len(active))
+}
+
+func TestLimitTrackedGamesNeverEvictsPendingJobs(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 100)
+	c.cfg.maxTrackedGames = 2
+	m := c.m.(*stubSchedulerMetrics)
+	ctx := context.Background()
+	games := []common.Address{{0xaa}, {0xbb}, {0xcc}, {0xdd}}
+
+	require.NoError(t, c.schedule(ctx, asGames(games...), 0))
+	require.Len(t, workQueue, len(games))
+	require.Len(t, c.states, len(games), "should exceed limit rather than evict games with pending jobs")
+	require.Equal(t, len(games), m.tracked)
+
+	// Once results are processed the least recently scheduled games are evicted
+	for i := 0; i < len(games); i++ {
+		require.NoError(t, c.processResult(<-workQueue))
+	}
+	require.NoError(t, c.schedule(ctx, asGames(games[2:]...), 1))
+	require.Len(t, c.states, 2)
+	require.Contains(t, c.states, games[2])
+	require.Contains(t, c.states, games[3])
+}
+
+func TestEvictedGamesForgetPolicyState(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 100)
+	c.cfg.maxTrackedGames = 1
+	ctx := context.Background()
+	games := []common.Address{{0xaa}, {0xbb}}
+
+	require.NoError(t, c.schedule(ctx, asGames(games...), 0))
+	for i := 0; i < len(games); i++ {
+		j := <-workQueue
+		j.acted = true
+		j.deadline = c.cfg.clock.Now().Add(time.Hour)
+		require.NoError(t, c.processResult(j))
+	}
+	c.failures.recordFailure(games[0], c.cfg.clock.Now())
+	WithActionCooldown(time.Minute)(&c.cfg)
+	c.cooldown.recordResult(job{addr: games[0], acted: true}, OutcomeSuccess, 0, c.cfg.clock.Now())
+	require.Contains(t, c.priorities, games[0])
+
+	require.NoError(t, c.schedule(ctx, asGames(games[1]), 1))
+	require.NotContains(t, c.states, games[0])
+	require.NotContains(t, c.priorities, games[0])
+	require.NotContains(t, c.cooldown.until, games[0])
+	require.NotContains(t, c.failures.games, games[0])
+}
+
+func TestTrackedGamesUnlimitedByDefault(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 100)
+	m := c.m.(*stubSchedulerMetrics)
+	var games []common.Address
+	for i := 0; i < 50; i++ {
+		games = append(games, common.Address{byte(i + 1)})
+	}
+	require.NoError(t, c.schedule(context.Background(), asGames(games...), 0))
+	require.Len(t, workQueue, len(games))
+	require.Len(t, c.states, len(games))
+	require.Equal(t, len(games), m.tracked)
+}
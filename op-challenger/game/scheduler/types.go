@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -14,16 +15,90 @@ type GamePlayer interface {
 	Status() types.GameStatus
 }
 
+// FollowUpRequester is an optional interface a GamePlayer can implement to request another progression
+// pass as soon as the current one completes, rather than waiting for the game to next be scheduled.
+type FollowUpRequester interface {
+	// FollowUpRequested is called after each ProgressGame call and returns true if the game needs another pass.
+	FollowUpRequested() bool
+}
+
+// ActionReporter is an optional interface a GamePlayer can implement to report whether its most recent
+// ProgressGame call took any action (e.g. sent a transaction). Players that don't implement it are
+// assumed to have taken action on every pass.
+type ActionReporter interface {
+	ActionTaken() bool
+}
+
+// ErrorReporter is an optional interface a GamePlayer can implement to report that its most recent
+// ProgressGame call failed, e.g. because of an RPC error. Reported errors are treated as transient
+// and the game continues to be scheduled.
+type ErrorReporter interface {
+	// ProgressError returns the error from the most recent ProgressGame call or nil if it succeeded.
+	ProgressError() error
+}
+
 type DiskManager interface {
 	DirForGame(addr common.Address) string
 	RemoveAllExcept(addrs []common.Address) error
 }
 
 type job struct {
+	// id uniquely identifies the job within the coordinator that created it.
+	id     uint64
 	block  uint64
 	addr   common.Address
 	player GamePlayer
 	status types.GameStatus
+
+	// followUp is set by the worker when the player requested another progression pass.
+	followUp bool
+	// acted is set by the worker when the player took action while progressing the game.
+	acted bool
+	// priority determines the order jobs in a batch are enqueued, higher priority jobs first.
+	priority int
+	// err is set by the worker when the player reported the progression failed.
+	err error
+	// enqueuedAt is the time the job was added to the job queue, used to measure how long it waited for a worker.
+	enqueuedAt time.Time
+	// timeout limits how long the player may take to progress the game, or zero for no limit.
+	timeout time.Duration
+	// actionsSuppressed is set by the worker when the game was progressed while actions were paused.
+	actionsSuppressed bool
+	// gas is set by the worker to the estimated gas the player reported spending.
+	gas uint64
+	// scratchpad is a copy of the game's scratchpad for the player to use, updated by the worker with the
+	// player's changes.
+	scratchpad Scratchpad
+	// cycle is the scheduling cycle in which the job was created.
+	cycle uint64
+	// actionCategory is set by the worker to the kind of action the player reported taking, if any.
+	actionCategory string
+	// retriedInvalid is set on jobs retrying a game after an invalid result, see InvalidResultRetryOnce.
+	retriedInvalid bool
+	// createdAt is the time the job was created, used to measure its latency.
+	createdAt time.Time
+	// cancelled is set by the worker when the job was cancelled because its game was excluded, see SetGameFilter.
+	cancelled bool
+	// correlationID is the correlation id of the batch that scheduled the game, see ScheduleCorrelated.
+	correlationID string
+	// deadline is the game's next on-chain deadline, or zero if unknown. It is set when the job is created and
+	// updated by the worker after progressing the game, see DeadlineReporter.
+	deadline time.Time
+	// ackCycle is the cycle reported to the sink set by WithAckSink once the job reaches a terminal state, or 0 if
+	// the job doesn't acknowledge a scheduled game.
+	ackCycle uint64
+	// claimsAtRisk is set when the game's player last reported the challenger has claims at risk, see
+	// ClaimRiskReporter. It is set when the job is created and updated by the worker after progressing the game.
+	claimsAtRisk bool
+	// factory is the factory of the source the game is from, see RegisterSource, or zero for the primary factory.
+	factory common.Address
+	// startedAt is set by the worker to the time it started progressing the game and duration to how long it took.
+	startedAt time.Time
+	duration  time.Duration
+	// timedOut is set by the worker when the progression was stopped because the job's timeout expired.
+	timedOut bool
+	// retriedTimeout is set on jobs retrying a game after its previous job timed out, see WithJobTimeout.
+	retriedTimeout bool
 }
 
 func newJob(block uint64, addr common.Address, player GamePlayer, status types.GameStatus) *job {
@@ -34,3 +109,25 @@ func newJob(block uint64, addr common.Address, player GamePlayer, status types.G
 		status: status,
 	}
 }
+
+// ResultSummary describes the outcome of a single progression of a game.
+type ResultSummary struct {
+	Game     common.Address
+	Block    uint64
+	Status   types.GameStatus
+	Acted    bool
+	FollowUp bool
+	// CorrelationID is the correlation id of the batch that scheduled the game, see ScheduleCorrelated.
+	CorrelationID string
+}
+
+func (j job) summary() ResultSummary {
+	return ResultSummary{
+		Game:          j.addr,
+		Block:         j.block,
+		Status:        j.status,
+		Acted:         j.acted,
+		FollowUp:      j.followUp,
+		CorrelationID: j.correlationID,
+	}
+}
@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type UpstreamMetricer interface {
+	RecordUpstreamInUse(n int)
+}
+
+// upstreamLimiter bounds the number of concurrent interactions with the upstream node across scheduling-time
+// checks and job progression, see WithUpstreamConcurrency.
+// A nil upstreamLimiter doesn't limit interactions so callers don't need to check whether a limit is set.
+// Safe for concurrent use.
+type upstreamLimiter struct {
+	m     UpstreamMetricer
+	slots chan struct{}
+	inUse atomic.Int32
+}
+
+func newUpstreamLimiter(m UpstreamMetricer, limit uint) *upstreamLimiter {
+	if limit == 0 {
+		return nil
+	}
+	return &upstreamLimiter{m: m, slots: make(chan struct{}, limit)}
+}
+
+// Acquire blocks until a slot is available or ctx is done. Returns a function to release the slot.
+func (u *upstreamLimiter) Acquire(ctx context.Context) (func(), error) {
+	if u == nil {
+		return func() {}, nil
+	}
+	select {
+	case u.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	u.m.RecordUpstreamInUse(int(u.inUse.Add(1)))
+	return func() {
+		u.m.RecordUpstreamInUse(int(u.inUse.Add(-1)))
+		<-u.slots
+	}, nil
+}
+
+// InUse returns the number of slots currently held.
+func (u *upstreamLimiter) InUse() int {
+	if u == nil {
+		return 0
+	}
+	return int(u.inUse.Load())
+}
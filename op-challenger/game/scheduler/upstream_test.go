@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpstreamLimiter(t *testing.T) {
+	m := &upstreamMetrics{}
+	limiter := newUpstreamLimiter(m, 2)
+	ctx := context.Background()
+	release1, err := limiter.Acquire(ctx)
+	require.NoError(t, err)
+	release2, err := limiter.Acquire(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, limiter.InUse())
+	require.EqualValues(t, 2, m.inUse.Load())
+
+	// Blocks while all slots are in use
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = limiter.Acquire(timeoutCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, 2, limiter.InUse())
+
+	release1()
+	require.Equal(t, 1, limiter.InUse())
+	require.EqualValues(t, 1, m.inUse.Load())
+	release3, err := limiter.Acquire(ctx)
+	require.NoError(t, err)
+	release2()
+	release3()
+	require.Zero(t, limiter.InUse())
+	require.Zero(t, m.inUse.Load())
+}
+
+func TestUpstreamLimiterUnlimited(t *testing.T) {
+	limiter := newUpstreamLimiter(&upstreamMetrics{}, 0)
+	require.Nil(t, limiter)
+	release, err := limiter.Acquire(context.Background())
+	require.NoError(t, err)
+	release()
+	require.Zero(t, limiter.InUse())
+}
+
+func TestUpstreamConcurrencySharedBySchedulingAndProgression(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	calls := newConcurrencyTracker()
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &upstreamPlayer{calls: calls}, nil
+	}
+	readiness := func(ctx context.Context, addr common.Address) (bool, error) {
+		calls.pass()
+		return true, nil
+	}
+	m := &upstreamMetrics{}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 100)}
+	s := NewScheduler(logger, m, disk, 4, createPlayer, false, WithUpstreamConcurrency(2), WithReadinessCheck(readiness))
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	// Each batch has new games so their prestates are validated and readiness checked while the previous batch's
+	// games are being progressed.
+	schedule := func(i int) {
+		var games []common.Address
+		for j := 0; j < 4; j++ {
+			games = append(games, common.Address{byte(i + 1), byte(j + 1)})
+		}
+		require.Eventually(t, func() bool {
+			return s.Schedule(asGames(games...), uint64(i)) == nil
+		}, 10*time.Second, time.Millisecond)
+	}
+	schedule(0)
+	// Progressing the first batch holds every upstream slot so scheduling the next batch must wait for one
+	calls.waitActive(t, 2)
+	schedule(1)
+	calls.release()
+	schedule(2)
+	schedule(3)
+	require.NoError(t, s.WaitIdle(ctx))
+	require.EqualValues(t, 2, calls.maxActive.Load(), "upstream calls exceeded limit")
+	require.Zero(t, calls.active.Load())
+	require.EqualValues(t, 2, m.maxInUse.Load())
+	require.Equal(t, uint(2), s.EffectiveConfig().UpstreamConcurrency)
+}
+
+func TestValidatePrestateWithoutLock(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	validating := make(chan struct{})
+	release := make(chan struct{})
+	c.createPlayer = func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &slowPrestatePlayer{validating: validating, release: release}, nil
+	}
+	ctx := context.Background()
+	game := common.Address{0xaa}
+	scheduled := make(chan error, 1)
+	go func() {
+		scheduled <- c.schedule(ctx, asGames(game), 1)
+	}()
+	<-validating
+	// Inspecting the games doesn't wait for the prestate to be validated
+	require.Empty(t, c.trackedGames(nil))
+	close(release)
+	require.NoError(t, <-scheduled)
+	require.Equal(t, game, (<-workQueue).addr)
+	require.Len(t, c.trackedGames(nil), 1)
+}
+
+// slowPrestatePlayer is a GamePlayer whose prestate validation blocks until release is closed.
+type slowPrestatePlayer struct {
+	test.StubGamePlayer
+	validating chan struct{}
+	release    chan struct{}
+}
+
+func (p *slowPrestatePlayer) ValidatePrestate(_ context.Context) error {
+	close(p.validating)
+	<-p.release
+	return nil
+}
+
+// upstreamPlayer is a GamePlayer that calls the upstream node to validate its prestate and progress the game. Its
+// progressions are held until calls is released.
+type upstreamPlayer struct {
+	calls *concurrencyTracker
+}
+
+func (p *upstreamPlayer) ValidatePrestate(_ context.Context) error {
+	p.calls.pass()
+	return nil
+}
+
+func (p *upstreamPlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	p.calls.call(ctx)
+	return types.GameStatusInProgress
+}
+
+func (p *upstreamPlayer) Status() types.GameStatus {
+	return types.GameStatusInProgress
+}
+
+type upstreamMetrics struct {
+	metrics.NoopMetricsImpl
+	inUse    atomic.Int32
+	maxInUse atomic.Int32
+}
+
+func (m *upstreamMetrics) RecordUpstreamInUse(n int) {
+	m.inUse.Store(int32(n))
+	recordMax(&m.maxInUse, int32(n))
+}
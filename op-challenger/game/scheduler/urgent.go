@@ -0,0 +1,146 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrNoUrgentWorkers = errors.New("no urgent workers configured")
+
+// urgentRequest asks the scheduler loop to progress games using the urgent workers, see ScheduleUrgent.
+type urgentRequest struct {
+	games  []common.Address
+	result chan error
+}
+
+// ScheduleUrgent immediately progresses each of the games using the reserve of workers set by WithUrgentWorkers,
+// so they don't wait behind the backlog in the job queue when the main pool is busy. Like ForceSchedule, the
+// usual scheduling checks are bypassed and only one job progresses a game at a time: if a game already has a job
+// in flight, another pass is started by an urgent worker as soon as it completes.
+// Returns ErrNoUrgentWorkers if no urgent workers are configured. Otherwise returns an error wrapping
+// ErrGameNotScheduled or ErrGameResolved for each game that couldn't be progressed, while still progressing the
+// others.
+func (s *Scheduler) ScheduleUrgent(ctx context.Context, games []common.Address) error {
+	if !s.started.Load() {
+		return ErrNotStarted
+	}
+	if s.coordinator.jobLimitReached.Load() {
+		return ErrJobLimitReached
+	}
+	req := urgentRequest{games: games, result: make(chan error, 1)}
+	select {
+	case s.urgentRequests <- req:
+	case <-s.stopped:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-req.result:
+		return err
+	case <-s.stopped:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) handleUrgent(ctx context.Context, req urgentRequest) {
+	req.result <- s.coordinator.scheduleUrgent(ctx, req.games)
+}
+
+// startUrgentWorkers starts the reserve of workers that only progress urgent jobs. They are not counted as live
+// workers and are not retired by DrainTo.
+func (s *Scheduler) startUrgentWorkers(ctx context.Context) {
+	for i := uint(0); i < s.cfg.urgentWorkers; i++ {
+		s.m.IncIdleExecutors()
+		id := int(s.nextWorkerID.Add(1))
+		scratchDir, err := s.createScratchDir(id)
+		if err != nil {
+			s.logger.Error("Failed to create worker scratch directory", "worker", id, "err", err)
+		}
+		s.wg.Add(1)
+		w := &worker{
+			id:           id,
+			logger:       s.logger,
+			clock:        s.cfg.clock,
+			in:           s.urgentQueue,
+			out:          s.resultQueue,
+			threadActive: s.jobStarted,
+			threadIdle:   s.jobFinished,
+			tracer:       s.coordinator.tracer,
+			resources:    s.resources,
+			upstream:     s.coordinator.upstream,
+			canceller:    s.coordinator.canceller,
+			deadlines:    s.cfg.deadlineResolver,
+			scratchDir:   scratchDir,
+
+			actionsPaused: &s.actionsPaused,
+		}
+		go w.progressGames(ctx, &s.wg)
+	}
+}
+
+// scheduleUrgent enqueues a job to progress each of the games on the urgent queue without applying the usual
+// scheduling checks. Games that already have a job pending are progressed again once its result is processed.
+func (c *coordinator) scheduleUrgent(ctx context.Context, games []common.Address) error {
+	if c.urgentQueue == nil {
+		return ErrNoUrgentWorkers
+	}
+	var errs []error
+	var jobs []job
+	c.lock.Lock()
+	for _, addr := range games {
+		state, ok := c.states[addr]
+		if !ok || state.player == nil {
+			errs = append(errs, fmt.Errorf("%w: %v", ErrGameNotScheduled, addr))
+			continue
+		}
+		if state.status != types.GameStatusInProgress {
+			errs = append(errs, fmt.Errorf("%w: %v", ErrGameResolved, addr))
+			continue
+		}
+		if state.pendingJobID != 0 {
+			c.tracer.Log(addr, "Urgent schedule waiting for in-flight job", "job", state.pendingJobID)
+			state.urgent = true
+			continue
+		}
+		jobs = append(jobs, *c.newForcedJob(addr, state))
+	}
+	c.lock.Unlock()
+
+	c.logger.Info("Scheduling urgent games", "games", len(games), "jobs", len(jobs))
+	for i, j := range jobs {
+		if err := c.enqueueJobOn(ctx, c.urgentQueue, j); err != nil {
+			c.abandonJobs(jobs[i:])
+			errs = append(errs, fmt.Errorf("failed to enqueue urgent jobs: %w", err))
+			break
+		}
+		c.tracer.Log(j.addr, "Enqueued urgent job", "block", j.block)
+	}
+	return errors.Join(errs...)
+}
+
+// enqueueUrgent enqueues the urgent job requested while the game's previous job was in flight.
+// If the urgent queue is full, the job is deferred to the main job queue rather than blocking result processing.
+// The lock must be held.
+func (c *coordinator) enqueueUrgent(addr common.Address, state *gameState) {
+	state.urgent = false
+	if state.inflight || state.status != types.GameStatusInProgress {
+		return
+	}
+	j := c.newForcedJob(addr, state)
+	j.enqueuedAt = c.cfg.clock.Now()
+	select {
+	case c.urgentQueue <- *j:
+		c.tracer.Log(addr, "Enqueued urgent job", "block", j.block)
+	default:
+		c.logger.Warn("Urgent workers busy, deferring urgent job to main job queue", "game", addr)
+		c.deferred = append(c.deferred, *j)
+		c.enqueueDeferred()
+	}
+}
@@ -0,0 +1,144 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleUrgentWhileMainPoolSaturated(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	urgentGame := common.Address{0xaa}
+	urgent := &signalPlayer{
+		StubGamePlayer: &test.StubGamePlayer{StatusValue: types.GameStatusInProgress, ActionTakenValue: true},
+		progressed:     make(chan struct{}, 10),
+	}
+	release := make(chan struct{})
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		if g.Proxy == urgentGame {
+			return urgent, nil
+		}
+		return &blockingPlayer{release: release}, nil
+	}
+	disk := &stubDiskManager{gameDirExists: make(map[common.Address]bool)}
+	// The cooldown stops the urgent game being regularly scheduled after its first pass.
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 1, createPlayer, false, WithUrgentWorkers(1), WithActionCooldown(time.Hour))
+	s.Start(ctx)
+	defer s.Close()
+	defer close(release)
+
+	require.NoError(t, s.Schedule(asGames(urgentGame), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	readWithTimeout(t, urgent.progressed)
+
+	// Saturate the main pool, leaving jobs queued and deferred behind the one in progress.
+	backlog := []common.Address{urgentGame, {0x01}, {0x02}, {0x03}, {0x04}, {0x05}}
+	require.NoError(t, s.Schedule(asGames(backlog...), 1))
+	require.Eventually(t, func() bool {
+		return s.activeWorkers.Load() == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, s.ScheduleUrgent(ctx, []common.Address{urgentGame}))
+	readWithTimeout(t, urgent.progressed)
+	require.Equal(t, 2, urgent.ProgressCount)
+}
+
+func TestScheduleUrgentWaitsForInFlightJob(t *testing.T) {
+	c, workQueue, _, games, _, _ := setupCoordinatorTest(t, 10)
+	urgentQueue := make(chan job, 1)
+	c.urgentQueue = urgentQueue
+	gameAddr := common.Address{0xaa}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr), 0))
+	inflight := <-workQueue
+
+	require.NoError(t, c.scheduleUrgent(ctx, []common.Address{gameAddr}))
+	require.Empty(t, urgentQueue, "should not progress game while a job is in flight")
+
+	require.NoError(t, c.processResult(runJob(ctx, inflight)))
+	require.Empty(t, workQueue)
+	require.Len(t, urgentQueue, 1, "should progress game with urgent workers once in-flight job completes")
+	require.NoError(t, c.processResult(runJob(ctx, <-urgentQueue)))
+	require.Empty(t, urgentQueue, "should only progress one urgent pass")
+	require.Equal(t, 2, games.created[gameAddr].ProgressCount)
+	require.True(t, c.idle.IsIdle())
+}
+
+func TestScheduleUrgentFallsBackToJobQueueWhenUrgentWorkersBusy(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	urgentQueue := make(chan job, 1)
+	c.urgentQueue = urgentQueue
+	busy := common.Address{0xaa}
+	gameAddr := common.Address{0xbb}
+	ctx := context.Background()
+
+	require.NoError(t, c.schedule(ctx, asGames(busy, gameAddr), 0))
+	first, second := <-workQueue, <-workQueue
+	require.NoError(t, c.scheduleUrgent(ctx, []common.Address{busy, gameAddr}))
+	require.NoError(t, c.processResult(runJob(ctx, first)))
+	require.NoError(t, c.processResult(runJob(ctx, second)))
+	require.Len(t, urgentQueue, 1)
+	require.Len(t, workQueue, 1)
+	require.NotEqual(t, (<-urgentQueue).addr, (<-workQueue).addr)
+}
+
+func TestScheduleUrgentRejectsUnknownAndResolvedGames(t *testing.T) {
+	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
+	ctx := context.Background()
+	require.ErrorIs(t, c.scheduleUrgent(ctx, []common.Address{{0xaa}}), ErrNoUrgentWorkers)
+
+	urgentQueue := make(chan job, 2)
+	c.urgentQueue = urgentQueue
+	resolvedAddr := common.Address{0xaa}
+	inProgressAddr := common.Address{0xbb}
+	require.NoError(t, c.schedule(ctx, asGames(resolvedAddr, inProgressAddr), 0))
+	for len(workQueue) > 0 {
+		j := <-workQueue
+		if j.addr == resolvedAddr {
+			j.status = types.GameStatusDefenderWon
+		}
+		require.NoError(t, c.processResult(j))
+	}
+
+	err := c.scheduleUrgent(ctx, []common.Address{{0xcc}, resolvedAddr, inProgressAddr})
+	require.ErrorIs(t, err, ErrGameNotScheduled)
+	require.ErrorIs(t, err, ErrGameResolved)
+	require.Len(t, urgentQueue, 1, "should still progress valid games")
+	require.Equal(t, inProgressAddr, (<-urgentQueue).addr)
+}
+
+func TestSchedulerScheduleUrgentWithoutUrgentWorkers(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{StatusValue: types.GameStatusInProgress}, nil
+	}
+	s := NewScheduler(logger, metrics.NoopMetrics, &stubDiskManager{gameDirExists: make(map[common.Address]bool)}, 1, createPlayer, false)
+	s.Start(ctx)
+	defer s.Close()
+	require.ErrorIs(t, s.ScheduleUrgent(ctx, []common.Address{{0xaa}}), ErrNoUrgentWorkers)
+}
+
+// signalPlayer notifies progressed each time the game is progressed.
+type signalPlayer struct {
+	*test.StubGamePlayer
+	progressed chan struct{}
+}
+
+func (p *signalPlayer) ProgressGame(ctx context.Context) types.GameStatus {
+	status := p.StubGamePlayer.ProgressGame(ctx)
+	p.progressed <- struct{}{}
+	return status
+}
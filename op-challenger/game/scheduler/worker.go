@@ -2,23 +2,160 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/log"
 )
 
+// worker progresses games for jobs received from in and returns the updated jobs via out.
+type worker struct {
+	id     int
+	logger log.Logger
+	clock  clock.Clock
+	in     <-chan job
+	out    chan<- job
+	// threadActive and threadIdle are called with the worker id and job before and after each job is progressed.
+	threadActive func(workerID int, j job)
+	threadIdle   func(workerID int, j job)
+	tracer       *gameTracer
+	resources    *resourceLocks
+	// upstream limits concurrent interactions with the upstream node, see WithUpstreamConcurrency.
+	upstream *upstreamLimiter
+	// canceller cancels the context of jobs whose game is excluded by a new filter, see SetGameFilter.
+	canceller *jobCanceller
+	// deadlines looks up the deadline of games whose player doesn't report one, see WithDeadlineResolver.
+	deadlines DeadlineResolver
+	// retire stops the worker when received from while it is waiting for a job. Nil if the worker is never retired.
+	retire <-chan struct{}
+	// scratchDir is the worker's scratch directory passed to players, see ScratchDir. Empty if not supported.
+	scratchDir string
+	// actionsPaused is set while players should only observe games, see Scheduler.PauseActions.
+	actionsPaused *atomic.Bool
+}
+
 // progressGames accepts jobs from in channel, calls ProgressGame on the job.player and returns the job
 // with updated job.resolved via the out channel.
 // The loop exits when the ctx is done.  wg.Done() is called when the function returns.
-func progressGames(ctx context.Context, in <-chan job, out chan<- job, wg *sync.WaitGroup, threadActive, threadIdle func()) {
+func (w *worker) progressGames(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
+	defer w.removeScratchDir()
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case j := <-in:
-			threadActive()
-			j.status = j.player.ProgressGame(ctx)
-			out <- j
-			threadIdle()
+		case <-w.retire:
+			return
+		case j := <-w.in:
+			w.threadActive(w.id, j)
+			release, err := w.acquireResources(ctx, j)
+			if err != nil {
+				// Context is done so the worker is exiting.
+				w.abandonJob(j)
+				return
+			}
+			releaseUpstream, err := w.upstream.Acquire(ctx)
+			if err != nil {
+				release()
+				w.abandonJob(j)
+				return
+			}
+			jobCtx := ctx
+			if w.scratchDir != "" {
+				jobCtx = withScratchDir(jobCtx, w.scratchDir)
+			}
+			if w.actionsPaused.Load() {
+				jobCtx = types.WithActionsSuppressed(jobCtx)
+				j.actionsSuppressed = true
+			}
+			// Bound the timeout when the job starts rather than when it was created as it may have waited in the queue.
+			j.timeout = deadlineTimeout(j.timeout, j.deadline, w.clock.Now())
+			cancel := func() {}
+			if j.timeout > 0 {
+				jobCtx, cancel = context.WithTimeout(jobCtx, j.timeout)
+			}
+			jobCtx, done := w.canceller.start(jobCtx, j.id)
+			w.tracer.Log(j.addr, "Progressing game", "block", j.block, "worker", w.id, "actionsSuppressed", j.actionsSuppressed, "timeout", j.timeout)
+			j.startedAt = w.clock.Now()
+			j = runJob(jobCtx, j)
+			j.duration = w.clock.Since(j.startedAt)
+			j.timedOut = errors.Is(jobCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+			j.cancelled = done()
+			cancel()
+			j.deadline = w.resolveDeadline(ctx, j)
+			releaseUpstream()
+			release()
+			w.tracer.Log(j.addr, "Progressed game", "status", j.status, "followUp", j.followUp)
+			select {
+			case w.out <- j:
+			case <-ctx.Done():
+				// The scheduler loop has stopped so the result will never be processed.
+				w.abandonJob(j)
+				return
+			}
+			w.threadIdle(w.id, j)
+		}
+	}
+}
+
+// abandonJob reports the worker idle when it exits after taking j from the queue without returning its result, so
+// the job is no longer reported as in flight.
+func (w *worker) abandonJob(j job) {
+	w.threadIdle(w.id, j)
+}
+
+// acquireResources locks any shared resources the job's player requires.
+func (w *worker) acquireResources(ctx context.Context, j job) (func(), error) {
+	user, ok := j.player.(ResourceUser)
+	if !ok {
+		return func() {}, nil
+	}
+	resources := user.RequiredResources()
+	if len(resources) == 0 {
+		return func() {}, nil
+	}
+	w.tracer.Log(j.addr, "Acquiring resources", "resources", resources)
+	return w.resources.Acquire(ctx, resources)
+}
+
+// runJob progresses the game for the job once and returns the job updated with the result.
+func runJob(ctx context.Context, j job) job {
+	user, usesScratchpad := j.player.(ScratchpadUser)
+	if usesScratchpad {
+		user.LoadScratchpad(j.scratchpad)
+	}
+	j.status = j.player.ProgressGame(ctx)
+	if usesScratchpad {
+		j.scratchpad = user.SaveScratchpad()
+	}
+	j.acted = true
+	if reporter, ok := j.player.(ActionReporter); ok {
+		j.acted = reporter.ActionTaken()
+	}
+	if reporter, ok := j.player.(GasReporter); ok {
+		j.gas = reporter.GasSpent()
+	}
+	if reporter, ok := j.player.(ActionCategoryReporter); ok {
+		j.actionCategory = reporter.ActionCategory()
+	}
+	if reporter, ok := j.player.(ErrorReporter); ok {
+		j.err = reporter.ProgressError()
+	}
+	if requester, ok := j.player.(FollowUpRequester); ok {
+		j.followUp = requester.FollowUpRequested()
+	}
+	if reporter, ok := j.player.(DeadlineReporter); ok {
+		j.deadline = time.Time{}
+		if deadline, ok := reporter.NextDeadline(); ok {
+			j.deadline = deadline
 		}
 	}
+	if reporter, ok := j.player.(ClaimRiskReporter); ok {
+		j.claimsAtRisk = reporter.ClaimsAtRisk()
+	}
+	return j
 }
@@ -9,7 +9,12 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/stretchr/testify/require"
 )
@@ -23,7 +28,19 @@ func TestWorkerShouldProcessJobsUntilContextDone(t *testing.T) {
 	defer cancel()
 	var wg sync.WaitGroup
 	wg.Add(1)
-	go progressGames(ctx, in, out, &wg, ms.ThreadActive, ms.ThreadIdle)
+	w := &worker{
+		id:           1,
+		clock:        clock.SystemClock,
+		in:           in,
+		out:          out,
+		threadActive: ms.ThreadActive,
+		threadIdle:   ms.ThreadIdle,
+		tracer:       newGameTracer(testlog.Logger(t, log.LevelInfo), 1),
+		resources:    newResourceLocks(metrics.NoopMetrics, clock.SystemClock),
+
+		actionsPaused: new(atomic.Bool),
+	}
+	go w.progressGames(ctx, &wg)
 
 	in <- job{
 		player: &test.StubGamePlayer{StatusValue: types.GameStatusInProgress},
@@ -56,16 +73,87 @@ func TestWorkerShouldProcessJobsUntilContextDone(t *testing.T) {
 	wg.Wait()
 }
 
+func TestWorkerIdleWhenExitingWhileAcquiringResources(t *testing.T) {
+	in := make(chan job, 1)
+	out := make(chan job, 1)
+	ms := &metricSink{}
+	resources := newResourceLocks(metrics.NoopMetrics, clock.SystemClock)
+	release, err := resources.Acquire(context.Background(), []string{"vm"})
+	require.NoError(t, err)
+	defer release()
+
+	w := newTestWorker(t, in, out, ms)
+	w.resources = resources
+	requireIdleWhenAbandoned(t, w, in, ms, job{player: &resourcePlayer{resources: []string{"vm"}}})
+	require.Empty(t, out)
+}
+
+func TestWorkerIdleWhenExitingWhileAcquiringUpstream(t *testing.T) {
+	in := make(chan job, 1)
+	out := make(chan job, 1)
+	ms := &metricSink{}
+	upstream := newUpstreamLimiter(metrics.NoopMetrics, 1)
+	release, err := upstream.Acquire(context.Background())
+	require.NoError(t, err)
+	defer release()
+
+	w := newTestWorker(t, in, out, ms)
+	w.upstream = upstream
+	requireIdleWhenAbandoned(t, w, in, ms, job{player: &test.StubGamePlayer{}})
+	require.Empty(t, out)
+}
+
+func TestWorkerIdleWhenExitingWhileReturningResult(t *testing.T) {
+	in := make(chan job, 1)
+	// Nothing receives the result so the worker is blocked returning it.
+	out := make(chan job)
+	ms := &metricSink{}
+	player := &test.StubGamePlayer{}
+	w := newTestWorker(t, in, out, ms)
+	requireIdleWhenAbandoned(t, w, in, ms, job{player: player})
+}
+
+// requireIdleWhenAbandoned sends j to the worker and stops it once the job has started, then checks the worker
+// reported it was idle again.
+func requireIdleWhenAbandoned(t *testing.T, w *worker, in chan<- job, ms *metricSink, j job) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go w.progressGames(ctx, &wg)
+	in <- j
+	require.Eventually(t, func() bool { return ms.activeCalls.Load() == 1 }, 10*time.Second, time.Millisecond)
+
+	cancel()
+	wg.Wait()
+	require.EqualValues(t, 1, ms.idleCalls.Load(), "should report idle after abandoning job")
+}
+
+func newTestWorker(t *testing.T, in <-chan job, out chan<- job, ms *metricSink) *worker {
+	return &worker{
+		id:           1,
+		clock:        clock.SystemClock,
+		in:           in,
+		out:          out,
+		threadActive: ms.ThreadActive,
+		threadIdle:   ms.ThreadIdle,
+		tracer:       newGameTracer(testlog.Logger(t, log.LevelInfo), 1),
+		resources:    newResourceLocks(metrics.NoopMetrics, clock.SystemClock),
+
+		actionsPaused: new(atomic.Bool),
+	}
+}
+
 type metricSink struct {
 	activeCalls atomic.Int32
 	idleCalls   atomic.Int32
 }
 
-func (m *metricSink) ThreadActive() {
+func (m *metricSink) ThreadActive(_ int, _ job) {
 	m.activeCalls.Add(1)
 }
 
-func (m *metricSink) ThreadIdle() {
+func (m *metricSink) ThreadIdle(_ int, _ job) {
 	m.idleCalls.Add(1)
 }
 
@@ -81,3 +169,52 @@ func readWithTimeout[T any](t *testing.T, ch <-chan T) T {
 		return val
 	}
 }
+
+func TestWorkerStateListener(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	var lock sync.Mutex
+	transitions := make(map[int][]WorkerState)
+	listener := func(workerID int, state WorkerState) {
+		lock.Lock()
+		defer lock.Unlock()
+		transitions[workerID] = append(transitions[workerID], state)
+	}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return &test.StubGamePlayer{}, nil
+	}
+	disk := &trackingDiskManager{removeExceptCalls: make(chan []common.Address, 10)}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 3, createPlayer, false, WithWorkerStateListener(listener))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	s.Start(ctx)
+	defer s.Close()
+
+	var games []common.Address
+	for i := 0; i < 10; i++ {
+		games = append(games, common.Address{byte(i + 1)})
+	}
+	require.NoError(t, s.Schedule(asGames(games...), 0))
+	require.NoError(t, s.WaitIdle(ctx))
+	// Workers report idle after returning the result so wait for all transitions to be reported
+	require.Eventually(t, func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		var total int
+		for _, states := range transitions {
+			total += len(states)
+		}
+		return total == 2*len(games)
+	}, 10*time.Second, 10*time.Millisecond)
+
+	lock.Lock()
+	defer lock.Unlock()
+	for workerID, states := range transitions {
+		for i, state := range states {
+			expected := WorkerActive
+			if i%2 == 1 {
+				expected = WorkerIdle
+			}
+			require.Equalf(t, expected, state, "worker %v transition %v", workerID, i)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
 	"sync/atomic"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak"
@@ -19,9 +20,11 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/shared"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/registry"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/rpc"
 	"github.com/ethereum-optimism/optimism/op-challenger/version"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
@@ -29,10 +32,14 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
+// resolvedClaimWorkers is the number of bonds of resolved games claimed concurrently.
+const resolvedClaimWorkers = 2
+
 type Service struct {
 	logger  log.Logger
 	metrics metrics.Metricer
@@ -44,24 +51,36 @@ type Service struct {
 	preimages *keccak.LargePreimageScheduler
 
 	txMgr    *txmgr.SimpleTxManager
-	txSender *sender.TxSender
+	txSender fault.TxSender
+	// shadowTxSender logs transactions instead of sending them. Game players switch to it when the scheduler enables
+	// shadow mode.
+	shadowTxSender fault.TxSender
+	// auxTxSender sends the bond claims and preimage challenges, which aren't sent by game players, so is the
+	// shadowTxSender in shadow mode.
+	auxTxSender fault.TxSender
 
 	systemClock clock.Clock
 	l1Clock     *clock.SimpleClock
 
 	claimants []common.Address
 	claimer   *claims.BondClaimScheduler
+	// resolvedClaimer claims the bonds of games as the scheduler finds they have resolved.
+	resolvedClaimer *claims.ResolvedGameClaimer
 
 	factoryContract *contracts.DisputeGameFactoryContract
 	registry        *registry.GameTypeRegistry
+	traces          *shared.TraceCache
 	oracles         *registry.OracleRegistry
 	rollupClient    *sources.RollupClient
+	// sources are the additional game sources whose games are progressed alongside those of factoryContract.
+	sources []*sourceGames
 
 	l1Client   *ethclient.Client
 	pollClient client.RPC
 
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
+	rpcServer    *oprpc.Server
 
 	balanceMetricer io.Closer
 
@@ -93,6 +112,7 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	if err := s.initL1Client(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init l1 client: %w", err)
 	}
+	s.initShadowMode(ctx, cfg)
 	if err := s.initRollupClient(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init rollup client: %w", err)
 	}
@@ -111,17 +131,27 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	if err := s.registerGameTypes(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to register game types: %w", err)
 	}
+	if err := s.registerGameSources(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to register game sources: %w", err)
+	}
 	if err := s.initBondClaims(); err != nil {
 		return fmt.Errorf("failed to init bond claiming: %w", err)
 	}
 	if err := s.initScheduler(cfg); err != nil {
 		return fmt.Errorf("failed to init scheduler: %w", err)
 	}
+	if err := s.initSourceSchedulers(); err != nil {
+		return fmt.Errorf("failed to init game sources: %w", err)
+	}
 	if err := s.initLargePreimages(); err != nil {
 		return fmt.Errorf("failed to init large preimage scheduler: %w", err)
 	}
+	if err := s.initRPCServer(&cfg.RPC); err != nil {
+		return fmt.Errorf("failed to init rpc server: %w", err)
+	}
 
 	s.initMonitor(cfg)
+	s.initSourceMonitors(cfg)
 
 	s.metrics.RecordInfo(version.SimpleWithMeta)
 	s.metrics.RecordUp()
@@ -133,13 +163,26 @@ func (s *Service) initClaimants(cfg *config.Config) {
 	s.claimants = append(claimants, cfg.AdditionalBondClaimants...)
 }
 
+// initShadowMode creates the tx sender that only logs transactions, which game players switch to when the scheduler
+// enables shadow mode, and uses it for bond claims and preimage challenges if shadow mode is enabled. The sender
+// reports each transaction as simulated so players log the moves they would have sent without sending them again
+// on later passes, and bonds aren't recorded as claimed.
+func (s *Service) initShadowMode(ctx context.Context, cfg *config.Config) {
+	s.shadowTxSender = sender.NewShadowTxSender(ctx, s.logger, s.metrics, s.txSender.From(), s.l1Client)
+	s.auxTxSender = s.txSender
+	if cfg.ShadowMode {
+		s.logger.Warn("Running in shadow mode, transactions will be logged but not sent")
+		s.auxTxSender = s.shadowTxSender
+	}
+}
+
 func (s *Service) initTxManager(ctx context.Context, cfg *config.Config) error {
 	txMgr, err := txmgr.NewSimpleTxManager("challenger", s.logger, s.metrics, cfg.TxMgrConfig)
 	if err != nil {
 		return fmt.Errorf("failed to create the transaction manager: %w", err)
 	}
 	s.txMgr = txMgr
-	s.txSender = sender.NewTxSender(ctx, s.logger, txMgr, cfg.MaxPendingTx)
+	s.txSender = sender.NewTxSender(ctx, s.logger, s.metrics, txMgr, cfg.MaxPendingTx)
 	return nil
 }
 
@@ -205,8 +248,10 @@ func (s *Service) initFactoryContract(cfg *config.Config) error {
 }
 
 func (s *Service) initBondClaims() error {
-	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.txSender, s.claimants...)
-	s.claimer = claims.NewBondClaimScheduler(s.logger, s.metrics, claimer)
+	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.auxTxSender, s.claimants...)
+	s.resolvedClaimer = claims.NewResolvedGameClaimer(s.logger, s.metrics, s.systemClock, claimer, resolvedClaimWorkers)
+	// The sweep claims through the resolved game claimer so it skips the games whose bonds it is already claiming.
+	s.claimer = claims.NewBondClaimScheduler(s.logger, s.metrics, s.resolvedClaimer)
 	return nil
 }
 
@@ -226,7 +271,9 @@ func (s *Service) registerGameTypes(ctx context.Context, cfg *config.Config) err
 	gameTypeRegistry := registry.NewGameTypeRegistry()
 	oracles := registry.NewOracleRegistry()
 	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
-	closer, err := fault.RegisterGameTypes(ctx, s.systemClock, s.l1Clock, s.logger, s.metrics, cfg, gameTypeRegistry, oracles, s.rollupClient, s.txSender, s.factoryContract, caller, s.l1Client, cfg.SelectiveClaimResolution, s.claimants)
+	// VM executions are shared between games so games disputing the same output root don't each regenerate them.
+	s.traces = shared.NewTraceCache(filepath.Join(cfg.Datadir, "shared-traces"))
+	closer, err := fault.RegisterGameTypes(ctx, s.systemClock, s.l1Clock, s.logger, s.metrics, cfg, gameTypeRegistry, oracles, s.rollupClient, s.txSender, s.shadowTxSender, s.factoryContract, caller, s.l1Client, cfg.SelectiveClaimResolution, s.claimants, s.traces)
 	if err != nil {
 		return err
 	}
@@ -237,15 +284,42 @@ func (s *Service) registerGameTypes(ctx context.Context, cfg *config.Config) err
 }
 
 func (s *Service) initScheduler(cfg *config.Config) error {
-	disk := newDiskManager(cfg.Datadir)
-	s.sched = scheduler.NewScheduler(s.logger, s.metrics, disk, cfg.MaxConcurrency, s.registry.CreatePlayer, cfg.AllowInvalidPrestate)
+	disk := newGameDiskManager(cfg.Datadir, cfg.DiskQuota, s.traces)
+	s.sched = scheduler.NewScheduler(s.logger, s.metrics, disk, cfg.MaxConcurrency, s.registry.CreatePlayer, cfg.AllowInvalidPrestate,
+		// Players report their chess clock deadlines and claims at risk so the most urgent games are progressed first.
+		scheduler.WithPriorityDispatch(),
+		scheduler.WithShadowMode(cfg.ShadowMode),
+		scheduler.WithJobTimeout(cfg.JobTimeout),
+		scheduler.WithResolutionHandler(s.resolvedClaimer.GameResolved))
+	return nil
+}
+
+// initRPCServer starts the JSON-RPC server if the admin API is enabled, as the admin and challenger APIs are the
+// only APIs served.
+func (s *Service) initRPCServer(cfg *oprpc.CLIConfig) error {
+	if !cfg.EnableAdmin {
+		return nil
+	}
+	server := oprpc.NewServer(
+		cfg.ListenAddr,
+		cfg.ListenPort,
+		version.SimpleWithMeta,
+		oprpc.WithLogger(s.logger),
+	)
+	server.AddAPI(rpc.GetAdminAPI(rpc.NewAdminAPI(s.sched, s.metrics, s.logger)))
+	server.AddAPI(rpc.GetChallengerAPI(rpc.NewChallengerAPI(s.sched, s.metrics)))
+	s.logger.Info("Starting JSON-RPC server with admin API")
+	if err := server.Start(); err != nil {
+		return fmt.Errorf("unable to start RPC server: %w", err)
+	}
+	s.rpcServer = server
 	return nil
 }
 
 func (s *Service) initLargePreimages() error {
 	fetcher := fetcher.NewPreimageFetcher(s.logger, s.l1Client)
 	verifier := keccak.NewPreimageVerifier(s.logger, fetcher)
-	challenger := keccak.NewPreimageChallenger(s.logger, s.metrics, verifier, s.txSender)
+	challenger := keccak.NewPreimageChallenger(s.logger, s.metrics, verifier, s.auxTxSender)
 	s.preimages = keccak.NewLargePreimageScheduler(s.logger, s.l1Clock, s.oracles, challenger)
 	return nil
 }
@@ -255,12 +329,16 @@ func (s *Service) initMonitor(cfg *config.Config) {
 }
 
 func (s *Service) Start(ctx context.Context) error {
+	s.resolvedClaimer.Start(ctx)
 	s.logger.Info("starting scheduler")
-	s.sched.Start(ctx)
+	if err := s.sched.StartChecked(ctx); err != nil {
+		return fmt.Errorf("failed to start scheduler: %w", err)
+	}
 	s.claimer.Start(ctx)
 	s.preimages.Start(ctx)
 	s.logger.Info("starting monitoring")
 	s.monitor.StartMonitoring()
+	s.startSources(ctx)
 	s.logger.Info("challenger game service start completed")
 	return nil
 }
@@ -273,14 +351,25 @@ func (s *Service) Stop(ctx context.Context) error {
 	s.logger.Info("stopping challenger game service")
 
 	var result error
+	if s.rpcServer != nil {
+		if err := s.rpcServer.Stop(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close rpc server: %w", err))
+		}
+	}
 	if s.sched != nil {
 		if err := s.sched.Close(); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to close scheduler: %w", err))
 		}
 	}
+	if s.resolvedClaimer != nil {
+		if err := s.resolvedClaimer.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close resolved game claimer: %w", err))
+		}
+	}
 	if s.monitor != nil {
 		s.monitor.StopMonitoring()
 	}
+	s.stopSources()
 	if s.faultGamesCloser != nil {
 		s.faultGamesCloser()
 	}
@@ -0,0 +1,132 @@
+package game
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/registry"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+)
+
+// sourceGames holds the clients and monitor used to progress the games of an additional game source, see
+// config.GameSource. The games are progressed by the service's scheduler alongside those of the primary factory.
+type sourceGames struct {
+	source          config.GameSource
+	rollupClient    *sources.RollupClient
+	factoryContract *contracts.DisputeGameFactoryContract
+	registry        *registry.GameTypeRegistry
+	closer          fault.CloseFunc
+	claimer         *claims.BondClaimScheduler
+	monitor         *gameMonitor
+}
+
+// sourceScheduler schedules the games found by the monitor of a source as games of that source.
+type sourceScheduler struct {
+	sched   *scheduler.Scheduler
+	factory common.Address
+}
+
+func (s sourceScheduler) Schedule(games []types.GameMetadata, blockNumber uint64) error {
+	return s.sched.ScheduleSource(s.factory, games, blockNumber)
+}
+
+// noPreimageScheduler is used by the monitors of sources as the large preimages of all games are challenged by the
+// primary monitor.
+type noPreimageScheduler struct{}
+
+func (noPreimageScheduler) Schedule(common.Hash, uint64) error {
+	return nil
+}
+
+// registerGameSources dials the rollup node of each configured game source and registers the game types used to
+// create the players of its games.
+func (s *Service) registerGameSources(ctx context.Context, cfg *config.Config) error {
+	for _, source := range cfg.GameSources {
+		src := &sourceGames{source: source}
+		// Add the source before dialing so its clients are closed by Stop if registration fails.
+		s.sources = append(s.sources, src)
+		rollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, source.RollupRpc)
+		if err != nil {
+			return fmt.Errorf("failed to dial rollup client of game source %v: %w", source.GameFactoryAddress, err)
+		}
+		src.rollupClient = rollupClient
+		src.factoryContract = contracts.NewDisputeGameFactoryContract(s.metrics, source.GameFactoryAddress,
+			batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize))
+
+		srcCfg := *cfg
+		srcCfg.GameFactoryAddress = source.GameFactoryAddress
+		srcCfg.RollupRpc = source.RollupRpc
+		if source.L2Rpc != "" {
+			srcCfg.L2Rpc = source.L2Rpc
+		}
+		src.registry = registry.NewGameTypeRegistry()
+		caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
+		// VM executions aren't shared with the primary factory's games as the same game address may be used by
+		// games on different chains.
+		closer, err := fault.RegisterGameTypes(ctx, s.systemClock, s.l1Clock, s.logger.New("source", source.Label), s.metrics, &srcCfg, src.registry, s.oracles, rollupClient, s.txSender, s.shadowTxSender, src.factoryContract, caller, s.l1Client, cfg.SelectiveClaimResolution, s.claimants, nil)
+		if err != nil {
+			return fmt.Errorf("failed to register game types of game source %v: %w", source.GameFactoryAddress, err)
+		}
+		src.closer = closer
+	}
+	return nil
+}
+
+// initSourceSchedulers registers each game source with the scheduler.
+func (s *Service) initSourceSchedulers() error {
+	for _, src := range s.sources {
+		err := s.sched.RegisterSource(scheduler.GameSource{
+			Factory:      src.source.GameFactoryAddress,
+			Label:        src.source.Label,
+			CreatePlayer: src.registry.CreatePlayer,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to register game source %v: %w", src.source.GameFactoryAddress, err)
+		}
+	}
+	return nil
+}
+
+// initSourceMonitors creates the monitor that schedules the games of each game source as new L1 blocks arrive. Each
+// source sweeps its bonds with its own BondClaimScheduler so the claims of one source aren't skipped while those of
+// another are being made, claiming through the resolved game claimer so no game is claimed twice at once.
+func (s *Service) initSourceMonitors(cfg *config.Config) {
+	for _, src := range s.sources {
+		src.claimer = claims.NewBondClaimScheduler(s.logger, s.metrics, s.resolvedClaimer)
+		sched := sourceScheduler{sched: s.sched, factory: src.source.GameFactoryAddress}
+		src.monitor = newGameMonitor(s.logger.New("source", src.source.Label), s.l1Clock, src.factoryContract, sched, noPreimageScheduler{}, cfg.GameWindow, src.claimer, s.l1Client.BlockNumber, cfg.GameAllowlist, s.pollClient)
+	}
+}
+
+func (s *Service) startSources(ctx context.Context) {
+	for _, src := range s.sources {
+		src.claimer.Start(ctx)
+		src.monitor.StartMonitoring()
+	}
+}
+
+// stopSources stops the monitors of the game sources and closes their clients. Components that weren't created
+// because initialization failed are skipped.
+func (s *Service) stopSources() {
+	for _, src := range s.sources {
+		if src.monitor != nil {
+			src.monitor.StopMonitoring()
+		}
+		if src.closer != nil {
+			src.closer()
+		}
+		if src.rollupClient != nil {
+			src.rollupClient.Close()
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package types
+
+import "context"
+
+type actionsSuppressedKey struct{}
+
+// WithActionsSuppressed returns a context that instructs players not to take any actions, see ActionsSuppressed.
+func WithActionsSuppressed(ctx context.Context) context.Context {
+	return context.WithValue(ctx, actionsSuppressedKey{}, true)
+}
+
+// ActionsSuppressed returns true if the game is being progressed while actions are paused, for example by the
+// scheduler's PauseActions. Players should check it in ProgressGame and, if set, skip any step that would take an
+// action such as sending a transaction while still updating and reporting the game status.
+func ActionsSuppressed(ctx context.Context) bool {
+	suppressed, _ := ctx.Value(actionsSuppressedKey{}).(bool)
+	return suppressed
+}
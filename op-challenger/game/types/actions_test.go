@@ -0,0 +1,14 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionsSuppressed(t *testing.T) {
+	ctx := context.Background()
+	require.False(t, ActionsSuppressed(ctx))
+	require.True(t, ActionsSuppressed(WithActionsSuppressed(ctx)))
+}
@@ -2,6 +2,8 @@ package metrics
 
 import (
 	"io"
+	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
@@ -11,6 +13,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
@@ -32,6 +35,9 @@ type Metricer interface {
 	// Record contract metrics
 	contractMetrics.ContractMetricer
 
+	// Record RPC server metrics
+	opmetrics.RPCMetricer
+
 	RecordActedL1Block(n uint64)
 
 	RecordGameStep()
@@ -46,16 +52,72 @@ type Metricer interface {
 
 	RecordBondClaimFailed()
 	RecordBondClaimed(amount uint64)
+	RecordBondsOutstanding(games int, amount *big.Int)
+
+	RecordSentTx(purpose string)
+	RecordSimulatedTx(purpose string)
 
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
+	RecordGameStatusRegression(from, to types.GameStatus)
 
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
+	RecordGameCoolingDown()
+	RecordDuplicateResult()
+	RecordJobsDropped(n int)
+	RecordGasBudgetDeferred()
+	RecordGameNotReady()
+	RecordOldestInFlightAge(age time.Duration)
+	RecordDispatchDelay(d time.Duration)
+	RecordGameUpdateTimedOut()
+	RecordJobDuration(d time.Duration)
+	RecordBatchSize(n int)
+	RecordInvalidGameFiltered()
+	RecordPendingResults(n int)
+	RecordResultBackpressure(paused bool)
+	RecordResultProcessingLag(lag float64)
+	RecordMemoryPressure(paused bool)
+	RecordWorkerPoolSize(n uint)
+	RecordGameQuarantined()
+	RecordBatchCoalesced()
+	RecordDiskUsage(bytes uint64)
+	RecordDiskEvictions(n int)
+	RecordSourceGamesStatus(source string, inProgress, defenderWon, challengerWon int)
+	RecordSourceGameUpdateCompleted(source string)
+	RecordSourceActedL1Block(source string, n uint64)
+	RecordSourceTrackedGames(source string, n int)
+	RecordSourceResolvedGamesRetained(source string, n int)
+	RecordSourceQuarantinedGames(source string, n int)
+	RecordSourceDiskUsage(source string, bytes uint64)
+	RecordSourceDiskInconsistencies(source string, orphaned, missing int)
+	RecordQuarantinedGames(n int)
+	RecordGlobalBackoff(d time.Duration)
+	RecordResolvedGamesRetained(n int)
+	RecordTrackedGames(n int)
+	RecordInvalidResult()
+	RecordDiskInconsistencies(orphaned, missing int)
+	RecordGameAbandoned(reason string)
+	RecordEventDropped()
+	RecordLongRunningJob(addr common.Address, elapsed time.Duration)
+	RecordCycleGated()
+	RecordBatchRejected()
+	RecordPlayerInitFailure()
+	RecordUpstreamInUse(n int)
+	RecordFilterReconciled(cancelled, added int)
+	RecordForcedSchedule()
+	RecordActionSuppressed()
+	RecordResultSinkError()
+	RecordResultSinkDropped()
+	RecordAuditWriteFailure()
 
 	IncActiveExecutors()
 	DecActiveExecutors()
 	IncIdleExecutors()
 	DecIdleExecutors()
+
+	RecordResourceWaitTime(resource string, t float64)
+	RecordDiskOp(op string, d time.Duration)
+	RecordGameDirQuarantined()
 }
 
 // Metrics implementation must implement RegistryMetricer to allow the metrics server to work.
@@ -69,6 +131,7 @@ type Metrics struct {
 	txmetrics.TxMetrics
 	*opmetrics.CacheMetrics
 	*contractMetrics.ContractMetrics
+	opmetrics.RPCMetrics
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
@@ -77,6 +140,8 @@ type Metrics struct {
 
 	bondClaimFailures prometheus.Counter
 	bondsClaimed      prometheus.Counter
+	bondClaimsPending prometheus.Gauge
+	bondsOutstanding  prometheus.Gauge
 
 	preimageChallenged      prometheus.Counter
 	preimageChallengeFailed prometheus.Counter
@@ -92,7 +157,62 @@ type Metrics struct {
 	asteriscExecutionTime prometheus.Histogram
 
 	trackedGames  prometheus.GaugeVec
+	regressions   prometheus.CounterVec
+	sourceGames   prometheus.GaugeVec
+	sourceUpdates prometheus.CounterVec
+	sourceActed   prometheus.GaugeVec
+	sourceStates  prometheus.GaugeVec
+	sourceRetain  prometheus.GaugeVec
+	sourceBreaker prometheus.GaugeVec
+	sourceDisk    prometheus.GaugeVec
+	sourceDrift   prometheus.GaugeVec
 	inflightGames prometheus.Gauge
+	coolingDown   prometheus.Counter
+	duplicates    prometheus.Counter
+	droppedJobs   prometheus.Counter
+	gasDeferred   prometheus.Counter
+	notReady      prometheus.Counter
+	invalidGames  prometheus.Counter
+	sinkErrors    prometheus.Counter
+	sinkDropped   prometheus.Counter
+	oldestJobAge  prometheus.Gauge
+	quarantined   prometheus.Counter
+	dispatchDelay prometheus.Histogram
+	batchSize     prometheus.Histogram
+	pendingResult prometheus.Gauge
+	dispatchPause prometheus.Gauge
+	globalBackoff prometheus.Gauge
+	retainedGames prometheus.Gauge
+	gameStates    prometheus.Gauge
+	invalidResult prometheus.Counter
+	diskDrift     prometheus.GaugeVec
+	abandoned     prometheus.CounterVec
+	auditFailures prometheus.Counter
+	eventsDropped prometheus.Counter
+	longRunning   prometheus.Counter
+	gatedCycles   prometheus.Counter
+	rejectedBatch prometheus.Counter
+	initFailures  prometheus.Counter
+	upstreamInUse prometheus.Gauge
+	resultLag     prometheus.Gauge
+	memoryPause   prometheus.Gauge
+	poolSize      prometheus.Gauge
+	filterCancels prometheus.Counter
+	filterAdds    prometheus.Counter
+	forcedSched   prometheus.Counter
+	suppressed    prometheus.Counter
+	breakerTrips  prometheus.Counter
+	breakerGames  prometheus.Gauge
+	coalesced     prometheus.Counter
+	diskUsage     prometheus.Gauge
+	diskEvictions prometheus.Counter
+	sentTxs       prometheus.CounterVec
+	simulatedTxs  prometheus.CounterVec
+	timedOutJobs  prometheus.Counter
+	jobDuration   prometheus.Histogram
+
+	resourceWaitTime prometheus.HistogramVec
+	diskOpTime       prometheus.HistogramVec
 }
 
 func (m *Metrics) Registry() *prometheus.Registry {
@@ -116,6 +236,8 @@ func NewMetrics() *Metrics {
 
 		ContractMetrics: contractMetrics.MakeContractMetrics(Namespace, factory),
 
+		RPCMetrics: opmetrics.MakeRPCMetrics(Namespace, factory),
+
 		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "info",
@@ -185,6 +307,16 @@ func NewMetrics() *Metrics {
 			Name:      "bonds",
 			Help:      "Number of bonds claimed by the challenge agent",
 		}),
+		bondClaimsPending: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "bond_claims_pending",
+			Help:      "Number of resolved games with bonds waiting to be claimed",
+		}),
+		bondsOutstanding: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "bonds_outstanding",
+			Help:      "Credit in wei waiting to be claimed from resolved games, such as credit still locked in DelayedWETH",
+		}),
 		preimageChallenged: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "preimage_challenged",
@@ -202,6 +334,72 @@ func NewMetrics() *Metrics {
 		}, []string{
 			"status",
 		}),
+		sourceGames: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "source_tracked_games",
+			Help:      "Number of games being tracked by the challenger for each additional game source",
+		}, []string{
+			"source",
+			"status",
+		}),
+		sourceUpdates: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "source_game_updates_completed",
+			Help:      "Number of game progressions completed for each additional game source",
+		}, []string{
+			"source",
+		}),
+		sourceActed: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "source_highest_acted_l1_block",
+			Help:      "Highest L1 block acted on by the challenger for each additional game source",
+		}, []string{
+			"source",
+		}),
+		sourceStates: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "source_tracked_game_states",
+			Help:      "Number of distinct games the scheduler currently holds state for, for each additional game source",
+		}, []string{
+			"source",
+		}),
+		sourceRetain: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "source_resolved_games_retained",
+			Help:      "Number of resolved games whose data is currently retained for each additional game source",
+		}, []string{
+			"source",
+		}),
+		sourceBreaker: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "source_quarantined_games",
+			Help:      "Number of games currently quarantined after failing repeatedly for each additional game source",
+		}, []string{
+			"source",
+		}),
+		sourceDisk: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "source_game_data_bytes",
+			Help:      "Total size of the game data for each additional game source, when a disk quota is set",
+		}, []string{
+			"source",
+		}),
+		sourceDrift: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "source_disk_inconsistencies",
+			Help:      "Number of inconsistencies between tracked games and game directories for each additional game source",
+		}, []string{
+			"source",
+			"type",
+		}),
+		regressions: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "game_status_regressions",
+			Help:      "Number of times a resolved game was reported with a different status",
+		}, []string{
+			"from",
+			"to",
+		}),
 		highestActedL1Block: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "highest_acted_l1_block",
@@ -212,6 +410,248 @@ func NewMetrics() *Metrics {
 			Name:      "inflight_games",
 			Help:      "Number of games being tracked by the challenger",
 		}),
+		coolingDown: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "game_cooling_down",
+			Help:      "Number of times a game was not scheduled because it is cooling down after an action",
+		}),
+		duplicates: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "duplicate_results",
+			Help:      "Number of duplicate game progression results that were dropped",
+		}),
+		droppedJobs: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "dropped_jobs",
+			Help:      "Number of game progression jobs dropped because the job queue was full",
+		}),
+		gasDeferred: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "gas_budget_deferred",
+			Help:      "Number of times a game was not scheduled because the gas budget was used",
+		}),
+		notReady: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "games_not_ready",
+			Help:      "Number of times a game was not scheduled because it failed the readiness check",
+		}),
+		invalidGames: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "invalid_games_filtered",
+			Help:      "Number of obviously invalid games, such as the zero address, removed before scheduling",
+		}),
+		sinkErrors: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "result_sink_errors",
+			Help:      "Number of job results the result sink failed to publish",
+		}),
+		sinkDropped: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "result_sink_dropped",
+			Help:      "Number of job results dropped because the result sink buffer was full",
+		}),
+		dispatchDelay: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "job_dispatch_delay",
+			Help:      "Time (in seconds) jobs waited in the job queue before being picked up by a worker",
+			Buckets:   []float64{.001, .01, .1, .5, 1, 5, 10, 30, 60, 300},
+		}),
+		batchSize: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "schedule_batch_size",
+			Help:      "Number of games in each batch accepted for scheduling",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+		}),
+		pendingResult: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "pending_results",
+			Help:      "Number of job results waiting to be processed",
+		}),
+		dispatchPause: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "result_backpressure_paused",
+			Help:      "1 if job dispatch is paused because too many results are pending, 0 otherwise",
+		}),
+		quarantined: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "game_dirs_quarantined",
+			Help:      "Number of corrupt game directories quarantined at startup",
+		}),
+		globalBackoff: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "global_backoff_delay",
+			Help:      "Time (in seconds) the dispatch of each batch of jobs is currently delayed because of a high failure rate",
+		}),
+		forcedSched: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "forced_schedules",
+			Help:      "Number of times a game was manually forced to be progressed",
+		}),
+		suppressed: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "actions_suppressed",
+			Help:      "Number of game progressions run with actions suppressed because actions were paused",
+		}),
+		retainedGames: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "resolved_games_retained",
+			Help:      "Number of resolved games whose data is currently retained for post-mortem analysis",
+		}),
+		rejectedBatch: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "rejected_batches",
+			Help:      "Number of batches rejected for exceeding the maximum accepted batch size",
+		}),
+		filterCancels: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "filter_cancelled_jobs",
+			Help:      "Number of jobs cancelled because a new game filter excluded their game",
+		}),
+		filterAdds: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "filter_added_games",
+			Help:      "Number of games scheduled immediately because a new game filter included them",
+		}),
+		upstreamInUse: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "upstream_in_use",
+			Help:      "Number of concurrent interactions with the upstream node currently in progress",
+		}),
+		memoryPause: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "memory_dispatch_paused",
+			Help:      "1 if job dispatch is paused because process memory use is too high, 0 otherwise",
+		}),
+		poolSize: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "worker_pool_size",
+			Help:      "Number of workers the scheduler is configured to run",
+		}),
+		breakerTrips: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "games_quarantined",
+			Help:      "Number of times a game was quarantined after failing repeatedly",
+		}),
+		breakerGames: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "quarantined_games",
+			Help:      "Number of games currently quarantined after failing repeatedly",
+		}),
+		diskUsage: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "game_data_bytes",
+			Help:      "Total size of the game data in the data directory, when a disk quota is set",
+		}),
+		diskEvictions: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "game_data_evictions",
+			Help:      "Number of in progress games whose data was removed to stay within the disk quota",
+		}),
+		coalesced: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "batches_coalesced",
+			Help:      "Number of schedule batches merged into a batch still waiting to be processed",
+		}),
+		sentTxs: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "sent_transactions",
+			Help:      "Number of transactions successfully sent, as opposed to those simulated in shadow mode",
+		}, []string{
+			"purpose",
+		}),
+		simulatedTxs: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "simulated_transactions",
+			Help:      "Number of transactions logged but not sent because the challenger is running in shadow mode",
+		}, []string{
+			"purpose",
+		}),
+		timedOutJobs: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "game_update_timeouts",
+			Help:      "Number of game progressions stopped because they exceeded their timeout",
+		}),
+		jobDuration: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "job_duration",
+			Help:      "Time (in seconds) workers took to progress each game",
+			Buckets:   []float64{.1, .5, 1, 5, 10, 30, 60, 300, 600, 1800},
+		}),
+		resultLag: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "result_processing_lag",
+			Help:      "Rate game results were returned by workers minus the rate they were processed, in results per second",
+		}),
+		initFailures: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "player_init_failures",
+			Help:      "Number of times a game player was created but failed to initialize its disk state",
+		}),
+		gatedCycles: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "gated_cycles",
+			Help:      "Number of scheduling cycles skipped because the batch gate was closed",
+		}),
+		longRunning: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "long_running_jobs",
+			Help:      "Number of jobs that were in flight for longer than the warning threshold",
+		}),
+		eventsDropped: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "events_dropped",
+			Help:      "Number of lifecycle events dropped because the event publisher's buffer was full",
+		}),
+		auditFailures: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "audit_write_failures",
+			Help:      "Number of audit records of actions taken that failed to be written",
+		}),
+		abandoned: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "games_abandoned",
+			Help:      "Number of games the scheduler stopped progressing, by reason",
+		}, []string{
+			"reason",
+		}),
+		diskDrift: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "disk_inconsistencies",
+			Help:      "Number of inconsistencies between tracked games and game directories found by the last reconciliation",
+		}, []string{
+			"type",
+		}),
+		invalidResult: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "invalid_results",
+			Help:      "Number of game progression results discarded because they failed validation",
+		}),
+		gameStates: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "tracked_game_states",
+			Help:      "Number of distinct games the scheduler currently holds state for",
+		}),
+		oldestJobAge: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "oldest_inflight_job_age",
+			Help:      "Time (in seconds) the longest running in-flight job has been progressing, 0 if there are none",
+		}),
+		resourceWaitTime: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "resource_wait_time",
+			Help:      "Time (in seconds) spent waiting to acquire a shared resource before progressing a game",
+			Buckets:   []float64{.01, .1, .5, 1, 5, 10, 30, 60, 300, 600},
+		}, []string{
+			"resource",
+		}),
+		diskOpTime: *factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "disk_op_time",
+			Help:      "Time (in seconds) taken by scheduler disk operations",
+			Buckets:   []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1, 5},
+		}, []string{
+			"op",
+		}),
 	}
 }
 
@@ -267,6 +707,20 @@ func (m *Metrics) RecordBondClaimed(amount uint64) {
 	m.bondsClaimed.Add(float64(amount))
 }
 
+func (m *Metrics) RecordBondsOutstanding(games int, amount *big.Int) {
+	m.bondClaimsPending.Set(float64(games))
+	wei, _ := new(big.Float).SetInt(amount).Float64()
+	m.bondsOutstanding.Set(wei)
+}
+
+func (m *Metrics) RecordSentTx(purpose string) {
+	m.sentTxs.WithLabelValues(purpose).Add(1)
+}
+
+func (m *Metrics) RecordSimulatedTx(purpose string) {
+	m.simulatedTxs.WithLabelValues(purpose).Add(1)
+}
+
 func (m *Metrics) RecordCannonExecutionTime(t float64) {
 	m.cannonExecutionTime.Observe(t)
 }
@@ -305,6 +759,58 @@ func (m *Metrics) RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 	m.trackedGames.WithLabelValues("challenger_won").Set(float64(challengerWon))
 }
 
+func (m *Metrics) RecordSourceGamesStatus(source string, inProgress, defenderWon, challengerWon int) {
+	m.sourceGames.WithLabelValues(source, "in_progress").Set(float64(inProgress))
+	m.sourceGames.WithLabelValues(source, "defender_won").Set(float64(defenderWon))
+	m.sourceGames.WithLabelValues(source, "challenger_won").Set(float64(challengerWon))
+}
+
+func (m *Metrics) RecordSourceGameUpdateCompleted(source string) {
+	m.sourceUpdates.WithLabelValues(source).Inc()
+}
+
+func (m *Metrics) RecordSourceActedL1Block(source string, n uint64) {
+	m.sourceActed.WithLabelValues(source).Set(float64(n))
+}
+
+func (m *Metrics) RecordSourceTrackedGames(source string, n int) {
+	m.sourceStates.WithLabelValues(source).Set(float64(n))
+}
+
+func (m *Metrics) RecordSourceResolvedGamesRetained(source string, n int) {
+	m.sourceRetain.WithLabelValues(source).Set(float64(n))
+}
+
+func (m *Metrics) RecordSourceQuarantinedGames(source string, n int) {
+	m.sourceBreaker.WithLabelValues(source).Set(float64(n))
+}
+
+func (m *Metrics) RecordSourceDiskUsage(source string, bytes uint64) {
+	m.sourceDisk.WithLabelValues(source).Set(float64(bytes))
+}
+
+func (m *Metrics) RecordSourceDiskInconsistencies(source string, orphaned, missing int) {
+	m.sourceDrift.WithLabelValues(source, "orphaned_dir").Set(float64(orphaned))
+	m.sourceDrift.WithLabelValues(source, "missing_dir").Set(float64(missing))
+}
+
+func (m *Metrics) RecordGameStatusRegression(from, to types.GameStatus) {
+	m.regressions.WithLabelValues(statusLabel(from), statusLabel(to)).Inc()
+}
+
+func statusLabel(status types.GameStatus) string {
+	switch status {
+	case types.GameStatusInProgress:
+		return "in_progress"
+	case types.GameStatusDefenderWon:
+		return "defender_won"
+	case types.GameStatusChallengerWon:
+		return "challenger_won"
+	default:
+		return "unknown"
+	}
+}
+
 func (m *Metrics) RecordActedL1Block(n uint64) {
 	m.highestActedL1Block.Set(float64(n))
 }
@@ -316,3 +822,182 @@ func (m *Metrics) RecordGameUpdateScheduled() {
 func (m *Metrics) RecordGameUpdateCompleted() {
 	m.inflightGames.Sub(1)
 }
+
+func (m *Metrics) RecordGameCoolingDown() {
+	m.coolingDown.Add(1)
+}
+
+func (m *Metrics) RecordDuplicateResult() {
+	m.duplicates.Add(1)
+}
+
+func (m *Metrics) RecordJobsDropped(n int) {
+	m.droppedJobs.Add(float64(n))
+}
+
+func (m *Metrics) RecordGasBudgetDeferred() {
+	m.gasDeferred.Add(1)
+}
+
+func (m *Metrics) RecordGameNotReady() {
+	m.notReady.Add(1)
+}
+
+func (m *Metrics) RecordInvalidGameFiltered() {
+	m.invalidGames.Add(1)
+}
+
+func (m *Metrics) RecordPendingResults(n int) {
+	m.pendingResult.Set(float64(n))
+}
+
+func (m *Metrics) RecordWorkerPoolSize(n uint) {
+	m.poolSize.Set(float64(n))
+}
+
+func (m *Metrics) RecordGameQuarantined() {
+	m.breakerTrips.Add(1)
+}
+
+func (m *Metrics) RecordQuarantinedGames(n int) {
+	m.breakerGames.Set(float64(n))
+}
+
+func (m *Metrics) RecordDiskUsage(bytes uint64) {
+	m.diskUsage.Set(float64(bytes))
+}
+
+func (m *Metrics) RecordDiskEvictions(n int) {
+	m.diskEvictions.Add(float64(n))
+}
+
+func (m *Metrics) RecordBatchCoalesced() {
+	m.coalesced.Add(1)
+}
+
+func (m *Metrics) RecordMemoryPressure(paused bool) {
+	if paused {
+		m.memoryPause.Set(1)
+	} else {
+		m.memoryPause.Set(0)
+	}
+}
+
+func (m *Metrics) RecordResultProcessingLag(lag float64) {
+	m.resultLag.Set(lag)
+}
+
+func (m *Metrics) RecordResultBackpressure(paused bool) {
+	if paused {
+		m.dispatchPause.Set(1)
+	} else {
+		m.dispatchPause.Set(0)
+	}
+}
+
+func (m *Metrics) RecordGlobalBackoff(d time.Duration) {
+	m.globalBackoff.Set(d.Seconds())
+}
+
+func (m *Metrics) RecordActionSuppressed() {
+	m.suppressed.Inc()
+}
+
+func (m *Metrics) RecordForcedSchedule() {
+	m.forcedSched.Inc()
+}
+
+func (m *Metrics) RecordResolvedGamesRetained(n int) {
+	m.retainedGames.Set(float64(n))
+}
+
+func (m *Metrics) RecordAuditWriteFailure() {
+	m.auditFailures.Inc()
+}
+
+func (m *Metrics) RecordBatchRejected() {
+	m.rejectedBatch.Inc()
+}
+
+func (m *Metrics) RecordFilterReconciled(cancelled, added int) {
+	m.filterCancels.Add(float64(cancelled))
+	m.filterAdds.Add(float64(added))
+}
+
+func (m *Metrics) RecordUpstreamInUse(n int) {
+	m.upstreamInUse.Set(float64(n))
+}
+
+func (m *Metrics) RecordPlayerInitFailure() {
+	m.initFailures.Inc()
+}
+
+func (m *Metrics) RecordCycleGated() {
+	m.gatedCycles.Inc()
+}
+
+// RecordLongRunningJob counts the job. The game isn't used as a label to avoid unbounded cardinality.
+func (m *Metrics) RecordLongRunningJob(_ common.Address, _ time.Duration) {
+	m.longRunning.Inc()
+}
+
+func (m *Metrics) RecordEventDropped() {
+	m.eventsDropped.Inc()
+}
+
+func (m *Metrics) RecordGameAbandoned(reason string) {
+	m.abandoned.WithLabelValues(reason).Inc()
+}
+
+func (m *Metrics) RecordDiskInconsistencies(orphaned, missing int) {
+	m.diskDrift.WithLabelValues("orphaned_dir").Set(float64(orphaned))
+	m.diskDrift.WithLabelValues("missing_dir").Set(float64(missing))
+}
+
+func (m *Metrics) RecordInvalidResult() {
+	m.invalidResult.Inc()
+}
+
+func (m *Metrics) RecordTrackedGames(n int) {
+	m.gameStates.Set(float64(n))
+}
+
+func (m *Metrics) RecordResultSinkError() {
+	m.sinkErrors.Add(1)
+}
+
+func (m *Metrics) RecordResultSinkDropped() {
+	m.sinkDropped.Add(1)
+}
+
+func (m *Metrics) RecordDispatchDelay(d time.Duration) {
+	m.dispatchDelay.Observe(d.Seconds())
+}
+
+func (m *Metrics) RecordGameUpdateTimedOut() {
+	m.timedOutJobs.Add(1)
+}
+
+func (m *Metrics) RecordJobDuration(d time.Duration) {
+	m.jobDuration.Observe(d.Seconds())
+}
+
+func (m *Metrics) RecordBatchSize(n int) {
+	m.batchSize.Observe(float64(n))
+}
+
+func (m *Metrics) RecordOldestInFlightAge(age time.Duration) {
+	m.oldestJobAge.Set(age.Seconds())
+}
+
+func (m *Metrics) RecordResourceWaitTime(resource string, t float64) {
+	m.resourceWaitTime.WithLabelValues(resource).Observe(t)
+}
+
+func (m *Metrics) RecordGameDirQuarantined() {
+	m.quarantined.Add(1)
+}
+
+func (m *Metrics) RecordDiskOp(op string, d time.Duration) {
+	m.diskOpTime.WithLabelValues(op).Observe(d.Seconds())
+}
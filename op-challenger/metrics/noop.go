@@ -2,18 +2,23 @@ package metrics
 
 import (
 	"io"
+	"math/big"
+	"time"
 
 	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
 
 type NoopMetricsImpl struct {
 	txmetrics.NoopTxMetrics
 	contractMetrics.NoopMetrics
+	opmetrics.NoopRPCMetrics
 }
 
 func (i *NoopMetricsImpl) StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer {
@@ -36,20 +41,80 @@ func (*NoopMetricsImpl) RecordPreimageChallengeFailed() {}
 func (*NoopMetricsImpl) RecordBondClaimFailed()   {}
 func (*NoopMetricsImpl) RecordBondClaimed(uint64) {}
 
+func (*NoopMetricsImpl) RecordBondsOutstanding(int, *big.Int) {}
+
+func (*NoopMetricsImpl) RecordSentTx(string)      {}
+func (*NoopMetricsImpl) RecordSimulatedTx(string) {}
+
 func (*NoopMetricsImpl) RecordCannonExecutionTime(t float64)   {}
 func (*NoopMetricsImpl) RecordAsteriscExecutionTime(t float64) {}
 func (*NoopMetricsImpl) RecordClaimResolutionTime(t float64)   {}
 func (*NoopMetricsImpl) RecordGameActTime(t float64)           {}
 
 func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {}
+func (*NoopMetricsImpl) RecordGameStatusRegression(_, _ types.GameStatus)             {}
 
 func (*NoopMetricsImpl) RecordGameUpdateScheduled() {}
 func (*NoopMetricsImpl) RecordGameUpdateCompleted() {}
+func (*NoopMetricsImpl) RecordGameCoolingDown()     {}
+func (*NoopMetricsImpl) RecordDuplicateResult()     {}
+func (*NoopMetricsImpl) RecordJobsDropped(_ int)    {}
+func (*NoopMetricsImpl) RecordGasBudgetDeferred()   {}
+func (*NoopMetricsImpl) RecordGameNotReady()        {}
+func (*NoopMetricsImpl) RecordInvalidGameFiltered() {}
+func (*NoopMetricsImpl) RecordResultSinkError()     {}
+func (*NoopMetricsImpl) RecordResultSinkDropped()   {}
+func (*NoopMetricsImpl) RecordAuditWriteFailure()   {}
+
+func (*NoopMetricsImpl) RecordPendingResults(_ int)          {}
+func (*NoopMetricsImpl) RecordResultProcessingLag(_ float64) {}
+func (*NoopMetricsImpl) RecordMemoryPressure(_ bool)         {}
+func (*NoopMetricsImpl) RecordWorkerPoolSize(_ uint)         {}
+func (*NoopMetricsImpl) RecordResultBackpressure(_ bool)     {}
+func (*NoopMetricsImpl) RecordGlobalBackoff(_ time.Duration) {}
+func (*NoopMetricsImpl) RecordResolvedGamesRetained(_ int)   {}
+func (*NoopMetricsImpl) RecordDiskInconsistencies(_, _ int)  {}
+func (*NoopMetricsImpl) RecordGameAbandoned(_ string)        {}
+func (*NoopMetricsImpl) RecordCycleGated()                   {}
+func (*NoopMetricsImpl) RecordEventDropped()                 {}
+func (*NoopMetricsImpl) RecordInvalidResult()                {}
+func (*NoopMetricsImpl) RecordTrackedGames(_ int)            {}
+func (*NoopMetricsImpl) RecordForcedSchedule()               {}
+func (*NoopMetricsImpl) RecordActionSuppressed()             {}
+
+func (*NoopMetricsImpl) RecordOldestInFlightAge(_ time.Duration) {}
+func (*NoopMetricsImpl) RecordDispatchDelay(_ time.Duration)     {}
+func (*NoopMetricsImpl) RecordGameUpdateTimedOut()               {}
+func (*NoopMetricsImpl) RecordJobDuration(_ time.Duration)       {}
+func (*NoopMetricsImpl) RecordBatchSize(_ int)                   {}
+func (*NoopMetricsImpl) RecordBatchRejected()                    {}
+func (*NoopMetricsImpl) RecordPlayerInitFailure()                {}
+func (*NoopMetricsImpl) RecordUpstreamInUse(_ int)               {}
+func (*NoopMetricsImpl) RecordFilterReconciled(_, _ int)         {}
+
+func (*NoopMetricsImpl) RecordLongRunningJob(_ common.Address, _ time.Duration) {}
 
 func (*NoopMetricsImpl) IncActiveExecutors() {}
 func (*NoopMetricsImpl) DecActiveExecutors() {}
 func (*NoopMetricsImpl) IncIdleExecutors()   {}
 func (*NoopMetricsImpl) DecIdleExecutors()   {}
 
+func (*NoopMetricsImpl) RecordResourceWaitTime(_ string, _ float64)         {}
+func (*NoopMetricsImpl) RecordDiskOp(_ string, _ time.Duration)             {}
+func (*NoopMetricsImpl) RecordGameDirQuarantined()                          {}
+func (*NoopMetricsImpl) RecordSourceGamesStatus(_ string, _, _, _ int)      {}
+func (*NoopMetricsImpl) RecordSourceGameUpdateCompleted(_ string)           {}
+func (*NoopMetricsImpl) RecordSourceActedL1Block(_ string, _ uint64)        {}
+func (*NoopMetricsImpl) RecordSourceTrackedGames(_ string, _ int)           {}
+func (*NoopMetricsImpl) RecordSourceResolvedGamesRetained(_ string, _ int)  {}
+func (*NoopMetricsImpl) RecordSourceQuarantinedGames(_ string, _ int)       {}
+func (*NoopMetricsImpl) RecordSourceDiskUsage(_ string, _ uint64)           {}
+func (*NoopMetricsImpl) RecordSourceDiskInconsistencies(_ string, _, _ int) {}
+func (*NoopMetricsImpl) RecordDiskUsage(_ uint64)                           {}
+func (*NoopMetricsImpl) RecordDiskEvictions(_ int)                          {}
+func (*NoopMetricsImpl) RecordBatchCoalesced()                              {}
+func (*NoopMetricsImpl) RecordGameQuarantined()                             {}
+func (*NoopMetricsImpl) RecordQuarantinedGames(_ int)                       {}
+
 func (*NoopMetricsImpl) CacheAdd(_ string, _ int, _ bool) {}
 func (*NoopMetricsImpl) CacheGet(_ string, _ bool)        {}
@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/rpc"
+)
+
+var ErrInvalidConcurrency = errors.New("invalid concurrency")
+
+type WorkerPool interface {
+	SetConcurrency(ctx context.Context, target uint) error
+}
+
+type adminAPI struct {
+	*rpc.CommonAdminAPI
+	pool WorkerPool
+}
+
+func NewAdminAPI(pool WorkerPool, m metrics.RPCMetricer, log log.Logger) *adminAPI {
+	return &adminAPI{
+		CommonAdminAPI: rpc.NewCommonAdminAPI(m, log),
+		pool:           pool,
+	}
+}
+
+func GetAdminAPI(api *adminAPI) gethrpc.API {
+	return gethrpc.API{
+		Namespace: "admin",
+		Service:   api,
+	}
+}
+
+// SetMaxConcurrency changes the number of workers progressing games without restarting the challenger.
+// Returns ErrInvalidConcurrency if n is zero.
+func (a *adminAPI) SetMaxConcurrency(ctx context.Context, n uint) error {
+	recordDur := a.M.RecordRPCServerRequest("admin_setMaxConcurrency")
+	defer recordDur()
+	if n == 0 {
+		return fmt.Errorf("%w: must be at least 1", ErrInvalidConcurrency)
+	}
+	return a.pool.SetConcurrency(ctx, n)
+}
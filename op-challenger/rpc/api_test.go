@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestSetMaxConcurrency(t *testing.T) {
+	t.Run("Forwarded", func(t *testing.T) {
+		pool := &stubWorkerPool{}
+		client := newAdminTestClient(t, pool)
+		require.NoError(t, client.Call(nil, "admin_setMaxConcurrency", 5))
+		require.Equal(t, []uint{5}, pool.targets)
+	})
+
+	t.Run("ErrorPropagated", func(t *testing.T) {
+		pool := &stubWorkerPool{err: errors.New("boom")}
+		client := newAdminTestClient(t, pool)
+		err := client.Call(nil, "admin_setMaxConcurrency", 5)
+		require.ErrorContains(t, err, "boom")
+	})
+
+	t.Run("RejectZero", func(t *testing.T) {
+		pool := &stubWorkerPool{}
+		client := newAdminTestClient(t, pool)
+		err := client.Call(nil, "admin_setMaxConcurrency", 0)
+		require.ErrorContains(t, err, ErrInvalidConcurrency.Error())
+		require.Empty(t, pool.targets)
+	})
+
+	t.Run("RejectInvalid", func(t *testing.T) {
+		pool := &stubWorkerPool{}
+		client := newAdminTestClient(t, pool)
+		require.Error(t, client.Call(nil, "admin_setMaxConcurrency", -1))
+		require.Error(t, client.Call(nil, "admin_setMaxConcurrency", "many"))
+		require.Empty(t, pool.targets)
+	})
+}
+
+func newAdminTestClient(t *testing.T, pool WorkerPool) *gethrpc.Client {
+	api := NewAdminAPI(pool, &metrics.NoopRPCMetrics{}, testlog.Logger(t, log.LevelInfo))
+	return newTestClient(t, GetAdminAPI(api))
+}
+
+// newTestClient returns a client connected in process to a server serving the API.
+func newTestClient(t *testing.T, api gethrpc.API) *gethrpc.Client {
+	server := gethrpc.NewServer()
+	t.Cleanup(server.Stop)
+	require.NoError(t, server.RegisterName(api.Namespace, api.Service))
+	client := gethrpc.DialInProc(server)
+	t.Cleanup(client.Close)
+	return client
+}
+
+type stubWorkerPool struct {
+	targets []uint
+	err     error
+}
+
+func (s *stubWorkerPool) SetConcurrency(_ context.Context, target uint) error {
+	s.targets = append(s.targets, target)
+	return s.err
+}
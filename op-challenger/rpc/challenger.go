@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+type GameScheduler interface {
+	ListGames(ctx context.Context) ([]scheduler.TrackedGame, error)
+	ForceSchedule(ctx context.Context, addr common.Address) error
+	IgnoreGame(ctx context.Context, addr common.Address) error
+	UnignoreGame(ctx context.Context, addr common.Address) error
+}
+
+// challengerAPI lets operators inspect and adjust the games being scheduled while the challenger is running.
+type challengerAPI struct {
+	m     metrics.RPCMetricer
+	sched GameScheduler
+}
+
+func NewChallengerAPI(sched GameScheduler, m metrics.RPCMetricer) *challengerAPI {
+	return &challengerAPI{
+		m:     m,
+		sched: sched,
+	}
+}
+
+func GetChallengerAPI(api *challengerAPI) gethrpc.API {
+	return gethrpc.API{
+		Namespace: "challenger",
+		Service:   api,
+	}
+}
+
+// ListGames returns each game tracked by the scheduler with its status, when it was last updated and whether it
+// has a job pending or being progressed.
+func (a *challengerAPI) ListGames(ctx context.Context) ([]scheduler.TrackedGame, error) {
+	recordDur := a.m.RecordRPCServerRequest("challenger_listGames")
+	defer recordDur()
+	return a.sched.ListGames(ctx)
+}
+
+// ForceUpdate progresses the game immediately, bypassing the checks that would otherwise delay it.
+func (a *challengerAPI) ForceUpdate(ctx context.Context, addr common.Address) error {
+	recordDur := a.m.RecordRPCServerRequest("challenger_forceUpdate")
+	defer recordDur()
+	return a.sched.ForceSchedule(ctx, addr)
+}
+
+// IgnoreGame stops the game from being scheduled until UnignoreGame is called.
+func (a *challengerAPI) IgnoreGame(ctx context.Context, addr common.Address) error {
+	recordDur := a.m.RecordRPCServerRequest("challenger_ignoreGame")
+	defer recordDur()
+	return a.sched.IgnoreGame(ctx, addr)
+}
+
+// UnignoreGame allows a game ignored by IgnoreGame to be scheduled again.
+func (a *challengerAPI) UnignoreGame(ctx context.Context, addr common.Address) error {
+	recordDur := a.m.RecordRPCServerRequest("challenger_unignoreGame")
+	defer recordDur()
+	return a.sched.UnignoreGame(ctx, addr)
+}
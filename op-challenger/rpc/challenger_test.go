@@ -0,0 +1,105 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+func TestListGames(t *testing.T) {
+	t.Run("Forwarded", func(t *testing.T) {
+		games := []scheduler.TrackedGame{
+			{Game: common.Address{0xaa}, Status: types.GameStatusInProgress, LastUpdated: time.Unix(100, 0).UTC(), Pending: true},
+			{Game: common.Address{0xbb}, Factory: common.Address{0xfa}, InProgress: true, Ignored: true},
+		}
+		client := newChallengerTestClient(t, &stubGameScheduler{games: games})
+		var result []scheduler.TrackedGame
+		require.NoError(t, client.Call(&result, "challenger_listGames"))
+		require.Equal(t, games, result)
+	})
+
+	t.Run("ErrorPropagated", func(t *testing.T) {
+		client := newChallengerTestClient(t, &stubGameScheduler{err: errors.New("boom")})
+		var result []scheduler.TrackedGame
+		require.ErrorContains(t, client.Call(&result, "challenger_listGames"), "boom")
+	})
+}
+
+func TestForceUpdate(t *testing.T) {
+	t.Run("Forwarded", func(t *testing.T) {
+		sched := &stubGameScheduler{}
+		client := newChallengerTestClient(t, sched)
+		require.NoError(t, client.Call(nil, "challenger_forceUpdate", common.Address{0xaa}))
+		require.Equal(t, []common.Address{{0xaa}}, sched.forced)
+	})
+
+	t.Run("ErrorPropagated", func(t *testing.T) {
+		client := newChallengerTestClient(t, &stubGameScheduler{err: scheduler.ErrGameNotScheduled})
+		err := client.Call(nil, "challenger_forceUpdate", common.Address{0xaa})
+		require.ErrorContains(t, err, scheduler.ErrGameNotScheduled.Error())
+	})
+
+	t.Run("RejectInvalidAddress", func(t *testing.T) {
+		sched := &stubGameScheduler{}
+		client := newChallengerTestClient(t, sched)
+		require.Error(t, client.Call(nil, "challenger_forceUpdate", "invalid"))
+		require.Empty(t, sched.forced)
+	})
+}
+
+func TestIgnoreGame(t *testing.T) {
+	t.Run("Forwarded", func(t *testing.T) {
+		sched := &stubGameScheduler{}
+		client := newChallengerTestClient(t, sched)
+		require.NoError(t, client.Call(nil, "challenger_ignoreGame", common.Address{0xaa}))
+		require.NoError(t, client.Call(nil, "challenger_unignoreGame", common.Address{0xbb}))
+		require.Equal(t, []common.Address{{0xaa}}, sched.ignored)
+		require.Equal(t, []common.Address{{0xbb}}, sched.unignored)
+	})
+
+	t.Run("ErrorPropagated", func(t *testing.T) {
+		client := newChallengerTestClient(t, &stubGameScheduler{err: errors.New("boom")})
+		require.ErrorContains(t, client.Call(nil, "challenger_ignoreGame", common.Address{0xaa}), "boom")
+		require.ErrorContains(t, client.Call(nil, "challenger_unignoreGame", common.Address{0xaa}), "boom")
+	})
+}
+
+func newChallengerTestClient(t *testing.T, sched GameScheduler) *gethrpc.Client {
+	return newTestClient(t, GetChallengerAPI(NewChallengerAPI(sched, &metrics.NoopRPCMetrics{})))
+}
+
+type stubGameScheduler struct {
+	err       error
+	games     []scheduler.TrackedGame
+	forced    []common.Address
+	ignored   []common.Address
+	unignored []common.Address
+}
+
+func (s *stubGameScheduler) ListGames(_ context.Context) ([]scheduler.TrackedGame, error) {
+	return s.games, s.err
+}
+
+func (s *stubGameScheduler) ForceSchedule(_ context.Context, addr common.Address) error {
+	s.forced = append(s.forced, addr)
+	return s.err
+}
+
+func (s *stubGameScheduler) IgnoreGame(_ context.Context, addr common.Address) error {
+	s.ignored = append(s.ignored, addr)
+	return s.err
+}
+
+func (s *stubGameScheduler) UnignoreGame(_ context.Context, addr common.Address) error {
+	s.unignored = append(s.unignored, addr)
+	return s.err
+}
@@ -13,17 +13,23 @@ import (
 
 var ErrTransactionReverted = errors.New("transaction published but reverted")
 
+type SenderMetricer interface {
+	RecordSentTx(purpose string)
+}
+
 type TxSender struct {
 	log log.Logger
+	m   SenderMetricer
 
 	txMgr txmgr.TxManager
 	queue *txmgr.Queue[int]
 }
 
-func NewTxSender(ctx context.Context, logger log.Logger, txMgr txmgr.TxManager, maxPending uint64) *TxSender {
+func NewTxSender(ctx context.Context, logger log.Logger, m SenderMetricer, txMgr txmgr.TxManager, maxPending uint64) *TxSender {
 	queue := txmgr.NewQueue[int](ctx, txMgr, maxPending)
 	return &TxSender{
 		log:   logger,
+		m:     m,
 		txMgr: txMgr,
 		queue: queue,
 	}
@@ -49,6 +55,7 @@ func (s *TxSender) SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidat
 			if rcpt.Receipt.Status != types.ReceiptStatusSuccessful {
 				errs[rcpt.ID] = fmt.Errorf("%w purpose: %v hash: %v", ErrTransactionReverted, txPurpose, rcpt.Receipt.TxHash)
 			} else {
+				s.m.RecordSentTx(txPurpose)
 				s.log.Debug("Transaction successfully published", "tx_hash", rcpt.Receipt.TxHash, "purpose", txPurpose)
 			}
 		}
@@ -20,7 +20,7 @@ func TestSendAndWaitQueueWithMaxPending(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 	txMgr := &stubTxMgr{sending: make(map[byte]chan *types.Receipt)}
-	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), txMgr, 5)
+	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), &stubSentMetrics{}, txMgr, 5)
 
 	tx := func(i byte) txmgr.TxCandidate {
 		return txmgr.TxCandidate{TxData: []byte{i}}
@@ -99,7 +99,8 @@ func TestSendAndWaitReturnIndividualErrors(t *testing.T) {
 			2: types.ReceiptStatusSuccessful,
 		},
 	}
-	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), txMgr, 500)
+	m := &stubSentMetrics{}
+	sender := NewTxSender(ctx, testlog.Logger(t, log.LevelInfo), m, txMgr, 500)
 
 	tx := func(i byte) txmgr.TxCandidate {
 		return txmgr.TxCandidate{TxData: []byte{i}}
@@ -110,6 +111,22 @@ func TestSendAndWaitReturnIndividualErrors(t *testing.T) {
 	require.NoError(t, errs[0])
 	require.ErrorIs(t, errs[1], ErrTransactionReverted)
 	require.NoError(t, errs[2])
+	// Only the successful transactions are recorded as sent
+	require.Equal(t, map[string]int{"testing": 2}, m.sent)
+}
+
+type stubSentMetrics struct {
+	lock sync.Mutex
+	sent map[string]int
+}
+
+func (m *stubSentMetrics) RecordSentTx(purpose string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.sent == nil {
+		m.sent = make(map[string]int)
+	}
+	m.sent[purpose]++
 }
 
 type stubTxMgr struct {
@@ -0,0 +1,82 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrTxSimulated is returned by ShadowTxSender for each transaction it logs instead of sending, so callers don't
+// treat the transaction as having been included.
+var ErrTxSimulated = errors.New("transaction simulated in shadow mode, not sent")
+
+type GasEstimator interface {
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+}
+
+type ShadowMetricer interface {
+	RecordSimulatedTx(purpose string)
+}
+
+// ShadowTxSender is a drop-in replacement for TxSender that logs the transactions it would send, with their
+// estimated gas and value, instead of sending them. It allows the challenger to be run against a live network to
+// validate its configuration without spending funds.
+type ShadowTxSender struct {
+	ctx       context.Context
+	log       log.Logger
+	m         ShadowMetricer
+	from      common.Address
+	estimator GasEstimator
+}
+
+func NewShadowTxSender(ctx context.Context, logger log.Logger, m ShadowMetricer, from common.Address, estimator GasEstimator) *ShadowTxSender {
+	return &ShadowTxSender{
+		ctx:       ctx,
+		log:       logger,
+		m:         m,
+		from:      from,
+		estimator: estimator,
+	}
+}
+
+func (s *ShadowTxSender) From() common.Address {
+	return s.from
+}
+
+// SendAndWaitDetailed estimates the gas for each transaction and logs it without sending it. Returns an error
+// wrapping ErrTxSimulated for each transaction logged, or the estimation error for each transaction whose gas
+// couldn't be estimated, typically because it would revert.
+func (s *ShadowTxSender) SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidate) []error {
+	errs := make([]error, len(txs))
+	for i, tx := range txs {
+		gas := tx.GasLimit
+		if gas == 0 {
+			estimated, err := s.estimator.EstimateGas(s.ctx, ethereum.CallMsg{
+				From:  s.from,
+				To:    tx.To,
+				Data:  tx.TxData,
+				Value: tx.Value,
+			})
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to estimate gas for simulated transaction, purpose: %v: %w", txPurpose, err)
+				continue
+			}
+			gas = estimated
+		}
+		s.m.RecordSimulatedTx(txPurpose)
+		s.log.Info("Simulated transaction, not sent in shadow mode",
+			"purpose", txPurpose, "to", tx.To, "value", tx.Value, "gas", gas, "data", common.Bytes2Hex(tx.TxData))
+		errs[i] = fmt.Errorf("%w, purpose: %v", ErrTxSimulated, txPurpose)
+	}
+	return errs
+}
+
+func (s *ShadowTxSender) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error {
+	errs := s.SendAndWaitDetailed(txPurpose, txs...)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,75 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShadowTxSender(t *testing.T) {
+	from := common.Address{0xaa}
+	to := common.Address{0xbb}
+	estimator := &stubGasEstimator{gas: 50_000}
+	m := &stubShadowMetrics{}
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	sender := NewShadowTxSender(context.Background(), logger, m, from, estimator)
+	require.Equal(t, from, sender.From())
+
+	move := txmgr.TxCandidate{To: &to, TxData: []byte{0x01}, Value: big.NewInt(100)}
+	step := txmgr.TxCandidate{To: &to, TxData: []byte{0x02}, GasLimit: 80_000}
+	require.ErrorIs(t, sender.SendAndWaitSimple("perform action", move, step), ErrTxSimulated)
+
+	// Only the transaction without a gas limit is estimated
+	require.Len(t, estimator.calls, 1)
+	require.Equal(t, ethereum.CallMsg{From: from, To: &to, Data: []byte{0x01}, Value: big.NewInt(100)}, estimator.calls[0])
+	require.Equal(t, map[string]int{"perform action": 2}, m.simulated)
+
+	levelFilter := testlog.NewLevelFilter(log.LevelInfo)
+	msgFilter := testlog.NewMessageFilter("Simulated transaction, not sent in shadow mode")
+	simulated := logs.FindLogs(levelFilter, msgFilter)
+	require.Len(t, simulated, 2)
+	require.Equal(t, uint64(50_000), simulated[0].AttrValue("gas"))
+	require.Equal(t, big.NewInt(100), simulated[0].AttrValue("value"))
+	require.Equal(t, uint64(80_000), simulated[1].AttrValue("gas"))
+}
+
+func TestShadowTxSenderEstimateFailure(t *testing.T) {
+	estimateErr := errors.New("execution reverted")
+	m := &stubShadowMetrics{}
+	sender := NewShadowTxSender(context.Background(), testlog.Logger(t, log.LevelInfo), m, common.Address{0xaa}, &stubGasEstimator{err: estimateErr})
+	errs := sender.SendAndWaitDetailed("resolve game", txmgr.TxCandidate{}, txmgr.TxCandidate{GasLimit: 10})
+	require.ErrorIs(t, errs[0], estimateErr)
+	require.NotErrorIs(t, errs[0], ErrTxSimulated)
+	require.ErrorIs(t, errs[1], ErrTxSimulated)
+	require.Equal(t, map[string]int{"resolve game": 1}, m.simulated)
+}
+
+type stubGasEstimator struct {
+	gas   uint64
+	err   error
+	calls []ethereum.CallMsg
+}
+
+func (s *stubGasEstimator) EstimateGas(_ context.Context, msg ethereum.CallMsg) (uint64, error) {
+	s.calls = append(s.calls, msg)
+	return s.gas, s.err
+}
+
+type stubShadowMetrics struct {
+	simulated map[string]int
+}
+
+func (m *stubShadowMetrics) RecordSimulatedTx(purpose string) {
+	if m.simulated == nil {
+		m.simulated = make(map[string]int)
+	}
+	m.simulated[purpose]++
+}